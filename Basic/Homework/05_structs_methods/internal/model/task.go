@@ -3,6 +3,8 @@ package model
 import (
 	"fmt"
 	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
 )
 
 const (
@@ -26,7 +28,7 @@ type Task struct {
 }
 
 func NewTask(name, descr string, dueDate string) Task {
-	userDueDate, err := time.Parse("02.01.2006", dueDate)
+	userDueDate, err := dateinput.Parse(dueDate, time.Now(), time.Local)
 	if err != nil {
 		panic("Введенная дата исполнения имеет не корректный формат.")
 	}
@@ -43,6 +45,6 @@ func NewTask(name, descr string, dueDate string) Task {
 func (myTask *Task) String() string {
 	return fmt.Sprintf(
 		"Имя задачи: %v\nОписание задачи: %v\nДата постановки задачи: %v\nДата исполнения: %v\nСтатус: %v\n",
-		myTask.name, myTask.description, myTask.initTimeStamp.Format(time.DateTime), myTask.dueDate.Format(time.DateTime), myTask.status,
+		myTask.name, myTask.description, myTask.initTimeStamp.Format(time.DateTime), myTask.dueDate.Local().Format(time.DateTime), myTask.status,
 	)
 }
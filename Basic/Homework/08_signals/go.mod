@@ -1,3 +1,7 @@
 module github.com/corridda/OTUS_Golang_Developer/Basic/Homework/08_signals
 
 go 1.25.4
+
+require github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared v0.0.0
+
+replace github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared => ../shared
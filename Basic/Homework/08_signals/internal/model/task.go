@@ -3,6 +3,8 @@ package model
 import (
 	"fmt"
 	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
 )
 
 const (
@@ -26,7 +28,7 @@ type Task struct {
 }
 
 func NewTask(name, descr, dueDate string) Task {
-	userDueDate, err := time.Parse("02.01.2006", dueDate)
+	userDueDate, err := dateinput.Parse(dueDate, time.Now(), time.Local)
 	if err != nil {
 		fmt.Println("Введенная дата исполнения имеет не корректный формат.")
 		return Task{
@@ -49,13 +51,13 @@ func NewTask(name, descr, dueDate string) Task {
 func (myTask Task) String() string {
 	return fmt.Sprintf(
 		"Имя задачи: %v\nОписание задачи: %v\nДата постановки задачи: %v\nДата исполнения: %v\nСтатус: %v\n",
-		myTask.name, myTask.description, myTask.initTimeStamp.Format("02.01.2006"), myTask.dueDate.Format("02.01.2006"), myTask.status,
+		myTask.name, myTask.description, myTask.initTimeStamp.Format("02.01.2006"), myTask.dueDate.Local().Format("02.01.2006"), myTask.status,
 	)
 }
 
 // ChangeAlarm implements repository.Remindable.
 func (myTask *Task) ChangeAlarm(new_date string) {
-	userDate, err := time.Parse("02.01.2006", new_date)
+	userDate, err := dateinput.Parse(new_date, time.Now(), time.Local)
 	if err != nil {
 		fmt.Println("Введенная дата исполнения имеет не корректный формат.\nВведите требуемое значение даты в соответствии с форматом: ДД-ММ-ГГГГ.")
 	} else {
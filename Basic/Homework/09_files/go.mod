@@ -1,3 +1,7 @@
 module github.com/corridda/OTUS_Golang_Developer/Basic/Homework/09_files
 
 go 1.25.5
+
+require github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared v0.0.0
+
+replace github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared => ../shared
@@ -3,6 +3,8 @@ package model
 import (
 	"fmt"
 	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
 )
 
 type Note struct {
@@ -12,7 +14,7 @@ type Note struct {
 }
 
 func NewNote(name, descr, alarmDateTime string) Note {
-	userDueDate, err := time.Parse("02.01.2006 15:04", alarmDateTime)
+	userDueDate, err := dateinput.Parse(alarmDateTime, time.Now(), time.Local)
 	if err != nil {
 		fmt.Println("Введенные дата и время напоминания имеют не корректный формат.")
 		return Note{
@@ -31,13 +33,13 @@ func NewNote(name, descr, alarmDateTime string) Note {
 func (myNote Note) String() string {
 	return fmt.Sprintf(
 		"Имя заметки: %v\nОписание заметки: %v\nДата и время напоминания: %v\n",
-		myNote.Name, myNote.Description, myNote.AlarmTimeStamp.Format("02.01.2006 15:04"),
+		myNote.Name, myNote.Description, myNote.AlarmTimeStamp.Local().Format("02.01.2006 15:04"),
 	)
 }
 
 // ChangeAlarm implements repository.Remindable.
 func (myNote *Note) ChangeAlarm(new_date_time string) {
-	userDateTime, err := time.Parse("02.01.2006 15:04", new_date_time)
+	userDateTime, err := dateinput.Parse(new_date_time, time.Now(), time.Local)
 	if err != nil {
 		fmt.Println("Введенные дата и время напоминания имеют не корректный формат.\nВведите требуемые значения даты и времени в соответствии с форматом: ДД-ММ-ГГГГ ЧЧ:ММ.")
 	} else {
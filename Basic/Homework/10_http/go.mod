@@ -2,7 +2,10 @@ module github.com/corridda/OTUS_Golang_Developer/Basic/Homework/10_http
 
 go 1.25.5
 
-require github.com/gin-gonic/gin v1.11.0
+require (
+	github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared v0.0.0
+	github.com/gin-gonic/gin v1.11.0
+)
 
 require (
 	github.com/bytedance/sonic v1.14.0 // indirect
@@ -37,3 +40,5 @@ require (
 	golang.org/x/tools v0.34.0 // indirect
 	google.golang.org/protobuf v1.36.9 // indirect
 )
+
+replace github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared => ../shared
@@ -3,6 +3,7 @@ module github.com/corridda/OTUS_Golang_Developer/Basic/Homework/11_swagger
 go 1.25.5
 
 require (
+	github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared v0.0.0
 	github.com/gin-gonic/gin v1.11.0
 	github.com/swaggo/files v1.0.1
 	github.com/swaggo/gin-swagger v1.6.1
@@ -52,3 +53,5 @@ require (
 	google.golang.org/protobuf v1.36.9 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
+
+replace github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared => ../shared
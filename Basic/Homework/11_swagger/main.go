@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,6 +19,7 @@ import (
 	_ "github.com/corridda/OTUS_Golang_Developer/Basic/Homework/11_swagger/docs"
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/11_swagger/internal/repository"
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/11_swagger/internal/service"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/envflag"
 )
 
 // @title Программа для управления задачами
@@ -22,6 +28,10 @@ import (
 // @host localhost:8080/
 
 func main() {
+	addr := envflag.String("http-addr", ":8080", "HTTP listen address")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 10*time.Second, "time to drain in-flight requests on SIGINT/SIGTERM")
+	flag.Parse()
+
 	// создание json-файлов для хранения задач и заметок
 	createFiles("tasks.json", "notes.json")
 
@@ -48,9 +58,19 @@ func main() {
 		panic(fmt.Sprintf("ошибка наполнения repository.Notes из файла notes.json: %v", err))
 	}
 
+	// контекст, отменяемый по сигналам SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	// запуск логгера для новых задач/заметок
+	// логгер останавливается отдельно, после сервера, чтобы успеть вывести последние изменения
+	loggerCtx, stopLogger := context.WithCancel(context.Background())
+	loggerDone := make(chan struct{})
 	ticker := time.NewTicker(time.Millisecond * 200)
-	go service.LogRemidables(ticker, &mutex)
+	go func() {
+		defer close(loggerDone)
+		service.LogRemidables(loggerCtx, ticker, &mutex)
+	}()
 
 	// Создаём роутер
 	r := gin.Default()
@@ -59,17 +79,40 @@ func main() {
 	// Именно он будет отвечать за хендлеры и обрабатывать каждый запрос.
 	handle := repository.New()
 
-	url := ginSwagger.URL("http://localhost:8080/swagger/doc.json")
+	// относительный путь: документация открывается на любом адресе из -http-addr
+	url := ginSwagger.URL("/swagger/doc.json")
 	r.GET("swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
 
 	// Инициализация маршрутов и обработчиков запросов
 	// Функция нужна чтобы добавить каждый хендлер в сервер
 	repository.InitHandler(r, handle)
 
-	// Запуск сервера на :8080
-	if err := r.Run(":8080"); err != nil {
+	// Запуск сервера на адресе -http-addr (REMINDABLES_HTTP_ADDR), по умолчанию :8080
+	srv := &http.Server{Addr: *addr, Handler: r}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		stopLogger()
+		<-loggerDone
 		panic(err)
+	case <-ctx.Done():
+		stop()
+	}
+	fmt.Println("\nПолучен сигнал завершения, ожидаем завершения обработки запросов...")
+
+	// Завершение: сервер дожидается текущих запросов не дольше shutdownTimeout, затем останавливается логгер
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		fmt.Printf("Сервер остановлен принудительно: %v\n", err)
 	}
+	stopLogger()
+	<-loggerDone
+	fmt.Println("Программа завершила свою работу.")
 
 }
 
@@ -2,112 +2,60 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"time"
 
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/12_gRPC/proto_api/pkg/grpc/v1/remindables_api"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/envflag"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func stringlifyTask(
-	id int32,
-	name string,
-	description string,
-	initTimeStamp string,
-	dueDate string,
-	status string,
-) string {
-	return fmt.Sprintf("\n{\n\tId: %v\n\tName: %v\n\tDescription: %v\n\tInitTimeStamp: %v\n\tDueDate: %v\n\tStatus: %v\n}\n", id, name, description, initTimeStamp, dueDate, status)
-}
-
-func stringlifyPostedTask(resp *remindables_api.PostNewTaskResponse) string {
-	id := resp.Id
-	name := resp.Name
-	description := resp.Description
-	initTimeStamp := resp.InitTimeStamp.AsTime().Format("02.01.2006 15:04")
-	dueDate := resp.DueDate.AsTime().Format("02.01.2006")
-	status := resp.Status
-	return stringlifyTask(id, name, description, initTimeStamp, dueDate, status)
-}
-
-func stringlifyGottenTask(resp *remindables_api.GetTaskResponse) string {
-	id := resp.Id
-	name := resp.Name
-	description := resp.Description
-	initTimeStamp := resp.InitTimeStamp.AsTime().Format("02.01.2006 15:04")
-	dueDate := resp.DueDate.AsTime().Format("02.01.2006")
-	status := resp.Status
-	return stringlifyTask(id, name, description, initTimeStamp, dueDate, status)
-}
-
-func stringlifyPutTask(resp *remindables_api.PutTaskResponse) string {
-	id := resp.Id
-	name := resp.Name
-	description := resp.Description
-	initTimeStamp := resp.InitTimeStamp.AsTime().Format("02.01.2006 15:04")
-	dueDate := resp.DueDate.AsTime().Format("02.01.2006")
-	status := resp.Status
-	return stringlifyTask(id, name, description, initTimeStamp, dueDate, status)
-}
-
-func stringlifyDeletedTask(resp *remindables_api.DeleteTaskResponse) string {
-	id := resp.Id
-	name := resp.Name
-	description := resp.Description
-	initTimeStamp := resp.InitTimeStamp.AsTime().Format("02.01.2006 15:04")
-	dueDate := resp.DueDate.AsTime().Format("02.01.2006")
-	status := resp.Status
-	return stringlifyTask(id, name, description, initTimeStamp, dueDate, status)
-}
-
-func stringlifyNote(
-	id int32,
-	name string,
-	description string,
-	alarmTimeStamp string,
-) string {
-	return fmt.Sprintf("\n{\n\tId: %v\n\tName: %v\n\tDescription: %v\n\tAlarmTimeStamp: %v\n}\n", id, name, description, alarmTimeStamp)
-}
-
-func stringlifyPostedNote(resp *remindables_api.PostNewNoteResponse) string {
-	id := resp.Id
-	name := resp.Name
-	description := resp.Description
-	alarmTimeStamp := resp.AlarmTimeStamp.AsTime().Format("02.01.2006 15:04")
-	return stringlifyNote(id, name, description, alarmTimeStamp)
-}
-
-func stringlifyGottenNote(resp *remindables_api.GetNoteResponse) string {
-	id := resp.Id
-	name := resp.Name
-	description := resp.Description
-	alarmTimeStamp := resp.AlarmTimeStamp.AsTime().Format("02.01.2006 15:04")
-	return stringlifyNote(id, name, description, alarmTimeStamp)
+func stringlifyTask(resp *remindables_api.Task) string {
+	return fmt.Sprintf("\n{\n\tId: %v\n\tName: %v\n\tDescription: %v\n\tInitTimeStamp: %v\n\tDueDate: %v\n\tStatus: %v\n}\n",
+		resp.Id,
+		resp.Name,
+		resp.Description,
+		resp.InitTimeStamp.AsTime().Format("02.01.2006 15:04"),
+		resp.DueDate.AsTime().Format("02.01.2006"),
+		resp.Status,
+	)
 }
 
-func stringlifyPutNote(resp *remindables_api.PutNoteResponse) string {
-	id := resp.Id
-	name := resp.Name
-	description := resp.Description
-	alarmTimeStamp := resp.AlarmTimeStamp.AsTime().Format("02.01.2006 15:04")
-	return stringlifyNote(id, name, description, alarmTimeStamp)
+func stringlifyNote(resp *remindables_api.Note) string {
+	return fmt.Sprintf("\n{\n\tId: %v\n\tName: %v\n\tDescription: %v\n\tAlarmTimeStamp: %v\n}\n",
+		resp.Id,
+		resp.Name,
+		resp.Description,
+		resp.AlarmTimeStamp.AsTime().Format("02.01.2006 15:04"),
+	)
 }
 
-func stringlifyDeletedNote(resp *remindables_api.DeleteNoteResponse) string {
-	id := resp.Id
-	name := resp.Name
-	description := resp.Description
-	alarmTimeStamp := resp.AlarmTimeStamp.AsTime().Format("02.01.2006 15:04")
-	return stringlifyNote(id, name, description, alarmTimeStamp)
+// mustParseDate разбирает дату или относительное выражение value в часовом поясе loc
+func mustParseDate(value string, loc *time.Location) time.Time {
+	date, err := dateinput.Parse(value, time.Now(), loc)
+	if err != nil {
+		log.Fatalf("Invalid date %q: %v", value, err)
+	}
+	return date
 }
 
 func main() {
-	conn, err := grpc.NewClient("localhost:5001", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	addr := envflag.String("grpc-addr", "localhost:5001", "gRPC server address")
+	tz := flag.String("tz", "Local", "IANA time zone for dates without an explicit offset, e.g. Europe/Moscow")
+	flag.Parse()
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		log.Fatalf("Unknown time zone %q: %v", *tz, err)
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		log.Fatalf("Could not connect: %v", err)
 	}
@@ -116,7 +64,7 @@ func main() {
 	cl := remindables_api.NewRemindablesServiceClient(conn)
 
 	// Создать новую задачу
-	dueDate, _ := time.Parse("02.01.2006", "03.04.2026")
+	dueDate := mustParseDate("03.04.2026", loc)
 	res0, err := cl.PostNewTask(context.Background(), &remindables_api.PostNewTaskRequest{
 		Name:        "taskName 1",
 		Description: "taskDescr 1",
@@ -126,10 +74,10 @@ func main() {
 		fmt.Println(err.Error())
 		return
 	}
-	fmt.Printf("Успешно создана новая задача:%s", stringlifyPostedTask(res0))
+	fmt.Printf("Успешно создана новая задача:%s", stringlifyTask(res0))
 
 	// Создать новую задачу
-	dueDate, _ = time.Parse("02.01.2006", "04.04.2026")
+	dueDate = mustParseDate("через 3 дня", loc)
 	res1, err := cl.PostNewTask(context.Background(), &remindables_api.PostNewTaskRequest{
 		Name:        "taskName 2",
 		Description: "taskDescr 2",
@@ -139,10 +87,10 @@ func main() {
 		fmt.Println(err.Error())
 		return
 	}
-	fmt.Printf("Успешно создана новая задача:%s", stringlifyPostedTask(res1))
+	fmt.Printf("Успешно создана новая задача:%s", stringlifyTask(res1))
 
 	// Создать новую заметку
-	alarmTimeStamp, _ := time.Parse("02.01.2006 15:04", "03.04.2026 20:00")
+	alarmTimeStamp := mustParseDate("03.04.2026 20:00", loc)
 	res2, err := cl.PostNewNote(context.Background(), &remindables_api.PostNewNoteRequest{
 		Name:           "noteName 1",
 		Description:    "noteDescr 1",
@@ -152,10 +100,10 @@ func main() {
 		fmt.Println(err.Error())
 		return
 	}
-	fmt.Printf("Успешно создана новая заметка:%s", stringlifyPostedNote(res2))
+	fmt.Printf("Успешно создана новая заметка:%s", stringlifyNote(res2))
 
 	// Создать новую заметку
-	alarmTimeStamp, _ = time.Parse("02.01.2006 15:04", "04.04.2026 20:00")
+	alarmTimeStamp = mustParseDate("завтра 20:00", loc)
 	res3, err := cl.PostNewNote(context.Background(), &remindables_api.PostNewNoteRequest{
 		Name:           "noteName 2",
 		Description:    "noteDescr 2",
@@ -165,7 +113,7 @@ func main() {
 		fmt.Println(err.Error())
 		return
 	}
-	fmt.Printf("Успешно создана новая заметка:%s", stringlifyPostedNote(res3))
+	fmt.Printf("Успешно создана новая заметка:%s", stringlifyNote(res3))
 
 	// Считать все имеющиеся задачи
 	res4, err := cl.GetTasks(
@@ -184,7 +132,7 @@ func main() {
 		if err != nil {
 			panic("ошибка получения задач из хранилища")
 		}
-		fmt.Printf("\nЗадача:%v\n", stringlifyGottenTask(task))
+		fmt.Printf("\nЗадача:%v\n", stringlifyTask(task))
 	}
 
 	// Считать все имеющиеся заметки
@@ -204,7 +152,7 @@ func main() {
 		if err != nil {
 			panic("ошибка получения заметок из хранилища")
 		}
-		fmt.Printf("\nЗаметкa:%v\n", stringlifyGottenNote(note))
+		fmt.Printf("\nЗаметкa:%v\n", stringlifyNote(note))
 	}
 
 	// Считать задачу по ее ID
@@ -215,7 +163,7 @@ func main() {
 		fmt.Println(err.Error())
 		return
 	}
-	fmt.Printf("Успешно считана задача по ID=%d:%s", 1, stringlifyGottenTask(res6))
+	fmt.Printf("Успешно считана задача по ID=%d:%s", 1, stringlifyTask(res6))
 
 	// Считать заметку по ее ID
 	res7, err := cl.GetNotesById(context.Background(), &remindables_api.GetNoteRequest{
@@ -225,10 +173,10 @@ func main() {
 		fmt.Println(err.Error())
 		return
 	}
-	fmt.Printf("Успешно считана заметка по ID=%d:%s", 1, stringlifyGottenNote(res7))
+	fmt.Printf("Успешно считана заметка по ID=%d:%s", 1, stringlifyNote(res7))
 
 	// Изменить задачу по ее ID
-	dueDate, _ = time.Parse("02.01.2006", "31.12.2026")
+	dueDate = mustParseDate("31.12.2026", loc)
 	res8, err := cl.PutTaskById(context.Background(), &remindables_api.PutTaskRequest{
 		Id:          1,
 		Name:        "new taskName 1",
@@ -239,10 +187,10 @@ func main() {
 		fmt.Println(err.Error())
 		return
 	}
-	fmt.Printf("Успешно изменена задача с ID=%d:%s", 1, stringlifyPutTask(res8))
+	fmt.Printf("Успешно изменена задача с ID=%d:%s", 1, stringlifyTask(res8))
 
 	// Изменить заметку по ее ID
-	alarmTimeStamp, _ = time.Parse("02.01.2006 15:04", "31.12.2026 23:59")
+	alarmTimeStamp = mustParseDate("2026-12-31 23:59", loc)
 	res9, err := cl.PutNoteById(context.Background(), &remindables_api.PutNoteRequest{
 		Id:             1,
 		Name:           "new noteName 1",
@@ -253,7 +201,7 @@ func main() {
 		fmt.Println(err.Error())
 		return
 	}
-	fmt.Printf("Успешно изменена заметка с ID=%d:%s", 1, stringlifyPutNote(res9))
+	fmt.Printf("Успешно изменена заметка с ID=%d:%s", 1, stringlifyNote(res9))
 
 	// Удалить задачу по ее ID
 	res10, err := cl.DeleteTaskById(context.Background(), &remindables_api.DeleteTaskRequest{
@@ -263,7 +211,7 @@ func main() {
 		fmt.Println(err.Error())
 		return
 	}
-	fmt.Printf("Успешно удалена задача с ID=%d:%s", 2, stringlifyDeletedTask(res10))
+	fmt.Printf("Успешно удалена задача с ID=%d:%s", 2, stringlifyTask(res10))
 
 	// Удалить заметку по ее ID
 	res11, err := cl.DeleteNoteById(context.Background(), &remindables_api.DeleteNoteRequest{
@@ -273,5 +221,5 @@ func main() {
 		fmt.Println(err.Error())
 		return
 	}
-	fmt.Printf("Успешно удалена заметка с ID=%d:%s", 2, stringlifyDeletedNote(res11))
+	fmt.Printf("Успешно удалена заметка с ID=%d:%s", 2, stringlifyNote(res11))
 }
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/12_gRPC/internal/model"
+)
+
+// ErrNotFound запись с указанным Id не найдена
+var ErrNotFound = errors.New("запись не найдена")
+
+// TaskStore хранилище задач
+type TaskStore interface {
+	ListTasks(ctx context.Context) ([]model.Task, error)
+	GetTask(ctx context.Context, id int) (model.Task, error)
+	CreateTask(ctx context.Context, name, description string, dueDate time.Time) (model.Task, error)
+	UpdateTask(ctx context.Context, id int, name, description string, dueDate time.Time) (model.Task, error)
+	DeleteTask(ctx context.Context, id int) (model.Task, error)
+}
+
+// NoteStore хранилище заметок
+type NoteStore interface {
+	ListNotes(ctx context.Context) ([]model.Note, error)
+	GetNote(ctx context.Context, id int) (model.Note, error)
+	CreateNote(ctx context.Context, name, description string, alarmTimeStamp time.Time) (model.Note, error)
+	UpdateNote(ctx context.Context, id int, name, description string, alarmTimeStamp time.Time) (model.Note, error)
+	DeleteNote(ctx context.Context, id int) (model.Note, error)
+}
+
+// FileStore хранилище задач и заметок в срезах Tasks/Notes и файлах tasks.json/notes.json
+type FileStore struct {
+	mu sync.RWMutex
+}
+
+// NewFileStore создаёт хранилище поверх срезов Tasks/Notes,
+// заполненных FillTasksFromJSON/FillNotesFromJSON
+func NewFileStore() *FileStore {
+	return &FileStore{}
+}
+
+// ListTasks возвращает копию среза задач
+func (s *FileStore) ListTasks(_ context.Context) ([]model.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(Tasks), nil
+}
+
+// GetTask возвращает задачу по Id
+func (s *FileStore) GetTask(_ context.Context, id int) (model.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	idx := slices.IndexFunc(Tasks, func(task model.Task) bool {
+		return task.Id == id
+	})
+	if idx == -1 {
+		return model.Task{}, ErrNotFound
+	}
+	return Tasks[idx], nil
+}
+
+// CreateTask создаёт задачу и дописывает её в tasks.json
+func (s *FileStore) CreateTask(_ context.Context, name, description string, dueDate time.Time) (model.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task := *PostNewTask(name, description, dueDate)
+	if task.Id == 0 {
+		return model.Task{}, errors.New("ошибка создания новой задачи")
+	}
+	return task, nil
+}
+
+// UpdateTask изменяет задачу по Id и перезаписывает tasks.json
+func (s *FileStore) UpdateTask(_ context.Context, id int, name, description string, dueDate time.Time) (model.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task := *PutTaskById(int32(id), name, description, dueDate)
+	if task.Id == 0 {
+		return model.Task{}, ErrNotFound
+	}
+	return task, nil
+}
+
+// DeleteTask удаляет задачу по Id и перезаписывает tasks.json
+func (s *FileStore) DeleteTask(_ context.Context, id int) (model.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task := *DeleteTaskById(int32(id))
+	if task.Id == 0 {
+		return model.Task{}, ErrNotFound
+	}
+	return task, nil
+}
+
+// ListNotes возвращает копию среза заметок
+func (s *FileStore) ListNotes(_ context.Context) ([]model.Note, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(Notes), nil
+}
+
+// GetNote возвращает заметку по Id
+func (s *FileStore) GetNote(_ context.Context, id int) (model.Note, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	idx := slices.IndexFunc(Notes, func(note model.Note) bool {
+		return note.Id == id
+	})
+	if idx == -1 {
+		return model.Note{}, ErrNotFound
+	}
+	return Notes[idx], nil
+}
+
+// CreateNote создаёт заметку и дописывает её в notes.json
+func (s *FileStore) CreateNote(_ context.Context, name, description string, alarmTimeStamp time.Time) (model.Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	note := *PostNewNote(name, description, alarmTimeStamp)
+	if note.Id == 0 {
+		return model.Note{}, errors.New("ошибка создания новой заметки")
+	}
+	return note, nil
+}
+
+// UpdateNote изменяет заметку по Id и перезаписывает notes.json
+func (s *FileStore) UpdateNote(_ context.Context, id int, name, description string, alarmTimeStamp time.Time) (model.Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	note := *PutNoteById(int32(id), name, description, alarmTimeStamp)
+	if note.Id == 0 {
+		return model.Note{}, ErrNotFound
+	}
+	return note, nil
+}
+
+// DeleteNote удаляет заметку по Id и перезаписывает notes.json
+func (s *FileStore) DeleteNote(_ context.Context, id int) (model.Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	note := *DeleteNoteById(int32(id))
+	if note.Id == 0 {
+		return model.Note{}, ErrNotFound
+	}
+	return note, nil
+}
@@ -1,3 +1,3 @@
 module github.com/corridda/OTUS_Golang_Developer/Basic/Homework/12_gRPC/proto_api
 
-go 1.26.1
+go 1.26.0
@@ -11,7 +11,9 @@ package remindables_api
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -25,9 +27,11 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// GetTaskRequest запрос задачи; includeNotes включает в ответ GetTasksById прикреплённые заметки
 type GetTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeNotes  bool                   `protobuf:"varint,2,opt,name=includeNotes,proto3" json:"includeNotes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -69,6 +73,13 @@ func (x *GetTaskRequest) GetId() int32 {
 	return 0
 }
 
+func (x *GetTaskRequest) GetIncludeNotes() bool {
+	if x != nil {
+		return x.IncludeNotes
+	}
+	return false
+}
+
 type GetNoteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -114,10 +125,19 @@ func (x *GetNoteRequest) GetId() int32 {
 }
 
 type PostNewTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=dueDate,proto3" json:"dueDate,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	DueDate     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=dueDate,proto3" json:"dueDate,omitempty"`
+	// текстовая дата, напр. "завтра 9:00"; если задана, используется вместо dueDate
+	// и разбирается в часовом поясе из метаданных x-timezone
+	DueDateText string `protobuf:"bytes,4,opt,name=dueDateText,proto3" json:"dueDateText,omitempty"`
+	// правило повторения RRULE, напр. "FREQ=WEEKLY;BYDAY=MO"; пусто - без повторения
+	Recurrence string `protobuf:"bytes,5,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// приоритет: low, normal, high, urgent; пусто - normal
+	Priority string `protobuf:"bytes,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	// метки; приводятся к нижнему регистру, повторы удаляются
+	Tags          []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -173,13 +193,50 @@ func (x *PostNewTaskRequest) GetDueDate() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *PostNewTaskRequest) GetDueDateText() string {
+	if x != nil {
+		return x.DueDateText
+	}
+	return ""
+}
+
+func (x *PostNewTaskRequest) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *PostNewTaskRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *PostNewTaskRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type PostNewNoteRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
 	AlarmTimeStamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=alarmTimeStamp,proto3" json:"alarmTimeStamp,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// текстовая дата, напр. "завтра 9:00"; если задана, используется вместо alarmTimeStamp
+	// и разбирается в часовом поясе из метаданных x-timezone
+	AlarmTimeStampText string `protobuf:"bytes,4,opt,name=alarmTimeStampText,proto3" json:"alarmTimeStampText,omitempty"`
+	// правило повторения RRULE, напр. "FREQ=WEEKLY;BYDAY=MO"; пусто - без повторения
+	Recurrence string `protobuf:"bytes,5,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// приоритет: low, normal, high, urgent; пусто - normal
+	Priority string `protobuf:"bytes,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	// метки; приводятся к нижнему регистру, повторы удаляются
+	Tags          []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PostNewNoteRequest) Reset() {
@@ -233,12 +290,55 @@ func (x *PostNewNoteRequest) GetAlarmTimeStamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *PostNewNoteRequest) GetAlarmTimeStampText() string {
+	if x != nil {
+		return x.AlarmTimeStampText
+	}
+	return ""
+}
+
+func (x *PostNewNoteRequest) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *PostNewNoteRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *PostNewNoteRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type PutTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=dueDate,proto3" json:"dueDate,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	DueDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=dueDate,proto3" json:"dueDate,omitempty"`
+	// текстовая дата, напр. "завтра 9:00"; если задана, используется вместо dueDate
+	// и разбирается в часовом поясе из метаданных x-timezone
+	DueDateText string `protobuf:"bytes,5,opt,name=dueDateText,proto3" json:"dueDateText,omitempty"`
+	// правило повторения RRULE, напр. "FREQ=WEEKLY;BYDAY=MO"; пусто - без повторения
+	Recurrence string `protobuf:"bytes,6,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// приоритет: low, normal, high, urgent; пусто - normal
+	Priority string `protobuf:"bytes,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	// метки; приводятся к нижнему регистру, повторы удаляются
+	Tags []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	// ожидаемая версия задачи; если версия не совпадает, задача не изменяется
+	// и возвращается FAILED_PRECONDITION. 0 - без проверки версии
+	ExpectedVersion int32 `protobuf:"varint,9,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	// изменяемые поля: name, description, dueDate (вместе с dueDateText), recurrence, priority, tags;
+	// остальные поля задачи не меняются. Без маски задача заменяется полями запроса целиком
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,10,opt,name=updateMask,proto3" json:"updateMask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -301,14 +401,70 @@ func (x *PutTaskRequest) GetDueDate() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *PutTaskRequest) GetDueDateText() string {
+	if x != nil {
+		return x.DueDateText
+	}
+	return ""
+}
+
+func (x *PutTaskRequest) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *PutTaskRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *PutTaskRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PutTaskRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *PutTaskRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type PutNoteRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	AlarmTimeStamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=alarmTimeStamp,proto3" json:"alarmTimeStamp,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// текстовая дата, напр. "завтра 9:00"; если задана, используется вместо alarmTimeStamp
+	// и разбирается в часовом поясе из метаданных x-timezone
+	AlarmTimeStampText string `protobuf:"bytes,5,opt,name=alarmTimeStampText,proto3" json:"alarmTimeStampText,omitempty"`
+	// правило повторения RRULE, напр. "FREQ=WEEKLY;BYDAY=MO"; пусто - без повторения
+	Recurrence string `protobuf:"bytes,6,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// приоритет: low, normal, high, urgent; пусто - normal
+	Priority string `protobuf:"bytes,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	// метки; приводятся к нижнему регистру, повторы удаляются
+	Tags []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	// ожидаемая версия заметки; соглашения те же, что у PutTaskRequest.expectedVersion
+	ExpectedVersion int32 `protobuf:"varint,9,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	// изменяемые поля: name, description, alarmTimeStamp (вместе с alarmTimeStampText), recurrence,
+	// priority, tags; соглашения те же, что у PutTaskRequest.updateMask
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,10,opt,name=updateMask,proto3" json:"updateMask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PutNoteRequest) Reset() {
@@ -369,11 +525,61 @@ func (x *PutNoteRequest) GetAlarmTimeStamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *PutNoteRequest) GetAlarmTimeStampText() string {
+	if x != nil {
+		return x.AlarmTimeStampText
+	}
+	return ""
+}
+
+func (x *PutNoteRequest) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *PutNoteRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *PutNoteRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PutNoteRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *PutNoteRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// DeleteTaskRequest удаление задачи; cascade - политика удаления подзадач:
+// restrict (по умолчанию), cascade или orphan; notes - политика удаления прикреплённых
+// заметок: detach (по умолчанию) или delete
 type DeleteTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Cascade string                 `protobuf:"bytes,2,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	Notes   string                 `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
+	// ожидаемая версия задачи; если версия не совпадает, задача не удаляется
+	// и возвращается FAILED_PRECONDITION. 0 - без проверки версии
+	ExpectedVersion int32 `protobuf:"varint,4,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DeleteTaskRequest) Reset() {
@@ -413,11 +619,34 @@ func (x *DeleteTaskRequest) GetId() int32 {
 	return 0
 }
 
+func (x *DeleteTaskRequest) GetCascade() string {
+	if x != nil {
+		return x.Cascade
+	}
+	return ""
+}
+
+func (x *DeleteTaskRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *DeleteTaskRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
 type DeleteNoteRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ожидаемая версия заметки; соглашения те же, что у PutTaskRequest.expectedVersion
+	ExpectedVersion int32 `protobuf:"varint,2,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DeleteNoteRequest) Reset() {
@@ -457,7 +686,15 @@ func (x *DeleteNoteRequest) GetId() int32 {
 	return 0
 }
 
-type GetTaskResponse struct {
+func (x *DeleteNoteRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+// Task задача; возвращается всеми методами, работающими с задачами
+type Task struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -465,24 +702,41 @@ type GetTaskResponse struct {
 	InitTimeStamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=initTimeStamp,proto3" json:"initTimeStamp,omitempty"`
 	DueDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=dueDate,proto3" json:"dueDate,omitempty"`
 	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Recurrence    string                 `protobuf:"bytes,7,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	Timezone      string                 `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// состояние напоминания о сроке: pending, fired, snoozed, acknowledged, dismissed
+	ReminderState string `protobuf:"bytes,9,opt,name=reminderState,proto3" json:"reminderState,omitempty"`
+	// срок до откладывания напоминания; не задан, если напоминание не откладывалось
+	SnoozedFrom *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=snoozedFrom,proto3" json:"snoozedFrom,omitempty"`
+	Priority    string                 `protobuf:"bytes,11,opt,name=priority,proto3" json:"priority,omitempty"`
+	Tags        []string               `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Id родительской задачи; 0 - задача не является подзадачей
+	ParentId  int32   `protobuf:"varint,13,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	BlockedBy []int32 `protobuf:"varint,14,rep,packed,name=blockedBy,proto3" json:"blockedBy,omitempty"`
+	// заметки, прикреплённые к задаче; только в ответе GetTasksById с includeNotes
+	Notes []*Note `protobuf:"bytes,15,rep,name=notes,proto3" json:"notes,omitempty"`
+	// время перемещения в корзину; задано у задач из корзины и в ответе DeleteTaskById
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`
+	// версия задачи; увеличивается при каждом изменении
+	Version       int32 `protobuf:"varint,17,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskResponse) Reset() {
-	*x = GetTaskResponse{}
+func (x *Task) Reset() {
+	*x = Task{}
 	mi := &file_api_grpc_v1_remindables_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskResponse) String() string {
+func (x *Task) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskResponse) ProtoMessage() {}
+func (*Task) ProtoMessage() {}
 
-func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+func (x *Task) ProtoReflect() protoreflect.Message {
 	mi := &file_api_grpc_v1_remindables_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -494,148 +748,172 @@ func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
-func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
 	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetTaskResponse) GetId() int32 {
+func (x *Task) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *GetTaskResponse) GetName() string {
+func (x *Task) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *GetTaskResponse) GetDescription() string {
+func (x *Task) GetDescription() string {
 	if x != nil {
 		return x.Description
 	}
 	return ""
 }
 
-func (x *GetTaskResponse) GetInitTimeStamp() *timestamppb.Timestamp {
+func (x *Task) GetInitTimeStamp() *timestamppb.Timestamp {
 	if x != nil {
 		return x.InitTimeStamp
 	}
 	return nil
 }
 
-func (x *GetTaskResponse) GetDueDate() *timestamppb.Timestamp {
+func (x *Task) GetDueDate() *timestamppb.Timestamp {
 	if x != nil {
 		return x.DueDate
 	}
 	return nil
 }
 
-func (x *GetTaskResponse) GetStatus() string {
+func (x *Task) GetStatus() string {
 	if x != nil {
 		return x.Status
 	}
 	return ""
 }
 
-type GetNoteResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	AlarmTimeStamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=alarmTimeStamp,proto3" json:"alarmTimeStamp,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *Task) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
 }
 
-func (x *GetNoteResponse) Reset() {
-	*x = GetNoteResponse{}
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Task) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
 }
 
-func (x *GetNoteResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Task) GetReminderState() string {
+	if x != nil {
+		return x.ReminderState
+	}
+	return ""
 }
 
-func (*GetNoteResponse) ProtoMessage() {}
+func (x *Task) GetSnoozedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SnoozedFrom
+	}
+	return nil
+}
 
-func (x *GetNoteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[9]
+func (x *Task) GetPriority() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Priority
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetNoteResponse.ProtoReflect.Descriptor instead.
-func (*GetNoteResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{9}
+func (x *Task) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
 }
 
-func (x *GetNoteResponse) GetId() int32 {
+func (x *Task) GetParentId() int32 {
 	if x != nil {
-		return x.Id
+		return x.ParentId
 	}
 	return 0
 }
 
-func (x *GetNoteResponse) GetName() string {
+func (x *Task) GetBlockedBy() []int32 {
 	if x != nil {
-		return x.Name
+		return x.BlockedBy
 	}
-	return ""
+	return nil
 }
 
-func (x *GetNoteResponse) GetDescription() string {
+func (x *Task) GetNotes() []*Note {
 	if x != nil {
-		return x.Description
+		return x.Notes
 	}
-	return ""
+	return nil
 }
 
-func (x *GetNoteResponse) GetAlarmTimeStamp() *timestamppb.Timestamp {
+func (x *Task) GetDeletedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.AlarmTimeStamp
+		return x.DeletedAt
 	}
 	return nil
 }
 
-type PostNewTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	InitTimeStamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=initTimeStamp,proto3" json:"initTimeStamp,omitempty"`
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=dueDate,proto3" json:"dueDate,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+func (x *Task) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Note заметка; возвращается всеми методами, работающими с заметками
+type Note struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	AlarmTimeStamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=alarmTimeStamp,proto3" json:"alarmTimeStamp,omitempty"`
+	Recurrence     string                 `protobuf:"bytes,5,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	Timezone       string                 `protobuf:"bytes,6,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// состояние напоминания: pending, fired, snoozed, acknowledged, dismissed
+	ReminderState string `protobuf:"bytes,7,opt,name=reminderState,proto3" json:"reminderState,omitempty"`
+	// время напоминания до откладывания; не задано, если напоминание не откладывалось
+	SnoozedFrom *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=snoozedFrom,proto3" json:"snoozedFrom,omitempty"`
+	Priority    string                 `protobuf:"bytes,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	Tags        []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Id задачи, к которой прикреплена заметка; 0 - заметка не прикреплена
+	TaskId int32 `protobuf:"varint,11,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	// время перемещения в корзину; задано у заметок из корзины и в ответе DeleteNoteById
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`
+	// версия заметки; увеличивается при каждом изменении
+	Version int32 `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	// дата создания заметки
+	InitTimeStamp *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=initTimeStamp,proto3" json:"initTimeStamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostNewTaskResponse) Reset() {
-	*x = PostNewTaskResponse{}
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[10]
+func (x *Note) Reset() {
+	*x = Note{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostNewTaskResponse) String() string {
+func (x *Note) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostNewTaskResponse) ProtoMessage() {}
+func (*Note) ProtoMessage() {}
 
-func (x *PostNewTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[10]
+func (x *Note) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -646,148 +924,1015 @@ func (x *PostNewTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostNewTaskResponse.ProtoReflect.Descriptor instead.
-func (*PostNewTaskResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use Note.ProtoReflect.Descriptor instead.
+func (*Note) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *PostNewTaskResponse) GetId() int32 {
+func (x *Note) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *PostNewTaskResponse) GetName() string {
+func (x *Note) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *PostNewTaskResponse) GetDescription() string {
+func (x *Note) GetDescription() string {
 	if x != nil {
 		return x.Description
 	}
 	return ""
 }
 
-func (x *PostNewTaskResponse) GetInitTimeStamp() *timestamppb.Timestamp {
+func (x *Note) GetAlarmTimeStamp() *timestamppb.Timestamp {
 	if x != nil {
-		return x.InitTimeStamp
+		return x.AlarmTimeStamp
 	}
 	return nil
 }
 
-func (x *PostNewTaskResponse) GetDueDate() *timestamppb.Timestamp {
+func (x *Note) GetRecurrence() string {
 	if x != nil {
-		return x.DueDate
+		return x.Recurrence
 	}
-	return nil
+	return ""
 }
 
-func (x *PostNewTaskResponse) GetStatus() string {
+func (x *Note) GetTimezone() string {
 	if x != nil {
-		return x.Status
+		return x.Timezone
 	}
 	return ""
 }
 
-type PostNewNoteResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	AlarmTimeStamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=alarmTimeStamp,proto3" json:"alarmTimeStamp,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
-}
-
-func (x *PostNewNoteResponse) Reset() {
-	*x = PostNewNoteResponse{}
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[11]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Note) GetReminderState() string {
+	if x != nil {
+		return x.ReminderState
+	}
+	return ""
 }
 
-func (x *PostNewNoteResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Note) GetSnoozedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SnoozedFrom
+	}
+	return nil
 }
 
-func (*PostNewNoteResponse) ProtoMessage() {}
-
-func (x *PostNewNoteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[11]
+func (x *Note) GetPriority() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Priority
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use PostNewNoteResponse.ProtoReflect.Descriptor instead.
-func (*PostNewNoteResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{11}
+func (x *Note) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
 }
 
-func (x *PostNewNoteResponse) GetId() int32 {
+func (x *Note) GetTaskId() int32 {
 	if x != nil {
-		return x.Id
+		return x.TaskId
 	}
 	return 0
 }
 
-func (x *PostNewNoteResponse) GetName() string {
+func (x *Note) GetDeletedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Name
+		return x.DeletedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *PostNewNoteResponse) GetDescription() string {
+func (x *Note) GetVersion() int32 {
 	if x != nil {
-		return x.Description
+		return x.Version
 	}
-	return ""
+	return 0
 }
 
-func (x *PostNewNoteResponse) GetAlarmTimeStamp() *timestamppb.Timestamp {
+func (x *Note) GetInitTimeStamp() *timestamppb.Timestamp {
 	if x != nil {
-		return x.AlarmTimeStamp
+		return x.InitTimeStamp
 	}
 	return nil
 }
 
-type PutTaskResponse struct {
+type TransitionTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	InitTimeStamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=initTimeStamp,proto3" json:"initTimeStamp,omitempty"`
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=dueDate,proto3" json:"dueDate,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PutTaskResponse) Reset() {
-	*x = PutTaskResponse{}
+func (x *TransitionTaskRequest) Reset() {
+	*x = TransitionTaskRequest{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionTaskRequest) ProtoMessage() {}
+
+func (x *TransitionTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionTaskRequest.ProtoReflect.Descriptor instead.
+func (*TransitionTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TransitionTaskRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TransitionTaskRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type StatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=changedAt,proto3" json:"changedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusChange) Reset() {
+	*x = StatusChange{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusChange) ProtoMessage() {}
+
+func (x *StatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusChange.ProtoReflect.Descriptor instead.
+func (*StatusChange) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StatusChange) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *StatusChange) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *StatusChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *StatusChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+type GetTaskHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int32                  `protobuf:"varint,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	Items         []*StatusChange        `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskHistoryResponse) Reset() {
+	*x = GetTaskHistoryResponse{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryResponse) ProtoMessage() {}
+
+func (x *GetTaskHistoryResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_grpc_v1_remindables_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTaskHistoryResponse) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *GetTaskHistoryResponse) GetItems() []*StatusChange {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// OccurrencesRequest запрос повторений задачи или заметки в интервале [from, to);
+// без from - от текущего момента, без to - 90 дней от from, без limit - 100 повторений
+type OccurrencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OccurrencesRequest) Reset() {
+	*x = OccurrencesRequest{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OccurrencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OccurrencesRequest) ProtoMessage() {}
+
+func (x *OccurrencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OccurrencesRequest.ProtoReflect.Descriptor instead.
+func (*OccurrencesRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *OccurrencesRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OccurrencesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *OccurrencesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *OccurrencesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type OccurrencesResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Id            int32                    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Recurrence    string                   `protobuf:"bytes,2,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	Timezone      string                   `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Items         []*timestamppb.Timestamp `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OccurrencesResponse) Reset() {
+	*x = OccurrencesResponse{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OccurrencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OccurrencesResponse) ProtoMessage() {}
+
+func (x *OccurrencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OccurrencesResponse.ProtoReflect.Descriptor instead.
+func (*OccurrencesResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OccurrencesResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OccurrencesResponse) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *OccurrencesResponse) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *OccurrencesResponse) GetItems() []*timestamppb.Timestamp {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// SnoozeRequest откладывание сработавшего напоминания задачи или заметки:
+// задаётся ровно одно из полей duration, until, untilText
+type SnoozeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Duration *durationpb.Duration   `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	Until    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	// текстовое время, напр. "завтра 9:00"; разбирается в часовом поясе из метаданных x-timezone
+	UntilText     string `protobuf:"bytes,4,opt,name=untilText,proto3" json:"untilText,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnoozeRequest) Reset() {
+	*x = SnoozeRequest{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnoozeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnoozeRequest) ProtoMessage() {}
+
+func (x *SnoozeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnoozeRequest.ProtoReflect.Descriptor instead.
+func (*SnoozeRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SnoozeRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SnoozeRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *SnoozeRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *SnoozeRequest) GetUntilText() string {
+	if x != nil {
+		return x.UntilText
+	}
+	return ""
+}
+
+type Snooze struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	SnoozedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=snoozedAt,proto3" json:"snoozedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snooze) Reset() {
+	*x = Snooze{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snooze) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snooze) ProtoMessage() {}
+
+func (x *Snooze) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snooze.ProtoReflect.Descriptor instead.
+func (*Snooze) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Snooze) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *Snooze) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *Snooze) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Snooze) GetSnoozedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SnoozedAt
+	}
+	return nil
+}
+
+type GetSnoozesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Items         []*Snooze              `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSnoozesResponse) Reset() {
+	*x = GetSnoozesResponse{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSnoozesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnoozesResponse) ProtoMessage() {}
+
+func (x *GetSnoozesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnoozesResponse.ProtoReflect.Descriptor instead.
+func (*GetSnoozesResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetSnoozesResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetSnoozesResponse) GetItems() []*Snooze {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// ListTasksRequest отбор задач по приоритету (любой из priority) и меткам (все из tags);
+// пустые поля не ограничивают отбор
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Priority      []string               `protobuf:"bytes,1,rep,name=priority,proto3" json:"priority,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListTasksRequest) GetPriority() []string {
+	if x != nil {
+		return x.Priority
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// ListNotesRequest отбор заметок; соглашения те же, что у ListTasksRequest
+type ListNotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Priority      []string               `protobuf:"bytes,1,rep,name=priority,proto3" json:"priority,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotesRequest) Reset() {
+	*x = ListNotesRequest{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotesRequest) ProtoMessage() {}
+
+func (x *ListNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotesRequest.ProtoReflect.Descriptor instead.
+func (*ListNotesRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListNotesRequest) GetPriority() []string {
+	if x != nil {
+		return x.Priority
+	}
+	return nil
+}
+
+func (x *ListNotesRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// SetTaskParentRequest смена родительской задачи; parentId = 0 отвязывает задачу от родителя
+type SetTaskParentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParentId      int32                  `protobuf:"varint,2,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTaskParentRequest) Reset() {
+	*x = SetTaskParentRequest{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTaskParentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTaskParentRequest) ProtoMessage() {}
+
+func (x *SetTaskParentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTaskParentRequest.ProtoReflect.Descriptor instead.
+func (*SetTaskParentRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetTaskParentRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetTaskParentRequest) GetParentId() int32 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+// TaskBlockerRequest блокировка задачи id задачей blockerId или снятие блокировки
+type TaskBlockerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BlockerId     int32                  `protobuf:"varint,2,opt,name=blockerId,proto3" json:"blockerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskBlockerRequest) Reset() {
+	*x = TaskBlockerRequest{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskBlockerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskBlockerRequest) ProtoMessage() {}
+
+func (x *TaskBlockerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskBlockerRequest.ProtoReflect.Descriptor instead.
+func (*TaskBlockerRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TaskBlockerRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskBlockerRequest) GetBlockerId() int32 {
+	if x != nil {
+		return x.BlockerId
+	}
+	return 0
+}
+
+// TaskTreeResponse узел дерева зависимостей: задача, её подзадачи и блокирующие её задачи
+type TaskTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Subtasks      []*TaskTreeResponse    `protobuf:"bytes,2,rep,name=subtasks,proto3" json:"subtasks,omitempty"`
+	BlockedBy     []*TaskTreeResponse    `protobuf:"bytes,3,rep,name=blockedBy,proto3" json:"blockedBy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskTreeResponse) Reset() {
+	*x = TaskTreeResponse{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskTreeResponse) ProtoMessage() {}
+
+func (x *TaskTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskTreeResponse.ProtoReflect.Descriptor instead.
+func (*TaskTreeResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TaskTreeResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskTreeResponse) GetSubtasks() []*TaskTreeResponse {
+	if x != nil {
+		return x.Subtasks
+	}
+	return nil
+}
+
+func (x *TaskTreeResponse) GetBlockedBy() []*TaskTreeResponse {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return nil
+}
+
+// SetNoteTaskRequest прикрепление заметки к задаче; taskId = 0 открепляет заметку
+type SetNoteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        int32                  `protobuf:"varint,2,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetNoteTaskRequest) Reset() {
+	*x = SetNoteTaskRequest{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNoteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNoteTaskRequest) ProtoMessage() {}
+
+func (x *SetNoteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNoteTaskRequest.ProtoReflect.Descriptor instead.
+func (*SetNoteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetNoteTaskRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetNoteTaskRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+// Credentials логин и пароль пользователя
+type Credentials struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Credentials) Reset() {
+	*x = Credentials{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Credentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Credentials) ProtoMessage() {}
+
+func (x *Credentials) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Credentials.ProtoReflect.Descriptor instead.
+func (*Credentials) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Credentials) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *Credentials) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// UserResponse учётная запись пользователя
+type UserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserResponse) Reset() {
+	*x = UserResponse{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PutTaskResponse) String() string {
+func (x *UserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutTaskResponse) ProtoMessage() {}
+func (*UserResponse) ProtoMessage() {}
 
-func (x *PutTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[12]
+func (x *UserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,78 +1943,180 @@ func (x *PutTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutTaskResponse.ProtoReflect.Descriptor instead.
-func (*PutTaskResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
+func (*UserResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *PutTaskResponse) GetId() int32 {
+func (x *UserResponse) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *PutTaskResponse) GetName() string {
+func (x *UserResponse) GetLogin() string {
 	if x != nil {
-		return x.Name
+		return x.Login
 	}
 	return ""
 }
 
-func (x *PutTaskResponse) GetDescription() string {
+func (x *UserResponse) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Description
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// LoginResponse токен доступа; остальные методы ждут его в метаданных authorization: Bearer <token>
+type LoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	User          *UserResponse          `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LoginResponse) GetToken() string {
+	if x != nil {
+		return x.Token
 	}
 	return ""
 }
 
-func (x *PutTaskResponse) GetInitTimeStamp() *timestamppb.Timestamp {
+func (x *LoginResponse) GetExpiresAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.InitTimeStamp
+		return x.ExpiresAt
 	}
 	return nil
 }
 
-func (x *PutTaskResponse) GetDueDate() *timestamppb.Timestamp {
+func (x *LoginResponse) GetUser() *UserResponse {
 	if x != nil {
-		return x.DueDate
+		return x.User
 	}
 	return nil
 }
 
-func (x *PutTaskResponse) GetStatus() string {
+// ShareTarget объект доступа: задача или заметка с Id entityId либо список - задачи и заметки
+// владельца с меткой list; entityType - task, note или list
+type ShareTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntityType    string                 `protobuf:"bytes,1,opt,name=entityType,proto3" json:"entityType,omitempty"`
+	EntityId      int32                  `protobuf:"varint,2,opt,name=entityId,proto3" json:"entityId,omitempty"`
+	List          string                 `protobuf:"bytes,3,opt,name=list,proto3" json:"list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareTarget) Reset() {
+	*x = ShareTarget{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareTarget) ProtoMessage() {}
+
+func (x *ShareTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[27]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareTarget.ProtoReflect.Descriptor instead.
+func (*ShareTarget) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ShareTarget) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
 	}
 	return ""
 }
 
-type PutNoteResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	AlarmTimeStamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=alarmTimeStamp,proto3" json:"alarmTimeStamp,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *ShareTarget) GetEntityId() int32 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
 }
 
-func (x *PutNoteResponse) Reset() {
-	*x = PutNoteResponse{}
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[13]
+func (x *ShareTarget) GetList() string {
+	if x != nil {
+		return x.List
+	}
+	return ""
+}
+
+// ShareRequest выдача пользователю login роли role (viewer или editor)
+type ShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        *ShareTarget           `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareRequest) Reset() {
+	*x = ShareRequest{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PutNoteResponse) String() string {
+func (x *ShareRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutNoteResponse) ProtoMessage() {}
+func (*ShareRequest) ProtoMessage() {}
 
-func (x *PutNoteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[13]
+func (x *ShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -880,66 +2127,114 @@ func (x *PutNoteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutNoteResponse.ProtoReflect.Descriptor instead.
-func (*PutNoteResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ShareRequest.ProtoReflect.Descriptor instead.
+func (*ShareRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *PutNoteResponse) GetId() int32 {
+func (x *ShareRequest) GetTarget() *ShareTarget {
 	if x != nil {
-		return x.Id
+		return x.Target
 	}
-	return 0
+	return nil
 }
 
-func (x *PutNoteResponse) GetName() string {
+func (x *ShareRequest) GetLogin() string {
 	if x != nil {
-		return x.Name
+		return x.Login
 	}
 	return ""
 }
 
-func (x *PutNoteResponse) GetDescription() string {
+func (x *ShareRequest) GetRole() string {
 	if x != nil {
-		return x.Description
+		return x.Role
 	}
 	return ""
 }
 
-func (x *PutNoteResponse) GetAlarmTimeStamp() *timestamppb.Timestamp {
+// UnshareRequest отзыв доступа пользователя login
+type UnshareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        *ShareTarget           `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareRequest) Reset() {
+	*x = UnshareRequest{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareRequest) ProtoMessage() {}
+
+func (x *UnshareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[29]
 	if x != nil {
-		return x.AlarmTimeStamp
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareRequest.ProtoReflect.Descriptor instead.
+func (*UnshareRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UnshareRequest) GetTarget() *ShareTarget {
+	if x != nil {
+		return x.Target
 	}
 	return nil
 }
 
-type DeleteTaskResponse struct {
+func (x *UnshareRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+// ShareResponse выданный доступ
+type ShareResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	InitTimeStamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=initTimeStamp,proto3" json:"initTimeStamp,omitempty"`
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=dueDate,proto3" json:"dueDate,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Target        *ShareTarget           `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	OwnerId       int32                  `protobuf:"varint,3,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	UserId        int32                  `protobuf:"varint,4,opt,name=userId,proto3" json:"userId,omitempty"`
+	Login         string                 `protobuf:"bytes,5,opt,name=login,proto3" json:"login,omitempty"`
+	Role          string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTaskResponse) Reset() {
-	*x = DeleteTaskResponse{}
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[14]
+func (x *ShareResponse) Reset() {
+	*x = ShareResponse{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTaskResponse) String() string {
+func (x *ShareResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTaskResponse) ProtoMessage() {}
+func (*ShareResponse) ProtoMessage() {}
 
-func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[14]
+func (x *ShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -950,78 +2245,83 @@ func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ShareResponse.ProtoReflect.Descriptor instead.
+func (*ShareResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *DeleteTaskResponse) GetId() int32 {
+func (x *ShareResponse) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *DeleteTaskResponse) GetName() string {
+func (x *ShareResponse) GetTarget() *ShareTarget {
 	if x != nil {
-		return x.Name
+		return x.Target
 	}
-	return ""
+	return nil
 }
 
-func (x *DeleteTaskResponse) GetDescription() string {
+func (x *ShareResponse) GetOwnerId() int32 {
 	if x != nil {
-		return x.Description
+		return x.OwnerId
 	}
-	return ""
+	return 0
 }
 
-func (x *DeleteTaskResponse) GetInitTimeStamp() *timestamppb.Timestamp {
+func (x *ShareResponse) GetUserId() int32 {
 	if x != nil {
-		return x.InitTimeStamp
+		return x.UserId
 	}
-	return nil
+	return 0
 }
 
-func (x *DeleteTaskResponse) GetDueDate() *timestamppb.Timestamp {
+func (x *ShareResponse) GetLogin() string {
 	if x != nil {
-		return x.DueDate
+		return x.Login
 	}
-	return nil
+	return ""
 }
 
-func (x *DeleteTaskResponse) GetStatus() string {
+func (x *ShareResponse) GetRole() string {
 	if x != nil {
-		return x.Status
+		return x.Role
 	}
 	return ""
 }
 
-type DeleteNoteResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	AlarmTimeStamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=alarmTimeStamp,proto3" json:"alarmTimeStamp,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *ShareResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *DeleteNoteResponse) Reset() {
-	*x = DeleteNoteResponse{}
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[15]
+// SharesResponse доступы, выданные к объекту
+type SharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ShareResponse       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharesResponse) Reset() {
+	*x = SharesResponse{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteNoteResponse) String() string {
+func (x *SharesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteNoteResponse) ProtoMessage() {}
+func (*SharesResponse) ProtoMessage() {}
 
-func (x *DeleteNoteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_v1_remindables_proto_msgTypes[15]
+func (x *SharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1032,35 +2332,67 @@ func (x *DeleteNoteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteNoteResponse.ProtoReflect.Descriptor instead.
-func (*DeleteNoteResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use SharesResponse.ProtoReflect.Descriptor instead.
+func (*SharesResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *DeleteNoteResponse) GetId() int32 {
+func (x *SharesResponse) GetItems() []*ShareResponse {
 	if x != nil {
-		return x.Id
+		return x.Items
 	}
-	return 0
+	return nil
+}
+
+// TrashResponse содержимое корзины: задачи и заметки от недавно удалённых к давним
+type TrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Notes         []*Note                `protobuf:"bytes,2,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashResponse) Reset() {
+	*x = TrashResponse{}
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *DeleteNoteResponse) GetName() string {
+func (*TrashResponse) ProtoMessage() {}
+
+func (x *TrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_v1_remindables_proto_msgTypes[32]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashResponse.ProtoReflect.Descriptor instead.
+func (*TrashResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_v1_remindables_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *DeleteNoteResponse) GetDescription() string {
+func (x *TrashResponse) GetTasks() []*Task {
 	if x != nil {
-		return x.Description
+		return x.Tasks
 	}
-	return ""
+	return nil
 }
 
-func (x *DeleteNoteResponse) GetAlarmTimeStamp() *timestamppb.Timestamp {
+func (x *TrashResponse) GetNotes() []*Note {
 	if x != nil {
-		return x.AlarmTimeStamp
+		return x.Notes
 	}
 	return nil
 }
@@ -1069,92 +2401,246 @@ var File_api_grpc_v1_remindables_proto protoreflect.FileDescriptor
 
 const file_api_grpc_v1_remindables_proto_rawDesc = "" +
 	"\n" +
-	"\x1dapi/grpc/v1/remindables.proto\x12\x0eremindables.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\" \n" +
+	"\x1dapi/grpc/v1/remindables.proto\x12\x0eremindables.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a google/protobuf/field_mask.proto\"D\n" +
 	"\x0eGetTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\" \n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\"\n" +
+	"\fincludeNotes\x18\x02 \x01(\bR\fincludeNotes\" \n" +
 	"\x0eGetNoteRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"\x80\x01\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\xf2\x01\n" +
 	"\x12PostNewTaskRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x124\n" +
-	"\adueDate\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\"\x8e\x01\n" +
+	"\adueDate\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12 \n" +
+	"\vdueDateText\x18\x04 \x01(\tR\vdueDateText\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x05 \x01(\tR\n" +
+	"recurrence\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\tR\bpriority\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\"\x8e\x02\n" +
 	"\x12PostNewNoteRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12B\n" +
-	"\x0ealarmTimeStamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0ealarmTimeStamp\"\x8c\x01\n" +
+	"\x0ealarmTimeStamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0ealarmTimeStamp\x12.\n" +
+	"\x12alarmTimeStampText\x18\x04 \x01(\tR\x12alarmTimeStampText\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x05 \x01(\tR\n" +
+	"recurrence\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\tR\bpriority\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\"\xe4\x02\n" +
 	"\x0ePutTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x124\n" +
-	"\adueDate\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\"\x9a\x01\n" +
+	"\adueDate\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12 \n" +
+	"\vdueDateText\x18\x05 \x01(\tR\vdueDateText\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x06 \x01(\tR\n" +
+	"recurrence\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\tR\bpriority\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12(\n" +
+	"\x0fexpectedVersion\x18\t \x01(\x05R\x0fexpectedVersion\x12:\n" +
+	"\n" +
+	"updateMask\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"\x80\x03\n" +
 	"\x0ePutNoteRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12B\n" +
-	"\x0ealarmTimeStamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0ealarmTimeStamp\"#\n" +
+	"\x0ealarmTimeStamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0ealarmTimeStamp\x12.\n" +
+	"\x12alarmTimeStampText\x18\x05 \x01(\tR\x12alarmTimeStampText\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x06 \x01(\tR\n" +
+	"recurrence\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\tR\bpriority\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12(\n" +
+	"\x0fexpectedVersion\x18\t \x01(\x05R\x0fexpectedVersion\x12:\n" +
+	"\n" +
+	"updateMask\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"}\n" +
 	"\x11DeleteTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"#\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\acascade\x18\x02 \x01(\tR\acascade\x12\x14\n" +
+	"\x05notes\x18\x03 \x01(\tR\x05notes\x12(\n" +
+	"\x0fexpectedVersion\x18\x04 \x01(\x05R\x0fexpectedVersion\"M\n" +
 	"\x11DeleteNoteRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"\xe7\x01\n" +
-	"\x0fGetTaskResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12@\n" +
-	"\rinitTimeStamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rinitTimeStamp\x124\n" +
-	"\adueDate\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\"\x9b\x01\n" +
-	"\x0fGetNoteResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12B\n" +
-	"\x0ealarmTimeStamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0ealarmTimeStamp\"\xeb\x01\n" +
-	"\x13PostNewTaskResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12@\n" +
-	"\rinitTimeStamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rinitTimeStamp\x124\n" +
-	"\adueDate\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\"\x9f\x01\n" +
-	"\x13PostNewNoteResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12B\n" +
-	"\x0ealarmTimeStamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0ealarmTimeStamp\"\xe7\x01\n" +
-	"\x0fPutTaskResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12@\n" +
-	"\rinitTimeStamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rinitTimeStamp\x124\n" +
-	"\adueDate\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\"\x9b\x01\n" +
-	"\x0fPutNoteResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12B\n" +
-	"\x0ealarmTimeStamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0ealarmTimeStamp\"\xea\x01\n" +
-	"\x12DeleteTaskResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12(\n" +
+	"\x0fexpectedVersion\x18\x02 \x01(\x05R\x0fexpectedVersion\"\xe6\x04\n" +
+	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12@\n" +
 	"\rinitTimeStamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rinitTimeStamp\x124\n" +
 	"\adueDate\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\"\x9e\x01\n" +
-	"\x12DeleteNoteResponse\x12\x0e\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\a \x01(\tR\n" +
+	"recurrence\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\x12$\n" +
+	"\rreminderState\x18\t \x01(\tR\rreminderState\x12<\n" +
+	"\vsnoozedFrom\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vsnoozedFrom\x12\x1a\n" +
+	"\bpriority\x18\v \x01(\tR\bpriority\x12\x12\n" +
+	"\x04tags\x18\f \x03(\tR\x04tags\x12\x1a\n" +
+	"\bparentId\x18\r \x01(\x05R\bparentId\x12\x1c\n" +
+	"\tblockedBy\x18\x0e \x03(\x05R\tblockedBy\x12*\n" +
+	"\x05notes\x18\x0f \x03(\v2\x14.remindables.v1.NoteR\x05notes\x128\n" +
+	"\tdeletedAt\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x18\n" +
+	"\aversion\x18\x11 \x01(\x05R\aversion\"\x8e\x04\n" +
+	"\x04Note\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12B\n" +
-	"\x0ealarmTimeStamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0ealarmTimeStamp2\xc6\x06\n" +
-	"\x12RemindablesService\x12E\n" +
-	"\bGetTasks\x12\x16.google.protobuf.Empty\x1a\x1f.remindables.v1.GetTaskResponse0\x01\x12E\n" +
-	"\bGetNotes\x12\x16.google.protobuf.Empty\x1a\x1f.remindables.v1.GetNoteResponse0\x01\x12O\n" +
-	"\fGetTasksById\x12\x1e.remindables.v1.GetTaskRequest\x1a\x1f.remindables.v1.GetTaskResponse\x12O\n" +
-	"\fGetNotesById\x12\x1e.remindables.v1.GetNoteRequest\x1a\x1f.remindables.v1.GetNoteResponse\x12V\n" +
-	"\vPostNewTask\x12\".remindables.v1.PostNewTaskRequest\x1a#.remindables.v1.PostNewTaskResponse\x12V\n" +
-	"\vPostNewNote\x12\".remindables.v1.PostNewNoteRequest\x1a#.remindables.v1.PostNewNoteResponse\x12N\n" +
-	"\vPutTaskById\x12\x1e.remindables.v1.PutTaskRequest\x1a\x1f.remindables.v1.PutTaskResponse\x12N\n" +
-	"\vPutNoteById\x12\x1e.remindables.v1.PutNoteRequest\x1a\x1f.remindables.v1.PutNoteResponse\x12W\n" +
-	"\x0eDeleteTaskById\x12!.remindables.v1.DeleteTaskRequest\x1a\".remindables.v1.DeleteTaskResponse\x12W\n" +
-	"\x0eDeleteNoteById\x12!.remindables.v1.DeleteNoteRequest\x1a\".remindables.v1.DeleteNoteResponseB\x1dZ\x1bpkg/grpc/v1/remindables_apib\x06proto3"
+	"\x0ealarmTimeStamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0ealarmTimeStamp\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x05 \x01(\tR\n" +
+	"recurrence\x12\x1a\n" +
+	"\btimezone\x18\x06 \x01(\tR\btimezone\x12$\n" +
+	"\rreminderState\x18\a \x01(\tR\rreminderState\x12<\n" +
+	"\vsnoozedFrom\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vsnoozedFrom\x12\x1a\n" +
+	"\bpriority\x18\t \x01(\tR\bpriority\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12\x16\n" +
+	"\x06taskId\x18\v \x01(\x05R\x06taskId\x128\n" +
+	"\tdeletedAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x18\n" +
+	"\aversion\x18\r \x01(\x05R\aversion\x12@\n" +
+	"\rinitTimeStamp\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\rinitTimeStamp\"?\n" +
+	"\x15TransitionTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x82\x01\n" +
+	"\fStatusChange\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x128\n" +
+	"\tchangedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"d\n" +
+	"\x16GetTaskHistoryResponse\x12\x16\n" +
+	"\x06taskId\x18\x01 \x01(\x05R\x06taskId\x122\n" +
+	"\x05items\x18\x02 \x03(\v2\x1c.remindables.v1.StatusChangeR\x05items\"\x96\x01\n" +
+	"\x12OccurrencesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x93\x01\n" +
+	"\x13OccurrencesResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x02 \x01(\tR\n" +
+	"recurrence\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x120\n" +
+	"\x05items\x18\x04 \x03(\v2\x1a.google.protobuf.TimestampR\x05items\"\xa6\x01\n" +
+	"\rSnoozeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\x120\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x1c\n" +
+	"\tuntilText\x18\x04 \x01(\tR\tuntilText\"\xba\x01\n" +
+	"\x06Snooze\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x120\n" +
+	"\x05until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x128\n" +
+	"\tsnoozedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tsnoozedAt\"R\n" +
+	"\x12GetSnoozesResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12,\n" +
+	"\x05items\x18\x02 \x03(\v2\x16.remindables.v1.SnoozeR\x05items\"B\n" +
+	"\x10ListTasksRequest\x12\x1a\n" +
+	"\bpriority\x18\x01 \x03(\tR\bpriority\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"B\n" +
+	"\x10ListNotesRequest\x12\x1a\n" +
+	"\bpriority\x18\x01 \x03(\tR\bpriority\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"B\n" +
+	"\x14SetTaskParentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bparentId\x18\x02 \x01(\x05R\bparentId\"B\n" +
+	"\x12TaskBlockerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1c\n" +
+	"\tblockerId\x18\x02 \x01(\x05R\tblockerId\"\xba\x01\n" +
+	"\x10TaskTreeResponse\x12(\n" +
+	"\x04task\x18\x01 \x01(\v2\x14.remindables.v1.TaskR\x04task\x12<\n" +
+	"\bsubtasks\x18\x02 \x03(\v2 .remindables.v1.TaskTreeResponseR\bsubtasks\x12>\n" +
+	"\tblockedBy\x18\x03 \x03(\v2 .remindables.v1.TaskTreeResponseR\tblockedBy\"<\n" +
+	"\x12SetNoteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06taskId\x18\x02 \x01(\x05R\x06taskId\"?\n" +
+	"\vCredentials\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"n\n" +
+	"\fUserResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x128\n" +
+	"\tcreatedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x91\x01\n" +
+	"\rLoginResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x128\n" +
+	"\texpiresAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x120\n" +
+	"\x04user\x18\x03 \x01(\v2\x1c.remindables.v1.UserResponseR\x04user\"]\n" +
+	"\vShareTarget\x12\x1e\n" +
+	"\n" +
+	"entityType\x18\x01 \x01(\tR\n" +
+	"entityType\x12\x1a\n" +
+	"\bentityId\x18\x02 \x01(\x05R\bentityId\x12\x12\n" +
+	"\x04list\x18\x03 \x01(\tR\x04list\"m\n" +
+	"\fShareRequest\x123\n" +
+	"\x06target\x18\x01 \x01(\v2\x1b.remindables.v1.ShareTargetR\x06target\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"[\n" +
+	"\x0eUnshareRequest\x123\n" +
+	"\x06target\x18\x01 \x01(\v2\x1b.remindables.v1.ShareTargetR\x06target\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\"\xea\x01\n" +
+	"\rShareResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x123\n" +
+	"\x06target\x18\x02 \x01(\v2\x1b.remindables.v1.ShareTargetR\x06target\x12\x18\n" +
+	"\aownerId\x18\x03 \x01(\x05R\aownerId\x12\x16\n" +
+	"\x06userId\x18\x04 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05login\x18\x05 \x01(\tR\x05login\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x128\n" +
+	"\tcreatedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"E\n" +
+	"\x0eSharesResponse\x123\n" +
+	"\x05items\x18\x01 \x03(\v2\x1d.remindables.v1.ShareResponseR\x05items\"g\n" +
+	"\rTrashResponse\x12*\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x14.remindables.v1.TaskR\x05tasks\x12*\n" +
+	"\x05notes\x18\x02 \x03(\v2\x14.remindables.v1.NoteR\x05notes2\x82\x17\n" +
+	"\x12RemindablesService\x12:\n" +
+	"\bGetTasks\x12\x16.google.protobuf.Empty\x1a\x14.remindables.v1.Task0\x01\x12:\n" +
+	"\bGetNotes\x12\x16.google.protobuf.Empty\x1a\x14.remindables.v1.Note0\x01\x12D\n" +
+	"\fGetTasksById\x12\x1e.remindables.v1.GetTaskRequest\x1a\x14.remindables.v1.Task\x12D\n" +
+	"\fGetNotesById\x12\x1e.remindables.v1.GetNoteRequest\x1a\x14.remindables.v1.Note\x12G\n" +
+	"\vPostNewTask\x12\".remindables.v1.PostNewTaskRequest\x1a\x14.remindables.v1.Task\x12G\n" +
+	"\vPostNewNote\x12\".remindables.v1.PostNewNoteRequest\x1a\x14.remindables.v1.Note\x12C\n" +
+	"\vPutTaskById\x12\x1e.remindables.v1.PutTaskRequest\x1a\x14.remindables.v1.Task\x12C\n" +
+	"\vPutNoteById\x12\x1e.remindables.v1.PutNoteRequest\x1a\x14.remindables.v1.Note\x12I\n" +
+	"\x0eDeleteTaskById\x12!.remindables.v1.DeleteTaskRequest\x1a\x14.remindables.v1.Task\x12I\n" +
+	"\x0eDeleteNoteById\x12!.remindables.v1.DeleteNoteRequest\x1a\x14.remindables.v1.Note\x12M\n" +
+	"\x0eTransitionTask\x12%.remindables.v1.TransitionTaskRequest\x1a\x14.remindables.v1.Task\x12X\n" +
+	"\x0eGetTaskHistory\x12\x1e.remindables.v1.GetTaskRequest\x1a&.remindables.v1.GetTaskHistoryResponse\x12]\n" +
+	"\x12GetTaskOccurrences\x12\".remindables.v1.OccurrencesRequest\x1a#.remindables.v1.OccurrencesResponse\x12]\n" +
+	"\x12GetNoteOccurrences\x12\".remindables.v1.OccurrencesRequest\x1a#.remindables.v1.OccurrencesResponse\x12A\n" +
+	"\n" +
+	"SnoozeTask\x12\x1d.remindables.v1.SnoozeRequest\x1a\x14.remindables.v1.Task\x12G\n" +
+	"\x0fAcknowledgeTask\x12\x1e.remindables.v1.GetTaskRequest\x1a\x14.remindables.v1.Task\x12C\n" +
+	"\vDismissTask\x12\x1e.remindables.v1.GetTaskRequest\x1a\x14.remindables.v1.Task\x12T\n" +
+	"\x0eGetTaskSnoozes\x12\x1e.remindables.v1.GetTaskRequest\x1a\".remindables.v1.GetSnoozesResponse\x12A\n" +
+	"\n" +
+	"SnoozeNote\x12\x1d.remindables.v1.SnoozeRequest\x1a\x14.remindables.v1.Note\x12G\n" +
+	"\x0fAcknowledgeNote\x12\x1e.remindables.v1.GetNoteRequest\x1a\x14.remindables.v1.Note\x12C\n" +
+	"\vDismissNote\x12\x1e.remindables.v1.GetNoteRequest\x1a\x14.remindables.v1.Note\x12T\n" +
+	"\x0eGetNoteSnoozes\x12\x1e.remindables.v1.GetNoteRequest\x1a\".remindables.v1.GetSnoozesResponse\x12E\n" +
+	"\tListTasks\x12 .remindables.v1.ListTasksRequest\x1a\x14.remindables.v1.Task0\x01\x12E\n" +
+	"\tListNotes\x12 .remindables.v1.ListNotesRequest\x1a\x14.remindables.v1.Note0\x01\x12K\n" +
+	"\rSetTaskParent\x12$.remindables.v1.SetTaskParentRequest\x1a\x14.remindables.v1.Task\x12J\n" +
+	"\x0eAddTaskBlocker\x12\".remindables.v1.TaskBlockerRequest\x1a\x14.remindables.v1.Task\x12M\n" +
+	"\x11RemoveTaskBlocker\x12\".remindables.v1.TaskBlockerRequest\x1a\x14.remindables.v1.Task\x12O\n" +
+	"\vGetTaskTree\x12\x1e.remindables.v1.GetTaskRequest\x1a .remindables.v1.TaskTreeResponse\x12G\n" +
+	"\vSetNoteTask\x12\".remindables.v1.SetNoteTaskRequest\x1a\x14.remindables.v1.Note\x12E\n" +
+	"\bRegister\x12\x1b.remindables.v1.Credentials\x1a\x1c.remindables.v1.UserResponse\x12C\n" +
+	"\x05Login\x12\x1b.remindables.v1.Credentials\x1a\x1d.remindables.v1.LoginResponse\x128\n" +
+	"\x06Logout\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\x05Share\x12\x1c.remindables.v1.ShareRequest\x1a\x1d.remindables.v1.ShareResponse\x12H\n" +
+	"\aUnshare\x12\x1e.remindables.v1.UnshareRequest\x1a\x1d.remindables.v1.ShareResponse\x12H\n" +
+	"\tGetShares\x12\x1b.remindables.v1.ShareTarget\x1a\x1e.remindables.v1.SharesResponse\x12A\n" +
+	"\bGetTrash\x12\x16.google.protobuf.Empty\x1a\x1d.remindables.v1.TrashResponse\x12C\n" +
+	"\vRestoreTask\x12\x1e.remindables.v1.GetTaskRequest\x1a\x14.remindables.v1.Task\x12C\n" +
+	"\vRestoreNote\x12\x1e.remindables.v1.GetNoteRequest\x1a\x14.remindables.v1.Note\x12A\n" +
+	"\tPurgeTask\x12\x1e.remindables.v1.GetTaskRequest\x1a\x14.remindables.v1.Task\x12A\n" +
+	"\tPurgeNote\x12\x1e.remindables.v1.GetNoteRequest\x1a\x14.remindables.v1.NoteB\x1dZ\x1bpkg/grpc/v1/remindables_apib\x06proto3"
 
 var (
 	file_api_grpc_v1_remindables_proto_rawDescOnce sync.Once
@@ -1168,69 +2654,171 @@ func file_api_grpc_v1_remindables_proto_rawDescGZIP() []byte {
 	return file_api_grpc_v1_remindables_proto_rawDescData
 }
 
-var file_api_grpc_v1_remindables_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_api_grpc_v1_remindables_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_api_grpc_v1_remindables_proto_goTypes = []any{
-	(*GetTaskRequest)(nil),        // 0: remindables.v1.GetTaskRequest
-	(*GetNoteRequest)(nil),        // 1: remindables.v1.GetNoteRequest
-	(*PostNewTaskRequest)(nil),    // 2: remindables.v1.PostNewTaskRequest
-	(*PostNewNoteRequest)(nil),    // 3: remindables.v1.PostNewNoteRequest
-	(*PutTaskRequest)(nil),        // 4: remindables.v1.PutTaskRequest
-	(*PutNoteRequest)(nil),        // 5: remindables.v1.PutNoteRequest
-	(*DeleteTaskRequest)(nil),     // 6: remindables.v1.DeleteTaskRequest
-	(*DeleteNoteRequest)(nil),     // 7: remindables.v1.DeleteNoteRequest
-	(*GetTaskResponse)(nil),       // 8: remindables.v1.GetTaskResponse
-	(*GetNoteResponse)(nil),       // 9: remindables.v1.GetNoteResponse
-	(*PostNewTaskResponse)(nil),   // 10: remindables.v1.PostNewTaskResponse
-	(*PostNewNoteResponse)(nil),   // 11: remindables.v1.PostNewNoteResponse
-	(*PutTaskResponse)(nil),       // 12: remindables.v1.PutTaskResponse
-	(*PutNoteResponse)(nil),       // 13: remindables.v1.PutNoteResponse
-	(*DeleteTaskResponse)(nil),    // 14: remindables.v1.DeleteTaskResponse
-	(*DeleteNoteResponse)(nil),    // 15: remindables.v1.DeleteNoteResponse
-	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 17: google.protobuf.Empty
+	(*GetTaskRequest)(nil),         // 0: remindables.v1.GetTaskRequest
+	(*GetNoteRequest)(nil),         // 1: remindables.v1.GetNoteRequest
+	(*PostNewTaskRequest)(nil),     // 2: remindables.v1.PostNewTaskRequest
+	(*PostNewNoteRequest)(nil),     // 3: remindables.v1.PostNewNoteRequest
+	(*PutTaskRequest)(nil),         // 4: remindables.v1.PutTaskRequest
+	(*PutNoteRequest)(nil),         // 5: remindables.v1.PutNoteRequest
+	(*DeleteTaskRequest)(nil),      // 6: remindables.v1.DeleteTaskRequest
+	(*DeleteNoteRequest)(nil),      // 7: remindables.v1.DeleteNoteRequest
+	(*Task)(nil),                   // 8: remindables.v1.Task
+	(*Note)(nil),                   // 9: remindables.v1.Note
+	(*TransitionTaskRequest)(nil),  // 10: remindables.v1.TransitionTaskRequest
+	(*StatusChange)(nil),           // 11: remindables.v1.StatusChange
+	(*GetTaskHistoryResponse)(nil), // 12: remindables.v1.GetTaskHistoryResponse
+	(*OccurrencesRequest)(nil),     // 13: remindables.v1.OccurrencesRequest
+	(*OccurrencesResponse)(nil),    // 14: remindables.v1.OccurrencesResponse
+	(*SnoozeRequest)(nil),          // 15: remindables.v1.SnoozeRequest
+	(*Snooze)(nil),                 // 16: remindables.v1.Snooze
+	(*GetSnoozesResponse)(nil),     // 17: remindables.v1.GetSnoozesResponse
+	(*ListTasksRequest)(nil),       // 18: remindables.v1.ListTasksRequest
+	(*ListNotesRequest)(nil),       // 19: remindables.v1.ListNotesRequest
+	(*SetTaskParentRequest)(nil),   // 20: remindables.v1.SetTaskParentRequest
+	(*TaskBlockerRequest)(nil),     // 21: remindables.v1.TaskBlockerRequest
+	(*TaskTreeResponse)(nil),       // 22: remindables.v1.TaskTreeResponse
+	(*SetNoteTaskRequest)(nil),     // 23: remindables.v1.SetNoteTaskRequest
+	(*Credentials)(nil),            // 24: remindables.v1.Credentials
+	(*UserResponse)(nil),           // 25: remindables.v1.UserResponse
+	(*LoginResponse)(nil),          // 26: remindables.v1.LoginResponse
+	(*ShareTarget)(nil),            // 27: remindables.v1.ShareTarget
+	(*ShareRequest)(nil),           // 28: remindables.v1.ShareRequest
+	(*UnshareRequest)(nil),         // 29: remindables.v1.UnshareRequest
+	(*ShareResponse)(nil),          // 30: remindables.v1.ShareResponse
+	(*SharesResponse)(nil),         // 31: remindables.v1.SharesResponse
+	(*TrashResponse)(nil),          // 32: remindables.v1.TrashResponse
+	(*timestamppb.Timestamp)(nil),  // 33: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),  // 34: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),    // 35: google.protobuf.Duration
+	(*emptypb.Empty)(nil),          // 36: google.protobuf.Empty
 }
 var file_api_grpc_v1_remindables_proto_depIdxs = []int32{
-	16, // 0: remindables.v1.PostNewTaskRequest.dueDate:type_name -> google.protobuf.Timestamp
-	16, // 1: remindables.v1.PostNewNoteRequest.alarmTimeStamp:type_name -> google.protobuf.Timestamp
-	16, // 2: remindables.v1.PutTaskRequest.dueDate:type_name -> google.protobuf.Timestamp
-	16, // 3: remindables.v1.PutNoteRequest.alarmTimeStamp:type_name -> google.protobuf.Timestamp
-	16, // 4: remindables.v1.GetTaskResponse.initTimeStamp:type_name -> google.protobuf.Timestamp
-	16, // 5: remindables.v1.GetTaskResponse.dueDate:type_name -> google.protobuf.Timestamp
-	16, // 6: remindables.v1.GetNoteResponse.alarmTimeStamp:type_name -> google.protobuf.Timestamp
-	16, // 7: remindables.v1.PostNewTaskResponse.initTimeStamp:type_name -> google.protobuf.Timestamp
-	16, // 8: remindables.v1.PostNewTaskResponse.dueDate:type_name -> google.protobuf.Timestamp
-	16, // 9: remindables.v1.PostNewNoteResponse.alarmTimeStamp:type_name -> google.protobuf.Timestamp
-	16, // 10: remindables.v1.PutTaskResponse.initTimeStamp:type_name -> google.protobuf.Timestamp
-	16, // 11: remindables.v1.PutTaskResponse.dueDate:type_name -> google.protobuf.Timestamp
-	16, // 12: remindables.v1.PutNoteResponse.alarmTimeStamp:type_name -> google.protobuf.Timestamp
-	16, // 13: remindables.v1.DeleteTaskResponse.initTimeStamp:type_name -> google.protobuf.Timestamp
-	16, // 14: remindables.v1.DeleteTaskResponse.dueDate:type_name -> google.protobuf.Timestamp
-	16, // 15: remindables.v1.DeleteNoteResponse.alarmTimeStamp:type_name -> google.protobuf.Timestamp
-	17, // 16: remindables.v1.RemindablesService.GetTasks:input_type -> google.protobuf.Empty
-	17, // 17: remindables.v1.RemindablesService.GetNotes:input_type -> google.protobuf.Empty
-	0,  // 18: remindables.v1.RemindablesService.GetTasksById:input_type -> remindables.v1.GetTaskRequest
-	1,  // 19: remindables.v1.RemindablesService.GetNotesById:input_type -> remindables.v1.GetNoteRequest
-	2,  // 20: remindables.v1.RemindablesService.PostNewTask:input_type -> remindables.v1.PostNewTaskRequest
-	3,  // 21: remindables.v1.RemindablesService.PostNewNote:input_type -> remindables.v1.PostNewNoteRequest
-	4,  // 22: remindables.v1.RemindablesService.PutTaskById:input_type -> remindables.v1.PutTaskRequest
-	5,  // 23: remindables.v1.RemindablesService.PutNoteById:input_type -> remindables.v1.PutNoteRequest
-	6,  // 24: remindables.v1.RemindablesService.DeleteTaskById:input_type -> remindables.v1.DeleteTaskRequest
-	7,  // 25: remindables.v1.RemindablesService.DeleteNoteById:input_type -> remindables.v1.DeleteNoteRequest
-	8,  // 26: remindables.v1.RemindablesService.GetTasks:output_type -> remindables.v1.GetTaskResponse
-	9,  // 27: remindables.v1.RemindablesService.GetNotes:output_type -> remindables.v1.GetNoteResponse
-	8,  // 28: remindables.v1.RemindablesService.GetTasksById:output_type -> remindables.v1.GetTaskResponse
-	9,  // 29: remindables.v1.RemindablesService.GetNotesById:output_type -> remindables.v1.GetNoteResponse
-	10, // 30: remindables.v1.RemindablesService.PostNewTask:output_type -> remindables.v1.PostNewTaskResponse
-	11, // 31: remindables.v1.RemindablesService.PostNewNote:output_type -> remindables.v1.PostNewNoteResponse
-	12, // 32: remindables.v1.RemindablesService.PutTaskById:output_type -> remindables.v1.PutTaskResponse
-	13, // 33: remindables.v1.RemindablesService.PutNoteById:output_type -> remindables.v1.PutNoteResponse
-	14, // 34: remindables.v1.RemindablesService.DeleteTaskById:output_type -> remindables.v1.DeleteTaskResponse
-	15, // 35: remindables.v1.RemindablesService.DeleteNoteById:output_type -> remindables.v1.DeleteNoteResponse
-	26, // [26:36] is the sub-list for method output_type
-	16, // [16:26] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	33, // 0: remindables.v1.PostNewTaskRequest.dueDate:type_name -> google.protobuf.Timestamp
+	33, // 1: remindables.v1.PostNewNoteRequest.alarmTimeStamp:type_name -> google.protobuf.Timestamp
+	33, // 2: remindables.v1.PutTaskRequest.dueDate:type_name -> google.protobuf.Timestamp
+	34, // 3: remindables.v1.PutTaskRequest.updateMask:type_name -> google.protobuf.FieldMask
+	33, // 4: remindables.v1.PutNoteRequest.alarmTimeStamp:type_name -> google.protobuf.Timestamp
+	34, // 5: remindables.v1.PutNoteRequest.updateMask:type_name -> google.protobuf.FieldMask
+	33, // 6: remindables.v1.Task.initTimeStamp:type_name -> google.protobuf.Timestamp
+	33, // 7: remindables.v1.Task.dueDate:type_name -> google.protobuf.Timestamp
+	33, // 8: remindables.v1.Task.snoozedFrom:type_name -> google.protobuf.Timestamp
+	9,  // 9: remindables.v1.Task.notes:type_name -> remindables.v1.Note
+	33, // 10: remindables.v1.Task.deletedAt:type_name -> google.protobuf.Timestamp
+	33, // 11: remindables.v1.Note.alarmTimeStamp:type_name -> google.protobuf.Timestamp
+	33, // 12: remindables.v1.Note.snoozedFrom:type_name -> google.protobuf.Timestamp
+	33, // 13: remindables.v1.Note.deletedAt:type_name -> google.protobuf.Timestamp
+	33, // 14: remindables.v1.Note.initTimeStamp:type_name -> google.protobuf.Timestamp
+	33, // 15: remindables.v1.StatusChange.changedAt:type_name -> google.protobuf.Timestamp
+	11, // 16: remindables.v1.GetTaskHistoryResponse.items:type_name -> remindables.v1.StatusChange
+	33, // 17: remindables.v1.OccurrencesRequest.from:type_name -> google.protobuf.Timestamp
+	33, // 18: remindables.v1.OccurrencesRequest.to:type_name -> google.protobuf.Timestamp
+	33, // 19: remindables.v1.OccurrencesResponse.items:type_name -> google.protobuf.Timestamp
+	35, // 20: remindables.v1.SnoozeRequest.duration:type_name -> google.protobuf.Duration
+	33, // 21: remindables.v1.SnoozeRequest.until:type_name -> google.protobuf.Timestamp
+	33, // 22: remindables.v1.Snooze.from:type_name -> google.protobuf.Timestamp
+	33, // 23: remindables.v1.Snooze.until:type_name -> google.protobuf.Timestamp
+	33, // 24: remindables.v1.Snooze.snoozedAt:type_name -> google.protobuf.Timestamp
+	16, // 25: remindables.v1.GetSnoozesResponse.items:type_name -> remindables.v1.Snooze
+	8,  // 26: remindables.v1.TaskTreeResponse.task:type_name -> remindables.v1.Task
+	22, // 27: remindables.v1.TaskTreeResponse.subtasks:type_name -> remindables.v1.TaskTreeResponse
+	22, // 28: remindables.v1.TaskTreeResponse.blockedBy:type_name -> remindables.v1.TaskTreeResponse
+	33, // 29: remindables.v1.UserResponse.createdAt:type_name -> google.protobuf.Timestamp
+	33, // 30: remindables.v1.LoginResponse.expiresAt:type_name -> google.protobuf.Timestamp
+	25, // 31: remindables.v1.LoginResponse.user:type_name -> remindables.v1.UserResponse
+	27, // 32: remindables.v1.ShareRequest.target:type_name -> remindables.v1.ShareTarget
+	27, // 33: remindables.v1.UnshareRequest.target:type_name -> remindables.v1.ShareTarget
+	27, // 34: remindables.v1.ShareResponse.target:type_name -> remindables.v1.ShareTarget
+	33, // 35: remindables.v1.ShareResponse.createdAt:type_name -> google.protobuf.Timestamp
+	30, // 36: remindables.v1.SharesResponse.items:type_name -> remindables.v1.ShareResponse
+	8,  // 37: remindables.v1.TrashResponse.tasks:type_name -> remindables.v1.Task
+	9,  // 38: remindables.v1.TrashResponse.notes:type_name -> remindables.v1.Note
+	36, // 39: remindables.v1.RemindablesService.GetTasks:input_type -> google.protobuf.Empty
+	36, // 40: remindables.v1.RemindablesService.GetNotes:input_type -> google.protobuf.Empty
+	0,  // 41: remindables.v1.RemindablesService.GetTasksById:input_type -> remindables.v1.GetTaskRequest
+	1,  // 42: remindables.v1.RemindablesService.GetNotesById:input_type -> remindables.v1.GetNoteRequest
+	2,  // 43: remindables.v1.RemindablesService.PostNewTask:input_type -> remindables.v1.PostNewTaskRequest
+	3,  // 44: remindables.v1.RemindablesService.PostNewNote:input_type -> remindables.v1.PostNewNoteRequest
+	4,  // 45: remindables.v1.RemindablesService.PutTaskById:input_type -> remindables.v1.PutTaskRequest
+	5,  // 46: remindables.v1.RemindablesService.PutNoteById:input_type -> remindables.v1.PutNoteRequest
+	6,  // 47: remindables.v1.RemindablesService.DeleteTaskById:input_type -> remindables.v1.DeleteTaskRequest
+	7,  // 48: remindables.v1.RemindablesService.DeleteNoteById:input_type -> remindables.v1.DeleteNoteRequest
+	10, // 49: remindables.v1.RemindablesService.TransitionTask:input_type -> remindables.v1.TransitionTaskRequest
+	0,  // 50: remindables.v1.RemindablesService.GetTaskHistory:input_type -> remindables.v1.GetTaskRequest
+	13, // 51: remindables.v1.RemindablesService.GetTaskOccurrences:input_type -> remindables.v1.OccurrencesRequest
+	13, // 52: remindables.v1.RemindablesService.GetNoteOccurrences:input_type -> remindables.v1.OccurrencesRequest
+	15, // 53: remindables.v1.RemindablesService.SnoozeTask:input_type -> remindables.v1.SnoozeRequest
+	0,  // 54: remindables.v1.RemindablesService.AcknowledgeTask:input_type -> remindables.v1.GetTaskRequest
+	0,  // 55: remindables.v1.RemindablesService.DismissTask:input_type -> remindables.v1.GetTaskRequest
+	0,  // 56: remindables.v1.RemindablesService.GetTaskSnoozes:input_type -> remindables.v1.GetTaskRequest
+	15, // 57: remindables.v1.RemindablesService.SnoozeNote:input_type -> remindables.v1.SnoozeRequest
+	1,  // 58: remindables.v1.RemindablesService.AcknowledgeNote:input_type -> remindables.v1.GetNoteRequest
+	1,  // 59: remindables.v1.RemindablesService.DismissNote:input_type -> remindables.v1.GetNoteRequest
+	1,  // 60: remindables.v1.RemindablesService.GetNoteSnoozes:input_type -> remindables.v1.GetNoteRequest
+	18, // 61: remindables.v1.RemindablesService.ListTasks:input_type -> remindables.v1.ListTasksRequest
+	19, // 62: remindables.v1.RemindablesService.ListNotes:input_type -> remindables.v1.ListNotesRequest
+	20, // 63: remindables.v1.RemindablesService.SetTaskParent:input_type -> remindables.v1.SetTaskParentRequest
+	21, // 64: remindables.v1.RemindablesService.AddTaskBlocker:input_type -> remindables.v1.TaskBlockerRequest
+	21, // 65: remindables.v1.RemindablesService.RemoveTaskBlocker:input_type -> remindables.v1.TaskBlockerRequest
+	0,  // 66: remindables.v1.RemindablesService.GetTaskTree:input_type -> remindables.v1.GetTaskRequest
+	23, // 67: remindables.v1.RemindablesService.SetNoteTask:input_type -> remindables.v1.SetNoteTaskRequest
+	24, // 68: remindables.v1.RemindablesService.Register:input_type -> remindables.v1.Credentials
+	24, // 69: remindables.v1.RemindablesService.Login:input_type -> remindables.v1.Credentials
+	36, // 70: remindables.v1.RemindablesService.Logout:input_type -> google.protobuf.Empty
+	28, // 71: remindables.v1.RemindablesService.Share:input_type -> remindables.v1.ShareRequest
+	29, // 72: remindables.v1.RemindablesService.Unshare:input_type -> remindables.v1.UnshareRequest
+	27, // 73: remindables.v1.RemindablesService.GetShares:input_type -> remindables.v1.ShareTarget
+	36, // 74: remindables.v1.RemindablesService.GetTrash:input_type -> google.protobuf.Empty
+	0,  // 75: remindables.v1.RemindablesService.RestoreTask:input_type -> remindables.v1.GetTaskRequest
+	1,  // 76: remindables.v1.RemindablesService.RestoreNote:input_type -> remindables.v1.GetNoteRequest
+	0,  // 77: remindables.v1.RemindablesService.PurgeTask:input_type -> remindables.v1.GetTaskRequest
+	1,  // 78: remindables.v1.RemindablesService.PurgeNote:input_type -> remindables.v1.GetNoteRequest
+	8,  // 79: remindables.v1.RemindablesService.GetTasks:output_type -> remindables.v1.Task
+	9,  // 80: remindables.v1.RemindablesService.GetNotes:output_type -> remindables.v1.Note
+	8,  // 81: remindables.v1.RemindablesService.GetTasksById:output_type -> remindables.v1.Task
+	9,  // 82: remindables.v1.RemindablesService.GetNotesById:output_type -> remindables.v1.Note
+	8,  // 83: remindables.v1.RemindablesService.PostNewTask:output_type -> remindables.v1.Task
+	9,  // 84: remindables.v1.RemindablesService.PostNewNote:output_type -> remindables.v1.Note
+	8,  // 85: remindables.v1.RemindablesService.PutTaskById:output_type -> remindables.v1.Task
+	9,  // 86: remindables.v1.RemindablesService.PutNoteById:output_type -> remindables.v1.Note
+	8,  // 87: remindables.v1.RemindablesService.DeleteTaskById:output_type -> remindables.v1.Task
+	9,  // 88: remindables.v1.RemindablesService.DeleteNoteById:output_type -> remindables.v1.Note
+	8,  // 89: remindables.v1.RemindablesService.TransitionTask:output_type -> remindables.v1.Task
+	12, // 90: remindables.v1.RemindablesService.GetTaskHistory:output_type -> remindables.v1.GetTaskHistoryResponse
+	14, // 91: remindables.v1.RemindablesService.GetTaskOccurrences:output_type -> remindables.v1.OccurrencesResponse
+	14, // 92: remindables.v1.RemindablesService.GetNoteOccurrences:output_type -> remindables.v1.OccurrencesResponse
+	8,  // 93: remindables.v1.RemindablesService.SnoozeTask:output_type -> remindables.v1.Task
+	8,  // 94: remindables.v1.RemindablesService.AcknowledgeTask:output_type -> remindables.v1.Task
+	8,  // 95: remindables.v1.RemindablesService.DismissTask:output_type -> remindables.v1.Task
+	17, // 96: remindables.v1.RemindablesService.GetTaskSnoozes:output_type -> remindables.v1.GetSnoozesResponse
+	9,  // 97: remindables.v1.RemindablesService.SnoozeNote:output_type -> remindables.v1.Note
+	9,  // 98: remindables.v1.RemindablesService.AcknowledgeNote:output_type -> remindables.v1.Note
+	9,  // 99: remindables.v1.RemindablesService.DismissNote:output_type -> remindables.v1.Note
+	17, // 100: remindables.v1.RemindablesService.GetNoteSnoozes:output_type -> remindables.v1.GetSnoozesResponse
+	8,  // 101: remindables.v1.RemindablesService.ListTasks:output_type -> remindables.v1.Task
+	9,  // 102: remindables.v1.RemindablesService.ListNotes:output_type -> remindables.v1.Note
+	8,  // 103: remindables.v1.RemindablesService.SetTaskParent:output_type -> remindables.v1.Task
+	8,  // 104: remindables.v1.RemindablesService.AddTaskBlocker:output_type -> remindables.v1.Task
+	8,  // 105: remindables.v1.RemindablesService.RemoveTaskBlocker:output_type -> remindables.v1.Task
+	22, // 106: remindables.v1.RemindablesService.GetTaskTree:output_type -> remindables.v1.TaskTreeResponse
+	9,  // 107: remindables.v1.RemindablesService.SetNoteTask:output_type -> remindables.v1.Note
+	25, // 108: remindables.v1.RemindablesService.Register:output_type -> remindables.v1.UserResponse
+	26, // 109: remindables.v1.RemindablesService.Login:output_type -> remindables.v1.LoginResponse
+	36, // 110: remindables.v1.RemindablesService.Logout:output_type -> google.protobuf.Empty
+	30, // 111: remindables.v1.RemindablesService.Share:output_type -> remindables.v1.ShareResponse
+	30, // 112: remindables.v1.RemindablesService.Unshare:output_type -> remindables.v1.ShareResponse
+	31, // 113: remindables.v1.RemindablesService.GetShares:output_type -> remindables.v1.SharesResponse
+	32, // 114: remindables.v1.RemindablesService.GetTrash:output_type -> remindables.v1.TrashResponse
+	8,  // 115: remindables.v1.RemindablesService.RestoreTask:output_type -> remindables.v1.Task
+	9,  // 116: remindables.v1.RemindablesService.RestoreNote:output_type -> remindables.v1.Note
+	8,  // 117: remindables.v1.RemindablesService.PurgeTask:output_type -> remindables.v1.Task
+	9,  // 118: remindables.v1.RemindablesService.PurgeNote:output_type -> remindables.v1.Note
+	79, // [79:119] is the sub-list for method output_type
+	39, // [39:79] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_api_grpc_v1_remindables_proto_init() }
@@ -1244,7 +2832,7 @@ func file_api_grpc_v1_remindables_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_grpc_v1_remindables_proto_rawDesc), len(file_api_grpc_v1_remindables_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
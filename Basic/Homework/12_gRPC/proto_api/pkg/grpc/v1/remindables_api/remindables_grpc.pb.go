@@ -22,32 +22,92 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RemindablesService_GetTasks_FullMethodName       = "/remindables.v1.RemindablesService/GetTasks"
-	RemindablesService_GetNotes_FullMethodName       = "/remindables.v1.RemindablesService/GetNotes"
-	RemindablesService_GetTasksById_FullMethodName   = "/remindables.v1.RemindablesService/GetTasksById"
-	RemindablesService_GetNotesById_FullMethodName   = "/remindables.v1.RemindablesService/GetNotesById"
-	RemindablesService_PostNewTask_FullMethodName    = "/remindables.v1.RemindablesService/PostNewTask"
-	RemindablesService_PostNewNote_FullMethodName    = "/remindables.v1.RemindablesService/PostNewNote"
-	RemindablesService_PutTaskById_FullMethodName    = "/remindables.v1.RemindablesService/PutTaskById"
-	RemindablesService_PutNoteById_FullMethodName    = "/remindables.v1.RemindablesService/PutNoteById"
-	RemindablesService_DeleteTaskById_FullMethodName = "/remindables.v1.RemindablesService/DeleteTaskById"
-	RemindablesService_DeleteNoteById_FullMethodName = "/remindables.v1.RemindablesService/DeleteNoteById"
+	RemindablesService_GetTasks_FullMethodName           = "/remindables.v1.RemindablesService/GetTasks"
+	RemindablesService_GetNotes_FullMethodName           = "/remindables.v1.RemindablesService/GetNotes"
+	RemindablesService_GetTasksById_FullMethodName       = "/remindables.v1.RemindablesService/GetTasksById"
+	RemindablesService_GetNotesById_FullMethodName       = "/remindables.v1.RemindablesService/GetNotesById"
+	RemindablesService_PostNewTask_FullMethodName        = "/remindables.v1.RemindablesService/PostNewTask"
+	RemindablesService_PostNewNote_FullMethodName        = "/remindables.v1.RemindablesService/PostNewNote"
+	RemindablesService_PutTaskById_FullMethodName        = "/remindables.v1.RemindablesService/PutTaskById"
+	RemindablesService_PutNoteById_FullMethodName        = "/remindables.v1.RemindablesService/PutNoteById"
+	RemindablesService_DeleteTaskById_FullMethodName     = "/remindables.v1.RemindablesService/DeleteTaskById"
+	RemindablesService_DeleteNoteById_FullMethodName     = "/remindables.v1.RemindablesService/DeleteNoteById"
+	RemindablesService_TransitionTask_FullMethodName     = "/remindables.v1.RemindablesService/TransitionTask"
+	RemindablesService_GetTaskHistory_FullMethodName     = "/remindables.v1.RemindablesService/GetTaskHistory"
+	RemindablesService_GetTaskOccurrences_FullMethodName = "/remindables.v1.RemindablesService/GetTaskOccurrences"
+	RemindablesService_GetNoteOccurrences_FullMethodName = "/remindables.v1.RemindablesService/GetNoteOccurrences"
+	RemindablesService_SnoozeTask_FullMethodName         = "/remindables.v1.RemindablesService/SnoozeTask"
+	RemindablesService_AcknowledgeTask_FullMethodName    = "/remindables.v1.RemindablesService/AcknowledgeTask"
+	RemindablesService_DismissTask_FullMethodName        = "/remindables.v1.RemindablesService/DismissTask"
+	RemindablesService_GetTaskSnoozes_FullMethodName     = "/remindables.v1.RemindablesService/GetTaskSnoozes"
+	RemindablesService_SnoozeNote_FullMethodName         = "/remindables.v1.RemindablesService/SnoozeNote"
+	RemindablesService_AcknowledgeNote_FullMethodName    = "/remindables.v1.RemindablesService/AcknowledgeNote"
+	RemindablesService_DismissNote_FullMethodName        = "/remindables.v1.RemindablesService/DismissNote"
+	RemindablesService_GetNoteSnoozes_FullMethodName     = "/remindables.v1.RemindablesService/GetNoteSnoozes"
+	RemindablesService_ListTasks_FullMethodName          = "/remindables.v1.RemindablesService/ListTasks"
+	RemindablesService_ListNotes_FullMethodName          = "/remindables.v1.RemindablesService/ListNotes"
+	RemindablesService_SetTaskParent_FullMethodName      = "/remindables.v1.RemindablesService/SetTaskParent"
+	RemindablesService_AddTaskBlocker_FullMethodName     = "/remindables.v1.RemindablesService/AddTaskBlocker"
+	RemindablesService_RemoveTaskBlocker_FullMethodName  = "/remindables.v1.RemindablesService/RemoveTaskBlocker"
+	RemindablesService_GetTaskTree_FullMethodName        = "/remindables.v1.RemindablesService/GetTaskTree"
+	RemindablesService_SetNoteTask_FullMethodName        = "/remindables.v1.RemindablesService/SetNoteTask"
+	RemindablesService_Register_FullMethodName           = "/remindables.v1.RemindablesService/Register"
+	RemindablesService_Login_FullMethodName              = "/remindables.v1.RemindablesService/Login"
+	RemindablesService_Logout_FullMethodName             = "/remindables.v1.RemindablesService/Logout"
+	RemindablesService_Share_FullMethodName              = "/remindables.v1.RemindablesService/Share"
+	RemindablesService_Unshare_FullMethodName            = "/remindables.v1.RemindablesService/Unshare"
+	RemindablesService_GetShares_FullMethodName          = "/remindables.v1.RemindablesService/GetShares"
+	RemindablesService_GetTrash_FullMethodName           = "/remindables.v1.RemindablesService/GetTrash"
+	RemindablesService_RestoreTask_FullMethodName        = "/remindables.v1.RemindablesService/RestoreTask"
+	RemindablesService_RestoreNote_FullMethodName        = "/remindables.v1.RemindablesService/RestoreNote"
+	RemindablesService_PurgeTask_FullMethodName          = "/remindables.v1.RemindablesService/PurgeTask"
+	RemindablesService_PurgeNote_FullMethodName          = "/remindables.v1.RemindablesService/PurgeNote"
 )
 
 // RemindablesServiceClient is the client API for RemindablesService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RemindablesServiceClient interface {
-	GetTasks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetTaskResponse], error)
-	GetNotes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetNoteResponse], error)
-	GetTasksById(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error)
-	GetNotesById(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*GetNoteResponse, error)
-	PostNewTask(ctx context.Context, in *PostNewTaskRequest, opts ...grpc.CallOption) (*PostNewTaskResponse, error)
-	PostNewNote(ctx context.Context, in *PostNewNoteRequest, opts ...grpc.CallOption) (*PostNewNoteResponse, error)
-	PutTaskById(ctx context.Context, in *PutTaskRequest, opts ...grpc.CallOption) (*PutTaskResponse, error)
-	PutNoteById(ctx context.Context, in *PutNoteRequest, opts ...grpc.CallOption) (*PutNoteResponse, error)
-	DeleteTaskById(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error)
-	DeleteNoteById(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error)
+	GetTasks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Task], error)
+	GetNotes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Note], error)
+	GetTasksById(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	GetNotesById(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	PostNewTask(ctx context.Context, in *PostNewTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	PostNewNote(ctx context.Context, in *PostNewNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	PutTaskById(ctx context.Context, in *PutTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	PutNoteById(ctx context.Context, in *PutNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	DeleteTaskById(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	DeleteNoteById(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	TransitionTask(ctx context.Context, in *TransitionTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	GetTaskHistory(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskHistoryResponse, error)
+	GetTaskOccurrences(ctx context.Context, in *OccurrencesRequest, opts ...grpc.CallOption) (*OccurrencesResponse, error)
+	GetNoteOccurrences(ctx context.Context, in *OccurrencesRequest, opts ...grpc.CallOption) (*OccurrencesResponse, error)
+	SnoozeTask(ctx context.Context, in *SnoozeRequest, opts ...grpc.CallOption) (*Task, error)
+	AcknowledgeTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	DismissTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	GetTaskSnoozes(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetSnoozesResponse, error)
+	SnoozeNote(ctx context.Context, in *SnoozeRequest, opts ...grpc.CallOption) (*Note, error)
+	AcknowledgeNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	DismissNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	GetNoteSnoozes(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*GetSnoozesResponse, error)
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Task], error)
+	ListNotes(ctx context.Context, in *ListNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Note], error)
+	SetTaskParent(ctx context.Context, in *SetTaskParentRequest, opts ...grpc.CallOption) (*Task, error)
+	AddTaskBlocker(ctx context.Context, in *TaskBlockerRequest, opts ...grpc.CallOption) (*Task, error)
+	RemoveTaskBlocker(ctx context.Context, in *TaskBlockerRequest, opts ...grpc.CallOption) (*Task, error)
+	GetTaskTree(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*TaskTreeResponse, error)
+	SetNoteTask(ctx context.Context, in *SetNoteTaskRequest, opts ...grpc.CallOption) (*Note, error)
+	Register(ctx context.Context, in *Credentials, opts ...grpc.CallOption) (*UserResponse, error)
+	Login(ctx context.Context, in *Credentials, opts ...grpc.CallOption) (*LoginResponse, error)
+	Logout(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Share(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*ShareResponse, error)
+	Unshare(ctx context.Context, in *UnshareRequest, opts ...grpc.CallOption) (*ShareResponse, error)
+	GetShares(ctx context.Context, in *ShareTarget, opts ...grpc.CallOption) (*SharesResponse, error)
+	GetTrash(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TrashResponse, error)
+	RestoreTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	RestoreNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	PurgeTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	PurgeNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error)
 }
 
 type remindablesServiceClient struct {
@@ -58,13 +118,13 @@ func NewRemindablesServiceClient(cc grpc.ClientConnInterface) RemindablesService
 	return &remindablesServiceClient{cc}
 }
 
-func (c *remindablesServiceClient) GetTasks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetTaskResponse], error) {
+func (c *remindablesServiceClient) GetTasks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Task], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &RemindablesService_ServiceDesc.Streams[0], RemindablesService_GetTasks_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[emptypb.Empty, GetTaskResponse]{ClientStream: stream}
+	x := &grpc.GenericClientStream[emptypb.Empty, Task]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -75,15 +135,15 @@ func (c *remindablesServiceClient) GetTasks(ctx context.Context, in *emptypb.Emp
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type RemindablesService_GetTasksClient = grpc.ServerStreamingClient[GetTaskResponse]
+type RemindablesService_GetTasksClient = grpc.ServerStreamingClient[Task]
 
-func (c *remindablesServiceClient) GetNotes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetNoteResponse], error) {
+func (c *remindablesServiceClient) GetNotes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Note], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &RemindablesService_ServiceDesc.Streams[1], RemindablesService_GetNotes_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[emptypb.Empty, GetNoteResponse]{ClientStream: stream}
+	x := &grpc.GenericClientStream[emptypb.Empty, Note]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -94,11 +154,11 @@ func (c *remindablesServiceClient) GetNotes(ctx context.Context, in *emptypb.Emp
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type RemindablesService_GetNotesClient = grpc.ServerStreamingClient[GetNoteResponse]
+type RemindablesService_GetNotesClient = grpc.ServerStreamingClient[Note]
 
-func (c *remindablesServiceClient) GetTasksById(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error) {
+func (c *remindablesServiceClient) GetTasksById(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetTaskResponse)
+	out := new(Task)
 	err := c.cc.Invoke(ctx, RemindablesService_GetTasksById_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -106,9 +166,9 @@ func (c *remindablesServiceClient) GetTasksById(ctx context.Context, in *GetTask
 	return out, nil
 }
 
-func (c *remindablesServiceClient) GetNotesById(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*GetNoteResponse, error) {
+func (c *remindablesServiceClient) GetNotesById(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetNoteResponse)
+	out := new(Note)
 	err := c.cc.Invoke(ctx, RemindablesService_GetNotesById_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -116,9 +176,9 @@ func (c *remindablesServiceClient) GetNotesById(ctx context.Context, in *GetNote
 	return out, nil
 }
 
-func (c *remindablesServiceClient) PostNewTask(ctx context.Context, in *PostNewTaskRequest, opts ...grpc.CallOption) (*PostNewTaskResponse, error) {
+func (c *remindablesServiceClient) PostNewTask(ctx context.Context, in *PostNewTaskRequest, opts ...grpc.CallOption) (*Task, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(PostNewTaskResponse)
+	out := new(Task)
 	err := c.cc.Invoke(ctx, RemindablesService_PostNewTask_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -126,9 +186,9 @@ func (c *remindablesServiceClient) PostNewTask(ctx context.Context, in *PostNewT
 	return out, nil
 }
 
-func (c *remindablesServiceClient) PostNewNote(ctx context.Context, in *PostNewNoteRequest, opts ...grpc.CallOption) (*PostNewNoteResponse, error) {
+func (c *remindablesServiceClient) PostNewNote(ctx context.Context, in *PostNewNoteRequest, opts ...grpc.CallOption) (*Note, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(PostNewNoteResponse)
+	out := new(Note)
 	err := c.cc.Invoke(ctx, RemindablesService_PostNewNote_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -136,9 +196,9 @@ func (c *remindablesServiceClient) PostNewNote(ctx context.Context, in *PostNewN
 	return out, nil
 }
 
-func (c *remindablesServiceClient) PutTaskById(ctx context.Context, in *PutTaskRequest, opts ...grpc.CallOption) (*PutTaskResponse, error) {
+func (c *remindablesServiceClient) PutTaskById(ctx context.Context, in *PutTaskRequest, opts ...grpc.CallOption) (*Task, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(PutTaskResponse)
+	out := new(Task)
 	err := c.cc.Invoke(ctx, RemindablesService_PutTaskById_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -146,9 +206,9 @@ func (c *remindablesServiceClient) PutTaskById(ctx context.Context, in *PutTaskR
 	return out, nil
 }
 
-func (c *remindablesServiceClient) PutNoteById(ctx context.Context, in *PutNoteRequest, opts ...grpc.CallOption) (*PutNoteResponse, error) {
+func (c *remindablesServiceClient) PutNoteById(ctx context.Context, in *PutNoteRequest, opts ...grpc.CallOption) (*Note, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(PutNoteResponse)
+	out := new(Note)
 	err := c.cc.Invoke(ctx, RemindablesService_PutNoteById_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -156,9 +216,9 @@ func (c *remindablesServiceClient) PutNoteById(ctx context.Context, in *PutNoteR
 	return out, nil
 }
 
-func (c *remindablesServiceClient) DeleteTaskById(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error) {
+func (c *remindablesServiceClient) DeleteTaskById(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*Task, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteTaskResponse)
+	out := new(Task)
 	err := c.cc.Invoke(ctx, RemindablesService_DeleteTaskById_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -166,9 +226,9 @@ func (c *remindablesServiceClient) DeleteTaskById(ctx context.Context, in *Delet
 	return out, nil
 }
 
-func (c *remindablesServiceClient) DeleteNoteById(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error) {
+func (c *remindablesServiceClient) DeleteNoteById(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*Note, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteNoteResponse)
+	out := new(Note)
 	err := c.cc.Invoke(ctx, RemindablesService_DeleteNoteById_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -176,20 +236,368 @@ func (c *remindablesServiceClient) DeleteNoteById(ctx context.Context, in *Delet
 	return out, nil
 }
 
+func (c *remindablesServiceClient) TransitionTask(ctx context.Context, in *TransitionTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, RemindablesService_TransitionTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) GetTaskHistory(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskHistoryResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_GetTaskHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) GetTaskOccurrences(ctx context.Context, in *OccurrencesRequest, opts ...grpc.CallOption) (*OccurrencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OccurrencesResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_GetTaskOccurrences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) GetNoteOccurrences(ctx context.Context, in *OccurrencesRequest, opts ...grpc.CallOption) (*OccurrencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OccurrencesResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_GetNoteOccurrences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) SnoozeTask(ctx context.Context, in *SnoozeRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, RemindablesService_SnoozeTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) AcknowledgeTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, RemindablesService_AcknowledgeTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) DismissTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, RemindablesService_DismissTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) GetTaskSnoozes(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetSnoozesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSnoozesResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_GetTaskSnoozes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) SnoozeNote(ctx context.Context, in *SnoozeRequest, opts ...grpc.CallOption) (*Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Note)
+	err := c.cc.Invoke(ctx, RemindablesService_SnoozeNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) AcknowledgeNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Note)
+	err := c.cc.Invoke(ctx, RemindablesService_AcknowledgeNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) DismissNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Note)
+	err := c.cc.Invoke(ctx, RemindablesService_DismissNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) GetNoteSnoozes(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*GetSnoozesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSnoozesResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_GetNoteSnoozes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Task], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RemindablesService_ServiceDesc.Streams[2], RemindablesService_ListTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListTasksRequest, Task]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RemindablesService_ListTasksClient = grpc.ServerStreamingClient[Task]
+
+func (c *remindablesServiceClient) ListNotes(ctx context.Context, in *ListNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Note], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RemindablesService_ServiceDesc.Streams[3], RemindablesService_ListNotes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListNotesRequest, Note]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RemindablesService_ListNotesClient = grpc.ServerStreamingClient[Note]
+
+func (c *remindablesServiceClient) SetTaskParent(ctx context.Context, in *SetTaskParentRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, RemindablesService_SetTaskParent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) AddTaskBlocker(ctx context.Context, in *TaskBlockerRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, RemindablesService_AddTaskBlocker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) RemoveTaskBlocker(ctx context.Context, in *TaskBlockerRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, RemindablesService_RemoveTaskBlocker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) GetTaskTree(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*TaskTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskTreeResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_GetTaskTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) SetNoteTask(ctx context.Context, in *SetNoteTaskRequest, opts ...grpc.CallOption) (*Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Note)
+	err := c.cc.Invoke(ctx, RemindablesService_SetNoteTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) Register(ctx context.Context, in *Credentials, opts ...grpc.CallOption) (*UserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) Login(ctx context.Context, in *Credentials, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) Logout(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RemindablesService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) Share(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*ShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_Share_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) Unshare(ctx context.Context, in *UnshareRequest, opts ...grpc.CallOption) (*ShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_Unshare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) GetShares(ctx context.Context, in *ShareTarget, opts ...grpc.CallOption) (*SharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SharesResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_GetShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) GetTrash(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrashResponse)
+	err := c.cc.Invoke(ctx, RemindablesService_GetTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) RestoreTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, RemindablesService_RestoreTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) RestoreNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Note)
+	err := c.cc.Invoke(ctx, RemindablesService_RestoreNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) PurgeTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, RemindablesService_PurgeTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remindablesServiceClient) PurgeNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*Note, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Note)
+	err := c.cc.Invoke(ctx, RemindablesService_PurgeNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RemindablesServiceServer is the server API for RemindablesService service.
 // All implementations must embed UnimplementedRemindablesServiceServer
 // for forward compatibility.
 type RemindablesServiceServer interface {
-	GetTasks(*emptypb.Empty, grpc.ServerStreamingServer[GetTaskResponse]) error
-	GetNotes(*emptypb.Empty, grpc.ServerStreamingServer[GetNoteResponse]) error
-	GetTasksById(context.Context, *GetTaskRequest) (*GetTaskResponse, error)
-	GetNotesById(context.Context, *GetNoteRequest) (*GetNoteResponse, error)
-	PostNewTask(context.Context, *PostNewTaskRequest) (*PostNewTaskResponse, error)
-	PostNewNote(context.Context, *PostNewNoteRequest) (*PostNewNoteResponse, error)
-	PutTaskById(context.Context, *PutTaskRequest) (*PutTaskResponse, error)
-	PutNoteById(context.Context, *PutNoteRequest) (*PutNoteResponse, error)
-	DeleteTaskById(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error)
-	DeleteNoteById(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error)
+	GetTasks(*emptypb.Empty, grpc.ServerStreamingServer[Task]) error
+	GetNotes(*emptypb.Empty, grpc.ServerStreamingServer[Note]) error
+	GetTasksById(context.Context, *GetTaskRequest) (*Task, error)
+	GetNotesById(context.Context, *GetNoteRequest) (*Note, error)
+	PostNewTask(context.Context, *PostNewTaskRequest) (*Task, error)
+	PostNewNote(context.Context, *PostNewNoteRequest) (*Note, error)
+	PutTaskById(context.Context, *PutTaskRequest) (*Task, error)
+	PutNoteById(context.Context, *PutNoteRequest) (*Note, error)
+	DeleteTaskById(context.Context, *DeleteTaskRequest) (*Task, error)
+	DeleteNoteById(context.Context, *DeleteNoteRequest) (*Note, error)
+	TransitionTask(context.Context, *TransitionTaskRequest) (*Task, error)
+	GetTaskHistory(context.Context, *GetTaskRequest) (*GetTaskHistoryResponse, error)
+	GetTaskOccurrences(context.Context, *OccurrencesRequest) (*OccurrencesResponse, error)
+	GetNoteOccurrences(context.Context, *OccurrencesRequest) (*OccurrencesResponse, error)
+	SnoozeTask(context.Context, *SnoozeRequest) (*Task, error)
+	AcknowledgeTask(context.Context, *GetTaskRequest) (*Task, error)
+	DismissTask(context.Context, *GetTaskRequest) (*Task, error)
+	GetTaskSnoozes(context.Context, *GetTaskRequest) (*GetSnoozesResponse, error)
+	SnoozeNote(context.Context, *SnoozeRequest) (*Note, error)
+	AcknowledgeNote(context.Context, *GetNoteRequest) (*Note, error)
+	DismissNote(context.Context, *GetNoteRequest) (*Note, error)
+	GetNoteSnoozes(context.Context, *GetNoteRequest) (*GetSnoozesResponse, error)
+	ListTasks(*ListTasksRequest, grpc.ServerStreamingServer[Task]) error
+	ListNotes(*ListNotesRequest, grpc.ServerStreamingServer[Note]) error
+	SetTaskParent(context.Context, *SetTaskParentRequest) (*Task, error)
+	AddTaskBlocker(context.Context, *TaskBlockerRequest) (*Task, error)
+	RemoveTaskBlocker(context.Context, *TaskBlockerRequest) (*Task, error)
+	GetTaskTree(context.Context, *GetTaskRequest) (*TaskTreeResponse, error)
+	SetNoteTask(context.Context, *SetNoteTaskRequest) (*Note, error)
+	Register(context.Context, *Credentials) (*UserResponse, error)
+	Login(context.Context, *Credentials) (*LoginResponse, error)
+	Logout(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	Share(context.Context, *ShareRequest) (*ShareResponse, error)
+	Unshare(context.Context, *UnshareRequest) (*ShareResponse, error)
+	GetShares(context.Context, *ShareTarget) (*SharesResponse, error)
+	GetTrash(context.Context, *emptypb.Empty) (*TrashResponse, error)
+	RestoreTask(context.Context, *GetTaskRequest) (*Task, error)
+	RestoreNote(context.Context, *GetNoteRequest) (*Note, error)
+	PurgeTask(context.Context, *GetTaskRequest) (*Task, error)
+	PurgeNote(context.Context, *GetNoteRequest) (*Note, error)
 	mustEmbedUnimplementedRemindablesServiceServer()
 }
 
@@ -200,36 +608,126 @@ type RemindablesServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedRemindablesServiceServer struct{}
 
-func (UnimplementedRemindablesServiceServer) GetTasks(*emptypb.Empty, grpc.ServerStreamingServer[GetTaskResponse]) error {
+func (UnimplementedRemindablesServiceServer) GetTasks(*emptypb.Empty, grpc.ServerStreamingServer[Task]) error {
 	return status.Error(codes.Unimplemented, "method GetTasks not implemented")
 }
-func (UnimplementedRemindablesServiceServer) GetNotes(*emptypb.Empty, grpc.ServerStreamingServer[GetNoteResponse]) error {
+func (UnimplementedRemindablesServiceServer) GetNotes(*emptypb.Empty, grpc.ServerStreamingServer[Note]) error {
 	return status.Error(codes.Unimplemented, "method GetNotes not implemented")
 }
-func (UnimplementedRemindablesServiceServer) GetTasksById(context.Context, *GetTaskRequest) (*GetTaskResponse, error) {
+func (UnimplementedRemindablesServiceServer) GetTasksById(context.Context, *GetTaskRequest) (*Task, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTasksById not implemented")
 }
-func (UnimplementedRemindablesServiceServer) GetNotesById(context.Context, *GetNoteRequest) (*GetNoteResponse, error) {
+func (UnimplementedRemindablesServiceServer) GetNotesById(context.Context, *GetNoteRequest) (*Note, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetNotesById not implemented")
 }
-func (UnimplementedRemindablesServiceServer) PostNewTask(context.Context, *PostNewTaskRequest) (*PostNewTaskResponse, error) {
+func (UnimplementedRemindablesServiceServer) PostNewTask(context.Context, *PostNewTaskRequest) (*Task, error) {
 	return nil, status.Error(codes.Unimplemented, "method PostNewTask not implemented")
 }
-func (UnimplementedRemindablesServiceServer) PostNewNote(context.Context, *PostNewNoteRequest) (*PostNewNoteResponse, error) {
+func (UnimplementedRemindablesServiceServer) PostNewNote(context.Context, *PostNewNoteRequest) (*Note, error) {
 	return nil, status.Error(codes.Unimplemented, "method PostNewNote not implemented")
 }
-func (UnimplementedRemindablesServiceServer) PutTaskById(context.Context, *PutTaskRequest) (*PutTaskResponse, error) {
+func (UnimplementedRemindablesServiceServer) PutTaskById(context.Context, *PutTaskRequest) (*Task, error) {
 	return nil, status.Error(codes.Unimplemented, "method PutTaskById not implemented")
 }
-func (UnimplementedRemindablesServiceServer) PutNoteById(context.Context, *PutNoteRequest) (*PutNoteResponse, error) {
+func (UnimplementedRemindablesServiceServer) PutNoteById(context.Context, *PutNoteRequest) (*Note, error) {
 	return nil, status.Error(codes.Unimplemented, "method PutNoteById not implemented")
 }
-func (UnimplementedRemindablesServiceServer) DeleteTaskById(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error) {
+func (UnimplementedRemindablesServiceServer) DeleteTaskById(context.Context, *DeleteTaskRequest) (*Task, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteTaskById not implemented")
 }
-func (UnimplementedRemindablesServiceServer) DeleteNoteById(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error) {
+func (UnimplementedRemindablesServiceServer) DeleteNoteById(context.Context, *DeleteNoteRequest) (*Note, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteNoteById not implemented")
 }
+func (UnimplementedRemindablesServiceServer) TransitionTask(context.Context, *TransitionTaskRequest) (*Task, error) {
+	return nil, status.Error(codes.Unimplemented, "method TransitionTask not implemented")
+}
+func (UnimplementedRemindablesServiceServer) GetTaskHistory(context.Context, *GetTaskRequest) (*GetTaskHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTaskHistory not implemented")
+}
+func (UnimplementedRemindablesServiceServer) GetTaskOccurrences(context.Context, *OccurrencesRequest) (*OccurrencesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTaskOccurrences not implemented")
+}
+func (UnimplementedRemindablesServiceServer) GetNoteOccurrences(context.Context, *OccurrencesRequest) (*OccurrencesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNoteOccurrences not implemented")
+}
+func (UnimplementedRemindablesServiceServer) SnoozeTask(context.Context, *SnoozeRequest) (*Task, error) {
+	return nil, status.Error(codes.Unimplemented, "method SnoozeTask not implemented")
+}
+func (UnimplementedRemindablesServiceServer) AcknowledgeTask(context.Context, *GetTaskRequest) (*Task, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcknowledgeTask not implemented")
+}
+func (UnimplementedRemindablesServiceServer) DismissTask(context.Context, *GetTaskRequest) (*Task, error) {
+	return nil, status.Error(codes.Unimplemented, "method DismissTask not implemented")
+}
+func (UnimplementedRemindablesServiceServer) GetTaskSnoozes(context.Context, *GetTaskRequest) (*GetSnoozesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTaskSnoozes not implemented")
+}
+func (UnimplementedRemindablesServiceServer) SnoozeNote(context.Context, *SnoozeRequest) (*Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method SnoozeNote not implemented")
+}
+func (UnimplementedRemindablesServiceServer) AcknowledgeNote(context.Context, *GetNoteRequest) (*Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcknowledgeNote not implemented")
+}
+func (UnimplementedRemindablesServiceServer) DismissNote(context.Context, *GetNoteRequest) (*Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method DismissNote not implemented")
+}
+func (UnimplementedRemindablesServiceServer) GetNoteSnoozes(context.Context, *GetNoteRequest) (*GetSnoozesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNoteSnoozes not implemented")
+}
+func (UnimplementedRemindablesServiceServer) ListTasks(*ListTasksRequest, grpc.ServerStreamingServer[Task]) error {
+	return status.Error(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedRemindablesServiceServer) ListNotes(*ListNotesRequest, grpc.ServerStreamingServer[Note]) error {
+	return status.Error(codes.Unimplemented, "method ListNotes not implemented")
+}
+func (UnimplementedRemindablesServiceServer) SetTaskParent(context.Context, *SetTaskParentRequest) (*Task, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTaskParent not implemented")
+}
+func (UnimplementedRemindablesServiceServer) AddTaskBlocker(context.Context, *TaskBlockerRequest) (*Task, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddTaskBlocker not implemented")
+}
+func (UnimplementedRemindablesServiceServer) RemoveTaskBlocker(context.Context, *TaskBlockerRequest) (*Task, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveTaskBlocker not implemented")
+}
+func (UnimplementedRemindablesServiceServer) GetTaskTree(context.Context, *GetTaskRequest) (*TaskTreeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTaskTree not implemented")
+}
+func (UnimplementedRemindablesServiceServer) SetNoteTask(context.Context, *SetNoteTaskRequest) (*Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetNoteTask not implemented")
+}
+func (UnimplementedRemindablesServiceServer) Register(context.Context, *Credentials) (*UserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedRemindablesServiceServer) Login(context.Context, *Credentials) (*LoginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedRemindablesServiceServer) Logout(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedRemindablesServiceServer) Share(context.Context, *ShareRequest) (*ShareResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Share not implemented")
+}
+func (UnimplementedRemindablesServiceServer) Unshare(context.Context, *UnshareRequest) (*ShareResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Unshare not implemented")
+}
+func (UnimplementedRemindablesServiceServer) GetShares(context.Context, *ShareTarget) (*SharesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetShares not implemented")
+}
+func (UnimplementedRemindablesServiceServer) GetTrash(context.Context, *emptypb.Empty) (*TrashResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTrash not implemented")
+}
+func (UnimplementedRemindablesServiceServer) RestoreTask(context.Context, *GetTaskRequest) (*Task, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreTask not implemented")
+}
+func (UnimplementedRemindablesServiceServer) RestoreNote(context.Context, *GetNoteRequest) (*Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreNote not implemented")
+}
+func (UnimplementedRemindablesServiceServer) PurgeTask(context.Context, *GetTaskRequest) (*Task, error) {
+	return nil, status.Error(codes.Unimplemented, "method PurgeTask not implemented")
+}
+func (UnimplementedRemindablesServiceServer) PurgeNote(context.Context, *GetNoteRequest) (*Note, error) {
+	return nil, status.Error(codes.Unimplemented, "method PurgeNote not implemented")
+}
 func (UnimplementedRemindablesServiceServer) mustEmbedUnimplementedRemindablesServiceServer() {}
 func (UnimplementedRemindablesServiceServer) testEmbeddedByValue()                            {}
 
@@ -256,22 +754,22 @@ func _RemindablesService_GetTasks_Handler(srv interface{}, stream grpc.ServerStr
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(RemindablesServiceServer).GetTasks(m, &grpc.GenericServerStream[emptypb.Empty, GetTaskResponse]{ServerStream: stream})
+	return srv.(RemindablesServiceServer).GetTasks(m, &grpc.GenericServerStream[emptypb.Empty, Task]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type RemindablesService_GetTasksServer = grpc.ServerStreamingServer[GetTaskResponse]
+type RemindablesService_GetTasksServer = grpc.ServerStreamingServer[Task]
 
 func _RemindablesService_GetNotes_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(emptypb.Empty)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(RemindablesServiceServer).GetNotes(m, &grpc.GenericServerStream[emptypb.Empty, GetNoteResponse]{ServerStream: stream})
+	return srv.(RemindablesServiceServer).GetNotes(m, &grpc.GenericServerStream[emptypb.Empty, Note]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type RemindablesService_GetNotesServer = grpc.ServerStreamingServer[GetNoteResponse]
+type RemindablesService_GetNotesServer = grpc.ServerStreamingServer[Note]
 
 func _RemindablesService_GetTasksById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTaskRequest)
@@ -417,6 +915,532 @@ func _RemindablesService_DeleteNoteById_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RemindablesService_TransitionTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).TransitionTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_TransitionTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).TransitionTask(ctx, req.(*TransitionTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_GetTaskHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).GetTaskHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_GetTaskHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).GetTaskHistory(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_GetTaskOccurrences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OccurrencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).GetTaskOccurrences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_GetTaskOccurrences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).GetTaskOccurrences(ctx, req.(*OccurrencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_GetNoteOccurrences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OccurrencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).GetNoteOccurrences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_GetNoteOccurrences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).GetNoteOccurrences(ctx, req.(*OccurrencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_SnoozeTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnoozeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).SnoozeTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_SnoozeTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).SnoozeTask(ctx, req.(*SnoozeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_AcknowledgeTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).AcknowledgeTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_AcknowledgeTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).AcknowledgeTask(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_DismissTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).DismissTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_DismissTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).DismissTask(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_GetTaskSnoozes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).GetTaskSnoozes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_GetTaskSnoozes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).GetTaskSnoozes(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_SnoozeNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnoozeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).SnoozeNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_SnoozeNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).SnoozeNote(ctx, req.(*SnoozeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_AcknowledgeNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).AcknowledgeNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_AcknowledgeNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).AcknowledgeNote(ctx, req.(*GetNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_DismissNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).DismissNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_DismissNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).DismissNote(ctx, req.(*GetNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_GetNoteSnoozes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).GetNoteSnoozes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_GetNoteSnoozes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).GetNoteSnoozes(ctx, req.(*GetNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_ListTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTasksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemindablesServiceServer).ListTasks(m, &grpc.GenericServerStream[ListTasksRequest, Task]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RemindablesService_ListTasksServer = grpc.ServerStreamingServer[Task]
+
+func _RemindablesService_ListNotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListNotesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemindablesServiceServer).ListNotes(m, &grpc.GenericServerStream[ListNotesRequest, Note]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RemindablesService_ListNotesServer = grpc.ServerStreamingServer[Note]
+
+func _RemindablesService_SetTaskParent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTaskParentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).SetTaskParent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_SetTaskParent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).SetTaskParent(ctx, req.(*SetTaskParentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_AddTaskBlocker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskBlockerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).AddTaskBlocker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_AddTaskBlocker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).AddTaskBlocker(ctx, req.(*TaskBlockerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_RemoveTaskBlocker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskBlockerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).RemoveTaskBlocker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_RemoveTaskBlocker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).RemoveTaskBlocker(ctx, req.(*TaskBlockerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_GetTaskTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).GetTaskTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_GetTaskTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).GetTaskTree(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_SetNoteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetNoteTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).SetNoteTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_SetNoteTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).SetNoteTask(ctx, req.(*SetNoteTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Credentials)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).Register(ctx, req.(*Credentials))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Credentials)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).Login(ctx, req.(*Credentials))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).Logout(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_Share_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).Share(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_Share_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).Share(ctx, req.(*ShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_Unshare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).Unshare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_Unshare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).Unshare(ctx, req.(*UnshareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_GetShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareTarget)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).GetShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_GetShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).GetShares(ctx, req.(*ShareTarget))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_GetTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).GetTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_GetTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).GetTrash(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_RestoreTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).RestoreTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_RestoreTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).RestoreTask(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_RestoreNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).RestoreNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_RestoreNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).RestoreNote(ctx, req.(*GetNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_PurgeTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).PurgeTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_PurgeTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).PurgeTask(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemindablesService_PurgeNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemindablesServiceServer).PurgeNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemindablesService_PurgeNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemindablesServiceServer).PurgeNote(ctx, req.(*GetNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RemindablesService_ServiceDesc is the grpc.ServiceDesc for RemindablesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -456,6 +1480,118 @@ var RemindablesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteNoteById",
 			Handler:    _RemindablesService_DeleteNoteById_Handler,
 		},
+		{
+			MethodName: "TransitionTask",
+			Handler:    _RemindablesService_TransitionTask_Handler,
+		},
+		{
+			MethodName: "GetTaskHistory",
+			Handler:    _RemindablesService_GetTaskHistory_Handler,
+		},
+		{
+			MethodName: "GetTaskOccurrences",
+			Handler:    _RemindablesService_GetTaskOccurrences_Handler,
+		},
+		{
+			MethodName: "GetNoteOccurrences",
+			Handler:    _RemindablesService_GetNoteOccurrences_Handler,
+		},
+		{
+			MethodName: "SnoozeTask",
+			Handler:    _RemindablesService_SnoozeTask_Handler,
+		},
+		{
+			MethodName: "AcknowledgeTask",
+			Handler:    _RemindablesService_AcknowledgeTask_Handler,
+		},
+		{
+			MethodName: "DismissTask",
+			Handler:    _RemindablesService_DismissTask_Handler,
+		},
+		{
+			MethodName: "GetTaskSnoozes",
+			Handler:    _RemindablesService_GetTaskSnoozes_Handler,
+		},
+		{
+			MethodName: "SnoozeNote",
+			Handler:    _RemindablesService_SnoozeNote_Handler,
+		},
+		{
+			MethodName: "AcknowledgeNote",
+			Handler:    _RemindablesService_AcknowledgeNote_Handler,
+		},
+		{
+			MethodName: "DismissNote",
+			Handler:    _RemindablesService_DismissNote_Handler,
+		},
+		{
+			MethodName: "GetNoteSnoozes",
+			Handler:    _RemindablesService_GetNoteSnoozes_Handler,
+		},
+		{
+			MethodName: "SetTaskParent",
+			Handler:    _RemindablesService_SetTaskParent_Handler,
+		},
+		{
+			MethodName: "AddTaskBlocker",
+			Handler:    _RemindablesService_AddTaskBlocker_Handler,
+		},
+		{
+			MethodName: "RemoveTaskBlocker",
+			Handler:    _RemindablesService_RemoveTaskBlocker_Handler,
+		},
+		{
+			MethodName: "GetTaskTree",
+			Handler:    _RemindablesService_GetTaskTree_Handler,
+		},
+		{
+			MethodName: "SetNoteTask",
+			Handler:    _RemindablesService_SetNoteTask_Handler,
+		},
+		{
+			MethodName: "Register",
+			Handler:    _RemindablesService_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _RemindablesService_Login_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _RemindablesService_Logout_Handler,
+		},
+		{
+			MethodName: "Share",
+			Handler:    _RemindablesService_Share_Handler,
+		},
+		{
+			MethodName: "Unshare",
+			Handler:    _RemindablesService_Unshare_Handler,
+		},
+		{
+			MethodName: "GetShares",
+			Handler:    _RemindablesService_GetShares_Handler,
+		},
+		{
+			MethodName: "GetTrash",
+			Handler:    _RemindablesService_GetTrash_Handler,
+		},
+		{
+			MethodName: "RestoreTask",
+			Handler:    _RemindablesService_RestoreTask_Handler,
+		},
+		{
+			MethodName: "RestoreNote",
+			Handler:    _RemindablesService_RestoreNote_Handler,
+		},
+		{
+			MethodName: "PurgeTask",
+			Handler:    _RemindablesService_PurgeTask_Handler,
+		},
+		{
+			MethodName: "PurgeNote",
+			Handler:    _RemindablesService_PurgeNote_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -468,6 +1604,16 @@ var RemindablesService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _RemindablesService_GetNotes_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListTasks",
+			Handler:       _RemindablesService_ListTasks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListNotes",
+			Handler:       _RemindablesService_ListNotes_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/grpc/v1/remindables.proto",
 }
@@ -3,18 +3,24 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"slices"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/12_gRPC/internal/model"
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/12_gRPC/internal/repository"
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/12_gRPC/proto_api/pkg/grpc/v1/remindables_api"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/envflag"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -25,23 +31,77 @@ import (
 
 type server struct {
 	remindables_api.UnimplementedRemindablesServiceServer
+	tasks repository.TaskStore
+	notes repository.NoteStore
+}
+
+// storeError приводит ошибки хранилища к статусам gRPC
+func storeError(err error, notFound string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return status.Error(codes.NotFound, notFound)
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// requestDate возвращает дату из запроса: текстовое значение text, если оно задано,
+// разбирается dateinput.Parse в часовом поясе из метаданных x-timezone (по умолчанию UTC),
+// иначе используется ts
+func requestDate(ctx context.Context, ts *timestamppb.Timestamp, text string) (time.Time, error) {
+	if text == "" {
+		return ts.AsTime(), nil
+	}
+	loc := time.UTC
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if tz := md.Get("x-timezone"); len(tz) > 0 && tz[0] != "" {
+			var err error
+			if loc, err = time.LoadLocation(tz[0]); err != nil {
+				return time.Time{}, status.Errorf(codes.InvalidArgument, "неизвестный часовой пояс %q", tz[0])
+			}
+		}
+	}
+	date, err := dateinput.Parse(text, time.Now(), loc)
+	if err != nil {
+		return time.Time{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return date, nil
+}
+
+// taskResponse преобразует задачу в сообщение Task
+func taskResponse(task model.Task) *remindables_api.Task {
+	return &remindables_api.Task{
+		Id:            int32(task.Id),
+		Name:          task.Name,
+		Description:   task.Description,
+		InitTimeStamp: timestamppb.New(task.InitTimeStamp),
+		DueDate:       timestamppb.New(task.DueDate),
+		Status:        task.Status,
+	}
+}
+
+// noteResponse преобразует заметку в сообщение Note
+func noteResponse(note model.Note) *remindables_api.Note {
+	return &remindables_api.Note{
+		Id:             int32(note.Id),
+		Name:           note.Name,
+		Description:    note.Description,
+		AlarmTimeStamp: timestamppb.New(note.AlarmTimeStamp),
+	}
 }
 
 // GetTasks implements remindables_api.RemindablesServiceClient.
 func (s *server) GetTasks(
 	args *emptypb.Empty,
-	stream grpc.ServerStreamingServer[remindables_api.GetTaskResponse],
+	stream grpc.ServerStreamingServer[remindables_api.Task],
 ) error {
-	tasks := repository.GetTasks()
-	for _, task := range *tasks {
-		stream.Send(&remindables_api.GetTaskResponse{
-			Id:            int32(task.Id),
-			Name:          task.Name,
-			Description:   task.Description,
-			InitTimeStamp: timestamppb.New(task.InitTimeStamp),
-			DueDate:       timestamppb.New(task.DueDate),
-			Status:        task.Status,
-		})
+	tasks, err := s.tasks.ListTasks(stream.Context())
+	if err != nil {
+		return storeError(err, "")
+	}
+	for _, task := range tasks {
+		err := stream.Send(taskResponse(task))
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -50,16 +110,17 @@ func (s *server) GetTasks(
 // GetNotes implements remindables_api.RemindablesServiceClient.
 func (s *server) GetNotes(
 	args *emptypb.Empty,
-	stream grpc.ServerStreamingServer[remindables_api.GetNoteResponse],
+	stream grpc.ServerStreamingServer[remindables_api.Note],
 ) error {
-	notes := repository.GetNotes()
-	for _, note := range *notes {
-		stream.Send(&remindables_api.GetNoteResponse{
-			Id:             int32(note.Id),
-			Name:           note.Name,
-			Description:    note.Description,
-			AlarmTimeStamp: timestamppb.New(note.AlarmTimeStamp),
-		})
+	notes, err := s.notes.ListNotes(stream.Context())
+	if err != nil {
+		return storeError(err, "")
+	}
+	for _, note := range notes {
+		err := stream.Send(noteResponse(note))
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -69,162 +130,122 @@ func (s *server) GetNotes(
 func (s *server) GetTasksById(
 	ctx context.Context,
 	userRequest *remindables_api.GetTaskRequest,
-) (*remindables_api.GetTaskResponse, error) {
-	tasks := *repository.GetTasks()
-	taskID := userRequest.GetId()
-	idx := slices.IndexFunc(tasks, func(task model.Task) bool {
-		return task.Id == int(taskID)
-	})
-	return &remindables_api.GetTaskResponse{
-		Id:            int32(tasks[idx].Id),
-		Name:          tasks[idx].Name,
-		Description:   tasks[idx].Description,
-		InitTimeStamp: timestamppb.New(tasks[idx].InitTimeStamp),
-		DueDate:       timestamppb.New(tasks[idx].DueDate),
-		Status:        tasks[idx].Status,
-	}, nil
+) (*remindables_api.Task, error) {
+	task, err := s.tasks.GetTask(ctx, int(userRequest.GetId()))
+	if err != nil {
+		return nil, storeError(err, "задача не найдена")
+	}
+	return taskResponse(task), nil
 }
 
 // GetNotesById implements remindables_api.RemindablesServiceClient.
 func (s *server) GetNotesById(
 	ctx context.Context,
 	userRequest *remindables_api.GetNoteRequest,
-) (*remindables_api.GetNoteResponse, error) {
-	notes := *repository.GetNotes()
-	noteID := userRequest.GetId()
-	idx := slices.IndexFunc(notes, func(note model.Note) bool {
-		return note.Id == int(noteID)
-	})
-	return &remindables_api.GetNoteResponse{
-		Id:             int32(notes[idx].Id),
-		Name:           notes[idx].Name,
-		Description:    notes[idx].Description,
-		AlarmTimeStamp: timestamppb.New(notes[idx].AlarmTimeStamp),
-	}, nil
+) (*remindables_api.Note, error) {
+	note, err := s.notes.GetNote(ctx, int(userRequest.GetId()))
+	if err != nil {
+		return nil, storeError(err, "заметка не найдена")
+	}
+	return noteResponse(note), nil
 }
 
 // PostNewTask implements remindables_api.RemindablesServiceClient.
 func (s *server) PostNewTask(
 	ctx context.Context,
 	userRequest *remindables_api.PostNewTaskRequest,
-) (*remindables_api.PostNewTaskResponse, error) {
+) (*remindables_api.Task, error) {
 	name := userRequest.GetName()
 	description := userRequest.GetDescription()
-	dueDate := userRequest.GetDueDate().AsTime()
-	task := *repository.PostNewTask(name, description, dueDate)
-	if task.Id == 0 {
-		return &remindables_api.PostNewTaskResponse{}, fmt.Errorf("ошибка создания новой задачи")
+	dueDate, err := requestDate(ctx, userRequest.GetDueDate(), userRequest.GetDueDateText())
+	if err != nil {
+		return nil, err
 	}
-	return &remindables_api.PostNewTaskResponse{
-		Id:            int32(task.Id),
-		Name:          task.Name,
-		Description:   task.Description,
-		InitTimeStamp: timestamppb.New(task.InitTimeStamp),
-		DueDate:       timestamppb.New(task.DueDate),
-		Status:        task.Status,
-	}, nil
+	task, err := s.tasks.CreateTask(ctx, name, description, dueDate)
+	if err != nil {
+		return nil, storeError(err, "")
+	}
+	return taskResponse(task), nil
 }
 
 // PostNewNote implements remindables_api.RemindablesServiceClient.
 func (s *server) PostNewNote(
 	ctx context.Context,
 	userRequest *remindables_api.PostNewNoteRequest,
-) (*remindables_api.PostNewNoteResponse, error) {
+) (*remindables_api.Note, error) {
 	name := userRequest.GetName()
 	description := userRequest.GetDescription()
-	alarmTimeStamp := userRequest.GetAlarmTimeStamp().AsTime()
-	note := *repository.PostNewNote(name, description, alarmTimeStamp)
-	if note.Id == 0 {
-		return &remindables_api.PostNewNoteResponse{}, fmt.Errorf("ошибка создания новой заметки")
+	alarmTimeStamp, err := requestDate(ctx, userRequest.GetAlarmTimeStamp(), userRequest.GetAlarmTimeStampText())
+	if err != nil {
+		return nil, err
 	}
-	return &remindables_api.PostNewNoteResponse{
-		Id:             int32(note.Id),
-		Name:           note.Name,
-		Description:    note.Description,
-		AlarmTimeStamp: timestamppb.New(note.AlarmTimeStamp),
-	}, nil
+	note, err := s.notes.CreateNote(ctx, name, description, alarmTimeStamp)
+	if err != nil {
+		return nil, storeError(err, "")
+	}
+	return noteResponse(note), nil
 }
 
 // PutTaskById implements remindables_api.RemindablesServiceClient.
 func (s *server) PutTaskById(
 	ctx context.Context,
 	userRequest *remindables_api.PutTaskRequest,
-) (*remindables_api.PutTaskResponse, error) {
+) (*remindables_api.Task, error) {
 	id := userRequest.GetId()
 	name := userRequest.GetName()
 	description := userRequest.GetDescription()
-	dueDate := userRequest.GetDueDate().AsTime()
-	task := *repository.PutTaskById(id, name, description, dueDate)
-	if task.Id == 0 {
-		return &remindables_api.PutTaskResponse{}, fmt.Errorf("ошибка изменения задачи")
+	dueDate, err := requestDate(ctx, userRequest.GetDueDate(), userRequest.GetDueDateText())
+	if err != nil {
+		return nil, err
 	}
-	return &remindables_api.PutTaskResponse{
-		Id:            int32(task.Id),
-		Name:          task.Name,
-		Description:   task.Description,
-		InitTimeStamp: timestamppb.New(task.InitTimeStamp),
-		DueDate:       timestamppb.New(task.DueDate),
-		Status:        task.Status,
-	}, nil
+	task, err := s.tasks.UpdateTask(ctx, int(id), name, description, dueDate)
+	if err != nil {
+		return nil, storeError(err, "задача не найдена")
+	}
+	return taskResponse(task), nil
 }
 
 // PutNoteById implements remindables_api.RemindablesServiceClient.
 func (s *server) PutNoteById(
 	ctx context.Context,
 	userRequest *remindables_api.PutNoteRequest,
-) (*remindables_api.PutNoteResponse, error) {
+) (*remindables_api.Note, error) {
 	id := userRequest.GetId()
 	name := userRequest.GetName()
 	description := userRequest.GetDescription()
-	alarmTimeStamp := userRequest.GetAlarmTimeStamp().AsTime()
-	note := *repository.PutNoteById(id, name, description, alarmTimeStamp)
-	if note.Id == 0 {
-		return &remindables_api.PutNoteResponse{}, fmt.Errorf("ошибка изменения заметки")
+	alarmTimeStamp, err := requestDate(ctx, userRequest.GetAlarmTimeStamp(), userRequest.GetAlarmTimeStampText())
+	if err != nil {
+		return nil, err
 	}
-	return &remindables_api.PutNoteResponse{
-		Id:             int32(note.Id),
-		Name:           note.Name,
-		Description:    note.Description,
-		AlarmTimeStamp: timestamppb.New(note.AlarmTimeStamp),
-	}, nil
+	note, err := s.notes.UpdateNote(ctx, int(id), name, description, alarmTimeStamp)
+	if err != nil {
+		return nil, storeError(err, "заметка не найдена")
+	}
+	return noteResponse(note), nil
 }
 
 // DeleteTaskById implements remindables_api.RemindablesServiceClient.
 func (s *server) DeleteTaskById(
 	ctx context.Context,
 	userRequest *remindables_api.DeleteTaskRequest,
-) (*remindables_api.DeleteTaskResponse, error) {
-	id := userRequest.GetId()
-	task := *repository.DeleteTaskById(id)
-	if task.Id == 0 {
-		return &remindables_api.DeleteTaskResponse{}, fmt.Errorf("ошибка удаления задачи")
+) (*remindables_api.Task, error) {
+	task, err := s.tasks.DeleteTask(ctx, int(userRequest.GetId()))
+	if err != nil {
+		return nil, storeError(err, "задача не найдена")
 	}
-	return &remindables_api.DeleteTaskResponse{
-		Id:            int32(task.Id),
-		Name:          task.Name,
-		Description:   task.Description,
-		InitTimeStamp: timestamppb.New(task.InitTimeStamp),
-		DueDate:       timestamppb.New(task.DueDate),
-		Status:        task.Status,
-	}, nil
+	return taskResponse(task), nil
 }
 
 // DeleteNoteById implements remindables_api.RemindablesServiceClient.
 func (s *server) DeleteNoteById(
 	ctx context.Context,
 	userRequest *remindables_api.DeleteNoteRequest,
-) (*remindables_api.DeleteNoteResponse, error) {
-	id := userRequest.GetId()
-	note := *repository.DeleteNoteById(id)
-	if note.Id == 0 {
-		return &remindables_api.DeleteNoteResponse{}, fmt.Errorf("ошибка удаления заметки")
+) (*remindables_api.Note, error) {
+	note, err := s.notes.DeleteNote(ctx, int(userRequest.GetId()))
+	if err != nil {
+		return nil, storeError(err, "заметка не найдена")
 	}
-	return &remindables_api.DeleteNoteResponse{
-		Id:             int32(note.Id),
-		Name:           note.Name,
-		Description:    note.Description,
-		AlarmTimeStamp: timestamppb.New(note.AlarmTimeStamp),
-	}, nil
+	return noteResponse(note), nil
 }
 
 func createFiles(fileNames ...string) {
@@ -252,6 +273,10 @@ func createFiles(fileNames ...string) {
 }
 
 func main() {
+	addr := envflag.String("grpc-addr", "localhost:5001", "gRPC listen address")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 10*time.Second, "time to drain in-flight RPCs on SIGINT/SIGTERM")
+	flag.Parse()
+
 	// создание json-файлов для хранения задач и заметок
 	createFiles("tasks.json", "notes.json")
 
@@ -277,7 +302,7 @@ func main() {
 		panic(fmt.Sprintf("ошибка наполнения repository.Notes из файла notes.json: %v", err))
 	}
 
-	lis, err := net.Listen("tcp", "localhost:5001")
+	lis, err := net.Listen("tcp", *addr)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
@@ -290,17 +315,53 @@ func main() {
 			loggingStreamInterceptor,
 		),
 	)
-	remindables_api.RegisterRemindablesServiceServer(s, &server{})
+	store := repository.NewFileStore()
+	remindables_api.RegisterRemindablesServiceServer(s, &server{tasks: store, notes: store})
 
 	reflection.Register(s)
 
-	log.Println("Server is running at :5001")
-	if err := s.Serve(lis); err != nil {
+	// контекст, отменяемый по сигналам SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("Server is running at %s", lis.Addr())
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveErr:
 		log.Fatalf("Failed to serve: %v", err)
+	case <-ctx.Done():
+		stop()
 	}
+	log.Println("Shutting down, waiting for in-flight RPCs...")
+	gracefulStop(s, *shutdownTimeout)
+	log.Println("Server stopped")
 
 }
 
+// gracefulStop дожидается завершения текущих вызовов не дольше timeout,
+// после чего закрывает оставшиеся соединения принудительно
+func gracefulStop(s *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+	case <-timer.C:
+		log.Printf("Graceful stop timed out after %s, forcing stop", timeout)
+		s.Stop()
+		<-stopped
+	}
+}
+
 func loggingStreamInterceptor(
 	srv any,
 	ss grpc.ServerStream,
@@ -3,6 +3,7 @@ module github.com/corridda/OTUS_Golang_Developer/Basic/Homework/13_tests
 go 1.25.5
 
 require (
+	github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared v0.0.0
 	github.com/gin-gonic/gin v1.11.0
 	github.com/stretchr/testify v1.11.1
 )
@@ -43,3 +44,5 @@ require (
 	google.golang.org/protobuf v1.36.9 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared => ../shared
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/13_tests/internal/model"
+)
+
+// TaskListQuery параметры фильтрации, сортировки и пагинации списка задач
+type TaskListQuery struct {
+	Status  string    `form:"status"`
+	DueFrom time.Time `form:"due_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	DueTo   time.Time `form:"due_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Name    string    `form:"name"`
+	Sort    string    `form:"sort,default=id" binding:"oneof=id -id name -name dueDate -dueDate"`
+	Limit   int       `form:"limit,default=50" binding:"gte=1,lte=500"`
+	Cursor  string    `form:"cursor"`
+}
+
+// NoteListQuery параметры фильтрации, сортировки и пагинации списка заметок
+type NoteListQuery struct {
+	AlarmFrom time.Time `form:"alarm_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	AlarmTo   time.Time `form:"alarm_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Name      string    `form:"name"`
+	Sort      string    `form:"sort,default=id" binding:"oneof=id -id name -name alarmTimeStamp -alarmTimeStamp"`
+	Limit     int       `form:"limit,default=50" binding:"gte=1,lte=500"`
+	Cursor    string    `form:"cursor"`
+}
+
+// Page страница списка с курсором на следующую страницу
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ErrInvalidCursor курсор не разобран или не соответствует порядку сортировки
+var ErrInvalidCursor = errors.New("некорректный курсор пагинации")
+
+// pageCursor положение последнего элемента страницы в выбранном порядке сортировки
+type pageCursor struct {
+	Sort string    `json:"s"`
+	Name string    `json:"n,omitempty"`
+	Time time.Time `json:"t"`
+	Id   int       `json:"id"`
+}
+
+func encodeCursor(cur pageCursor) string {
+	data, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(raw, sort string) (*pageCursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cur pageCursor
+	if err := json.Unmarshal(data, &cur); err != nil || cur.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return &cur, nil
+}
+
+// compareKeys сравнивает два элемента по полю сортировки с дополнительным упорядочиванием по Id
+func compareKeys(sort string, nameA, nameB string, timeA, timeB time.Time, idA, idB int) int {
+	var c int
+	switch strings.TrimPrefix(sort, "-") {
+	case "name":
+		c = cmp.Compare(nameA, nameB)
+	case "dueDate", "alarmTimeStamp":
+		c = timeA.Compare(timeB)
+	}
+	if c == 0 {
+		c = cmp.Compare(idA, idB)
+	}
+	if strings.HasPrefix(sort, "-") {
+		return -c
+	}
+	return c
+}
+
+// inRange проверяет попадание t в интервал [from, to); нулевые границы не ограничивают интервал
+func inRange(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+}
+
+// paginate отбирает элементы после курсора и обрезает результат до limit
+func paginate[T any](items []T, cur *pageCursor, limit int, after func(T, pageCursor) bool, next func(T) pageCursor) Page[T] {
+	if cur != nil {
+		start := slices.IndexFunc(items, func(item T) bool { return after(item, *cur) })
+		if start < 0 {
+			start = len(items)
+		}
+		items = items[start:]
+	}
+	page := Page[T]{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = encodeCursor(next(page.Items[limit-1]))
+	}
+	return page
+}
+
+// ListTasks возвращает страницу задач из tasks, отобранных и упорядоченных согласно query
+func ListTasks(tasks []model.Task, query TaskListQuery) (Page[model.Task], error) {
+	cur, err := decodeCursor(query.Cursor, query.Sort)
+	if err != nil {
+		return Page[model.Task]{Items: []model.Task{}}, err
+	}
+
+	name := strings.ToLower(query.Name)
+	filtered := make([]model.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if query.Status != "" && task.Status != query.Status {
+			continue
+		}
+		if !inRange(task.DueDate, query.DueFrom, query.DueTo) {
+			continue
+		}
+		if name != "" && !strings.Contains(strings.ToLower(task.Name), name) {
+			continue
+		}
+		filtered = append(filtered, task)
+	}
+	slices.SortStableFunc(filtered, func(a, b model.Task) int {
+		return compareKeys(query.Sort, a.Name, b.Name, a.DueDate, b.DueDate, a.Id, b.Id)
+	})
+
+	return paginate(
+		filtered,
+		cur,
+		query.Limit,
+		func(task model.Task, cur pageCursor) bool {
+			return compareKeys(query.Sort, task.Name, cur.Name, task.DueDate, cur.Time, task.Id, cur.Id) > 0
+		},
+		func(task model.Task) pageCursor {
+			return pageCursor{Sort: query.Sort, Name: task.Name, Time: task.DueDate, Id: task.Id}
+		},
+	), nil
+}
+
+// ListNotes возвращает страницу заметок из notes, отобранных и упорядоченных согласно query
+func ListNotes(notes []model.Note, query NoteListQuery) (Page[model.Note], error) {
+	cur, err := decodeCursor(query.Cursor, query.Sort)
+	if err != nil {
+		return Page[model.Note]{Items: []model.Note{}}, err
+	}
+
+	name := strings.ToLower(query.Name)
+	filtered := make([]model.Note, 0, len(notes))
+	for _, note := range notes {
+		if !inRange(note.AlarmTimeStamp, query.AlarmFrom, query.AlarmTo) {
+			continue
+		}
+		if name != "" && !strings.Contains(strings.ToLower(note.Name), name) {
+			continue
+		}
+		filtered = append(filtered, note)
+	}
+	slices.SortStableFunc(filtered, func(a, b model.Note) int {
+		return compareKeys(query.Sort, a.Name, b.Name, a.AlarmTimeStamp, b.AlarmTimeStamp, a.Id, b.Id)
+	})
+
+	return paginate(
+		filtered,
+		cur,
+		query.Limit,
+		func(note model.Note, cur pageCursor) bool {
+			return compareKeys(query.Sort, note.Name, cur.Name, note.AlarmTimeStamp, cur.Time, note.Id, cur.Id) > 0
+		},
+		func(note model.Note) pageCursor {
+			return pageCursor{Sort: query.Sort, Name: note.Name, Time: note.AlarmTimeStamp, Id: note.Id}
+		},
+	), nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/13_tests/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// Фиксированный набор задач, не зависящий от общего состояния repository.Tasks
+func listFixture() []model.Task {
+	day := func(d int) time.Time { return time.Date(2026, 11, d, 0, 0, 0, 0, time.UTC) }
+	return []model.Task{
+		{Id: 1, Name: "Отчет за квартал", DueDate: day(3), Status: model.Created},
+		{Id: 2, Name: "Купить хлеб", DueDate: day(1), Status: model.Completed},
+		{Id: 3, Name: "Отчет за год", DueDate: day(2), Status: model.Created},
+		{Id: 4, Name: "Позвонить", DueDate: day(2), Status: model.InProcess},
+		{Id: 5, Name: "отчет 100%", DueDate: day(5), Status: model.Created},
+	}
+}
+
+func taskIds(tasks []model.Task) []int {
+	ids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.Id)
+	}
+	return ids
+}
+
+func TestListTasks(t *testing.T) {
+	t.Run("filtering by status, name and due date range", func(t *testing.T) {
+		t.Parallel()
+
+		page, err := ListTasks(listFixture(), TaskListQuery{
+			Status:  model.Created,
+			Name:    "ОТЧЕТ",
+			DueFrom: time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+			DueTo:   time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC),
+			Sort:    "id",
+			Limit:   50,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 3}, taskIds(page.Items))
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("walking pages by cursor keeps order without gaps", func(t *testing.T) {
+		t.Parallel()
+
+		tasks := listFixture()
+		query := TaskListQuery{Sort: "-dueDate", Limit: 2}
+		var ids []int
+		for pages := 0; pages < len(tasks); pages++ {
+			page, err := ListTasks(tasks, query)
+			assert.NoError(t, err)
+			ids = append(ids, taskIds(page.Items)...)
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+
+		assert.Equal(t, []int{5, 1, 4, 3, 2}, ids)
+	})
+
+	t.Run("failure with cursor of another sort order", func(t *testing.T) {
+		t.Parallel()
+
+		page, err := ListTasks(listFixture(), TaskListQuery{Sort: "name", Limit: 1})
+		assert.NoError(t, err)
+
+		_, err = ListTasks(listFixture(), TaskListQuery{Sort: "id", Limit: 1, Cursor: page.NextCursor})
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
@@ -251,9 +251,20 @@ func FillNotesFromJSON(wg *sync.WaitGroup, errors chan<- error) {
 	}
 }
 
-// GetTasks Обработка Get-запроса типа /api/items для задач
+// GetTasks Обработка Get-запроса типа /api/items для задач, напр.:
+// /api/tasks/items?name=отчет&sort=-dueDate&limit=20
 func GetTasks(c *gin.Context) {
-	c.JSON(http.StatusOK, Tasks)
+	var query TaskListQuery
+	if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page, err := ListTasks(Tasks, query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, page)
 }
 
 // GetTasksById Обработка Get-запрос типа /api/item/id для задач
@@ -273,9 +284,20 @@ func GetTasksById(c *gin.Context) {
 	}
 }
 
-// GetNotes Обработка Get-запроса типа /api/items для заметок
+// GetNotes Обработка Get-запроса типа /api/items для заметок, напр.:
+// /api/notes/items?alarm_from=2026-10-01T00:00:00Z&sort=alarmTimeStamp&limit=20
 func GetNotes(c *gin.Context) {
-	c.JSON(http.StatusOK, Notes)
+	var query NoteListQuery
+	if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page, err := ListNotes(Notes, query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, page)
 }
 
 // GetNotesById Обработка Get-запроса типа /api/item/id для заметок
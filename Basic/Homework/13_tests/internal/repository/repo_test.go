@@ -114,6 +114,42 @@ func TestGetNotes(t *testing.T) {
 		// 5. Assert the results
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
+
+	t.Run("notes getting failure with unknown sort field", func(t *testing.T) {
+		// 1. Create a mock response recorder
+		w := httptest.NewRecorder()
+
+		// 2. Create a mock context and engine (engine is needed for params to work correctly)
+		ctx, _ := gin.CreateTestContext(w)
+
+		// 3. Mock the request, setting the URL path
+		req, _ := http.NewRequest(http.MethodGet, "/api/notes/items?sort=dueDate", nil)
+		ctx.Request = req
+
+		// 4. Call the handler function directly
+		GetNotes(ctx)
+
+		// 5. Assert the results
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("notes getting failure with invalid cursor", func(t *testing.T) {
+		// 1. Create a mock response recorder
+		w := httptest.NewRecorder()
+
+		// 2. Create a mock context and engine (engine is needed for params to work correctly)
+		ctx, _ := gin.CreateTestContext(w)
+
+		// 3. Mock the request, setting the URL path
+		req, _ := http.NewRequest(http.MethodGet, "/api/notes/items?cursor=bad", nil)
+		ctx.Request = req
+
+		// 4. Call the handler function directly
+		GetNotes(ctx)
+
+		// 5. Assert the results
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestGetTasksById(t *testing.T) {
@@ -284,6 +320,42 @@ func TestGetTasks(t *testing.T) {
 		// 5. Assert the results
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
+
+	t.Run("tasks getting failure with limit out of range", func(t *testing.T) {
+		// 1. Create a mock response recorder
+		w := httptest.NewRecorder()
+
+		// 2. Create a mock context and engine (engine is needed for params to work correctly)
+		ctx, _ := gin.CreateTestContext(w)
+
+		// 3. Mock the request, setting the URL path
+		req, _ := http.NewRequest(http.MethodGet, "/api/tasks/items?limit=0", nil)
+		ctx.Request = req
+
+		// 4. Call the handler function directly
+		GetTasks(ctx)
+
+		// 5. Assert the results
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("tasks getting failure with invalid cursor", func(t *testing.T) {
+		// 1. Create a mock response recorder
+		w := httptest.NewRecorder()
+
+		// 2. Create a mock context and engine (engine is needed for params to work correctly)
+		ctx, _ := gin.CreateTestContext(w)
+
+		// 3. Mock the request, setting the URL path
+		req, _ := http.NewRequest(http.MethodGet, "/api/tasks/items?cursor=bad", nil)
+		ctx.Request = req
+
+		// 4. Call the handler function directly
+		GetTasks(ctx)
+
+		// 5. Assert the results
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestPostNewTask(t *testing.T) {
@@ -1,16 +1,22 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/13_tests/internal/repository"
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/13_tests/internal/service"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/envflag"
 )
 
 func createFiles(fileNames ...string) {
@@ -38,6 +44,10 @@ func createFiles(fileNames ...string) {
 }
 
 func main() {
+	addr := envflag.String("http-addr", ":8080", "HTTP listen address")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 10*time.Second, "time to drain in-flight requests on SIGINT/SIGTERM")
+	flag.Parse()
+
 	// создание json-файлов для хранения задач и заметок
 	createFiles("tasks.json", "notes.json")
 
@@ -64,9 +74,19 @@ func main() {
 		panic(fmt.Sprintf("ошибка наполнения repository.Notes из файла notes.json: %v", err))
 	}
 
+	// контекст, отменяемый по сигналам SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	// запуск логгера для новых задач/заметок
+	// логгер останавливается отдельно, после сервера, чтобы успеть вывести последние изменения
+	loggerCtx, stopLogger := context.WithCancel(context.Background())
+	loggerDone := make(chan struct{})
 	ticker := time.NewTicker(time.Millisecond * 200)
-	go service.LogRemidables(ticker, &mutex)
+	go func() {
+		defer close(loggerDone)
+		service.LogRemidables(loggerCtx, ticker, &mutex)
+	}()
 
 	// Создаём роутер
 	r := gin.Default()
@@ -84,7 +104,31 @@ func main() {
 	apiTasks.DELETE("item/id", repository.DeleteTaskById) // /api/tasks/item/id/?id=<id_integer_number>
 	apiNotes.DELETE("item/id", repository.DeleteNoteById) // /api/notes/item/id/?id=<id_integer_number>
 
-	// Запуск сервера на :8080
-	r.Run(":8080")
+	// Запуск сервера на адресе -http-addr (REMINDABLES_HTTP_ADDR), по умолчанию :8080
+	srv := &http.Server{Addr: *addr, Handler: r}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		stopLogger()
+		<-loggerDone
+		panic(err)
+	case <-ctx.Done():
+		stop()
+	}
+	fmt.Println("\nПолучен сигнал завершения, ожидаем завершения обработки запросов...")
+
+	// Завершение: сервер дожидается текущих запросов не дольше shutdownTimeout, затем останавливается логгер
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		fmt.Printf("Сервер остановлен принудительно: %v\n", err)
+	}
+	stopLogger()
+	<-loggerDone
+	fmt.Println("Программа завершила свою работу.")
 
 }
@@ -0,0 +1,132 @@
+// Package config собирает настройки приложения из файла (YAML/TOML), переменных окружения и флагов.
+//
+// Приоритет источников, от низшего к высшему: значения по умолчанию,
+// файл конфигурации, переменные окружения REMINDABLES_*, флаги командной строки.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/14_redis_mongo/internal/repository"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/14_redis_mongo/internal/repository/mongodb"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/14_redis_mongo/internal/repository/redis"
+)
+
+var (
+	logLevels  = []string{"debug", "info", "warn", "error"}
+	logFormats = []string{"text", "json"}
+)
+
+// Config настройки приложения
+type Config struct {
+	HTTP     HTTPConfig
+	Mongo    mongodb.Config
+	Redis    redis.Config
+	Log      LogConfig
+	Shutdown ShutdownConfig
+	Timeouts repository.Timeouts
+	Locale   LocaleConfig
+}
+
+// HTTPConfig настройки HTTP-сервера
+type HTTPConfig struct {
+	Addr string
+}
+
+// ShutdownConfig настройки корректного завершения по SIGINT/SIGTERM
+type ShutdownConfig struct {
+	// Timeout время на завершение текущих запросов, после которого серверы останавливаются принудительно
+	Timeout time.Duration
+}
+
+// LocaleConfig настройки часового пояса
+type LocaleConfig struct {
+	// Timezone часовой пояс IANA, в котором разбираются даты без явного пояса,
+	// если клиент не передал X-Timezone
+	Timezone string
+}
+
+// LogConfig настройки журналирования
+type LogConfig struct {
+	Level  string
+	Format string
+}
+
+// Default возвращает конфигурацию по умолчанию
+func Default() Config {
+	mongoCfg := mongodb.DefaultConfig()
+	mongoCfg.Database = "remindables"
+	return Config{
+		HTTP:  HTTPConfig{Addr: ":8080"},
+		Mongo: mongoCfg,
+		Redis: redis.DefaultConfig(),
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+		},
+		Shutdown: ShutdownConfig{Timeout: 10 * time.Second},
+		Timeouts: repository.Timeouts{
+			Read:   5 * time.Second,
+			Write:  10 * time.Second,
+			Search: 10 * time.Second,
+			Redis:  2 * time.Second,
+		},
+		Locale: LocaleConfig{Timezone: "UTC"},
+	}
+}
+
+// Validate проверяет конфигурацию и возвращает все найденные ошибки сразу
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	_, port, err := net.SplitHostPort(c.HTTP.Addr)
+	check(err == nil && port != "", "http.addr: некорректный адрес %q, ожидается host:port", c.HTTP.Addr)
+
+	u, err := url.Parse(c.Mongo.URI)
+	check(err == nil && (u.Scheme == "mongodb" || u.Scheme == "mongodb+srv"),
+		"mongo.uri: некорректный URI %q", c.Mongo.URI)
+	check(c.Mongo.Database != "", "mongo.database: не задана")
+	check(c.Mongo.ConnectTimeout > 0, "mongo.connect_timeout: должен быть больше нуля")
+	check(c.Mongo.MinPoolSize <= c.Mongo.MaxPoolSize,
+		"mongo.min_pool_size: %d больше mongo.max_pool_size %d", c.Mongo.MinPoolSize, c.Mongo.MaxPoolSize)
+
+	check(c.Redis.Host != "", "redis.host: не задан")
+	check(c.Redis.Port > 0 && c.Redis.Port < 65536, "redis.port: некорректный порт %d", c.Redis.Port)
+	check(c.Redis.DB >= 0, "redis.db: номер базы не может быть отрицательным")
+	check(c.Redis.PoolSize > 0, "redis.pool_size: должен быть больше нуля")
+	check(c.Redis.MinIdleConns >= 0 && c.Redis.MinIdleConns <= c.Redis.PoolSize,
+		"redis.min_idle_conns: должно быть от 0 до redis.pool_size")
+	check(c.Redis.DialTimeout > 0, "redis.dial_timeout: должен быть больше нуля")
+	check(c.Redis.ReadTimeout > 0, "redis.read_timeout: должен быть больше нуля")
+	check(c.Redis.WriteTimeout > 0, "redis.write_timeout: должен быть больше нуля")
+
+	check(slices.Contains(logLevels, c.Log.Level),
+		"log.level: неизвестный уровень %q, допустимы %v", c.Log.Level, logLevels)
+	check(slices.Contains(logFormats, c.Log.Format),
+		"log.format: неизвестный формат %q, допустимы %v", c.Log.Format, logFormats)
+
+	check(c.Shutdown.Timeout > 0, "shutdown.timeout: должен быть больше нуля")
+	check(c.Timeouts.Read > 0, "timeouts.read: должен быть больше нуля")
+	check(c.Timeouts.Write > 0, "timeouts.write: должен быть больше нуля")
+	check(c.Timeouts.Search > 0, "timeouts.search: должен быть больше нуля")
+	check(c.Timeouts.Redis > 0, "timeouts.redis: должен быть больше нуля")
+
+	_, tzErr := time.LoadLocation(c.Locale.Timezone)
+	check(c.Locale.Timezone != "" && tzErr == nil,
+		"locale.timezone: неизвестный часовой пояс %q, ожидается имя IANA, напр. Europe/Moscow", c.Locale.Timezone)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("некорректная конфигурация:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -14,20 +15,12 @@ type Note struct {
 	AlarmTimeStamp time.Time          `bson:"alarmTimeStamp" json:"alarmTimeStamp"` // Сигнал напоминания в эту дату-время
 }
 
-func NewNote(name, descr, alarmDateTime string) Note {
-	userDueDate, err := time.Parse("02.01.2006 15:04", alarmDateTime)
-	if err != nil {
-		fmt.Println("Введенные дата и время напоминания имеют не корректный формат.")
-		return Note{
-			Name:        name,
-			Description: descr,
-		}
-	} else {
-		return Note{
-			Name:           name,
-			Description:    descr,
-			AlarmTimeStamp: userDueDate,
-		}
+// NewNote создаёт заметку с напоминанием alarmDateTime, уже разобранным dateinput.Parse
+func NewNote(name, descr string, alarmDateTime time.Time) Note {
+	return Note{
+		Name:           name,
+		Description:    descr,
+		AlarmTimeStamp: alarmDateTime,
 	}
 }
 
@@ -39,12 +32,12 @@ func (myNote Note) String() string {
 	)
 }
 
-// ChangeAlarm реализует repository.Remindable
-func (myNote *Note) ChangeAlarm(new_date_time string) {
-	userDateTime, err := time.Parse("02.01.2006 15:04", new_date_time)
+// ChangeAlarm реализует repository.Remindable: new_date_time разбирается dateinput.Parse в часовом поясе loc
+func (myNote *Note) ChangeAlarm(new_date_time string, loc *time.Location) error {
+	userDateTime, err := dateinput.Parse(new_date_time, time.Now(), loc)
 	if err != nil {
-		fmt.Println("Введенные дата и время напоминания имеют не корректный формат.\nВведите требуемые значения даты и времени в соответствии с форматом: ДД-ММ-ГГГГ ЧЧ:ММ.")
-	} else {
-		myNote.AlarmTimeStamp = userDateTime
+		return fmt.Errorf("некорректные дата и время напоминания %q: %w", new_date_time, err)
 	}
+	myNote.AlarmTimeStamp = userDateTime
+	return nil
 }
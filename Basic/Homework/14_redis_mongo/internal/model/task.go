@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -29,24 +30,14 @@ type Task struct {
 	Status        string             `bson:"status" json:"status"`
 }
 
-func NewTask(name, descr, dueDate string) Task {
-	userDueDate, err := time.Parse("02.01.2006", dueDate)
-	if err != nil {
-		fmt.Println("Введенная дата исполнения имеет не корректный формат.")
-		return Task{
-			Name:          name,
-			Description:   descr,
-			InitTimeStamp: time.Now(),
-			Status:        Created,
-		}
-	} else {
-		return Task{
-			Name:          name,
-			Description:   descr,
-			InitTimeStamp: time.Now(),
-			DueDate:       userDueDate,
-			Status:        Created,
-		}
+// NewTask создаёт задачу со сроком dueDate, уже разобранным dateinput.Parse
+func NewTask(name, descr string, dueDate time.Time) Task {
+	return Task{
+		Name:          name,
+		Description:   descr,
+		InitTimeStamp: time.Now(),
+		DueDate:       dueDate,
+		Status:        Created,
 	}
 }
 
@@ -58,12 +49,12 @@ func (myTask Task) String() string {
 	)
 }
 
-// ChangeAlarm реализует repository.Remindable
-func (myTask *Task) ChangeAlarm(new_date string) {
-	userDate, err := time.Parse("02.01.2006", new_date)
+// ChangeAlarm реализует repository.Remindable: new_date разбирается dateinput.Parse в часовом поясе loc
+func (myTask *Task) ChangeAlarm(new_date string, loc *time.Location) error {
+	userDate, err := dateinput.Parse(new_date, time.Now(), loc)
 	if err != nil {
-		fmt.Println("Введенная дата исполнения имеет не корректный формат.\nВведите требуемое значение даты в соответствии с форматом: ДД-ММ-ГГГГ.")
-	} else {
-		myTask.DueDate = userDate
+		return fmt.Errorf("некорректная дата исполнения %q: %w", new_date, err)
 	}
+	myTask.DueDate = userDate
+	return nil
 }
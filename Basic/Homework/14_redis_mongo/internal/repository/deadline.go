@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatusClientClosedRequest клиент закрыл соединение, не дождавшись ответа (код nginx)
+const StatusClientClosedRequest = 499
+
+// Timeouts предельное время операций в обработчиках
+type Timeouts struct {
+	Read   time.Duration // чтение из MongoDB
+	Write  time.Duration // изменение в MongoDB
+	Search time.Duration // полнотекстовый поиск в MongoDB
+	Redis  time.Duration // запись журнала в Redis
+}
+
+// opContext возвращает контекст операции с MongoDB: он отменяется вместе с запросом клиента
+// и по истечении timeout; при timeout <= 0 ограничение по времени не устанавливается
+func opContext(c *gin.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(c.Request.Context())
+	}
+	return context.WithTimeout(c.Request.Context(), timeout)
+}
+
+// logContext возвращает контекст записи журнала в Redis. Изменение к этому моменту уже выполнено,
+// поэтому запись не прерывается при отключении клиента и ограничена только timeout
+func logContext(c *gin.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx := context.WithoutCancel(c.Request.Context())
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// abortOnContext отвечает 504, если операция не уложилась в отведённое время,
+// и 499, если клиент отключился; возвращает true, если ответ отправлен
+func abortOnContext(c *gin.Context, ctx context.Context) bool {
+	switch err := ctx.Err(); {
+	case errors.Is(err, context.DeadlineExceeded):
+		c.JSON(http.StatusGatewayTimeout, gin.H{"GatewayTimeout": "Превышено время ожидания ответа БД"})
+		return true
+	case errors.Is(err, context.Canceled):
+		c.AbortWithStatus(StatusClientClosedRequest)
+		return true
+	}
+	return false
+}
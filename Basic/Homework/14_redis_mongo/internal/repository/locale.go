@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// locationKey ключ часового пояса запроса в gin.Context
+const locationKey = "location"
+
+// Locale определяет часовой пояс пользователя по заголовку X-Timezone (имя IANA)
+// и передаёт его обработчикам; без заголовка используется fallback из конфигурации
+func Locale(fallback *time.Location) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		loc := fallback
+		if tz := c.GetHeader("X-Timezone"); tz != "" {
+			var err error
+			if loc, err = time.LoadLocation(tz); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest,
+					gin.H{"error": "Некорректный часовой пояс в заголовке X-Timezone: " + tz})
+				return
+			}
+		}
+		c.Header("Vary", "X-Timezone")
+		c.Set(locationKey, loc)
+		c.Next()
+	}
+}
+
+// location возвращает часовой пояс запроса, определённый Locale; по умолчанию UTC
+func location(c *gin.Context) *time.Location {
+	if loc, ok := c.Get(locationKey); ok {
+		return loc.(*time.Location)
+	}
+	return time.UTC
+}
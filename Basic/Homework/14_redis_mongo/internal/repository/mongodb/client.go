@@ -207,9 +207,30 @@ func (c *Client) CreateUniqueIndex(ctx context.Context, collection string, keys
 	return c.CreateIndex(ctx, collection, keys, options.Index().SetUnique(true))
 }
 
-// CreateTextIndex создаёт текстовый индекс
-func (c *Client) CreateTextIndex(ctx context.Context, collection string, field string) (string, error) {
-	return c.CreateIndex(ctx, collection, bson.D{{Key: field, Value: "text"}}, nil)
+// CreateTextIndex создаёт текстовый индекс по полям fields с морфологией языка language.
+// В коллекции допускается только один текстовый индекс, поэтому он создаётся с фиксированным именем
+func (c *Client) CreateTextIndex(ctx context.Context, collection string, language string, fields ...string) (string, error) {
+	keys := bson.D{}
+	for _, field := range fields {
+		keys = append(keys, bson.E{Key: field, Value: "text"})
+	}
+	return c.CreateIndex(ctx, collection, keys,
+		options.Index().SetName(TextIndexName).SetDefaultLanguage(language),
+	)
+}
+
+// IndexExists проверяет существование индекса с именем name
+func (c *Client) IndexExists(ctx context.Context, collection string, name string) (bool, error) {
+	indexes, err := c.ListIndexes(ctx, collection)
+	if err != nil {
+		return false, err
+	}
+	for _, idx := range indexes {
+		if idx["name"] == name {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // CreateTTLIndex создаёт TTL индекс
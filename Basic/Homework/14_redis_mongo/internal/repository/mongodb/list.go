@@ -0,0 +1,205 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/14_redis_mongo/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TaskFilter параметры отбора, сортировки и пагинации задач
+type TaskFilter struct {
+	Status  string
+	DueFrom time.Time
+	DueTo   time.Time
+	Name    string
+	Sort    string // id, name, dueDate; префикс "-" - по убыванию
+	Limit   int
+	Cursor  string
+}
+
+// NoteFilter параметры отбора, сортировки и пагинации заметок
+type NoteFilter struct {
+	AlarmFrom time.Time
+	AlarmTo   time.Time
+	Name      string
+	Sort      string // id, name, alarmTimeStamp; префикс "-" - по убыванию
+	Limit     int
+	Cursor    string
+}
+
+// ErrInvalidCursor курсор не разобран или не соответствует порядку сортировки
+var ErrInvalidCursor = errors.New("некорректный курсор пагинации")
+
+// pageCursor положение последнего документа страницы в выбранном порядке сортировки
+type pageCursor struct {
+	Sort  string    `json:"s"`
+	Name  string    `json:"n,omitempty"`
+	Time  time.Time `json:"t"`
+	Id    string    `json:"id"`
+	objId primitive.ObjectID
+}
+
+func encodeCursor(cur pageCursor) string {
+	data, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(raw, sort string) (*pageCursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cur pageCursor
+	if err := json.Unmarshal(data, &cur); err != nil || cur.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	if cur.objId, err = primitive.ObjectIDFromHex(cur.Id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cur, nil
+}
+
+// Соответствие полей сортировки полям документов
+var (
+	taskSortFields = map[string]string{"id": "_id", "name": "name", "dueDate": "dueDate"}
+	noteSortFields = map[string]string{"id": "_id", "name": "name", "alarmTimeStamp": "alarmTimeStamp"}
+)
+
+// timeRange возвращает условие на поле-дату по границам [from, to)
+func timeRange(from, to time.Time) bson.M {
+	cond := bson.M{}
+	if !from.IsZero() {
+		cond["$gte"] = from
+	}
+	if !to.IsZero() {
+		cond["$lt"] = to
+	}
+	return cond
+}
+
+// findPage добавляет к filter keyset-условие по курсору и считывает страницу документов
+func findPage[T any](
+	ctx context.Context,
+	client *Client,
+	collection string,
+	filter bson.M,
+	sortFields map[string]string,
+	sort string,
+	cur *pageCursor,
+	limit int,
+) ([]*T, error) {
+	field := sortFields[strings.TrimPrefix(sort, "-")]
+	cmp, dir := "$gt", 1
+	if strings.HasPrefix(sort, "-") {
+		cmp, dir = "$lt", -1
+	}
+
+	if cur != nil {
+		var value any = cur.Name
+		if field == "dueDate" || field == "alarmTimeStamp" {
+			value = cur.Time
+		}
+		keyset := bson.M{"_id": bson.M{cmp: cur.objId}}
+		if field != "_id" {
+			keyset = bson.M{"$or": bson.A{
+				bson.M{field: bson.M{cmp: value}},
+				bson.M{field: value, "_id": bson.M{cmp: cur.objId}},
+			}}
+		}
+		filter = bson.M{"$and": bson.A{filter, keyset}}
+	}
+
+	order := bson.D{{Key: "_id", Value: dir}}
+	if field != "_id" {
+		order = bson.D{{Key: field, Value: dir}, {Key: "_id", Value: dir}}
+	}
+	opts := options.Find().SetSort(order).SetLimit(int64(limit) + 1)
+
+	var items []*T
+	if err := client.FindAll(ctx, collection, filter, &items, opts); err != nil {
+		return nil, fmt.Errorf("ошибка считывания страницы из БД: %w", err)
+	}
+	return items, nil
+}
+
+// List считывает страницу задач, отобранных и упорядоченных согласно filter.
+// Возвращает задачи и курсор следующей страницы (пустой, если страница последняя)
+func (r *TaskRepository) List(ctx context.Context, f TaskFilter) ([]*model.Task, string, error) {
+	cur, err := decodeCursor(f.Cursor, f.Sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filter := bson.M{}
+	if f.Status != "" {
+		filter["status"] = f.Status
+	}
+	if due := timeRange(f.DueFrom, f.DueTo); len(due) > 0 {
+		filter["dueDate"] = due
+	}
+	if f.Name != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(f.Name), "$options": "i"}
+	}
+
+	tasks, err := findPage[model.Task](ctx, r.client, r.collection, filter, taskSortFields, f.Sort, cur, f.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(tasks) <= f.Limit {
+		return tasks, "", nil
+	}
+	tasks = tasks[:f.Limit]
+	last := tasks[len(tasks)-1]
+	return tasks, encodeCursor(pageCursor{
+		Sort: f.Sort,
+		Name: last.Name,
+		Time: last.DueDate,
+		Id:   last.Id.Hex(),
+	}), nil
+}
+
+// List считывает страницу заметок, отобранных и упорядоченных согласно filter.
+// Возвращает заметки и курсор следующей страницы (пустой, если страница последняя)
+func (r *NoteRepository) List(ctx context.Context, f NoteFilter) ([]*model.Note, string, error) {
+	cur, err := decodeCursor(f.Cursor, f.Sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filter := bson.M{}
+	if alarm := timeRange(f.AlarmFrom, f.AlarmTo); len(alarm) > 0 {
+		filter["alarmTimeStamp"] = alarm
+	}
+	if f.Name != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(f.Name), "$options": "i"}
+	}
+
+	notes, err := findPage[model.Note](ctx, r.client, r.collection, filter, noteSortFields, f.Sort, cur, f.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(notes) <= f.Limit {
+		return notes, "", nil
+	}
+	notes = notes[:f.Limit]
+	last := notes[len(notes)-1]
+	return notes, encodeCursor(pageCursor{
+		Sort: f.Sort,
+		Name: last.Name,
+		Time: last.AlarmTimeStamp,
+		Id:   last.Id.Hex(),
+	}), nil
+}
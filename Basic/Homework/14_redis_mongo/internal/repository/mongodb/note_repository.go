@@ -72,13 +72,8 @@ func (r *NoteRepository) UpdateById(
 	id primitive.ObjectID,
 	name string,
 	description string,
-	alarmTimeStamp string,
+	alarmTimeStamp time.Time,
 ) error {
-	t, err := time.Parse("02.01.2006 15:04", alarmTimeStamp)
-	if err != nil {
-		return fmt.Errorf("Неверный формат даты/времени: %w", err)
-	}
-	alarmTimeStamp = t.Format(time.RFC3339)
 	update := bson.M{
 		"$set": bson.M{
 			"name":           name,
@@ -118,7 +113,6 @@ func (r *NoteRepository) EnsureIndexes(ctx context.Context) error {
 		keys interface{}
 		opts *options.IndexOptions
 	}{
-		{bson.D{{Key: "name", Value: "text"}}, nil},
 		{bson.D{{Key: "alarmTimeStamp", Value: 1}}, nil},
 	}
 
@@ -127,5 +121,19 @@ func (r *NoteRepository) EnsureIndexes(ctx context.Context) error {
 			return fmt.Errorf("failed to create index: %w", err)
 		}
 	}
+
+	// Прежний текстовый индекс только по name мешает создать индекс для полнотекстового поиска
+	legacy, err := r.client.IndexExists(ctx, r.collection, "name_text")
+	if err != nil {
+		return fmt.Errorf("failed to list indexes: %w", err)
+	}
+	if legacy {
+		if err := r.client.DropIndex(ctx, r.collection, "name_text"); err != nil {
+			return fmt.Errorf("failed to drop legacy text index: %w", err)
+		}
+	}
+	if _, err := r.client.CreateTextIndex(ctx, r.collection, textSearchLanguage, "name", "description"); err != nil {
+		return fmt.Errorf("failed to create text index: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,174 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TextIndexName имя текстового индекса коллекций задач и заметок
+const TextIndexName = "text_search"
+
+// textSearchLanguage язык морфологии текстового индекса и поисковых запросов
+const textSearchLanguage = "russian"
+
+// Число слов описания, попадающих во фрагмент вокруг первого совпадения
+const snippetWords = 25
+
+// SearchHit найденная задача или заметка с рангом и фрагментами текста,
+// в которых совпадения выделены тегами <b></b>
+type SearchHit struct {
+	Type               string             `json:"type"`
+	Id                 primitive.ObjectID `json:"id"`
+	Name               string             `json:"name"`
+	Score              float64            `json:"score"`
+	NameSnippet        string             `json:"nameSnippet"`
+	DescriptionSnippet string             `json:"descriptionSnippet"`
+}
+
+// searchDoc проекция документа, найденного по текстовому индексу
+type searchDoc struct {
+	Id          primitive.ObjectID `bson:"_id"`
+	Name        string             `bson:"name"`
+	Description string             `bson:"description"`
+	Score       float64            `bson:"score"`
+}
+
+// searchCollection находит limit наиболее релевантных документов коллекции по текстовому индексу
+func searchCollection(
+	ctx context.Context,
+	client *Client,
+	collection string,
+	entityType string,
+	query string,
+	limit int,
+) ([]SearchHit, error) {
+	filter := bson.M{"$text": bson.M{"$search": query, "$language": textSearchLanguage}}
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"name": 1, "description": 1, "score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	var docs []searchDoc
+	if err := client.FindAll(ctx, collection, filter, &docs, opts); err != nil {
+		return nil, fmt.Errorf("ошибка полнотекстового поиска в коллекции %s: %w", collection, err)
+	}
+
+	terms := searchTerms(query)
+	hits := make([]SearchHit, 0, len(docs))
+	for _, doc := range docs {
+		hits = append(hits, SearchHit{
+			Type:               entityType,
+			Id:                 doc.Id,
+			Name:               doc.Name,
+			Score:              doc.Score,
+			NameSnippet:        highlight(doc.Name, terms, 0),
+			DescriptionSnippet: highlight(doc.Description, terms, snippetWords),
+		})
+	}
+	return hits, nil
+}
+
+// Search находит задачи, наиболее релевантные запросу query
+func (r *TaskRepository) Search(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	return searchCollection(ctx, r.client, r.collection, "task", query, limit)
+}
+
+// Search находит заметки, наиболее релевантные запросу query
+func (r *NoteRepository) Search(ctx context.Context, query string, limit int) ([]SearchHit, error) {
+	return searchCollection(ctx, r.client, r.collection, "note", query, limit)
+}
+
+// MergeSearchHits объединяет результаты поиска по нескольким коллекциям
+// и возвращает страницу, упорядоченную по убыванию ранга
+func MergeSearchHits(limit, offset int, results ...[]SearchHit) []SearchHit {
+	hits := slices.Concat(results...)
+	slices.SortStableFunc(hits, func(a, b SearchHit) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Id.Hex(), b.Id.Hex())
+	})
+	if offset >= len(hits) {
+		return []SearchHit{}
+	}
+	return hits[offset:min(offset+limit, len(hits))]
+}
+
+// searchTerms выделяет из запроса искомые слова, пропуская исключения вида -слово
+func searchTerms(query string) []string {
+	var terms []string
+	for _, field := range strings.Fields(normalizeWord(query)) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		terms = append(terms, strings.FieldsFunc(field, isNotWordRune)...)
+	}
+	return terms
+}
+
+// normalizeWord приводит слово к нижнему регистру и заменяет "ё" на "е"
+func normalizeWord(word string) string {
+	return strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// matchesTerm приближённо сравнивает словоформы: слово совпадает с искомым,
+// если начинается с его основы (искомое слово без двух последних букв, но не короче трёх)
+func matchesTerm(word string, terms []string) bool {
+	word = normalizeWord(word)
+	for _, term := range terms {
+		stem := []rune(term)
+		if len(stem) > 4 {
+			stem = stem[:max(3, len(stem)-2)]
+		}
+		if strings.HasPrefix(word, string(stem)) {
+			return true
+		}
+	}
+	return false
+}
+
+// highlight выделяет совпадения в тексте тегами <b></b>. При maxWords > 0 возвращает
+// фрагмент не длиннее maxWords слов, начинающийся незадолго до первого совпадения
+func highlight(text string, terms []string, maxWords int) string {
+	words := strings.Fields(text)
+	first := -1
+	for i, word := range words {
+		core := strings.TrimFunc(word, isNotWordRune)
+		if core == "" || !matchesTerm(core, terms) {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		words[i] = strings.Replace(word, core, "<b>"+core+"</b>", 1)
+	}
+
+	if maxWords <= 0 || len(words) <= maxWords {
+		return strings.Join(words, " ")
+	}
+	start := max(0, first-maxWords/4)
+	end := min(len(words), start+maxWords)
+	snippet := strings.Join(words[start:end], " ")
+	if start > 0 {
+		snippet = "... " + snippet
+	}
+	if end < len(words) {
+		snippet += " ..."
+	}
+	return snippet
+}
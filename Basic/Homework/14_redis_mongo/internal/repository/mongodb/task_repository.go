@@ -72,13 +72,8 @@ func (r *TaskRepository) UpdateById(
 	id primitive.ObjectID,
 	name string,
 	description string,
-	dueDate string,
+	dueDate time.Time,
 ) error {
-	t, err := time.Parse("02.01.2006", dueDate)
-	if err != nil {
-		return fmt.Errorf("Неверный формат даты: %w", err)
-	}
-	dueDate = t.Format(time.RFC3339)
 	update := bson.M{
 		"$set": bson.M{
 			"name":        name,
@@ -119,8 +114,7 @@ func (r *TaskRepository) EnsureIndexes(ctx context.Context) error {
 		keys interface{}
 		opts *options.IndexOptions
 	}{
-		{bson.D{{Key: "name", Value: "text"}}, nil},
-		{bson.D{{Key: "status", Value: "text"}}, nil},
+		{bson.D{{Key: "status", Value: 1}}, nil},
 		{bson.D{{Key: "dueDate", Value: 1}}, nil},
 		{bson.D{{Key: "initTimeStamp", Value: -1}}, nil},
 	}
@@ -130,5 +124,19 @@ func (r *TaskRepository) EnsureIndexes(ctx context.Context) error {
 			return fmt.Errorf("failed to create index: %w", err)
 		}
 	}
+
+	// Прежний текстовый индекс только по name мешает создать индекс для полнотекстового поиска
+	legacy, err := r.client.IndexExists(ctx, r.collection, "name_text")
+	if err != nil {
+		return fmt.Errorf("failed to list indexes: %w", err)
+	}
+	if legacy {
+		if err := r.client.DropIndex(ctx, r.collection, "name_text"); err != nil {
+			return fmt.Errorf("failed to drop legacy text index: %w", err)
+		}
+	}
+	if _, err := r.client.CreateTextIndex(ctx, r.collection, textSearchLanguage, "name", "description"); err != nil {
+		return fmt.Errorf("failed to create text index: %w", err)
+	}
 	return nil
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/14_redis_mongo/internal/model"
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/14_redis_mongo/internal/repository/mongodb"
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/14_redis_mongo/internal/repository/redis"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -16,9 +18,41 @@ import (
 
 type Remindable interface {
 	String() string
-	ChangeAlarm(string)
+	ChangeAlarm(string, *time.Location) error
 }
 
+// TaskStore хранилище задач, с которым работают обработчики
+type TaskStore interface {
+	// Create сохраняет новую задачу и назначает ей Id
+	Create(ctx context.Context, task *model.Task) error
+	// GetById возвращает задачу по Id
+	GetById(ctx context.Context, id primitive.ObjectID) (*model.Task, error)
+	// List возвращает страницу задач, отобранных согласно filter, и курсор следующей страницы
+	List(ctx context.Context, filter mongodb.TaskFilter) ([]*model.Task, string, error)
+	// Search ищет задачи по тексту query
+	Search(ctx context.Context, query string, limit int) ([]mongodb.SearchHit, error)
+	// UpdateById изменяет имя, описание и срок задачи с Id
+	UpdateById(ctx context.Context, id primitive.ObjectID, name, description string, dueDate time.Time) error
+	// DeleteById удаляет задачу по Id
+	DeleteById(ctx context.Context, id primitive.ObjectID) error
+}
+
+// NoteStore хранилище заметок; соглашения те же, что у TaskStore
+type NoteStore interface {
+	Create(ctx context.Context, note *model.Note) error
+	GetById(ctx context.Context, id primitive.ObjectID) (*model.Note, error)
+	List(ctx context.Context, filter mongodb.NoteFilter) ([]*model.Note, string, error)
+	Search(ctx context.Context, query string, limit int) ([]mongodb.SearchHit, error)
+	UpdateById(ctx context.Context, id primitive.ObjectID, name, description string, alarmTimeStamp time.Time) error
+	DeleteById(ctx context.Context, id primitive.ObjectID) error
+}
+
+// Хранилища MongoDB реализуют TaskStore и NoteStore
+var (
+	_ TaskStore = (*mongodb.TaskRepository)(nil)
+	_ NoteStore = (*mongodb.NoteRepository)(nil)
+)
+
 type RemindableId struct {
 	Id string `form:"id" binding:"required"`
 }
@@ -47,14 +81,56 @@ type ChangingNote struct {
 	AlarmTimeStamp string `json:"alarmTimeStamp"`
 }
 
+// TaskListQuery параметры фильтрации, сортировки и пагинации списка задач
+type TaskListQuery struct {
+	Status  string    `form:"status"`
+	DueFrom time.Time `form:"due_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	DueTo   time.Time `form:"due_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Name    string    `form:"name"`
+	Sort    string    `form:"sort,default=id" binding:"oneof=id -id name -name dueDate -dueDate"`
+	Limit   int       `form:"limit,default=50" binding:"gte=1,lte=500"`
+	Cursor  string    `form:"cursor"`
+}
+
+// NoteListQuery параметры фильтрации, сортировки и пагинации списка заметок
+type NoteListQuery struct {
+	AlarmFrom time.Time `form:"alarm_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	AlarmTo   time.Time `form:"alarm_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Name      string    `form:"name"`
+	Sort      string    `form:"sort,default=id" binding:"oneof=id -id name -name alarmTimeStamp -alarmTimeStamp"`
+	Limit     int       `form:"limit,default=50" binding:"gte=1,lte=500"`
+	Cursor    string    `form:"cursor"`
+}
+
+// Page страница списка с курсором на следующую страницу
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// SearchQuery параметры полнотекстового поиска по задачам и заметкам
+type SearchQuery struct {
+	Q      string `form:"q" binding:"required"`
+	Type   string `form:"type" binding:"omitempty,oneof=task note"`
+	Limit  int    `form:"limit,default=20" binding:"gte=1,lte=100"`
+	Offset int    `form:"offset,default=0" binding:"gte=0"`
+}
+
+// SearchPage страница результатов поиска, упорядоченных по убыванию ранга
+type SearchPage struct {
+	Items  []mongodb.SearchHit `json:"items"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+}
+
 // CreateNewRemindable Создать объект типа, реализующего Remindable
 func CreateNewRemindable(
 	ctx context.Context,
-	taskRepo *mongodb.TaskRepository,
-	noteRepo *mongodb.NoteRepository,
+	taskRepo TaskStore,
+	noteRepo NoteStore,
 	name,
-	descr,
-	futurePoint string,
+	descr string,
+	futurePoint time.Time,
 	isTask bool,
 ) error {
 	var remindable Remindable
@@ -74,8 +150,8 @@ func CreateNewRemindable(
 // SaveRemindable Сохранить объект типа, реализующего Remindable в соотв. срезе и БД MongoDB
 func SaveRemindable(
 	ctx context.Context,
-	taskRepo *mongodb.TaskRepository,
-	noteRepo *mongodb.NoteRepository,
+	taskRepo TaskStore,
+	noteRepo NoteStore,
 	remindable Remindable,
 ) error {
 	r := remindable
@@ -92,28 +168,57 @@ func SaveRemindable(
 	return nil
 }
 
-// GetTasks Обработка Get-запроса типа /api/items для задач
+// GetTasks Обработка Get-запроса типа /api/items для задач, напр.:
+// /api/tasks/items?status=Создана&name=отчет&sort=-dueDate&limit=20&cursor=<next_cursor>
 func GetTasks(
-	ctx context.Context,
-	taskRepo *mongodb.TaskRepository,
+	timeouts Timeouts,
+	taskRepo TaskStore,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tasks, _, err := taskRepo.GetAll(ctx)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"BadRequest": "Ошибка считывания задач из БД"})
+		ctx, cancel := opContext(c, timeouts.Read)
+		defer cancel()
+
+		var query TaskListQuery
+		if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		c.JSON(http.StatusOK, tasks)
+		tasks, next, err := taskRepo.List(ctx, mongodb.TaskFilter{
+			Status:  query.Status,
+			DueFrom: query.DueFrom,
+			DueTo:   query.DueTo,
+			Name:    query.Name,
+			Sort:    query.Sort,
+			Limit:   query.Limit,
+			Cursor:  query.Cursor,
+		})
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		switch {
+		case errors.Is(err, mongodb.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case err != nil:
+			c.JSON(http.StatusBadRequest, gin.H{"BadRequest": "Ошибка считывания задач из БД"})
+		default:
+			if tasks == nil {
+				tasks = []*model.Task{}
+			}
+			c.JSON(http.StatusOK, Page[*model.Task]{Items: tasks, NextCursor: next})
+		}
 	}
 }
 
 // GetTasksById Обработка Get-запрос типа /api/item/id для задач
 // id в запросе передается в виде hex-строки, напр.:
 // /api/tasks/item/id?id=69959fd9aece410dd54f5739
-func GetTasksById(ctx context.Context,
-	taskRepo *mongodb.TaskRepository,
+func GetTasksById(timeouts Timeouts,
+	taskRepo TaskStore,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeouts.Read)
+		defer cancel()
+
 		var taskId RemindableId
 		if err := c.ShouldBindWith(&taskId, binding.Query); err == nil {
 			fmt.Printf("taskId: %v\n", taskId.Id)
@@ -124,6 +229,9 @@ func GetTasksById(ctx context.Context,
 				return
 			}
 			task, err := taskRepo.GetById(ctx, objectID)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(
 					http.StatusNotFound,
@@ -139,27 +247,55 @@ func GetTasksById(ctx context.Context,
 	}
 }
 
-// GetNotes Обработка Get-запроса типа /api/items для заметок
-func GetNotes(ctx context.Context,
-	noteRepo *mongodb.NoteRepository,
+// GetNotes Обработка Get-запроса типа /api/items для заметок, напр.:
+// /api/notes/items?alarm_from=2026-10-01T00:00:00Z&sort=alarmTimeStamp&limit=20&cursor=<next_cursor>
+func GetNotes(timeouts Timeouts,
+	noteRepo NoteStore,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		notes, _, err := noteRepo.GetAll(ctx)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"BadRequest": "Ошибка считывания заметок из БД"})
+		ctx, cancel := opContext(c, timeouts.Read)
+		defer cancel()
+
+		var query NoteListQuery
+		if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		c.JSON(http.StatusOK, notes)
+		notes, next, err := noteRepo.List(ctx, mongodb.NoteFilter{
+			AlarmFrom: query.AlarmFrom,
+			AlarmTo:   query.AlarmTo,
+			Name:      query.Name,
+			Sort:      query.Sort,
+			Limit:     query.Limit,
+			Cursor:    query.Cursor,
+		})
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		switch {
+		case errors.Is(err, mongodb.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case err != nil:
+			c.JSON(http.StatusBadRequest, gin.H{"BadRequest": "Ошибка считывания заметок из БД"})
+		default:
+			if notes == nil {
+				notes = []*model.Note{}
+			}
+			c.JSON(http.StatusOK, Page[*model.Note]{Items: notes, NextCursor: next})
+		}
 	}
 }
 
 // GetNotesById Обработка Get-запроса типа /api/item/id для заметок
 // id в запросе передается в виде hex-строки, напр.:
 // /api/notes/item/id?id=69959fd9aece410dd54f5739
-func GetNotesById(ctx context.Context,
-	noteRepo *mongodb.NoteRepository,
+func GetNotesById(timeouts Timeouts,
+	noteRepo NoteStore,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeouts.Read)
+		defer cancel()
+
 		var noteId RemindableId
 		if err := c.ShouldBindWith(&noteId, binding.Query); err == nil {
 			objectID, err := primitive.ObjectIDFromHex(noteId.Id)
@@ -168,6 +304,9 @@ func GetNotesById(ctx context.Context,
 				return
 			}
 			note, err := noteRepo.GetById(ctx, objectID)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(
 					http.StatusNotFound,
@@ -185,18 +324,26 @@ func GetNotesById(ctx context.Context,
 
 // PostNewTask Обработка Post-запроса типа /api/item для задач
 func PostNewTask(
-	ctx context.Context,
-	taskRepo *mongodb.TaskRepository,
-	noteRepo *mongodb.NoteRepository,
+	timeouts Timeouts,
+	taskRepo TaskStore,
+	noteRepo NoteStore,
 	client_redis *redis.Client,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeouts.Write)
+		defer cancel()
+
 		newTask := NewTask{}
 		err := c.ShouldBindJSON(&newTask)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		dueDate, err := dateinput.Parse(newTask.DueDate, time.Now(), location(c))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Некорректная дата исполнения %q: %v", newTask.DueDate, err)})
+			return
+		}
 
 		err = CreateNewRemindable(
 			ctx,
@@ -204,24 +351,28 @@ func PostNewTask(
 			noteRepo,
 			newTask.Name,
 			newTask.Description,
-			newTask.DueDate,
+			dueDate,
 			true,
 		)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
 		if err != nil {
 			fmt.Println(err)
 			c.JSON(http.StatusBadRequest, gin.H{"BadRequest": "Ошибка создания новой задачи"})
 			return
 		} else {
 			c.JSON(http.StatusOK, gin.H{"OK": "Создана новая задача"})
-			t, err := time.Parse("02.01.2006", newTask.DueDate)
+			rctx, rcancel := logContext(c, timeouts.Redis)
+			defer rcancel()
 			err = client_redis.SetJSON(
-				ctx,
+				rctx,
 				fmt.Sprintf("Создана новая задача %v", newTask.Name),
 				newTask,
-				time.Until(t),
+				time.Until(dueDate),
 			)
 			if err != nil {
-				panic(fmt.Errorf("Ошибка логирования в Redis новой задачи"))
+				fmt.Printf("Ошибка логирования в Redis новой задачи: %v\n", err)
 			}
 		}
 	}
@@ -229,18 +380,26 @@ func PostNewTask(
 
 // PostNewNote Обработка Post-запроса типа /api/item для заметок
 func PostNewNote(
-	ctx context.Context,
-	taskRepo *mongodb.TaskRepository,
-	noteRepo *mongodb.NoteRepository,
+	timeouts Timeouts,
+	taskRepo TaskStore,
+	noteRepo NoteStore,
 	client_redis *redis.Client,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeouts.Write)
+		defer cancel()
+
 		newNote := NewNote{}
 		err := c.ShouldBindJSON(&newNote)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		alarmTime, err := dateinput.Parse(newNote.AlarmTimeStamp, time.Now(), location(c))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Некорректные дата и время напоминания %q: %v", newNote.AlarmTimeStamp, err)})
+			return
+		}
 
 		err = CreateNewRemindable(
 			ctx,
@@ -248,23 +407,27 @@ func PostNewNote(
 			noteRepo,
 			newNote.Name,
 			newNote.Description,
-			newNote.AlarmTimeStamp,
+			alarmTime,
 			false,
 		)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"BadRequest": "Ошибка создания новой заметки"})
 			return
 		} else {
 			c.JSON(http.StatusOK, gin.H{"OK": "Создана новая заметка"})
-			t, err := time.Parse("02.01.2006 15:04", newNote.AlarmTimeStamp)
+			rctx, rcancel := logContext(c, timeouts.Redis)
+			defer rcancel()
 			err = client_redis.SetJSON(
-				ctx,
+				rctx,
 				fmt.Sprintf("Создана новая заметка %v", newNote.Name),
 				newNote,
-				time.Until(t),
+				time.Until(alarmTime),
 			)
 			if err != nil {
-				panic(fmt.Errorf("Ошибка логирования в Redis новой заметки"))
+				fmt.Printf("Ошибка логирования в Redis новой заметки: %v\n", err)
 			}
 		}
 	}
@@ -273,11 +436,14 @@ func PostNewNote(
 // PutTaskById Обработка Put-запроса типа /api/item/id для задач
 // id в запросе передается в виде hex-строки, напр.:
 // /api/tasks/item/id/?id=69959fd9aece410dd54f5739
-func PutTaskById(ctx context.Context,
-	taskRepo *mongodb.TaskRepository,
+func PutTaskById(timeouts Timeouts,
+	taskRepo TaskStore,
 	client_redis *redis.Client,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeouts.Write)
+		defer cancel()
+
 		var taskId RemindableId
 		if err := c.ShouldBindWith(&taskId, binding.Query); err == nil {
 			objectID, err := primitive.ObjectIDFromHex(taskId.Id)
@@ -292,8 +458,16 @@ func PutTaskById(ctx context.Context,
 				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			}
+			dueDate, err := dateinput.Parse(changingTask.DueDate, time.Now(), location(c))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Некорректная дата исполнения %q: %v", changingTask.DueDate, err)})
+				return
+			}
 
 			taskToBeChanged, err := taskRepo.GetById(ctx, objectID)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(
 					http.StatusNotFound,
@@ -307,11 +481,17 @@ func PutTaskById(ctx context.Context,
 				objectID,
 				changingTask.Name,
 				changingTask.Description,
-				changingTask.DueDate)
+				dueDate)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			}
 			changedTask, err := taskRepo.GetById(ctx, objectID)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(
 					http.StatusNotFound,
@@ -321,15 +501,16 @@ func PutTaskById(ctx context.Context,
 				c.JSON(http.StatusOK, gin.H{
 					"Изменена задача": changedTask,
 				})
-				t, err := time.Parse("02.01.2006", changingTask.DueDate)
+				rctx, rcancel := logContext(c, timeouts.Redis)
+				defer rcancel()
 				err = client_redis.SetJSON(
-					ctx,
+					rctx,
 					fmt.Sprintf("Изменена задача %v", taskToBeChanged.Name),
 					changingTask,
-					time.Until(t),
+					time.Until(dueDate),
 				)
 				if err != nil {
-					panic(fmt.Errorf("Ошибка логирования в Redis изменения задачи"))
+					fmt.Printf("Ошибка логирования в Redis изменения задачи: %v\n", err)
 				}
 			}
 		} else {
@@ -341,11 +522,14 @@ func PutTaskById(ctx context.Context,
 // PutNoteById Обработка Put-запроса типа /api/item/id для заметок
 // id в запросе передается в виде hex-строки, напр.:
 // /api/notes/item/id/?id=69959fd9aece410dd54f5739
-func PutNoteById(ctx context.Context,
-	noteRepo *mongodb.NoteRepository,
+func PutNoteById(timeouts Timeouts,
+	noteRepo NoteStore,
 	client_redis *redis.Client,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeouts.Write)
+		defer cancel()
+
 		var noteId RemindableId
 		if err := c.ShouldBindWith(&noteId, binding.Query); err == nil {
 			objectID, err := primitive.ObjectIDFromHex(noteId.Id)
@@ -360,8 +544,16 @@ func PutNoteById(ctx context.Context,
 				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			}
+			alarmTime, err := dateinput.Parse(changingNote.AlarmTimeStamp, time.Now(), location(c))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Некорректные дата и время напоминания %q: %v", changingNote.AlarmTimeStamp, err)})
+				return
+			}
 
 			noteToBeDeleted, err := noteRepo.GetById(ctx, objectID)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(
 					http.StatusNotFound,
@@ -375,11 +567,17 @@ func PutNoteById(ctx context.Context,
 				objectID,
 				changingNote.Name,
 				changingNote.Description,
-				changingNote.AlarmTimeStamp)
+				alarmTime)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			}
 			changedNote, err := noteRepo.GetById(ctx, objectID)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(
 					http.StatusNotFound,
@@ -389,15 +587,16 @@ func PutNoteById(ctx context.Context,
 				c.JSON(http.StatusOK, gin.H{
 					"Изменена заметка": changedNote,
 				})
-				t, err := time.Parse("02.01.2006 15:04", changingNote.AlarmTimeStamp)
+				rctx, rcancel := logContext(c, timeouts.Redis)
+				defer rcancel()
 				err = client_redis.SetJSON(
-					ctx,
+					rctx,
 					fmt.Sprintf("Изменена заметка %v", noteToBeDeleted.Name),
 					changingNote,
-					time.Until(t),
+					time.Until(alarmTime),
 				)
 				if err != nil {
-					panic(fmt.Errorf("Ошибка логирования в Redis изменения заметки"))
+					fmt.Printf("Ошибка логирования в Redis изменения заметки: %v\n", err)
 				}
 			}
 		} else {
@@ -409,11 +608,14 @@ func PutNoteById(ctx context.Context,
 // DeleteTaskById Обработка Delete-запроса типа /api/item/id для задач
 // id в запросе передается в виде hex-строки, напр.:
 // /api/tasks/item/id/?id=69959fd9aece410dd54f5739
-func DeleteTaskById(ctx context.Context,
-	taskRepo *mongodb.TaskRepository,
+func DeleteTaskById(timeouts Timeouts,
+	taskRepo TaskStore,
 	client_redis *redis.Client,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeouts.Write)
+		defer cancel()
+
 		var taskId RemindableId
 		if err := c.ShouldBindWith(&taskId, binding.Query); err == nil {
 			objectID, err := primitive.ObjectIDFromHex(taskId.Id)
@@ -423,6 +625,9 @@ func DeleteTaskById(ctx context.Context,
 			}
 
 			taskToBeDeleted, err := taskRepo.GetById(ctx, objectID)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(
 					http.StatusNotFound,
@@ -432,6 +637,9 @@ func DeleteTaskById(ctx context.Context,
 			}
 
 			err = taskRepo.DeleteById(ctx, objectID)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
@@ -439,14 +647,16 @@ func DeleteTaskById(ctx context.Context,
 				c.JSON(http.StatusOK, gin.H{
 					"Удалена задача": taskToBeDeleted,
 				})
+				rctx, rcancel := logContext(c, timeouts.Redis)
+				defer rcancel()
 				err = client_redis.SetJSON(
-					ctx,
+					rctx,
 					fmt.Sprintf("Удалена задача %v", taskToBeDeleted.Name),
 					taskToBeDeleted,
 					time.Until(taskToBeDeleted.DueDate),
 				)
 				if err != nil {
-					panic(fmt.Errorf("Ошибка логирования в Redis удаления задачи"))
+					fmt.Printf("Ошибка логирования в Redis удаления задачи: %v\n", err)
 				}
 			}
 		} else {
@@ -458,11 +668,14 @@ func DeleteTaskById(ctx context.Context,
 // DeleteNoteById Обработка Delete-запроса типа /api/item/id для заметок
 // id в запросе передается в виде hex-строки, напр.:
 // /api/notes/item/id/?id=69959fd9aece410dd54f5739
-func DeleteNoteById(ctx context.Context,
-	noteRepo *mongodb.NoteRepository,
+func DeleteNoteById(timeouts Timeouts,
+	noteRepo NoteStore,
 	client_redis *redis.Client,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeouts.Write)
+		defer cancel()
+
 		var noteId RemindableId
 		if err := c.ShouldBindWith(&noteId, binding.Query); err == nil {
 			objectID, err := primitive.ObjectIDFromHex(noteId.Id)
@@ -472,12 +685,18 @@ func DeleteNoteById(ctx context.Context,
 			}
 
 			noteToBeDeleted, err := noteRepo.GetById(ctx, objectID)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(http.StatusNotFound, gin.H{"NotFound": fmt.Sprintf("Заметки с id=%s не существует.", noteId.Id)})
 				return
 			}
 
 			err = noteRepo.DeleteById(ctx, objectID)
+			if err != nil && abortOnContext(c, ctx) {
+				return
+			}
 			if err != nil {
 				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
@@ -485,14 +704,16 @@ func DeleteNoteById(ctx context.Context,
 				c.JSON(http.StatusOK, gin.H{
 					"Удалена заметка": noteToBeDeleted,
 				})
+				rctx, rcancel := logContext(c, timeouts.Redis)
+				defer rcancel()
 				err = client_redis.SetJSON(
-					ctx,
+					rctx,
 					fmt.Sprintf("Удалена заметка %v", noteToBeDeleted.Name),
 					noteToBeDeleted,
 					time.Until(noteToBeDeleted.AlarmTimeStamp),
 				)
 				if err != nil {
-					panic(fmt.Errorf("Ошибка логирования в Redis удаления заметки"))
+					fmt.Printf("Ошибка логирования в Redis удаления заметки: %v\n", err)
 				}
 			}
 		} else {
@@ -500,3 +721,50 @@ func DeleteNoteById(ctx context.Context,
 		}
 	}
 }
+
+// GetSearch Обработка Get-запроса типа /api/search для задач и заметок, напр.:
+// /api/search?q=квартальный отчет&type=task&limit=10
+func GetSearch(
+	timeouts Timeouts,
+	taskRepo TaskStore,
+	noteRepo NoteStore,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeouts.Search)
+		defer cancel()
+
+		var query SearchQuery
+		if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// из каждой коллекции достаточно первых offset+limit результатов
+		var tasks, notes []mongodb.SearchHit
+		var err error
+		if query.Type != "note" {
+			if tasks, err = taskRepo.Search(ctx, query.Q, query.Offset+query.Limit); err != nil {
+				if abortOnContext(c, ctx) {
+					return
+				}
+				c.JSON(http.StatusInternalServerError, gin.H{"InternalServerError": "Ошибка полнотекстового поиска задач в БД"})
+				return
+			}
+		}
+		if query.Type != "task" {
+			if notes, err = noteRepo.Search(ctx, query.Q, query.Offset+query.Limit); err != nil {
+				if abortOnContext(c, ctx) {
+					return
+				}
+				c.JSON(http.StatusInternalServerError, gin.H{"InternalServerError": "Ошибка полнотекстового поиска заметок в БД"})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, SearchPage{
+			Items:  mongodb.MergeSearchHits(query.Limit, query.Offset, tasks, notes),
+			Limit:  query.Limit,
+			Offset: query.Offset,
+		})
+	}
+}
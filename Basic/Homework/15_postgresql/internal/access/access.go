@@ -0,0 +1,144 @@
+// Package access проверяет права пользователей на задачи и заметки и управляет доступом к ним.
+// Владелец записи может выдать другому пользователю роль просмотра или изменения как отдельной
+// задачи или заметки, так и списка - всех своих задач и заметок с заданной меткой
+package access
+
+import (
+	"context"
+	"errors"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// ErrForbidden роль пользователя не разрешает действие с записью
+var ErrForbidden = i18n.New("err.forbidden")
+
+// Store хранилище, по которому проверяются права и выдаётся доступ
+type Store interface {
+	storage.TaskStore
+	storage.NoteStore
+	storage.UserStore
+	storage.ShareStore
+}
+
+// Service проверка прав и управление доступом
+type Service struct {
+	store Store
+}
+
+// New создаёт службу доступа над хранилищем store
+func New(store Store) *Service {
+	return &Service{store: store}
+}
+
+// Role возвращает роль пользователя из ctx по отношению к записи id типа entityType
+// (storage.EntityTask или storage.EntityNote); невидимая пользователю запись возвращает storage.ErrNotFound
+func (s *Service) Role(ctx context.Context, entityType string, id int) (model.Role, error) {
+	var (
+		ownerId int
+		tags    []string
+	)
+	switch entityType {
+	case storage.EntityTask:
+		task, err := s.store.GetTask(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		ownerId, tags = task.OwnerId, task.Tags
+	case storage.EntityNote:
+		note, err := s.store.GetNote(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		ownerId, tags = note.OwnerId, note.Tags
+	default:
+		return "", storage.ErrNotFound
+	}
+	if storage.Owns(ctx, ownerId) {
+		return model.RoleOwner, nil
+	}
+	shares, err := s.store.SharedWith(ctx, ownerId)
+	if err != nil {
+		return "", err
+	}
+	return storage.GrantedRole(shares, entityType, id, ownerId, tags), nil
+}
+
+// Require проверяет, что роль пользователя из ctx по отношению к записи id типа entityType
+// разрешает действия роли need; иначе возвращает ErrForbidden
+func (s *Service) Require(ctx context.Context, entityType string, id int, need model.Role) error {
+	role, err := s.Role(ctx, entityType, id)
+	if err != nil {
+		return err
+	}
+	if !role.Allows(need) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Target проверяет объект доступа и возвращает его с нормализованным именем списка.
+// Управлять доступом к задаче или заметке может только её владелец; список всегда принадлежит
+// пользователю из ctx
+func (s *Service) Target(ctx context.Context, target storage.ShareTarget) (storage.ShareTarget, error) {
+	if target.EntityType == storage.EntityList {
+		list, err := model.NormalizeList(target.List)
+		return storage.ShareTarget{EntityType: storage.EntityList, List: list}, err
+	}
+	target.List = ""
+	return target, s.Require(ctx, target.EntityType, target.EntityId, model.RoleOwner)
+}
+
+// Share выдаёт пользователю grant.Login роль grant.Role по отношению к target;
+// повторная выдача тому же пользователю заменяет роль
+func (s *Service) Share(ctx context.Context, target storage.ShareTarget, grant model.Grant) (storage.Share, error) {
+	grant, err := model.NewGrant(grant.Login, grant.Role)
+	if err != nil {
+		return storage.Share{}, err
+	}
+	if target, err = s.Target(ctx, target); err != nil {
+		return storage.Share{}, err
+	}
+	user, err := s.recipient(ctx, grant.Login)
+	if err != nil {
+		return storage.Share{}, err
+	}
+	if user.Id == storage.OwnerFrom(ctx) {
+		return storage.Share{}, model.SelfGrant()
+	}
+	return s.store.Share(ctx, storage.Share{ShareTarget: target, UserId: user.Id, Login: user.Login, Role: grant.Role})
+}
+
+// Unshare отзывает доступ пользователя login к target и возвращает отозванный доступ
+func (s *Service) Unshare(ctx context.Context, target storage.ShareTarget, login string) (storage.Share, error) {
+	target, err := s.Target(ctx, target)
+	if err != nil {
+		return storage.Share{}, err
+	}
+	user, err := s.recipient(ctx, model.NormalizeLogin(login))
+	if err != nil {
+		return storage.Share{}, err
+	}
+	return s.store.Unshare(ctx, target, user.Id)
+}
+
+// Shares возвращает доступы, выданные к target
+func (s *Service) Shares(ctx context.Context, target storage.ShareTarget) ([]storage.Share, error) {
+	target, err := s.Target(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.Shares(ctx, target)
+}
+
+// recipient возвращает пользователя, которому выдаётся доступ; неизвестный логин возвращается
+// как storage.ErrNotFound с указанием логина
+func (s *Service) recipient(ctx context.Context, login string) (model.User, error) {
+	account, err := s.store.UserByLogin(ctx, login)
+	if errors.Is(err, storage.ErrNotFound) {
+		return model.User{}, i18n.Wrap(err, "ctx.user", login)
+	}
+	return account.User, err
+}
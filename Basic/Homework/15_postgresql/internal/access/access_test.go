@@ -0,0 +1,106 @@
+package access
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// Пользователи хранилища, заполняемого fixture
+const (
+	alice = iota + 1 // владелец задачи и заметки
+	bob              // просмотр задачи
+	carol            // изменение списка "work"
+	dave             // без доступа
+)
+
+// fixture создаёт хранилище с задачей и заметкой alice с меткой "work" и возвращает их Id
+func fixture(t *testing.T) (*Service, int, int) {
+	store := memory.New()
+	ctx := context.Background()
+	for _, login := range []string{"alice", "bob", "carol", "dave"} {
+		_, err := store.CreateUser(ctx, storage.Account{User: model.User{Login: login}})
+		assert.NoError(t, err)
+	}
+
+	ctx = storage.WithOwner(ctx, alice)
+	labels := model.Labels{Tags: []string{"work"}}
+	task, err := model.NewTask("task", "descr", "+1d", "", labels, time.UTC)
+	assert.NoError(t, err)
+	task, err = store.CreateTask(ctx, task)
+	assert.NoError(t, err)
+	note, err := model.NewNote("note", "descr", "+1h", "", labels, time.UTC)
+	assert.NoError(t, err)
+	note, err = store.CreateNote(ctx, note)
+	assert.NoError(t, err)
+
+	service := New(store)
+	_, err = service.Share(ctx, storage.ShareTarget{EntityType: storage.EntityTask, EntityId: task.Id}, model.Grant{Login: "bob", Role: model.RoleViewer})
+	assert.NoError(t, err)
+	_, err = service.Share(ctx, storage.ShareTarget{EntityType: storage.EntityList, List: "work"}, model.Grant{Login: "carol", Role: model.RoleEditor})
+	assert.NoError(t, err)
+	return service, task.Id, note.Id
+}
+
+func TestRequire(t *testing.T) {
+	service, taskId, noteId := fixture(t)
+	tests := []struct {
+		name       string
+		user       int
+		entityType string
+		id         int
+		need       model.Role
+		err        error
+	}{
+		{name: "owner deletes task", user: alice, entityType: storage.EntityTask, id: taskId, need: model.RoleOwner},
+		{name: "viewer reads task", user: bob, entityType: storage.EntityTask, id: taskId, need: model.RoleViewer},
+		{name: "viewer cannot edit task", user: bob, entityType: storage.EntityTask, id: taskId, need: model.RoleEditor, err: ErrForbidden},
+		{name: "task share does not cover note", user: bob, entityType: storage.EntityNote, id: noteId, need: model.RoleViewer, err: storage.ErrNotFound},
+		{name: "list editor edits note", user: carol, entityType: storage.EntityNote, id: noteId, need: model.RoleEditor},
+		{name: "list editor cannot delete task", user: carol, entityType: storage.EntityTask, id: taskId, need: model.RoleOwner, err: ErrForbidden},
+		{name: "stranger does not see task", user: dave, entityType: storage.EntityTask, id: taskId, need: model.RoleViewer, err: storage.ErrNotFound},
+		{name: "missing task", user: alice, entityType: storage.EntityTask, id: 100, need: model.RoleViewer, err: storage.ErrNotFound},
+		{name: "unknown entity type", user: alice, entityType: storage.EntityList, id: taskId, need: model.RoleViewer, err: storage.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := storage.WithOwner(context.Background(), tt.user)
+			err := service.Require(ctx, tt.entityType, tt.id, tt.need)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestShare(t *testing.T) {
+	service, taskId, _ := fixture(t)
+	task := storage.ShareTarget{EntityType: storage.EntityTask, EntityId: taskId}
+	tests := []struct {
+		name   string
+		user   int
+		target storage.ShareTarget
+		grant  model.Grant
+		err    error
+	}{
+		{name: "owner upgrades share", user: alice, target: task, grant: model.Grant{Login: "bob", Role: model.RoleEditor}},
+		{name: "viewer cannot share", user: bob, target: task, grant: model.Grant{Login: "dave", Role: model.RoleViewer}, err: ErrForbidden},
+		{name: "self grant", user: alice, target: task, grant: model.Grant{Login: "alice", Role: model.RoleViewer}, err: model.ErrValidation},
+		{name: "owner role is not granted", user: alice, target: task, grant: model.Grant{Login: "dave", Role: model.RoleOwner}, err: model.ErrValidation},
+		{name: "unknown user", user: alice, target: task, grant: model.Grant{Login: "erin", Role: model.RoleViewer}, err: storage.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := storage.WithOwner(context.Background(), tt.user)
+			_, err := service.Share(ctx, tt.target, tt.grant)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
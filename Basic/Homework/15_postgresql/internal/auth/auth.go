@@ -0,0 +1,163 @@
+// Package auth регистрирует пользователей, выдаёт токены доступа по логину и паролю и проверяет их.
+// Токены непрозрачные: клиент получает случайную строку, а хранилище - только её хеш SHA-256,
+// поэтому содержимое хранилища не позволяет воспользоваться действующими токенами
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrUnauthorized токен доступа не передан, не найден или истёк
+	ErrUnauthorized = i18n.New("err.unauthorized")
+	// ErrInvalidCredentials неверный логин или пароль
+	ErrInvalidCredentials = i18n.New("err.invalid_credentials")
+)
+
+// tokenBytes длина случайной части токена доступа
+const tokenBytes = 32
+
+// Token токен доступа, выданный пользователю при входе
+type Token struct {
+	Token     string     `json:"token"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	User      model.User `json:"user"`
+}
+
+// Service регистрация, вход и проверка токенов доступа
+type Service struct {
+	users storage.UserStore
+	ttl   time.Duration
+}
+
+// New создаёт службу, хранящую учётные записи и сессии в users; токены действуют ttl
+func New(users storage.UserStore, ttl time.Duration) *Service {
+	return &Service{users: users, ttl: ttl}
+}
+
+// dummyHash хеш, с которым сравнивается пароль неизвестного пользователя, чтобы время ответа
+// не выдавало, зарегистрирован ли логин
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("remindables"), bcrypt.DefaultCost)
+	return hash
+})
+
+// Register регистрирует пользователя с логином login и паролем password;
+// занятый логин возвращается как storage.ErrDuplicateName
+func (s *Service) Register(ctx context.Context, login, password string) (model.User, error) {
+	user, err := model.NewUser(login, password)
+	if err != nil {
+		return user, err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return user, fmt.Errorf("ошибка хеширования пароля: %w", err)
+	}
+	account, err := s.users.CreateUser(ctx, storage.Account{User: user, PasswordHash: string(hash)})
+	return account.User, err
+}
+
+// Login проверяет логин и пароль и выдаёт новый токен доступа
+func (s *Service) Login(ctx context.Context, login, password string) (Token, error) {
+	account, err := s.users.UserByLogin(ctx, model.NormalizeLogin(login))
+	if errors.Is(err, storage.ErrNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+		return Token{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return Token{}, err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(account.PasswordHash), []byte(password)) != nil {
+		return Token{}, ErrInvalidCredentials
+	}
+
+	raw := make([]byte, tokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return Token{}, fmt.Errorf("ошибка генерации токена: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	now := time.Now().UTC()
+	session := storage.Session{
+		TokenHash: hashToken(token),
+		UserId:    account.Id,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.ttl),
+	}
+	if err := s.users.CreateSession(ctx, session); err != nil {
+		return Token{}, err
+	}
+	return Token{Token: token, ExpiresAt: session.ExpiresAt, User: account.User}, nil
+}
+
+// Authenticate возвращает пользователя, которому выдан действующий токен token
+func (s *Service) Authenticate(ctx context.Context, token string) (model.User, error) {
+	if token == "" {
+		return model.User{}, ErrUnauthorized
+	}
+	tokenHash := hashToken(token)
+	session, err := s.users.GetSession(ctx, tokenHash)
+	if errors.Is(err, storage.ErrNotFound) {
+		return model.User{}, ErrUnauthorized
+	}
+	if err != nil {
+		return model.User{}, err
+	}
+	if !time.Now().Before(session.ExpiresAt) {
+		_ = s.users.DeleteSession(ctx, tokenHash)
+		return model.User{}, ErrUnauthorized
+	}
+	user, err := s.users.GetUser(ctx, session.UserId)
+	if errors.Is(err, storage.ErrNotFound) {
+		return model.User{}, ErrUnauthorized
+	}
+	return user, err
+}
+
+// Logout отзывает токен token
+func (s *Service) Logout(ctx context.Context, token string) error {
+	return s.users.DeleteSession(ctx, hashToken(token))
+}
+
+// hashToken возвращает хеш токена, под которым хранится сессия
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// BearerToken извлекает токен из значения заголовка Authorization вида "Bearer <токен>";
+// при другой схеме или пустом значении возвращает пустую строку
+func BearerToken(header string) string {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+type userKey struct{}
+
+// WithUser возвращает контекст запроса аутентифицированного пользователя user:
+// хранилище видит в нём только записи этого пользователя (см. storage.WithOwner)
+func WithUser(ctx context.Context, user model.User) context.Context {
+	return storage.WithOwner(context.WithValue(ctx, userKey{}, user), user.Id)
+}
+
+// UserFrom возвращает аутентифицированного пользователя из контекста
+func UserFrom(ctx context.Context) (model.User, bool) {
+	user, ok := ctx.Value(userKey{}).(model.User)
+	return user, ok
+}
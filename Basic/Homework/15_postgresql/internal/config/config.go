@@ -0,0 +1,313 @@
+// Package config собирает настройки приложения из файла (YAML/TOML), переменных окружения и флагов.
+//
+// Приоритет источников, от низшего к высшему: значения по умолчанию,
+// файл конфигурации, переменные окружения REMINDABLES_*, флаги командной строки.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+)
+
+// Поддерживаемые хранилища
+const (
+	BackendMemory   = "memory"
+	BackendFile     = "file"
+	BackendMongo    = "mongo"
+	BackendPostgres = "postgres"
+)
+
+// Поддерживаемые способы доставки напоминаний
+const (
+	NotifierStdout  = "stdout"
+	NotifierFile    = "file"
+	NotifierWebhook = "webhook"
+	NotifierSocket  = "socket"
+)
+
+var (
+	backends   = []string{BackendMemory, BackendFile, BackendMongo, BackendPostgres}
+	notifiers  = []string{NotifierStdout, NotifierFile, NotifierWebhook, NotifierSocket}
+	logLevels  = []string{"debug", "info", "warn", "error"}
+	logFormats = []string{"text", "json"}
+)
+
+// Config настройки приложения
+type Config struct {
+	HTTP      HTTPConfig
+	GRPC      GRPCConfig
+	Storage   StorageConfig
+	Postgres  PostgresConfig
+	Mongo     MongoConfig
+	Log       LogConfig
+	Locale    LocaleConfig
+	Reminders RemindersConfig
+	Shutdown  ShutdownConfig
+	Timeouts  TimeoutsConfig
+	Auth      AuthConfig
+	Trash     TrashConfig
+}
+
+// HTTPConfig настройки HTTP-сервера
+type HTTPConfig struct {
+	Addr string
+}
+
+// GRPCConfig настройки gRPC-сервера
+type GRPCConfig struct {
+	Addr string
+}
+
+// StorageConfig выбор хранилища задач и заметок
+type StorageConfig struct {
+	Backend string
+	DataDir string
+}
+
+// PostgresConfig параметры подключения к PostgreSQL
+type PostgresConfig struct {
+	Host           string
+	Port           int
+	User           string
+	Password       string
+	Database       string
+	SSLMode        string
+	Migrations     string
+	ConnectTimeout time.Duration
+}
+
+// dsnEscaper экранирует значение в одинарных кавычках строки подключения
+var dsnEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// DSN строка подключения в формате key=value
+func (c PostgresConfig) DSN() string {
+	dsn := fmt.Sprintf("host=%s port=%d user=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, c.Database, c.SSLMode)
+	if c.Password != "" {
+		dsn += fmt.Sprintf(" password='%s'", dsnEscaper.Replace(c.Password))
+	}
+	if c.ConnectTimeout > 0 {
+		dsn += fmt.Sprintf(" connect_timeout=%d", int(c.ConnectTimeout.Seconds()))
+	}
+	return dsn
+}
+
+// MongoConfig параметры подключения к MongoDB
+type MongoConfig struct {
+	URI      string
+	Database string
+}
+
+// ShutdownConfig настройки корректного завершения по SIGINT/SIGTERM
+type ShutdownConfig struct {
+	// Timeout время на завершение текущих запросов, после которого серверы останавливаются принудительно
+	Timeout time.Duration
+}
+
+// TimeoutsConfig предельное время операций с хранилищем в обработчиках HTTP и gRPC
+type TimeoutsConfig struct {
+	Read   time.Duration
+	Write  time.Duration
+	Search time.Duration
+}
+
+// AuthConfig настройки аутентификации пользователей
+type AuthConfig struct {
+	// TokenTTL срок действия токена доступа, выданного при входе
+	TokenTTL time.Duration
+}
+
+// TrashConfig настройки корзины удалённых задач и заметок
+type TrashConfig struct {
+	// Retention срок хранения записей в корзине, по истечении которого они удаляются окончательно
+	Retention time.Duration
+	// PurgeInterval период проверки корзины на записи с истёкшим сроком хранения
+	PurgeInterval time.Duration
+}
+
+// LocaleConfig настройки языка сообщений и часового пояса
+type LocaleConfig struct {
+	// Default язык ответов, если клиент не передал Accept-Language
+	Default string
+	// Timezone часовой пояс IANA, в котором разбираются даты без явного пояса,
+	// если клиент не передал X-Timezone
+	Timezone string
+}
+
+// RemindersConfig настройки диспетчера напоминаний
+type RemindersConfig struct {
+	Enabled bool
+	// Notifiers способы доставки через запятую: stdout, file, webhook, socket
+	Notifiers string
+	// File файл, в который дописываются напоминания способом file
+	File string
+	// WebhookURL адрес, на который напоминания отправляются POST-запросом способом webhook
+	WebhookURL string
+	// Socket путь к Unix-сокету, в который напоминания пишутся способом socket
+	Socket string
+	// Timeout предельное время доставки одного напоминания одним способом
+	Timeout time.Duration
+	// RetryMin и RetryMax границы экспоненциальной задержки повторной доставки после ошибки
+	RetryMin time.Duration
+	RetryMax time.Duration
+	// CatchUp наибольшее опоздание, с которым доставляются напоминания, пропущенные
+	// за время остановки приложения; более старые пропускаются; 0 - без ограничения
+	CatchUp time.Duration
+}
+
+// NotifierList возвращает способы доставки напоминаний списком
+func (c RemindersConfig) NotifierList() []string {
+	var list []string
+	for _, name := range strings.Split(c.Notifiers, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			list = append(list, name)
+		}
+	}
+	return list
+}
+
+// LogConfig настройки журналирования
+type LogConfig struct {
+	Level  string
+	Format string
+}
+
+// Default возвращает конфигурацию по умолчанию
+func Default() Config {
+	return Config{
+		HTTP: HTTPConfig{Addr: ":8080"},
+		GRPC: GRPCConfig{Addr: "localhost:5001"},
+		Storage: StorageConfig{
+			Backend: BackendPostgres,
+			DataDir: "./data",
+		},
+		Postgres: PostgresConfig{
+			Host:           "localhost",
+			Port:           5432,
+			User:           "otus_user",
+			Database:       "remindables",
+			SSLMode:        "disable",
+			Migrations:     "./migrations",
+			ConnectTimeout: 5 * time.Second,
+		},
+		Mongo: MongoConfig{
+			URI:      "mongodb://localhost:27017",
+			Database: "remindables",
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+		},
+		Locale: LocaleConfig{Default: string(i18n.Default), Timezone: "UTC"},
+		Reminders: RemindersConfig{
+			Enabled:   true,
+			Notifiers: NotifierStdout,
+			File:      "./data/reminders.log",
+			Timeout:   5 * time.Second,
+			RetryMin:  time.Second,
+			RetryMax:  5 * time.Minute,
+			CatchUp:   24 * time.Hour,
+		},
+		Shutdown: ShutdownConfig{Timeout: 10 * time.Second},
+		Timeouts: TimeoutsConfig{
+			Read:   5 * time.Second,
+			Write:  10 * time.Second,
+			Search: 10 * time.Second,
+		},
+		Auth: AuthConfig{TokenTTL: 24 * time.Hour},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+	}
+}
+
+// Validate проверяет конфигурацию и возвращает все найденные ошибки сразу
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(validAddr(c.HTTP.Addr), "http.addr: некорректный адрес %q, ожидается host:port", c.HTTP.Addr)
+	check(validAddr(c.GRPC.Addr), "grpc.addr: некорректный адрес %q, ожидается host:port", c.GRPC.Addr)
+	check(slices.Contains(backends, c.Storage.Backend),
+		"storage.backend: неизвестное хранилище %q, допустимы %v", c.Storage.Backend, backends)
+	check(slices.Contains(logLevels, c.Log.Level),
+		"log.level: неизвестный уровень %q, допустимы %v", c.Log.Level, logLevels)
+	check(slices.Contains(logFormats, c.Log.Format),
+		"log.format: неизвестный формат %q, допустимы %v", c.Log.Format, logFormats)
+	check(slices.Contains(i18n.Supported(), i18n.Lang(c.Locale.Default)),
+		"locale.default: неподдерживаемый язык %q, допустимы %v", c.Locale.Default, i18n.Supported())
+	_, tzErr := time.LoadLocation(c.Locale.Timezone)
+	check(c.Locale.Timezone != "" && tzErr == nil,
+		"locale.timezone: неизвестный часовой пояс %q, ожидается имя IANA, напр. Europe/Moscow", c.Locale.Timezone)
+
+	switch c.Storage.Backend {
+	case BackendFile:
+		check(c.Storage.DataDir != "", "storage.data_dir: не задан каталог файлового хранилища")
+	case BackendPostgres:
+		check(c.Postgres.Host != "", "postgres.host: не задан")
+		check(c.Postgres.Port > 0 && c.Postgres.Port < 65536, "postgres.port: некорректный порт %d", c.Postgres.Port)
+		check(c.Postgres.User != "", "postgres.user: не задан")
+		check(c.Postgres.Database != "", "postgres.database: не задана")
+		check(c.Postgres.Migrations != "", "postgres.migrations: не задан каталог миграций")
+		check(c.Postgres.ConnectTimeout >= 0, "postgres.connect_timeout: не может быть отрицательным")
+	case BackendMongo:
+		u, err := url.Parse(c.Mongo.URI)
+		check(err == nil && (u.Scheme == "mongodb" || u.Scheme == "mongodb+srv"),
+			"mongo.uri: некорректный URI %q", c.Mongo.URI)
+		check(c.Mongo.Database != "", "mongo.database: не задана")
+	}
+
+	if c.Reminders.Enabled {
+		for _, name := range c.Reminders.NotifierList() {
+			check(slices.Contains(notifiers, name),
+				"reminders.notifiers: неизвестный способ доставки %q, допустимы %v", name, notifiers)
+		}
+		check(len(c.Reminders.NotifierList()) > 0, "reminders.notifiers: не задан ни один способ доставки")
+		if slices.Contains(c.Reminders.NotifierList(), NotifierFile) {
+			check(c.Reminders.File != "", "reminders.file: не задан файл напоминаний")
+		}
+		if slices.Contains(c.Reminders.NotifierList(), NotifierWebhook) {
+			u, err := url.Parse(c.Reminders.WebhookURL)
+			check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+				"reminders.webhook_url: некорректный адрес %q, ожидается http(s)://host/path", c.Reminders.WebhookURL)
+		}
+		if slices.Contains(c.Reminders.NotifierList(), NotifierSocket) {
+			check(c.Reminders.Socket != "", "reminders.socket: не задан путь к Unix-сокету")
+		}
+		check(c.Reminders.Timeout > 0, "reminders.timeout: должен быть больше нуля")
+		check(c.Reminders.RetryMin > 0 && c.Reminders.RetryMax >= c.Reminders.RetryMin,
+			"reminders.retry_min, reminders.retry_max: ожидается 0 < retry_min <= retry_max")
+		check(c.Reminders.CatchUp >= 0, "reminders.catch_up: не может быть отрицательным")
+	}
+
+	check(c.Shutdown.Timeout > 0, "shutdown.timeout: должен быть больше нуля")
+	check(c.Timeouts.Read > 0, "timeouts.read: должен быть больше нуля")
+	check(c.Timeouts.Write > 0, "timeouts.write: должен быть больше нуля")
+	check(c.Timeouts.Search > 0, "timeouts.search: должен быть больше нуля")
+	check(c.Auth.TokenTTL > 0, "auth.token_ttl: должен быть больше нуля")
+	check(c.Trash.Retention > 0, "trash.retention: должен быть больше нуля")
+	check(c.Trash.PurgeInterval > 0, "trash.purge_interval: должен быть больше нуля")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("некорректная конфигурация:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// validAddr проверяет адрес вида host:port
+func validAddr(addr string) bool {
+	_, port, err := net.SplitHostPort(addr)
+	return err == nil && port != ""
+}
@@ -0,0 +1,248 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// EnvPrefix префикс переменных окружения приложения
+const EnvPrefix = "REMINDABLES_"
+
+// field параметр конфигурации: ключ в файле, описание и указатель на значение
+type field struct {
+	key   string
+	usage string
+	ptr   any
+}
+
+// fields перечень всех параметров конфигурации.
+// Ключ http.addr соответствует переменной REMINDABLES_HTTP_ADDR и флагу -http-addr
+func (c *Config) fields() []field {
+	return []field{
+		{"http.addr", "HTTP listen address", &c.HTTP.Addr},
+		{"grpc.addr", "gRPC listen address", &c.GRPC.Addr},
+		{"storage.backend", "storage backend: memory, file, mongo or postgres", &c.Storage.Backend},
+		{"storage.data_dir", "directory of the file storage", &c.Storage.DataDir},
+		{"postgres.host", "PostgreSQL host", &c.Postgres.Host},
+		{"postgres.port", "PostgreSQL port", &c.Postgres.Port},
+		{"postgres.user", "PostgreSQL user", &c.Postgres.User},
+		{"postgres.password", "PostgreSQL password", &c.Postgres.Password},
+		{"postgres.database", "PostgreSQL database", &c.Postgres.Database},
+		{"postgres.sslmode", "PostgreSQL sslmode", &c.Postgres.SSLMode},
+		{"postgres.migrations", "directory of goose migrations", &c.Postgres.Migrations},
+		{"postgres.connect_timeout", "PostgreSQL connect timeout, e.g. 5s", &c.Postgres.ConnectTimeout},
+		{"mongo.uri", "MongoDB connection URI", &c.Mongo.URI},
+		{"mongo.database", "MongoDB database", &c.Mongo.Database},
+		{"log.level", "log level: debug, info, warn or error", &c.Log.Level},
+		{"log.format", "log format: text or json", &c.Log.Format},
+		{"locale.default", "language of responses without Accept-Language: ru or en", &c.Locale.Default},
+		{"locale.timezone", "IANA time zone for dates without an explicit offset when X-Timezone is not sent", &c.Locale.Timezone},
+		{"reminders.enabled", "fire reminders when alarms and due dates arrive", &c.Reminders.Enabled},
+		{"reminders.notifiers", "comma-separated reminder notifiers: stdout, file, webhook, socket", &c.Reminders.Notifiers},
+		{"reminders.file", "file the file notifier appends reminders to", &c.Reminders.File},
+		{"reminders.webhook_url", "URL the webhook notifier posts reminders to", &c.Reminders.WebhookURL},
+		{"reminders.socket", "Unix socket the socket notifier writes reminders to", &c.Reminders.Socket},
+		{"reminders.timeout", "deadline of delivering a reminder by one notifier", &c.Reminders.Timeout},
+		{"reminders.retry_min", "initial delay before retrying a failed delivery", &c.Reminders.RetryMin},
+		{"reminders.retry_max", "maximum delay between delivery retries", &c.Reminders.RetryMax},
+		{"reminders.catch_up", "deliver reminders missed while stopped if they are at most this late", &c.Reminders.CatchUp},
+		{"shutdown.timeout", "time to drain in-flight requests on SIGINT/SIGTERM", &c.Shutdown.Timeout},
+		{"timeouts.read", "deadline of storage reads", &c.Timeouts.Read},
+		{"timeouts.write", "deadline of storage writes", &c.Timeouts.Write},
+		{"timeouts.search", "deadline of full-text search and log queries", &c.Timeouts.Search},
+		{"auth.token_ttl", "lifetime of access tokens issued at login", &c.Auth.TokenTTL},
+		{"trash.retention", "time deleted tasks and notes stay in the trash before they are purged, e.g. 720h", &c.Trash.Retention},
+		{"trash.purge_interval", "period of purging expired items from the trash", &c.Trash.PurgeInterval},
+	}
+}
+
+func envName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+func flagName(key string) string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(key)
+}
+
+// set разбирает строковое значение параметра в соответствии с его типом
+func (f field) set(raw string) error {
+	switch ptr := f.ptr.(type) {
+	case *string:
+		*ptr = raw
+	case *int:
+		v, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("%s: ожидается целое число, получено %q", f.key, raw)
+		}
+		*ptr = v
+	case *bool:
+		v, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("%s: ожидается true или false, получено %q", f.key, raw)
+		}
+		*ptr = v
+	case *time.Duration:
+		v, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("%s: ожидается длительность вида 5s или 1m30s, получено %q", f.key, raw)
+		}
+		*ptr = v
+	default:
+		return fmt.Errorf("%s: неподдерживаемый тип %T", f.key, f.ptr)
+	}
+	return nil
+}
+
+// Load собирает конфигурацию из значений по умолчанию, файла, окружения и флагов args.
+// Путь к файлу задаётся флагом -config или переменной REMINDABLES_CONFIG.
+// Возвращает flag.ErrHelp, если запрошена справка
+func Load(args []string, lookupEnv func(string) (string, bool)) (Config, error) {
+	cfg := Default()
+	fields := cfg.fields()
+
+	// флаги разбираются первыми, но применяются последними
+	fs := flag.NewFlagSet("remindables", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path to a YAML or TOML config file (env "+EnvPrefix+"CONFIG)")
+	flagValues := map[string]string{}
+	for _, f := range fields {
+		key := f.key
+		fs.Func(flagName(key), f.usage+" (env "+envName(key)+")", func(raw string) error {
+			flagValues[key] = raw
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	if fs.NArg() > 0 {
+		return Config{}, fmt.Errorf("неожиданные аргументы: %v", fs.Args())
+	}
+
+	path := *configPath
+	if path == "" {
+		path, _ = lookupEnv(EnvPrefix + "CONFIG")
+	}
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return Config{}, err
+		}
+		if err := apply(fields, values, "файл "+path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	envValues := map[string]string{}
+	for _, f := range fields {
+		if raw, ok := lookupEnv(envName(f.key)); ok {
+			envValues[f.key] = raw
+		}
+	}
+	if err := apply(fields, envValues, "переменные окружения"); err != nil {
+		return Config{}, err
+	}
+
+	if err := apply(fields, flagValues, "флаги"); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// apply присваивает значения values параметрам fields; source используется в тексте ошибки
+func apply(fields []field, values map[string]string, source string) error {
+	var errs []error
+	known := map[string]bool{}
+	for _, f := range fields {
+		known[f.key] = true
+		raw, ok := values[f.key]
+		if !ok {
+			continue
+		}
+		if err := f.set(raw); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	var unknown []string
+	for key := range values {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		errs = append(errs, fmt.Errorf("%s: неизвестный параметр", key))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s:\n%w", source, errors.Join(errs...))
+	}
+	return nil
+}
+
+// readFile читает файл конфигурации; формат определяется по расширению
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("чтение файла конфигурации: %w", err)
+	}
+
+	var tree map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("файл конфигурации %s: неподдерживаемый формат %q, ожидается .yaml, .yml или .toml", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("разбор файла конфигурации %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	flatten("", tree, values)
+	return values, nil
+}
+
+// flatten разворачивает вложенные секции файла в ключи вида section.key
+func flatten(prefix string, tree map[string]any, values map[string]string) {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if value == nil {
+			continue
+		}
+		if nested, ok := value.(map[string]any); ok {
+			flatten(key, nested, values)
+			continue
+		}
+		values[key] = fmt.Sprint(value)
+	}
+}
+
+// Logger создаёт журнал с уровнем и форматом из конфигурации
+func (c LogConfig) Logger(w io.Writer) *slog.Logger {
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(c.Level))
+	opts := &slog.HandlerOptions{Level: level}
+	if c.Format == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
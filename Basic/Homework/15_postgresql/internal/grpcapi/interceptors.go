@@ -0,0 +1,200 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/12_gRPC/proto_api/pkg/grpc/v1/remindables_api"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/auth"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// LoggingUnaryInterceptor журналирует вызовы унарных методов
+func LoggingUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	slog.Info("gRPC",
+		"method", info.FullMethod,
+		"status", status.Code(err).String(),
+		"error", err,
+		"duration", time.Since(start),
+	)
+	return resp, err
+}
+
+// LoggingStreamInterceptor журналирует вызовы потоковых методов
+func LoggingStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	slog.Info("gRPC",
+		"method", info.FullMethod,
+		"status", status.Code(err).String(),
+		"error", err,
+		"duration", time.Since(start),
+	)
+	return err
+}
+
+// RecoveryUnaryInterceptor перехватывает панику в унарном методе и возвращает Internal вместо падения процесса
+func RecoveryUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// RecoveryStreamInterceptor перехватывает панику в потоковом методе и возвращает Internal вместо падения процесса
+func RecoveryStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// recovered журналирует перехваченную панику вместе со стеком и возвращает статус Internal
+func recovered(method string, r any) error {
+	slog.Error("gRPC panic recovered",
+		"method", method,
+		"panic", r,
+		"stack", string(debug.Stack()),
+	)
+	return status.Error(codes.Internal, i18n.T(i18n.Default, "err.internal"))
+}
+
+// LocaleUnaryInterceptor определяет язык ответа по метаданным accept-language и часовой
+// пояс пользователя по метаданным x-timezone; без них используются fallbackLang и fallbackLoc
+func LocaleUnaryInterceptor(fallbackLang i18n.Lang, fallbackLoc *time.Location) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := withLocale(ctx, fallbackLang, fallbackLoc)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// LocaleStreamInterceptor определяет язык ответа и часовой пояс пользователя потокового метода
+func LocaleStreamInterceptor(fallbackLang i18n.Lang, fallbackLoc *time.Location) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := withLocale(ss.Context(), fallbackLang, fallbackLoc)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// withLocale возвращает контекст с языком и часовым поясом из метаданных вызова.
+// Неизвестный часовой пояс возвращает InvalidArgument
+func withLocale(ctx context.Context, fallbackLang i18n.Lang, fallbackLoc *time.Location) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	lang := i18n.Negotiate(strings.Join(md.Get("accept-language"), ","), fallbackLang)
+	ctx = i18n.WithLang(ctx, lang)
+
+	loc := fallbackLoc
+	if values := md.Get("x-timezone"); len(values) > 0 && values[0] != "" {
+		var err error
+		if loc, err = time.LoadLocation(values[0]); err != nil {
+			return nil, status.Error(codes.InvalidArgument, i18n.T(lang, "err.invalid_timezone", values[0]))
+		}
+	}
+	return i18n.WithLocation(ctx, loc), nil
+}
+
+// AuthUnaryInterceptor требует токен доступа в метаданных authorization ("Bearer <токен>")
+// у методов RemindablesService, кроме Register и Login, и передаёт пользователя методу
+// в контексте вызова. Без действующего токена возвращает Unauthenticated
+func AuthUnaryInterceptor(accounts *auth.Service, timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !requiresAuth(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := withUser(ctx, accounts, timeout)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor требует токен доступа у потоковых методов RemindablesService
+func AuthStreamInterceptor(accounts *auth.Service, timeout time.Duration) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !requiresAuth(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := withUser(ss.Context(), accounts, timeout)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// requiresAuth сообщает, нужен ли методу method токен доступа: служебные сервисы (reflection)
+// и методы получения токена доступны без него
+func requiresAuth(method string) bool {
+	switch method {
+	case remindables_api.RemindablesService_Register_FullMethodName,
+		remindables_api.RemindablesService_Login_FullMethodName:
+		return false
+	}
+	return strings.HasPrefix(method, "/"+remindables_api.RemindablesService_ServiceDesc.ServiceName+"/")
+}
+
+// withUser возвращает контекст пользователя, которому выдан токен из метаданных authorization;
+// проверка токена ограничена временем timeout
+func withUser(ctx context.Context, accounts *auth.Service, timeout time.Duration) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	token := auth.BearerToken(strings.Join(md.Get("authorization"), ","))
+
+	authCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	user, err := accounts.Authenticate(authCtx, token)
+	if err != nil {
+		return nil, toStatus(authCtx, i18n.Wrap(err, "ctx.authenticate"))
+	}
+	return auth.WithUser(ctx, user), nil
+}
+
+// contextStream поток с подменённым контекстом
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context реализует grpc.ServerStream
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,124 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/12_gRPC/proto_api/pkg/grpc/v1/remindables_api"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Пути updateMask запросов изменения и поля, которые они изменяют:
+// текстовая дата изменяет то же поле, что и дата-время
+var (
+	taskMaskPaths = map[string]string{
+		"name": "name", "description": "description", "dueDate": "date", "dueDateText": "date",
+		"recurrence": "recurrence", "priority": "priority", "tags": "tags",
+	}
+	noteMaskPaths = map[string]string{
+		"name": "name", "description": "description", "alarmTimeStamp": "date", "alarmTimeStampText": "date",
+		"recurrence": "recurrence", "priority": "priority", "tags": "tags",
+	}
+)
+
+// fieldSet изменяемые поля; nil означает все поля
+type fieldSet map[string]bool
+
+// has сообщает, изменяется ли поле field
+func (fields fieldSet) has(field string) bool {
+	return fields == nil || fields[field]
+}
+
+// maskFields разбирает updateMask по таблице путей paths. Без маски или с пустой маской
+// изменяются все поля; неизвестный путь возвращает InvalidArgument
+func maskFields(ctx context.Context, mask *fieldmaskpb.FieldMask, paths map[string]string) (fieldSet, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, nil
+	}
+	fields := fieldSet{}
+	for _, path := range mask.GetPaths() {
+		field, ok := paths[path]
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, i18n.T(i18n.LangFrom(ctx), "err.invalid_update_mask", path))
+		}
+		fields[field] = true
+	}
+	return fields, nil
+}
+
+// change аргументы Change задачи или заметки
+type change struct {
+	name, descr, date, rule string
+	labels                  model.Labels
+	loc                     *time.Location
+}
+
+// changeMasked возвращает аргументы Change: поля из fields берутся из запроса (req), остальные - из записи
+// (current). Дата записи передаётся в формате RFC 3339; если по маске не меняются ни дата, ни правило
+// повторения, повторения остаются в поясе записи tz, иначе используется пояс запроса loc
+func changeMasked(fields fieldSet, req, current change, tz string) change {
+	changed := current
+	if fields.has("name") {
+		changed.name = req.name
+	}
+	if fields.has("description") {
+		changed.descr = req.descr
+	}
+	if fields.has("date") {
+		changed.date = req.date
+	}
+	if fields.has("recurrence") {
+		changed.rule = req.rule
+	}
+	if fields.has("priority") {
+		changed.labels.Priority = req.labels.Priority
+	}
+	if fields.has("tags") {
+		changed.labels.Tags = req.labels.Tags
+	}
+	changed.loc = req.loc
+	if fields != nil && changed.date == current.date && changed.rule == current.rule {
+		changed.loc = model.Zone(tz, req.loc)
+	}
+	return changed
+}
+
+// taskChange возвращает аргументы Change задачи task по запросу req с маской fields
+func taskChange(ctx context.Context, req *remindables_api.PutTaskRequest, fields fieldSet, task model.Task) change {
+	return changeMasked(fields, change{
+		name:   req.GetName(),
+		descr:  req.GetDescription(),
+		date:   dateText(req.GetDueDate(), req.GetDueDateText()),
+		rule:   req.GetRecurrence(),
+		labels: model.Labels{Priority: model.Priority(req.GetPriority()), Tags: req.GetTags()},
+		loc:    i18n.LocationFrom(ctx),
+	}, change{
+		name:   task.Name,
+		descr:  task.Description,
+		date:   task.DueDate.UTC().Format(time.RFC3339Nano),
+		rule:   task.Recurrence,
+		labels: task.Labels,
+	}, task.Timezone)
+}
+
+// noteChange возвращает аргументы Change заметки note по запросу req с маской fields
+func noteChange(ctx context.Context, req *remindables_api.PutNoteRequest, fields fieldSet, note model.Note) change {
+	return changeMasked(fields, change{
+		name:   req.GetName(),
+		descr:  req.GetDescription(),
+		date:   dateText(req.GetAlarmTimeStamp(), req.GetAlarmTimeStampText()),
+		rule:   req.GetRecurrence(),
+		labels: model.Labels{Priority: model.Priority(req.GetPriority()), Tags: req.GetTags()},
+		loc:    i18n.LocationFrom(ctx),
+	}, change{
+		name:   note.Name,
+		descr:  note.Description,
+		date:   note.AlarmTimeStamp.UTC().Format(time.RFC3339Nano),
+		rule:   note.Recurrence,
+		labels: note.Labels,
+	}, note.Timezone)
+}
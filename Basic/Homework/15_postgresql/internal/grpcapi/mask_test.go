@@ -0,0 +1,127 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/12_gRPC/proto_api/pkg/grpc/v1/remindables_api"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestMaskFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  fieldSet
+		code  codes.Code
+	}{
+		{name: "no mask changes all fields", want: nil},
+		{name: "name only", paths: []string{"name"}, want: fieldSet{"name": true}},
+		{name: "text date changes the date", paths: []string{"dueDateText", "tags"}, want: fieldSet{"date": true, "tags": true}},
+		{name: "note field in task mask", paths: []string{"alarmTimeStamp"}, code: codes.InvalidArgument},
+		{name: "unknown path", paths: []string{"name", "owner"}, code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var mask *fieldmaskpb.FieldMask
+			if tt.paths != nil {
+				mask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+			}
+			fields, err := maskFields(context.Background(), mask, taskMaskPaths)
+
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.want, fields)
+		})
+	}
+}
+
+func TestFieldSetHas(t *testing.T) {
+	assert.True(t, fieldSet(nil).has("name"))
+	assert.True(t, fieldSet{"name": true}.has("name"))
+	assert.False(t, fieldSet{"name": true}.has("date"))
+}
+
+func TestTaskChange(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	task := model.Task{
+		Name:        "task",
+		Description: "descr",
+		DueDate:     time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC),
+		Recurrence:  "FREQ=DAILY",
+		Timezone:    "UTC",
+		Labels:      model.Labels{Priority: model.PriorityLow, Tags: []string{"a"}},
+	}
+	req := &remindables_api.PutTaskRequest{
+		Name:        "renamed",
+		Description: "new",
+		DueDateText: "завтра",
+		Recurrence:  "FREQ=WEEKLY",
+		Priority:    string(model.PriorityHigh),
+		Tags:        []string{"b"},
+	}
+	tests := []struct {
+		name   string
+		fields fieldSet
+		want   change
+	}{
+		{
+			name:   "all fields from the request",
+			fields: nil,
+			want: change{
+				name: "renamed", descr: "new", date: "завтра", rule: "FREQ=WEEKLY",
+				labels: model.Labels{Priority: model.PriorityHigh, Tags: []string{"b"}}, loc: moscow,
+			},
+		},
+		{
+			name:   "masked name keeps the series in its time zone",
+			fields: fieldSet{"name": true},
+			want: change{
+				name: "renamed", descr: "descr", date: "2026-11-02T09:00:00Z", rule: "FREQ=DAILY",
+				labels: model.Labels{Priority: model.PriorityLow, Tags: []string{"a"}}, loc: time.UTC,
+			},
+		},
+		{
+			name:   "masked date uses the request time zone",
+			fields: fieldSet{"date": true, "priority": true},
+			want: change{
+				name: "task", descr: "descr", date: "завтра", rule: "FREQ=DAILY",
+				labels: model.Labels{Priority: model.PriorityHigh, Tags: []string{"a"}}, loc: moscow,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := i18n.WithLocation(context.Background(), moscow)
+
+			assert.Equal(t, tt.want, taskChange(ctx, req, tt.fields, task))
+		})
+	}
+}
+
+func TestTaskChangeMaskedChecksMaskedFields(t *testing.T) {
+	// описание пустое, срок прошёл: поля вне маски не проверяются
+	task := model.Task{
+		Name:    "task",
+		DueDate: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Status:  model.Created,
+		Labels:  model.Labels{Priority: model.PriorityNormal, Tags: []string{}},
+	}
+	req := &remindables_api.PutTaskRequest{Name: "renamed", Tags: []string{"work"}}
+	ch := taskChange(context.Background(), req, fieldSet{"name": true, "tags": true}, task)
+
+	err := task.Change(ch.name, ch.descr, ch.date, ch.rule, ch.labels, ch.loc)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", task.Name)
+	assert.Equal(t, []string{"work"}, task.Tags)
+}
@@ -0,0 +1,1048 @@
+// Package grpcapi реализует gRPC-сервис remindables.v1.RemindablesService поверх хранилищ storage
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/12_gRPC/proto_api/pkg/grpc/v1/remindables_api"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/access"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/auth"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/config"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Размер страницы, которой считываются списки для потоковой передачи клиенту
+const streamPageSize = 500
+
+// Server gRPC-сервис задач и заметок
+type Server struct {
+	remindables_api.UnimplementedRemindablesServiceServer
+	tasks    storage.TaskStore
+	notes    storage.NoteStore
+	trash    storage.TrashStore
+	accounts *auth.Service
+	acl      *access.Service
+	timeouts config.TimeoutsConfig
+}
+
+// NewServer создаёт gRPC-сервис, работающий с хранилищами tasks и notes, корзиной trash
+// и учётными записями accounts с ограничением времени операций timeouts
+func NewServer(
+	tasks storage.TaskStore,
+	notes storage.NoteStore,
+	trash storage.TrashStore,
+	accounts *auth.Service,
+	acl *access.Service,
+	timeouts config.TimeoutsConfig,
+) *Server {
+	return &Server{tasks: tasks, notes: notes, trash: trash, accounts: accounts, acl: acl, timeouts: timeouts}
+}
+
+// toStatus приводит ошибки хранилища к статусам gRPC с текстом на языке вызова;
+// подробности непредвиденных ошибок только журналируются.
+// Если операция прервана контекстом ctx, возвращается DeadlineExceeded или Canceled
+func toStatus(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
+	}
+	lang := i18n.LangFrom(ctx)
+	msg := i18n.Localize(lang, err)
+	var invalid *model.ValidationError
+	switch {
+	case errors.As(err, &invalid):
+		return invalidArgument(lang, msg, invalid)
+	case errors.Is(err, auth.ErrUnauthorized), errors.Is(err, auth.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, msg)
+	case errors.Is(err, access.ErrForbidden):
+		return status.Error(codes.PermissionDenied, msg)
+	case errors.Is(err, storage.ErrNotFound):
+		return status.Error(codes.NotFound, msg)
+	case errors.Is(err, storage.ErrDuplicateName):
+		return status.Error(codes.AlreadyExists, msg)
+	case errors.Is(err, storage.ErrConflict):
+		return status.Error(codes.Aborted, msg)
+	case errors.Is(err, model.ErrInvalidTransition), errors.Is(err, model.ErrTaskClosed),
+		errors.Is(err, model.ErrReminderState), errors.Is(err, model.ErrDependencyCycle),
+		errors.Is(err, model.ErrOpenSubtasks), errors.Is(err, storage.ErrHasSubtasks),
+		errors.Is(err, storage.ErrRelatedNotFound), errors.Is(err, storage.ErrPreconditionFailed):
+		return status.Error(codes.FailedPrecondition, msg)
+	case errors.Is(err, storage.ErrInvalidCursor), errors.Is(err, model.ErrUnknownStatus),
+		errors.Is(err, model.ErrInvalidPeriod):
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	slog.Error("gRPC request failed", "error", err)
+	return status.Error(codes.Internal, i18n.T(lang, "err.internal"))
+}
+
+// invalidArgument возвращает статус InvalidArgument с нарушениями по полям в деталях BadRequest
+func invalidArgument(lang i18n.Lang, msg string, invalid *model.ValidationError) error {
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range invalid.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Localize(lang),
+			Reason:      strings.ToUpper(v.Rule),
+		})
+	}
+	st, err := status.New(codes.InvalidArgument, msg).WithDetails(badRequest)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return st.Err()
+}
+
+// withActor возвращает контекст хранилища с автором изменения: логином аутентифицированного
+// пользователя, а без него - значением метаданных x-actor или адресом клиента
+func withActor(ctx context.Context) context.Context {
+	if user, ok := auth.UserFrom(ctx); ok {
+		return storage.WithActor(ctx, user.Login)
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-actor"); len(values) > 0 && values[0] != "" {
+			return storage.WithActor(ctx, values[0])
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		return storage.WithActor(ctx, p.Addr.String())
+	}
+	return ctx
+}
+
+// expectVersion возвращает контекст изменения записи, ожидающего её версию version
+// (см. storage.WithExpectedVersion); 0 - без проверки версии
+func expectVersion(ctx context.Context, version int32) context.Context {
+	if version == 0 {
+		return ctx
+	}
+	return storage.WithExpectedVersion(ctx, int(version))
+}
+
+// entityKeys ключи контекста ошибки для записи каждого типа
+var entityKeys = map[string]string{
+	storage.EntityTask: "ctx.task",
+	storage.EntityNote: "ctx.note",
+}
+
+// require проверяет, что роль пользователя по отношению к записи id типа entityType
+// разрешает действия роли need; иначе возвращает статус PermissionDenied или NotFound
+func (s *Server) require(ctx context.Context, entityType string, id int32, need model.Role) error {
+	if err := s.acl.Require(ctx, entityType, int(id), need); err != nil {
+		return toStatus(ctx, i18n.Wrap(err, entityKeys[entityType], id))
+	}
+	return nil
+}
+
+func taskResponse(task model.Task) *remindables_api.Task {
+	return &remindables_api.Task{
+		Id:            int32(task.Id),
+		Name:          task.Name,
+		Description:   task.Description,
+		InitTimeStamp: timestamppb.New(task.InitTimeStamp),
+		DueDate:       timestamppb.New(task.DueDate),
+		Status:        string(task.Status),
+		Recurrence:    task.Recurrence,
+		Timezone:      task.Timezone,
+		ReminderState: string(task.ReminderState),
+		SnoozedFrom:   optionalTimestamp(task.SnoozedFrom),
+		Priority:      string(task.Priority),
+		Tags:          task.Tags,
+		ParentId:      optionalId(task.ParentId),
+		BlockedBy:     taskIds(task.BlockedBy),
+		DeletedAt:     optionalTimestamp(task.DeletedAt),
+		Version:       int32(task.Version),
+	}
+}
+
+func noteResponse(note model.Note) *remindables_api.Note {
+	return &remindables_api.Note{
+		Id:             int32(note.Id),
+		Name:           note.Name,
+		Description:    note.Description,
+		AlarmTimeStamp: timestamppb.New(note.AlarmTimeStamp),
+		InitTimeStamp:  timestamppb.New(note.InitTimeStamp),
+		Recurrence:     note.Recurrence,
+		Timezone:       note.Timezone,
+		ReminderState:  string(note.ReminderState),
+		SnoozedFrom:    optionalTimestamp(note.SnoozedFrom),
+		Priority:       string(note.Priority),
+		Tags:           note.Tags,
+		TaskId:         optionalId(note.TaskId),
+		DeletedAt:      optionalTimestamp(note.DeletedAt),
+		Version:        int32(note.Version),
+	}
+}
+
+// optionalId возвращает 0 для незаданного Id
+func optionalId(id *int) int32 {
+	if id == nil {
+		return 0
+	}
+	return int32(*id)
+}
+
+// optionalTimestamp возвращает nil для незаданного времени
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// GetTasks implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetTasks(_ *emptypb.Empty, stream grpc.ServerStreamingServer[remindables_api.Task]) error {
+	return s.streamTasks(storage.TaskFilter{}, stream)
+}
+
+// GetNotes implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetNotes(_ *emptypb.Empty, stream grpc.ServerStreamingServer[remindables_api.Note]) error {
+	return s.streamNotes(storage.NoteFilter{}, stream)
+}
+
+// ListTasks implements remindables_api.RemindablesServiceServer.
+func (s *Server) ListTasks(req *remindables_api.ListTasksRequest, stream grpc.ServerStreamingServer[remindables_api.Task]) error {
+	priorities, err := listPriorities(stream.Context(), req.GetPriority())
+	if err != nil {
+		return err
+	}
+	return s.streamTasks(storage.TaskFilter{Priority: priorities, Tags: model.NormalizeTags(req.GetTags())}, stream)
+}
+
+// ListNotes implements remindables_api.RemindablesServiceServer.
+func (s *Server) ListNotes(req *remindables_api.ListNotesRequest, stream grpc.ServerStreamingServer[remindables_api.Note]) error {
+	priorities, err := listPriorities(stream.Context(), req.GetPriority())
+	if err != nil {
+		return err
+	}
+	return s.streamNotes(storage.NoteFilter{Priority: priorities, Tags: model.NormalizeTags(req.GetTags())}, stream)
+}
+
+// listPriorities проверяет приоритеты из запроса списка
+func listPriorities(ctx context.Context, values []string) ([]model.Priority, error) {
+	priorities := make([]model.Priority, 0, len(values))
+	for _, value := range values {
+		priority := model.Priority(value)
+		if !priority.Known() {
+			return nil, status.Error(codes.InvalidArgument, i18n.T(i18n.LangFrom(ctx), "validation.unknown_priority", value))
+		}
+		priorities = append(priorities, priority)
+	}
+	return priorities, nil
+}
+
+// streamTasks передаёт клиенту все задачи, отобранные filter, страницами по streamPageSize
+func (s *Server) streamTasks(filter storage.TaskFilter, stream grpc.ServerStreamingServer[remindables_api.Task]) error {
+	filter.Sort, filter.Limit = "id", streamPageSize
+	for {
+		ctx, cancel := context.WithTimeout(stream.Context(), s.timeouts.Read)
+		page, err := s.tasks.ListTasks(ctx, filter)
+		if err != nil {
+			err = toStatus(ctx, err)
+		}
+		cancel()
+		if err != nil {
+			return err
+		}
+		for _, task := range page.Items {
+			if err := stream.Send(taskResponse(task)); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
+
+// streamNotes передаёт клиенту все заметки, отобранные filter, страницами по streamPageSize
+func (s *Server) streamNotes(filter storage.NoteFilter, stream grpc.ServerStreamingServer[remindables_api.Note]) error {
+	filter.Sort, filter.Limit = "id", streamPageSize
+	for {
+		ctx, cancel := context.WithTimeout(stream.Context(), s.timeouts.Read)
+		page, err := s.notes.ListNotes(ctx, filter)
+		if err != nil {
+			err = toStatus(ctx, err)
+		}
+		cancel()
+		if err != nil {
+			return err
+		}
+		for _, note := range page.Items {
+			if err := stream.Send(noteResponse(note)); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
+
+// GetTasksById implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetTasksById(ctx context.Context, req *remindables_api.GetTaskRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Read)
+	defer cancel()
+
+	task, err := s.tasks.GetTask(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task", req.GetId()))
+	}
+	resp := taskResponse(task)
+	if !req.GetIncludeNotes() {
+		return resp, nil
+	}
+	notes, err := s.notes.TaskNotes(ctx, task.Id)
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_notes", req.GetId()))
+	}
+	for _, note := range notes {
+		resp.Notes = append(resp.Notes, noteResponse(note))
+	}
+	return resp, nil
+}
+
+// GetNotesById implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetNotesById(ctx context.Context, req *remindables_api.GetNoteRequest) (*remindables_api.Note, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Read)
+	defer cancel()
+
+	note, err := s.notes.GetNote(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.note", req.GetId()))
+	}
+	return noteResponse(note), nil
+}
+
+// dateText возвращает дату из запроса в виде строки для model.NewTask и Change:
+// текстовое значение text, если оно задано, иначе ts в формате RFC 3339.
+// Пустая строка означает, что дата не передана
+func dateText(ts *timestamppb.Timestamp, text string) string {
+	if text != "" || ts == nil {
+		return text
+	}
+	return ts.AsTime().Format(time.RFC3339Nano)
+}
+
+// textField относит нарушения поля даты field к полю field+"Text", если дата передана текстом,
+// чтобы имя поля в ошибке совпадало с полем запроса
+func textField(err error, field, text string) error {
+	var invalid *model.ValidationError
+	if text != "" && errors.As(err, &invalid) {
+		invalid.Rename(field, field+"Text")
+	}
+	return err
+}
+
+// PostNewTask implements remindables_api.RemindablesServiceServer.
+func (s *Server) PostNewTask(ctx context.Context, req *remindables_api.PostNewTaskRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	task, err := model.NewTask(
+		req.GetName(),
+		req.GetDescription(),
+		dateText(req.GetDueDate(), req.GetDueDateText()),
+		req.GetRecurrence(),
+		model.Labels{Priority: model.Priority(req.GetPriority()), Tags: req.GetTags()},
+		i18n.LocationFrom(ctx),
+	)
+	err = textField(err, "dueDate", req.GetDueDateText())
+	if err == nil {
+		task, err = s.tasks.CreateTask(withActor(ctx), task)
+	}
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_create", req.GetName()))
+	}
+	return taskResponse(task), nil
+}
+
+// PostNewNote implements remindables_api.RemindablesServiceServer.
+func (s *Server) PostNewNote(ctx context.Context, req *remindables_api.PostNewNoteRequest) (*remindables_api.Note, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	note, err := model.NewNote(
+		req.GetName(),
+		req.GetDescription(),
+		dateText(req.GetAlarmTimeStamp(), req.GetAlarmTimeStampText()),
+		req.GetRecurrence(),
+		model.Labels{Priority: model.Priority(req.GetPriority()), Tags: req.GetTags()},
+		i18n.LocationFrom(ctx),
+	)
+	err = textField(err, "alarmTimeStamp", req.GetAlarmTimeStampText())
+	if err == nil {
+		note, err = s.notes.CreateNote(withActor(ctx), note)
+	}
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.note_create", req.GetName()))
+	}
+	return noteResponse(note), nil
+}
+
+// PutTaskById implements remindables_api.RemindablesServiceServer.
+func (s *Server) PutTaskById(ctx context.Context, req *remindables_api.PutTaskRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	fields, err := maskFields(ctx, req.GetUpdateMask(), taskMaskPaths)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.require(ctx, storage.EntityTask, req.GetId(), model.RoleEditor); err != nil {
+		return nil, err
+	}
+	task, err := s.tasks.UpdateTask(withActor(expectVersion(ctx, req.GetExpectedVersion())), int(req.GetId()), func(task *model.Task) error {
+		ch := taskChange(ctx, req, fields, *task)
+		return textField(task.Change(ch.name, ch.descr, ch.date, ch.rule, ch.labels, ch.loc), "dueDate", req.GetDueDateText())
+	})
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_update", req.GetId()))
+	}
+	return taskResponse(task), nil
+}
+
+// PutNoteById implements remindables_api.RemindablesServiceServer.
+func (s *Server) PutNoteById(ctx context.Context, req *remindables_api.PutNoteRequest) (*remindables_api.Note, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	fields, err := maskFields(ctx, req.GetUpdateMask(), noteMaskPaths)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.require(ctx, storage.EntityNote, req.GetId(), model.RoleEditor); err != nil {
+		return nil, err
+	}
+	note, err := s.notes.UpdateNote(withActor(expectVersion(ctx, req.GetExpectedVersion())), int(req.GetId()), func(note *model.Note) error {
+		ch := noteChange(ctx, req, fields, *note)
+		return textField(note.Change(ch.name, ch.descr, ch.date, ch.rule, ch.labels, ch.loc), "alarmTimeStamp", req.GetAlarmTimeStampText())
+	})
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.note_update", req.GetId()))
+	}
+	return noteResponse(note), nil
+}
+
+// DeleteTaskById implements remindables_api.RemindablesServiceServer.
+func (s *Server) DeleteTaskById(ctx context.Context, req *remindables_api.DeleteTaskRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	opts := storage.DeleteOptions{
+		Subtasks: storage.DeletePolicy(req.GetCascade()),
+		Notes:    storage.NotesPolicy(req.GetNotes()),
+	}
+	if !opts.Subtasks.Known() {
+		return nil, status.Error(codes.InvalidArgument, i18n.T(i18n.LangFrom(ctx), "err.invalid_cascade", req.GetCascade()))
+	}
+	if !opts.Notes.Known() {
+		return nil, status.Error(codes.InvalidArgument, i18n.T(i18n.LangFrom(ctx), "err.invalid_notes_policy", req.GetNotes()))
+	}
+	if err := s.require(ctx, storage.EntityTask, req.GetId(), model.RoleOwner); err != nil {
+		return nil, err
+	}
+	task, err := s.tasks.DeleteTask(withActor(expectVersion(ctx, req.GetExpectedVersion())), int(req.GetId()), opts)
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_delete", req.GetId()))
+	}
+	return taskResponse(task), nil
+}
+
+// DeleteNoteById implements remindables_api.RemindablesServiceServer.
+func (s *Server) DeleteNoteById(ctx context.Context, req *remindables_api.DeleteNoteRequest) (*remindables_api.Note, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	if err := s.require(ctx, storage.EntityNote, req.GetId(), model.RoleOwner); err != nil {
+		return nil, err
+	}
+	note, err := s.notes.DeleteNote(withActor(expectVersion(ctx, req.GetExpectedVersion())), int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.note_delete", req.GetId()))
+	}
+	return noteResponse(note), nil
+}
+
+// TransitionTask implements remindables_api.RemindablesServiceServer.
+func (s *Server) TransitionTask(ctx context.Context, req *remindables_api.TransitionTaskRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	to, err := model.ParseStatus(req.GetStatus())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if err := s.require(ctx, storage.EntityTask, req.GetId(), model.RoleEditor); err != nil {
+		return nil, err
+	}
+	task, err := s.tasks.UpdateTask(withActor(ctx), int(req.GetId()), func(task *model.Task) error {
+		return task.Transition(to)
+	})
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_transition", req.GetId()))
+	}
+	return taskResponse(task), nil
+}
+
+// GetTaskHistory implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetTaskHistory(ctx context.Context, req *remindables_api.GetTaskRequest) (*remindables_api.GetTaskHistoryResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Read)
+	defer cancel()
+
+	history, err := s.tasks.TaskHistory(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_history", req.GetId()))
+	}
+	resp := &remindables_api.GetTaskHistoryResponse{
+		TaskId: req.GetId(),
+		Items:  make([]*remindables_api.StatusChange, 0, len(history)),
+	}
+	for _, change := range history {
+		resp.Items = append(resp.Items, &remindables_api.StatusChange{
+			From:      string(change.From),
+			To:        string(change.To),
+			Actor:     change.Actor,
+			ChangedAt: timestamppb.New(change.ChangedAt),
+		})
+	}
+	return resp, nil
+}
+
+// occurrencesWindow возвращает интервал и предел выборки повторений из запроса
+func occurrencesWindow(req *remindables_api.OccurrencesRequest) (time.Time, time.Time, int, error) {
+	var from, to time.Time
+	if req.GetFrom() != nil {
+		from = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		to = req.GetTo().AsTime()
+	}
+	return model.OccurrencesWindow(from, to, int(req.GetLimit()), time.Now().UTC())
+}
+
+// occurrencesResponse формирует ответ со списком повторений
+func occurrencesResponse(id int32, rule, tz string, list []time.Time) *remindables_api.OccurrencesResponse {
+	resp := &remindables_api.OccurrencesResponse{
+		Id:         id,
+		Recurrence: rule,
+		Timezone:   tz,
+		Items:      make([]*timestamppb.Timestamp, 0, len(list)),
+	}
+	for _, t := range list {
+		resp.Items = append(resp.Items, timestamppb.New(t))
+	}
+	return resp
+}
+
+// GetTaskOccurrences implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetTaskOccurrences(ctx context.Context, req *remindables_api.OccurrencesRequest) (*remindables_api.OccurrencesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Read)
+	defer cancel()
+
+	from, to, limit, err := occurrencesWindow(req)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	task, err := s.tasks.GetTask(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_occurrences", req.GetId()))
+	}
+	return occurrencesResponse(req.GetId(), task.Recurrence, task.Timezone, task.Occurrences(from, to, limit)), nil
+}
+
+// GetNoteOccurrences implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetNoteOccurrences(ctx context.Context, req *remindables_api.OccurrencesRequest) (*remindables_api.OccurrencesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Read)
+	defer cancel()
+
+	from, to, limit, err := occurrencesWindow(req)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	note, err := s.notes.GetNote(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.note_occurrences", req.GetId()))
+	}
+	return occurrencesResponse(req.GetId(), note.Recurrence, note.Timezone, note.Occurrences(from, to, limit)), nil
+}
+
+// snoozeUntil возвращает время, до которого откладывается напоминание:
+// задаётся ровно одно из полей duration, until, untilText
+func snoozeUntil(ctx context.Context, req *remindables_api.SnoozeRequest) (time.Time, error) {
+	lang := i18n.LangFrom(ctx)
+	set := 0
+	for _, ok := range []bool{req.GetDuration() != nil, req.GetUntil() != nil, req.GetUntilText() != ""} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return time.Time{}, status.Error(codes.InvalidArgument, i18n.T(lang, "err.invalid_snooze"))
+	}
+
+	now := time.Now()
+	switch {
+	case req.GetDuration() != nil:
+		d := req.GetDuration().AsDuration()
+		if err := req.GetDuration().CheckValid(); err != nil || d <= 0 {
+			return time.Time{}, status.Error(codes.InvalidArgument,
+				i18n.T(lang, "err.invalid_query_date", d.String(), "duration"))
+		}
+		return now.Add(d), nil
+	case req.GetUntil() != nil:
+		return req.GetUntil().AsTime(), nil
+	}
+	until, err := dateinput.Parse(req.GetUntilText(), now, i18n.LocationFrom(ctx))
+	if err != nil {
+		return time.Time{}, status.Error(codes.InvalidArgument,
+			i18n.T(lang, "err.invalid_query_date", req.GetUntilText(), "untilText"))
+	}
+	return until, nil
+}
+
+// updateTaskReminder применяет к задаче действие с напоминанием change
+func (s *Server) updateTaskReminder(ctx context.Context, id int32, change func(task *model.Task) error) (*remindables_api.Task, error) {
+	if err := s.require(ctx, storage.EntityTask, id, model.RoleEditor); err != nil {
+		return nil, err
+	}
+	task, err := s.tasks.UpdateTask(withActor(ctx), int(id), change)
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_reminder", id))
+	}
+	return taskResponse(task), nil
+}
+
+// updateNoteReminder применяет к заметке действие с напоминанием change
+func (s *Server) updateNoteReminder(ctx context.Context, id int32, change func(note *model.Note) error) (*remindables_api.Note, error) {
+	if err := s.require(ctx, storage.EntityNote, id, model.RoleEditor); err != nil {
+		return nil, err
+	}
+	note, err := s.notes.UpdateNote(withActor(ctx), int(id), change)
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.note_reminder", id))
+	}
+	return noteResponse(note), nil
+}
+
+// snoozesResponse формирует ответ с историей откладывания напоминаний
+func snoozesResponse(id int32, snoozes []model.Snooze) *remindables_api.GetSnoozesResponse {
+	resp := &remindables_api.GetSnoozesResponse{
+		Id:    id,
+		Items: make([]*remindables_api.Snooze, 0, len(snoozes)),
+	}
+	for _, snooze := range snoozes {
+		resp.Items = append(resp.Items, &remindables_api.Snooze{
+			From:      timestamppb.New(snooze.From),
+			Until:     timestamppb.New(snooze.Until),
+			Actor:     snooze.Actor,
+			SnoozedAt: timestamppb.New(snooze.SnoozedAt),
+		})
+	}
+	return resp
+}
+
+// SnoozeTask implements remindables_api.RemindablesServiceServer.
+func (s *Server) SnoozeTask(ctx context.Context, req *remindables_api.SnoozeRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	until, err := snoozeUntil(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateTaskReminder(ctx, req.GetId(), func(task *model.Task) error {
+		return task.Snooze(until)
+	})
+}
+
+// AcknowledgeTask implements remindables_api.RemindablesServiceServer.
+func (s *Server) AcknowledgeTask(ctx context.Context, req *remindables_api.GetTaskRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	return s.updateTaskReminder(ctx, req.GetId(), (*model.Task).Acknowledge)
+}
+
+// DismissTask implements remindables_api.RemindablesServiceServer.
+func (s *Server) DismissTask(ctx context.Context, req *remindables_api.GetTaskRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	return s.updateTaskReminder(ctx, req.GetId(), (*model.Task).Dismiss)
+}
+
+// GetTaskSnoozes implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetTaskSnoozes(ctx context.Context, req *remindables_api.GetTaskRequest) (*remindables_api.GetSnoozesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Read)
+	defer cancel()
+
+	snoozes, err := s.tasks.TaskSnoozes(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_snoozes", req.GetId()))
+	}
+	return snoozesResponse(req.GetId(), snoozes), nil
+}
+
+// SnoozeNote implements remindables_api.RemindablesServiceServer.
+func (s *Server) SnoozeNote(ctx context.Context, req *remindables_api.SnoozeRequest) (*remindables_api.Note, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	until, err := snoozeUntil(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateNoteReminder(ctx, req.GetId(), func(note *model.Note) error {
+		return note.Snooze(until)
+	})
+}
+
+// AcknowledgeNote implements remindables_api.RemindablesServiceServer.
+func (s *Server) AcknowledgeNote(ctx context.Context, req *remindables_api.GetNoteRequest) (*remindables_api.Note, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	return s.updateNoteReminder(ctx, req.GetId(), (*model.Note).Acknowledge)
+}
+
+// DismissNote implements remindables_api.RemindablesServiceServer.
+func (s *Server) DismissNote(ctx context.Context, req *remindables_api.GetNoteRequest) (*remindables_api.Note, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	return s.updateNoteReminder(ctx, req.GetId(), (*model.Note).Dismiss)
+}
+
+// GetNoteSnoozes implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetNoteSnoozes(ctx context.Context, req *remindables_api.GetNoteRequest) (*remindables_api.GetSnoozesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Read)
+	defer cancel()
+
+	snoozes, err := s.notes.NoteSnoozes(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.note_snoozes", req.GetId()))
+	}
+	return snoozesResponse(req.GetId(), snoozes), nil
+}
+
+// taskIds приводит Id задач к типу сообщений gRPC
+func taskIds(ids []int) []int32 {
+	result := make([]int32, len(ids))
+	for i, id := range ids {
+		result[i] = int32(id)
+	}
+	return result
+}
+
+// updateTaskRelations применяет к задаче изменение связей change; key - ключ контекста ошибки
+func (s *Server) updateTaskRelations(ctx context.Context, id int32, key string, change func(task *model.Task) error) (*remindables_api.Task, error) {
+	if err := s.require(ctx, storage.EntityTask, id, model.RoleEditor); err != nil {
+		return nil, err
+	}
+	task, err := s.tasks.UpdateTask(withActor(ctx), int(id), change)
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, key, id))
+	}
+	return taskResponse(task), nil
+}
+
+// SetTaskParent implements remindables_api.RemindablesServiceServer.
+func (s *Server) SetTaskParent(ctx context.Context, req *remindables_api.SetTaskParentRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	var parentId *int
+	if req.GetParentId() != 0 {
+		id := int(req.GetParentId())
+		parentId = &id
+	}
+	return s.updateTaskRelations(ctx, req.GetId(), "ctx.task_parent", func(task *model.Task) error {
+		return task.SetParent(parentId)
+	})
+}
+
+// AddTaskBlocker implements remindables_api.RemindablesServiceServer.
+func (s *Server) AddTaskBlocker(ctx context.Context, req *remindables_api.TaskBlockerRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	return s.updateTaskRelations(ctx, req.GetId(), "ctx.task_blockers", func(task *model.Task) error {
+		return task.Block(int(req.GetBlockerId()))
+	})
+}
+
+// RemoveTaskBlocker implements remindables_api.RemindablesServiceServer.
+func (s *Server) RemoveTaskBlocker(ctx context.Context, req *remindables_api.TaskBlockerRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	return s.updateTaskRelations(ctx, req.GetId(), "ctx.task_blockers", func(task *model.Task) error {
+		task.Unblock(int(req.GetBlockerId()))
+		return nil
+	})
+}
+
+// GetTaskTree implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetTaskTree(ctx context.Context, req *remindables_api.GetTaskRequest) (*remindables_api.TaskTreeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Read)
+	defer cancel()
+
+	node, err := s.tasks.TaskTree(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_tree", req.GetId()))
+	}
+	return treeResponse(node), nil
+}
+
+// SetNoteTask implements remindables_api.RemindablesServiceServer.
+func (s *Server) SetNoteTask(ctx context.Context, req *remindables_api.SetNoteTaskRequest) (*remindables_api.Note, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	var taskId *int
+	if req.GetTaskId() != 0 {
+		id := int(req.GetTaskId())
+		taskId = &id
+	}
+	if err := s.require(ctx, storage.EntityNote, req.GetId(), model.RoleEditor); err != nil {
+		return nil, err
+	}
+	note, err := s.notes.UpdateNote(withActor(ctx), int(req.GetId()), func(note *model.Note) error {
+		note.AttachTo(taskId)
+		return nil
+	})
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.note_task", req.GetId()))
+	}
+	return noteResponse(note), nil
+}
+
+// treeResponse формирует ответ с деревом зависимостей задачи
+func treeResponse(node model.TaskNode) *remindables_api.TaskTreeResponse {
+	resp := &remindables_api.TaskTreeResponse{Task: taskResponse(node.Task)}
+	for _, subtask := range node.Subtasks {
+		resp.Subtasks = append(resp.Subtasks, treeResponse(subtask))
+	}
+	for _, blocker := range node.BlockedBy {
+		resp.BlockedBy = append(resp.BlockedBy, treeResponse(blocker))
+	}
+	return resp
+}
+
+func userResponse(user model.User) *remindables_api.UserResponse {
+	return &remindables_api.UserResponse{
+		Id:        int32(user.Id),
+		Login:     user.Login,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+	}
+}
+
+// Register implements remindables_api.RemindablesServiceServer.
+func (s *Server) Register(ctx context.Context, req *remindables_api.Credentials) (*remindables_api.UserResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	user, err := s.accounts.Register(ctx, req.GetLogin(), req.GetPassword())
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.register", req.GetLogin()))
+	}
+	return userResponse(user), nil
+}
+
+// Login implements remindables_api.RemindablesServiceServer.
+func (s *Server) Login(ctx context.Context, req *remindables_api.Credentials) (*remindables_api.LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	token, err := s.accounts.Login(ctx, req.GetLogin(), req.GetPassword())
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.login", req.GetLogin()))
+	}
+	return &remindables_api.LoginResponse{
+		Token:     token.Token,
+		ExpiresAt: timestamppb.New(token.ExpiresAt),
+		User:      userResponse(token.User),
+	}, nil
+}
+
+// Logout implements remindables_api.RemindablesServiceServer.
+func (s *Server) Logout(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if err := s.accounts.Logout(ctx, auth.BearerToken(strings.Join(md.Get("authorization"), ","))); err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.logout"))
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// shareTarget разбирает объект доступа из сообщения gRPC; entityType - task, note или list
+func shareTarget(ctx context.Context, target *remindables_api.ShareTarget) (storage.ShareTarget, error) {
+	switch target.GetEntityType() {
+	case storage.EntityTask, storage.EntityNote, storage.EntityList:
+	default:
+		return storage.ShareTarget{}, status.Error(codes.InvalidArgument,
+			i18n.T(i18n.LangFrom(ctx), "err.invalid_share_target", target.GetEntityType()))
+	}
+	return storage.ShareTarget{
+		EntityType: target.GetEntityType(),
+		EntityId:   int(target.GetEntityId()),
+		List:       target.GetList(),
+	}, nil
+}
+
+func shareResponse(share storage.Share) *remindables_api.ShareResponse {
+	return &remindables_api.ShareResponse{
+		Id: int32(share.Id),
+		Target: &remindables_api.ShareTarget{
+			EntityType: share.EntityType,
+			EntityId:   int32(share.EntityId),
+			List:       share.List,
+		},
+		OwnerId:   int32(share.OwnerId),
+		UserId:    int32(share.UserId),
+		Login:     share.Login,
+		Role:      string(share.Role),
+		CreatedAt: timestamppb.New(share.CreatedAt),
+	}
+}
+
+// Share implements remindables_api.RemindablesServiceServer.
+func (s *Server) Share(ctx context.Context, req *remindables_api.ShareRequest) (*remindables_api.ShareResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	target, err := shareTarget(ctx, req.GetTarget())
+	if err != nil {
+		return nil, err
+	}
+	grant := model.Grant{Login: req.GetLogin(), Role: model.Role(req.GetRole())}
+	share, err := s.acl.Share(withActor(ctx), target, grant)
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.share", req.GetLogin()))
+	}
+	return shareResponse(share), nil
+}
+
+// Unshare implements remindables_api.RemindablesServiceServer.
+func (s *Server) Unshare(ctx context.Context, req *remindables_api.UnshareRequest) (*remindables_api.ShareResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	target, err := shareTarget(ctx, req.GetTarget())
+	if err != nil {
+		return nil, err
+	}
+	share, err := s.acl.Unshare(withActor(ctx), target, req.GetLogin())
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.unshare", req.GetLogin()))
+	}
+	return shareResponse(share), nil
+}
+
+// GetShares implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetShares(ctx context.Context, req *remindables_api.ShareTarget) (*remindables_api.SharesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Read)
+	defer cancel()
+
+	target, err := shareTarget(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	shares, err := s.acl.Shares(ctx, target)
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.shares"))
+	}
+	resp := &remindables_api.SharesResponse{Items: make([]*remindables_api.ShareResponse, 0, len(shares))}
+	for _, share := range shares {
+		resp.Items = append(resp.Items, shareResponse(share))
+	}
+	return resp, nil
+}
+
+// GetTrash implements remindables_api.RemindablesServiceServer.
+func (s *Server) GetTrash(ctx context.Context, _ *emptypb.Empty) (*remindables_api.TrashResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Read)
+	defer cancel()
+
+	trash, err := s.trash.Trash(ctx)
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.trash"))
+	}
+	resp := &remindables_api.TrashResponse{
+		Tasks: make([]*remindables_api.Task, 0, len(trash.Tasks)),
+		Notes: make([]*remindables_api.Note, 0, len(trash.Notes)),
+	}
+	for _, task := range trash.Tasks {
+		resp.Tasks = append(resp.Tasks, taskResponse(task))
+	}
+	for _, note := range trash.Notes {
+		resp.Notes = append(resp.Notes, noteResponse(note))
+	}
+	return resp, nil
+}
+
+// RestoreTask implements remindables_api.RemindablesServiceServer.
+func (s *Server) RestoreTask(ctx context.Context, req *remindables_api.GetTaskRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	task, err := s.trash.RestoreTask(withActor(ctx), int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_restore", req.GetId()))
+	}
+	return taskResponse(task), nil
+}
+
+// RestoreNote implements remindables_api.RemindablesServiceServer.
+func (s *Server) RestoreNote(ctx context.Context, req *remindables_api.GetNoteRequest) (*remindables_api.Note, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	note, err := s.trash.RestoreNote(withActor(ctx), int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.note_restore", req.GetId()))
+	}
+	return noteResponse(note), nil
+}
+
+// PurgeTask implements remindables_api.RemindablesServiceServer.
+func (s *Server) PurgeTask(ctx context.Context, req *remindables_api.GetTaskRequest) (*remindables_api.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	task, err := s.trash.PurgeTask(withActor(ctx), int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.task_purge", req.GetId()))
+	}
+	return taskResponse(task), nil
+}
+
+// PurgeNote implements remindables_api.RemindablesServiceServer.
+func (s *Server) PurgeNote(ctx context.Context, req *remindables_api.GetNoteRequest) (*remindables_api.Note, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Write)
+	defer cancel()
+
+	note, err := s.trash.PurgeNote(withActor(ctx), int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, i18n.Wrap(err, "ctx.note_purge", req.GetId()))
+	}
+	return noteResponse(note), nil
+}
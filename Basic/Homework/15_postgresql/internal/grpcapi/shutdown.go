@@ -0,0 +1,26 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc"
+)
+
+// GracefulStop дожидается завершения текущих вызовов, пока не истечёт ctx,
+// после чего закрывает оставшиеся соединения принудительно
+func GracefulStop(ctx context.Context, s *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		slog.Warn("gRPC graceful stop timed out, forcing stop", "error", ctx.Err())
+		s.Stop()
+		<-stopped
+	}
+}
@@ -0,0 +1,305 @@
+package i18n
+
+// catalogs переводы сообщений по ключам; каталог языка Default должен содержать все ключи
+var catalogs = map[Lang]map[string]string{
+	RU: {
+		// Ошибки
+		"err.not_found":            "запись не найдена",
+		"err.duplicate_name":       "запись с таким именем уже существует",
+		"err.conflict":             "запись изменена параллельным запросом",
+		"err.invalid_cursor":       "некорректный курсор пагинации",
+		"err.precondition_failed":  "версия записи не совпадает с указанной в If-Match",
+		"err.validation":           "данные не прошли проверку",
+		"err.unknown_status":       "неизвестный статус задачи",
+		"err.invalid_transition":   "недопустимый переход статуса задачи",
+		"err.task_closed":          "задача закрыта для изменений",
+		"err.internal":             "внутренняя ошибка сервера",
+		"err.timeout":              "превышено время ожидания ответа хранилища",
+		"err.client_closed":        "клиент закрыл соединение",
+		"err.route_not_found":      "маршрут не найден",
+		"err.invalid_task_id":      "некорректный ID задачи",
+		"err.invalid_note_id":      "некорректный ID заметки",
+		"err.invalid_share_target": "неизвестный тип объекта доступа %q: ожидается task, note или list",
+		"err.invalid_timezone":     "неизвестный часовой пояс %q, ожидается имя IANA, напр. Europe/Moscow",
+		"err.invalid_query_date":   "не удалось разобрать дату %q в параметре %s",
+		"err.invalid_period":       "конец интервала должен быть позже его начала",
+		"err.invalid_snooze":       "укажите ровно одно из полей for (длительность, напр. 15m) или until (время)",
+		"err.reminder_state":       "действие недоступно в текущем состоянии напоминания",
+		"err.dependency_cycle":     "связь задач образует цикл",
+		"err.open_subtasks":        "задачу нельзя завершить, пока открыты её подзадачи",
+		"err.has_subtasks":         "у задачи есть подзадачи; укажите cascade=cascade, чтобы удалить их, или cascade=orphan, чтобы отвязать",
+		"err.related_not_found":    "связанная задача не найдена",
+		"err.invalid_cascade":      "неизвестная политика удаления подзадач %q; допустимы restrict, cascade, orphan",
+		"err.invalid_notes_policy": "неизвестная политика удаления заметок %q; допустимы detach, delete",
+		"err.unauthorized":         "требуется вход: токен доступа не передан, не найден или истёк",
+		"err.invalid_credentials":  "неверный логин или пароль",
+		"err.forbidden":            "роль пользователя не разрешает это действие",
+		"err.patch_type":           "неподдерживаемый тип патча; ожидается application/merge-patch+json или application/json-patch+json",
+		"err.patch_malformed":      "тело запроса не является патчем указанного типа",
+		"err.patch_unprocessable":  "патч нельзя применить к записи",
+		"err.patch_test_failed":    "проверка test патча не выполнена",
+		"err.invalid_update_mask":  "неизвестное поле %q в updateMask",
+		"err.invalid_rule":         "некорректное правило повторения",
+
+		// Контекст ошибок
+		"ctx.task":             "задача с id=%d",
+		"ctx.note":             "заметка с id=%d",
+		"ctx.task_list":        "список задач",
+		"ctx.note_list":        "список заметок",
+		"ctx.task_create":      "создание задачи %q",
+		"ctx.note_create":      "создание заметки %q",
+		"ctx.task_update":      "изменение задачи с id=%d",
+		"ctx.note_update":      "изменение заметки с id=%d",
+		"ctx.task_delete":      "удаление задачи с id=%d",
+		"ctx.note_delete":      "удаление заметки с id=%d",
+		"ctx.task_transition":  "смена статуса задачи с id=%d",
+		"ctx.task_history":     "история задачи с id=%d",
+		"ctx.task_occurrences": "повторения задачи с id=%d",
+		"ctx.note_occurrences": "повторения заметки с id=%d",
+		"ctx.task_reminder":    "напоминание задачи с id=%d",
+		"ctx.note_reminder":    "напоминание заметки с id=%d",
+		"ctx.task_snoozes":     "история откладывания напоминаний задачи с id=%d",
+		"ctx.note_snoozes":     "история откладывания напоминаний заметки с id=%d",
+		"ctx.task_parent":      "родитель задачи с id=%d",
+		"ctx.task_blockers":    "блокировки задачи с id=%d",
+		"ctx.task_tree":        "дерево зависимостей задачи с id=%d",
+		"ctx.task_notes":       "заметки задачи с id=%d",
+		"ctx.note_task":        "прикрепление заметки с id=%d",
+		"ctx.linked_task":      "задача с id=%d",
+		"ctx.register":         "регистрация пользователя %q",
+		"ctx.login":            "вход пользователя %q",
+		"ctx.logout":           "выход",
+		"ctx.authenticate":     "проверка токена доступа",
+		"ctx.user":             "пользователь %q",
+		"ctx.share":            "выдача доступа пользователю %q",
+		"ctx.unshare":          "отзыв доступа пользователя %q",
+		"ctx.shares":           "список доступов",
+		"ctx.trash":            "корзина",
+		"ctx.task_restore":     "восстановление задачи с id=%d",
+		"ctx.note_restore":     "восстановление заметки с id=%d",
+		"ctx.task_purge":       "окончательное удаление задачи с id=%d",
+		"ctx.note_purge":       "окончательное удаление заметки с id=%d",
+		"ctx.parent_task":      "родительская задача с id=%d",
+		"ctx.blocker_task":     "блокирующая задача с id=%d",
+		"ctx.open_subtasks":    "открытые подзадачи: %v",
+		"ctx.subtasks":         "подзадачи: %v",
+		"ctx.cycle":            "цикл: %s",
+		"ctx.patch_op":         "операция %d (%s %q)",
+		"ctx.patch_result":     "результат патча: %s",
+		"ctx.reminder_state":   "действие %s в состоянии «%s»",
+		"ctx.log":              "журнал изменений",
+		"ctx.search":           "полнотекстовый поиск",
+		"ctx.status":           "статус %q",
+		"ctx.task_status":      "статус задачи %s",
+		"ctx.transition":       "%s -> %s, допустимы: %s",
+		"ctx.rrule_part":       "часть %q без значения",
+		"ctx.rrule_repeated":   "%s указан дважды",
+		"ctx.rrule_freq":       "неподдерживаемая частота %s",
+		"ctx.rrule_wkst":       "WKST=%s: поддерживается только WKST=MO",
+		"ctx.rrule_key":        "неподдерживаемая часть %s",
+		"ctx.rrule_no_freq":    "не задан FREQ",
+		"ctx.rrule_count":      "COUNT и UNTIL взаимоисключающие",
+		"ctx.rrule_yearly":     "BYDAY не поддерживается для FREQ=YEARLY",
+		"ctx.rrule_positive":   "%s=%s: ожидается целое положительное число",
+		"ctx.rrule_until":      "UNTIL=%s: ожидается ГГГГММДД или ГГГГММДДTЧЧММССZ",
+		"ctx.rrule_weekday":    "неизвестный день недели %q в BYDAY",
+
+		// Нарушения правил проверки полей
+		"validation.required":           "обязательное поле",
+		"validation.too_long":           "длина превышает %d символов",
+		"validation.invalid_date":       "не удалось разобрать дату %q; ожидается ДД.ММ.ГГГГ [ЧЧ:ММ], ГГГГ-ММ-ДД, RFC 3339 или «завтра 9:00», «через 3 дня», «+2h»",
+		"validation.in_past":            "срок исполнения не может быть в прошлом",
+		"validation.before_created":     "напоминание должно срабатывать после создания заметки",
+		"validation.invalid_recurrence": "некорректное правило повторения %q; ожидается RRULE вида FREQ=DAILY|WEEKLY|MONTHLY|YEARLY[;INTERVAL=n][;BYDAY=MO,WE][;COUNT=n|;UNTIL=ГГГГММДД]",
+		"validation.not_future":         "время должно быть в будущем",
+		"validation.unknown_priority":   "неизвестный приоритет %q; допустимы low, normal, high, urgent",
+		"validation.too_many_tags":      "меток не может быть больше %d",
+		"validation.too_short":          "длина меньше %d символов",
+		"validation.invalid_login":      "допустимы только латинские буквы, цифры и символы . _ -",
+		"validation.unknown_role":       "неизвестная роль %q; допустимы viewer, editor",
+		"validation.self_grant":         "нельзя выдать доступ самому себе",
+
+		// Статусы задач
+		"status.created":     "Создана",
+		"status.updated":     "Изменена",
+		"status.seen":        "Просмотрена",
+		"status.in_progress": "В работе",
+		"status.suspended":   "Приостановлена",
+		"status.submitted":   "Решена, ждет контроля",
+		"status.completed":   "Завершена",
+		"status.cancelled":   "Отменена",
+		"status.returned":    "Возвращена на доработку",
+		"status.backlog":     "Бэклог",
+		"status.none":        "нет",
+
+		// Состояния напоминаний
+		"reminder_state.pending":      "Ожидает",
+		"reminder_state.fired":        "Сработало",
+		"reminder_state.snoozed":      "Отложено",
+		"reminder_state.acknowledged": "Подтверждено",
+		"reminder_state.dismissed":    "Отклонено",
+
+		// Приоритеты задач и заметок
+		"priority.low":    "Низкий",
+		"priority.normal": "Обычный",
+		"priority.high":   "Высокий",
+		"priority.urgent": "Срочный",
+		"role.viewer":     "Просмотр",
+		"role.editor":     "Редактирование",
+		"role.owner":      "Владелец",
+
+		// Текстовое представление задач и заметок
+		"task.text": "Имя задачи: %v\nОписание задачи: %v\nДата постановки задачи: %v\nДата исполнения: %v\nСтатус: %v\n",
+		"note.text": "Имя заметки: %v\nОписание заметки: %v\nДата и время напоминания: %v\n",
+
+		// Тексты напоминаний: имя, id, срок или время напоминания, описание
+		"reminder.task": "Наступает срок задачи %q (id=%d) %s: %s",
+		"reminder.note": "Напоминание %q (id=%d) на %s: %s",
+	},
+	EN: {
+		"err.not_found":            "record not found",
+		"err.duplicate_name":       "a record with this name already exists",
+		"err.conflict":             "the record was modified by a concurrent request",
+		"err.invalid_cursor":       "invalid pagination cursor",
+		"err.precondition_failed":  "the record version does not match If-Match",
+		"err.validation":           "validation failed",
+		"err.unknown_status":       "unknown task status",
+		"err.invalid_transition":   "task status transition is not allowed",
+		"err.task_closed":          "the task is closed for changes",
+		"err.internal":             "internal server error",
+		"err.timeout":              "the storage did not respond in time",
+		"err.client_closed":        "the client closed the connection",
+		"err.route_not_found":      "route not found",
+		"err.invalid_task_id":      "invalid task ID",
+		"err.invalid_note_id":      "invalid note ID",
+		"err.invalid_share_target": "unknown share target type %q: expected task, note or list",
+		"err.invalid_timezone":     "unknown time zone %q, expected an IANA name such as Europe/Moscow",
+		"err.invalid_query_date":   "cannot parse date %q in parameter %s",
+		"err.invalid_period":       "the end of the period must be after its start",
+		"err.invalid_snooze":       "specify exactly one of for (a duration such as 15m) or until (a time)",
+		"err.reminder_state":       "the action is not available in the current reminder state",
+		"err.dependency_cycle":     "the task relation forms a cycle",
+		"err.open_subtasks":        "the task cannot be completed while its subtasks are open",
+		"err.has_subtasks":         "the task has subtasks; pass cascade=cascade to delete them or cascade=orphan to detach them",
+		"err.related_not_found":    "the related task does not exist",
+		"err.invalid_cascade":      "unknown subtasks deletion policy %q; allowed: restrict, cascade, orphan",
+		"err.invalid_notes_policy": "unknown notes deletion policy %q; allowed: detach, delete",
+		"err.unauthorized":         "authentication required: the access token is missing, unknown or expired",
+		"err.invalid_credentials":  "invalid login or password",
+		"err.forbidden":            "your role does not permit this action",
+		"err.patch_type":           "unsupported patch type; expected application/merge-patch+json or application/json-patch+json",
+		"err.patch_malformed":      "the request body is not a patch of the given type",
+		"err.patch_unprocessable":  "the patch cannot be applied to the record",
+		"err.patch_test_failed":    "the patch test operation failed",
+		"err.invalid_update_mask":  "unknown field %q in updateMask",
+		"err.invalid_rule":         "invalid recurrence rule",
+
+		"ctx.task":             "task id=%d",
+		"ctx.note":             "note id=%d",
+		"ctx.task_list":        "task list",
+		"ctx.note_list":        "note list",
+		"ctx.task_create":      "creating task %q",
+		"ctx.note_create":      "creating note %q",
+		"ctx.task_update":      "updating task id=%d",
+		"ctx.note_update":      "updating note id=%d",
+		"ctx.task_delete":      "deleting task id=%d",
+		"ctx.note_delete":      "deleting note id=%d",
+		"ctx.task_transition":  "changing status of task id=%d",
+		"ctx.task_history":     "history of task id=%d",
+		"ctx.task_occurrences": "occurrences of task id=%d",
+		"ctx.note_occurrences": "occurrences of note id=%d",
+		"ctx.task_reminder":    "reminder of task id=%d",
+		"ctx.note_reminder":    "reminder of note id=%d",
+		"ctx.task_snoozes":     "snooze history of task id=%d",
+		"ctx.note_snoozes":     "snooze history of note id=%d",
+		"ctx.task_parent":      "parent of task id=%d",
+		"ctx.task_blockers":    "blockers of task id=%d",
+		"ctx.task_tree":        "dependency tree of task id=%d",
+		"ctx.task_notes":       "notes of task id=%d",
+		"ctx.note_task":        "attaching note id=%d",
+		"ctx.linked_task":      "task id=%d",
+		"ctx.register":         "registration of user %q",
+		"ctx.login":            "login of user %q",
+		"ctx.logout":           "logout",
+		"ctx.authenticate":     "access token check",
+		"ctx.user":             "user %q",
+		"ctx.share":            "sharing with user %q",
+		"ctx.unshare":          "revoking access of user %q",
+		"ctx.shares":           "access list",
+		"ctx.trash":            "trash",
+		"ctx.task_restore":     "restoring task id=%d",
+		"ctx.note_restore":     "restoring note id=%d",
+		"ctx.task_purge":       "permanently deleting task id=%d",
+		"ctx.note_purge":       "permanently deleting note id=%d",
+		"ctx.parent_task":      "parent task id=%d",
+		"ctx.blocker_task":     "blocking task id=%d",
+		"ctx.open_subtasks":    "open subtasks: %v",
+		"ctx.subtasks":         "subtasks: %v",
+		"ctx.cycle":            "cycle: %s",
+		"ctx.patch_op":         "operation %d (%s %q)",
+		"ctx.patch_result":     "patch result: %s",
+		"ctx.reminder_state":   "%s while the reminder is %q",
+		"ctx.log":              "change log",
+		"ctx.search":           "full-text search",
+		"ctx.status":           "status %q",
+		"ctx.task_status":      "task status %s",
+		"ctx.transition":       "%s -> %s, allowed: %s",
+		"ctx.rrule_part":       "part %q has no value",
+		"ctx.rrule_repeated":   "%s is given twice",
+		"ctx.rrule_freq":       "unsupported frequency %s",
+		"ctx.rrule_wkst":       "WKST=%s: only WKST=MO is supported",
+		"ctx.rrule_key":        "unsupported part %s",
+		"ctx.rrule_no_freq":    "FREQ is missing",
+		"ctx.rrule_count":      "COUNT and UNTIL are mutually exclusive",
+		"ctx.rrule_yearly":     "BYDAY is not supported with FREQ=YEARLY",
+		"ctx.rrule_positive":   "%s=%s: a positive integer is expected",
+		"ctx.rrule_until":      "UNTIL=%s: YYYYMMDD or YYYYMMDDTHHMMSSZ is expected",
+		"ctx.rrule_weekday":    "unknown weekday %q in BYDAY",
+
+		"validation.required":           "is required",
+		"validation.too_long":           "must be at most %d characters long",
+		"validation.invalid_date":       "cannot parse date %q; expected DD.MM.YYYY [HH:MM], YYYY-MM-DD, RFC 3339 or \"tomorrow 9:00\", \"in 3 days\", \"+2h\"",
+		"validation.in_past":            "due date must not be in the past",
+		"validation.before_created":     "alarm must fire after the note is created",
+		"validation.invalid_recurrence": "invalid recurrence rule %q; expected an RRULE such as FREQ=DAILY|WEEKLY|MONTHLY|YEARLY[;INTERVAL=n][;BYDAY=MO,WE][;COUNT=n|;UNTIL=YYYYMMDD]",
+		"validation.not_future":         "must be in the future",
+		"validation.unknown_priority":   "unknown priority %q; allowed: low, normal, high, urgent",
+		"validation.too_many_tags":      "must contain at most %d tags",
+		"validation.too_short":          "must be at least %d characters long",
+		"validation.invalid_login":      "may contain only latin letters, digits and . _ -",
+		"validation.unknown_role":       "unknown role %q; allowed: viewer, editor",
+		"validation.self_grant":         "you cannot share with yourself",
+
+		"status.created":     "Created",
+		"status.updated":     "Updated",
+		"status.seen":        "Seen",
+		"status.in_progress": "In progress",
+		"status.suspended":   "Suspended",
+		"status.submitted":   "Submitted for review",
+		"status.completed":   "Completed",
+		"status.cancelled":   "Cancelled",
+		"status.returned":    "Returned for rework",
+		"status.backlog":     "Backlog",
+		"status.none":        "none",
+
+		"reminder_state.pending":      "Pending",
+		"reminder_state.fired":        "Fired",
+		"reminder_state.snoozed":      "Snoozed",
+		"reminder_state.acknowledged": "Acknowledged",
+		"reminder_state.dismissed":    "Dismissed",
+
+		"priority.low":    "Low",
+		"priority.normal": "Normal",
+		"priority.high":   "High",
+		"priority.urgent": "Urgent",
+		"role.viewer":     "Viewer",
+		"role.editor":     "Editor",
+		"role.owner":      "Owner",
+
+		"task.text": "Task name: %v\nTask description: %v\nCreated on: %v\nDue date: %v\nStatus: %v\n",
+		"note.text": "Note name: %v\nNote description: %v\nAlarm time: %v\n",
+
+		"reminder.task": "Task %q (id=%d) is due %s: %s",
+		"reminder.note": "Reminder %q (id=%d) at %s: %s",
+	},
+}
@@ -0,0 +1,168 @@
+// Package i18n переводит сообщения приложения на русский и английский языки.
+//
+// Язык запроса определяется по заголовку Accept-Language (метаданным accept-language в gRPC)
+// и передаётся в контексте; без него используется язык по умолчанию из конфигурации.
+// Так же в контексте передаётся часовой пояс пользователя для разбора введённых дат
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Lang код языка по ISO 639-1
+type Lang string
+
+// Поддерживаемые языки
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+)
+
+// Default язык сообщений, для которых язык не задан, в том числе текста Error()
+const Default = RU
+
+// Supported возвращает поддерживаемые языки
+func Supported() []Lang {
+	return []Lang{RU, EN}
+}
+
+// ParseLang разбирает тег языка вида "en", "en-US" или "ru_RU"
+func ParseLang(tag string) (Lang, bool) {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	base, _, _ = strings.Cut(base, "_")
+	lang := Lang(base)
+	return lang, slices.Contains(Supported(), lang)
+}
+
+// Negotiate выбирает язык по значению заголовка Accept-Language с учётом весов q;
+// если ни один из языков не поддерживается, возвращается fallback
+func Negotiate(acceptLanguage string, fallback Lang) Lang {
+	best, bestQ := fallback, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if lang, ok := ParseLang(tag); ok && q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+type langKey struct{}
+
+// WithLang возвращает контекст с языком сообщений lang
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langKey{}, lang)
+}
+
+// LangFrom возвращает язык сообщений из контекста или Default
+func LangFrom(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langKey{}).(Lang); ok {
+		return lang
+	}
+	return Default
+}
+
+type locationKey struct{}
+
+// WithLocation возвращает контекст с часовым поясом пользователя loc
+func WithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, locationKey{}, loc)
+}
+
+// LocationFrom возвращает часовой пояс пользователя из контекста или UTC
+func LocationFrom(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(locationKey{}).(*time.Location); ok && loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// Localizer значение, которое умеет представить себя на нужном языке
+type Localizer interface {
+	Localize(lang Lang) string
+}
+
+// T возвращает сообщение key на языке lang, подставляя аргументы args по правилам fmt.
+// Аргументы, реализующие Localizer, переводятся на тот же язык.
+// Отсутствующее в каталоге сообщение берётся из каталога языка Default, а затем заменяется ключом
+func T(lang Lang, key string, args ...any) string {
+	format, ok := catalogs[lang][key]
+	if !ok {
+		if format, ok = catalogs[Default][key]; !ok {
+			format = key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	localized := make([]any, len(args))
+	for i, arg := range args {
+		if l, ok := arg.(Localizer); ok {
+			arg = l.Localize(lang)
+		}
+		localized[i] = arg
+	}
+	return fmt.Sprintf(format, localized...)
+}
+
+// Error ошибка с переводимым текстом; может оборачивать ошибку-причину
+type Error struct {
+	key  string
+	args []any
+	err  error
+}
+
+// New создаёт ошибку с сообщением key из каталога
+func New(key string, args ...any) *Error {
+	return &Error{key: key, args: args}
+}
+
+// Wrap дополняет ошибку err контекстом key, например "задача с id=7: запись не найдена".
+// errors.Is и errors.As видят err через Unwrap
+func Wrap(err error, key string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{key: key, args: args, err: err}
+}
+
+// Error возвращает текст ошибки на языке Default
+func (e *Error) Error() string {
+	return e.Localize(Default)
+}
+
+// Unwrap возвращает ошибку-причину
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Localize возвращает текст ошибки на языке lang
+func (e *Error) Localize(lang Lang) string {
+	msg := T(lang, e.key, e.args...)
+	if e.err != nil {
+		msg += ": " + Localize(lang, e.err)
+	}
+	return msg
+}
+
+// Localize возвращает текст ошибки err на языке lang;
+// ошибки, не реализующие Localizer, возвращаются без перевода
+func Localize(lang Lang, err error) string {
+	if l, ok := err.(Localizer); ok {
+		return l.Localize(lang)
+	}
+	return err.Error()
+}
@@ -0,0 +1,7 @@
+package model
+
+// Форматы, в которых даты выводятся пользователю
+const (
+	DueDateLayout   = "02.01.2006"
+	AlarmTimeLayout = "02.01.2006 15:04"
+)
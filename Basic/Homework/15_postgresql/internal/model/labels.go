@@ -0,0 +1,99 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+)
+
+// Priority код приоритета задачи или заметки. Коды хранятся в БД и передаются в API без перевода
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+// Priorities допустимые приоритеты от низкого к высокому
+var Priorities = []Priority{PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent}
+
+// Ограничения набора меток
+const (
+	MaxTags      = 20
+	MaxTagLength = 50
+)
+
+// Labels приоритет и метки задачи или заметки
+type Labels struct {
+	Priority Priority `json:"priority"`
+	Tags     []string `json:"tags"`
+}
+
+// Known проверяет, входит ли priority в перечень приоритетов
+func (priority Priority) Known() bool {
+	return slices.Contains(Priorities, priority)
+}
+
+// Localize реализует i18n.Localizer: подпись приоритета на языке lang
+func (priority Priority) Localize(lang i18n.Lang) string {
+	return i18n.T(lang, "priority."+string(MigratePriority(priority)))
+}
+
+// MigratePriority приводит пустой приоритет записей, созданных до появления приоритетов,
+// к PriorityNormal
+func MigratePriority(priority Priority) Priority {
+	if priority == "" {
+		return PriorityNormal
+	}
+	return priority
+}
+
+// MigrateLabels заполняет приоритет и метки записей, созданных до их появления
+func MigrateLabels(labels Labels) Labels {
+	labels.Priority = MigratePriority(labels.Priority)
+	if labels.Tags == nil {
+		labels.Tags = make([]string, 0)
+	}
+	return labels
+}
+
+// NormalizeTags приводит метки к нижнему регистру без пробелов по краям, удаляет пустые
+// и повторяющиеся и упорядочивает их; для пустого набора возвращает пустой срез
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// labels проверяет приоритет и метки и возвращает их в нормализованном виде;
+// пустой приоритет означает PriorityNormal. Прежние приоритет и метки изменяемой
+// записи (before != nil) повторно не проверяются
+func (v *validator) labels(labels Labels, before *Labels) Labels {
+	priority := MigratePriority(labels.Priority)
+	if !priority.Known() && (before == nil || priority != MigratePriority(before.Priority)) {
+		v.add("priority", RuleUnknownPriority, string(labels.Priority))
+	}
+	tags := NormalizeTags(labels.Tags)
+	if before != nil && slices.Equal(tags, NormalizeTags(before.Tags)) {
+		return Labels{Priority: priority, Tags: tags}
+	}
+	if len(tags) > MaxTags {
+		v.add("tags", RuleTooManyTags, MaxTags)
+	}
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			v.add("tags", RuleTooLong, MaxTagLength)
+			break
+		}
+	}
+	return Labels{Priority: priority, Tags: tags}
+}
@@ -1,69 +1,96 @@
 package model
 
 import (
-	"database/sql"
-	"fmt"
-	"log"
 	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
 )
 
 type Note struct {
-	Id             int       `json:"id"`
-	Name           string    `json:"name"`
-	Description    string    `json:"description"`
-	AlarmTimeStamp time.Time `json:"alarmTimeStamp"` // Сигнал напоминания в эту дату-время
+	Id             int           `json:"id"`
+	Name           string        `json:"name"`
+	Description    string        `json:"description"`
+	AlarmTimeStamp time.Time     `json:"alarmTimeStamp"`        // Сигнал напоминания в эту дату-время
+	InitTimeStamp  time.Time     `json:"initTimeStamp"`         // Дата создания заметки
+	Recurrence     string        `json:"recurrence,omitempty"`  // Правило повторения RRULE
+	Timezone       string        `json:"timezone,omitempty"`    // Часовой пояс, в котором вычисляются повторения
+	ReminderState  ReminderState `json:"reminderState"`         // Состояние напоминания
+	SnoozedFrom    *time.Time    `json:"snoozedFrom,omitempty"` // Время напоминания до откладывания
+	Labels                       // Приоритет и метки
+	TaskId         *int          `json:"taskId,omitempty"` // Задача, к которой прикреплена заметка
+	OwnerId        int           `json:"ownerId"`          // Пользователь-владелец
+	UpdatedAt      *time.Time    `json:"updatedAt,omitempty"`
+	DeletedAt      *time.Time    `json:"deletedAt,omitempty"` // Время перемещения в корзину
+	Version        int           `json:"version"`             // Версия, увеличивается при каждом изменении
 }
 
-// NewNote генерирует и возвращает новую заметку
-func NewNote(rows_count sql.NullInt64, name, descr, alarmDateTime string) (Note, error) {
-	userDueDate, err := time.Parse("02.01.2006 15:04", alarmDateTime)
-	if err != nil {
-		log.Println("Введенные дата и время напоминания имеют не корректный формат.")
-		if rows_count.Valid {
-			return Note{
-				Id:          int(rows_count.Int64 + 1),
-				Name:        name,
-				Description: descr,
-			}, nil
-		} else {
-			return Note{
-				Id:          1,
-				Name:        name,
-				Description: descr,
-			}, nil
-		}
-
-	}
-	if rows_count.Valid {
-		return Note{
-			Id:             int(rows_count.Int64 + 1),
-			Name:           name,
-			Description:    descr,
-			AlarmTimeStamp: userDueDate,
-		}, nil
+// NewNote генерирует и возвращает новую заметку; alarmDateTime разбирается в часовом поясе loc,
+// в нём же вычисляются повторения по необязательному правилу recurrence.
+// Некорректные поля возвращаются одной ошибкой *ValidationError.
+// Id и дата создания заметки назначаются БД при сохранении
+func NewNote(name, descr, alarmDateTime, recurrence string, labels Labels, loc *time.Location) (Note, error) {
+	var v validator
+	alarm := v.note(name, descr, alarmDateTime, nil, time.Now(), loc)
+	rule := v.rule(recurrence, "")
+	labels = v.labels(labels, nil)
+	if err := v.err(); err != nil {
+		return Note{}, err
 	}
 	return Note{
-		Id:             1,
 		Name:           name,
 		Description:    descr,
-		AlarmTimeStamp: userDueDate,
+		AlarmTimeStamp: alarm,
+		Recurrence:     rule,
+		Timezone:       timezone(rule, loc),
+		ReminderState:  ReminderPending,
+		Labels:         labels,
 	}, nil
 }
 
-// String реализует repository.Remindable
+// Change проверяет и применяет к заметке новые имя, описание, время напоминания
+// (разбирается в часовом поясе loc), правило повторения, приоритет и метки; проверяются
+// только изменённые поля. Напоминание на новое время снова ожидает срабатывания.
+// При ошибке заметка не изменяется
+func (myNote *Note) Change(name, descr, alarmDateTime, recurrence string, labels Labels, loc *time.Location) error {
+	var v validator
+	alarm := v.note(name, descr, alarmDateTime, myNote, time.Now(), loc)
+	rule := v.rule(recurrence, myNote.Recurrence)
+	labels = v.labels(labels, &myNote.Labels)
+	if err := v.err(); err != nil {
+		return err
+	}
+	if !alarm.Equal(myNote.AlarmTimeStamp) {
+		myNote.ReminderState = ReminderPending
+		myNote.SnoozedFrom = nil
+	}
+	myNote.Name = name
+	myNote.Description = descr
+	myNote.AlarmTimeStamp = alarm
+	myNote.Recurrence = rule
+	myNote.Timezone = timezone(rule, loc)
+	myNote.Labels = labels
+	return nil
+}
+
+// String реализует repository.Remindable; текст на языке i18n.Default
 func (myNote Note) String() string {
-	return fmt.Sprintf(
-		"Имя заметки: %v\nОписание заметки: %v\nДата и время напоминания: %v\n",
-		myNote.Name, myNote.Description, myNote.AlarmTimeStamp.Format("02.01.2006 15:04"),
+	return myNote.Localize(i18n.Default)
+}
+
+// Localize возвращает текстовое представление заметки на языке lang
+func (myNote Note) Localize(lang i18n.Lang) string {
+	return i18n.T(lang, "note.text",
+		myNote.Name, myNote.Description, myNote.AlarmTimeStamp.Format(AlarmTimeLayout),
 	)
 }
 
 // ChangeAlarm реализует repository.Remindable
-func (myNote *Note) ChangeAlarm(new_date_time string) {
-	userDateTime, err := time.Parse("02.01.2006 15:04", new_date_time)
-	if err != nil {
-		fmt.Println("Введенные дата и время напоминания имеют не корректный формат.\nВведите требуемые значения даты и времени в соответствии с форматом: ДД-ММ-ГГГГ ЧЧ:ММ.")
-	} else {
-		myNote.AlarmTimeStamp = userDateTime
-	}
+func (myNote *Note) ChangeAlarm(newDateTime string, loc *time.Location) error {
+	return myNote.Change(myNote.Name, myNote.Description, newDateTime, myNote.Recurrence, myNote.Labels, loc)
+}
+
+// AttachTo прикрепляет заметку к задаче taskId; nil открепляет заметку.
+// Существование задачи проверяет хранилище
+func (myNote *Note) AttachTo(taskId *int) {
+	myNote.TaskId = taskId
 }
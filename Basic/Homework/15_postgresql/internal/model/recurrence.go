@@ -0,0 +1,131 @@
+package model
+
+import (
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/recurrence"
+)
+
+// Параметры выборки повторений по умолчанию
+const (
+	DefaultOccurrencesPeriod = 90 * 24 * time.Hour
+	DefaultOccurrencesLimit  = 100
+	MaxOccurrencesLimit      = 1000
+)
+
+// ErrInvalidPeriod конец интервала выборки повторений не позже его начала
+var ErrInvalidPeriod = i18n.New("err.invalid_period")
+
+// OccurrencesWindow дополняет параметры выборки повторений значениями по умолчанию:
+// без from - от now, без to - DefaultOccurrencesPeriod от from, без limit - DefaultOccurrencesLimit.
+// limit больше MaxOccurrencesLimit уменьшается до него
+func OccurrencesWindow(from, to time.Time, limit int, now time.Time) (time.Time, time.Time, int, error) {
+	if from.IsZero() {
+		from = now
+	}
+	if to.IsZero() {
+		to = from.Add(DefaultOccurrencesPeriod)
+	}
+	if !to.After(from) {
+		return from, to, limit, ErrInvalidPeriod
+	}
+	if limit <= 0 {
+		limit = DefaultOccurrencesLimit
+	}
+	return from, to, min(limit, MaxOccurrencesLimit), nil
+}
+
+// timezone возвращает часовой пояс, который сохраняется вместе с правилом повторения rule;
+// у неповторяющихся задач и заметок пояс не хранится
+func timezone(rule string, loc *time.Location) string {
+	if rule == "" {
+		return ""
+	}
+	if loc == nil {
+		return time.UTC.String()
+	}
+	return loc.String()
+}
+
+// location возвращает часовой пояс по имени; неизвестное или пустое имя означает UTC
+func location(name string) *time.Location {
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// Zone возвращает часовой пояс name, сохранённый вместе с правилом повторения;
+// у неповторяющихся задач и заметок пояс не хранится, и возвращается fallback.
+// Частичные изменения передают его в Change, чтобы не переносить серию в пояс запроса
+func Zone(name string, fallback *time.Location) *time.Location {
+	if name == "" {
+		return fallback
+	}
+	return location(name)
+}
+
+// advance вычисляет повторение, следующее за current, и правило для оставшейся части серии
+func advance(rule, tz string, current time.Time) (time.Time, string, bool) {
+	if rule == "" {
+		return time.Time{}, "", false
+	}
+	parsed, err := recurrence.Parse(rule)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	next, rest, ok := parsed.Advance(current, location(tz))
+	if !ok {
+		return time.Time{}, "", false
+	}
+	return next.UTC(), rest.String(), true
+}
+
+// occurrences возвращает не больше limit повторений серии, начинающейся в start, в интервале
+// [from, to); неповторяющаяся задача или заметка даёт единственную дату start
+func occurrences(rule, tz string, start, from, to time.Time, limit int) []time.Time {
+	list := make([]time.Time, 0)
+	if rule == "" {
+		if limit > 0 && !start.Before(from) && start.Before(to) {
+			list = append(list, start)
+		}
+		return list
+	}
+	parsed, err := recurrence.Parse(rule)
+	if err != nil {
+		return list
+	}
+	for _, t := range parsed.Between(start, from, to, location(tz), limit) {
+		list = append(list, t.UTC())
+	}
+	return list
+}
+
+// Occurrences возвращает не больше limit сроков исполнения задачи в интервале [from, to)
+func (myTask Task) Occurrences(from, to time.Time, limit int) []time.Time {
+	return occurrences(myTask.Recurrence, myTask.Timezone, myTask.DueDate, from, to, limit)
+}
+
+// Occurrences возвращает не больше limit срабатываний напоминания заметки в интервале [from, to)
+func (myNote Note) Occurrences(from, to time.Time, limit int) []time.Time {
+	return occurrences(myNote.Recurrence, myNote.Timezone, myNote.AlarmTimeStamp, from, to, limit)
+}
+
+// Advance переносит напоминание повторяющейся заметки на ближайшее повторение после now,
+// отсчитывая серию от времени напоминания до откладывания; напоминание снова ожидает срабатывания.
+// Возвращает false, если заметка не повторяется или в серии не осталось будущих повторений
+func (myNote *Note) Advance(now time.Time) bool {
+	next, rest, ok := advance(myNote.Recurrence, myNote.Timezone, myNote.alarmBase())
+	for ok && !next.After(now) {
+		next, rest, ok = advance(rest, myNote.Timezone, next)
+	}
+	if !ok {
+		return false
+	}
+	myNote.AlarmTimeStamp = next
+	myNote.Recurrence = rest
+	myNote.ReminderState = ReminderPending
+	myNote.SnoozedFrom = nil
+	return true
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+)
+
+var (
+	// ErrDependencyCycle связь задач образует цикл подзадач или блокировок
+	ErrDependencyCycle = i18n.New("err.dependency_cycle")
+	// ErrOpenSubtasks задачу нельзя завершить, пока открыты её подзадачи
+	ErrOpenSubtasks = i18n.New("err.open_subtasks")
+)
+
+// TaskNode узел дерева зависимостей: задача, её подзадачи и задачи, которыми она заблокирована
+type TaskNode struct {
+	Task      Task       `json:"task"`
+	Subtasks  []TaskNode `json:"subtasks"`
+	BlockedBy []TaskNode `json:"blockedBy"`
+}
+
+// DependencyCycle возвращает ErrDependencyCycle с путём по Id задач, замыкающим цикл
+func DependencyCycle(path ...int) error {
+	ids := make([]string, len(path))
+	for i, id := range path {
+		ids[i] = strconv.Itoa(id)
+	}
+	return i18n.Wrap(ErrDependencyCycle, "ctx.cycle", strings.Join(ids, " -> "))
+}
+
+// MigrateBlockedBy возвращает пустой список блокировок для записей, созданных до появления зависимостей
+func MigrateBlockedBy(ids []int) []int {
+	if ids == nil {
+		return make([]int, 0)
+	}
+	return ids
+}
+
+// SetParent делает задачу подзадачей задачи parentId; nil отвязывает задачу от родителя.
+// Существование родителя и отсутствие циклов проверяет хранилище
+func (myTask *Task) SetParent(parentId *int) error {
+	if parentId != nil && *parentId == myTask.Id {
+		return DependencyCycle(myTask.Id, myTask.Id)
+	}
+	myTask.ParentId = parentId
+	return nil
+}
+
+// Block отмечает задачу заблокированной задачей id; повторная блокировка ничего не меняет.
+// Существование блокирующей задачи и отсутствие циклов проверяет хранилище
+func (myTask *Task) Block(id int) error {
+	if id == myTask.Id {
+		return DependencyCycle(myTask.Id, myTask.Id)
+	}
+	if !slices.Contains(myTask.BlockedBy, id) {
+		myTask.BlockedBy = append(slices.Clone(myTask.BlockedBy), id)
+		slices.Sort(myTask.BlockedBy)
+	}
+	return nil
+}
+
+// Unblock снимает блокировку задачей id; отсутствие блокировки ошибкой не считается
+func (myTask *Task) Unblock(id int) {
+	myTask.BlockedBy = slices.DeleteFunc(slices.Clone(MigrateBlockedBy(myTask.BlockedBy)), func(blocker int) bool {
+		return blocker == id
+	})
+}
+
+// Completes сообщает, что смена статуса from -> to завершает задачу,
+// в том числе повторяющуюся, которая сразу переносится на следующий срок
+func Completes(from, to Status) bool {
+	return slices.ContainsFunc(StatusSteps(from, to), func(step StatusChange) bool {
+		return step.To == Completed
+	})
+}
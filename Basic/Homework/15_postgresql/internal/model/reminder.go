@@ -0,0 +1,220 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+)
+
+// ReminderState состояние напоминания задачи или заметки. Коды хранятся в БД
+// и передаются в API без перевода
+type ReminderState string
+
+const (
+	// ReminderPending напоминание ожидает срабатывания
+	ReminderPending ReminderState = "pending"
+	// ReminderFired напоминание сработало и ждёт реакции пользователя
+	ReminderFired ReminderState = "fired"
+	// ReminderSnoozed напоминание отложено и сработает снова
+	ReminderSnoozed ReminderState = "snoozed"
+	// ReminderAcknowledged пользователь подтвердил сработавшее напоминание
+	ReminderAcknowledged ReminderState = "acknowledged"
+	// ReminderDismissed пользователь отклонил напоминание
+	ReminderDismissed ReminderState = "dismissed"
+)
+
+// Действия с напоминанием, для текста ошибки ErrReminderState
+const (
+	ReminderActionSnooze      = "snooze"
+	ReminderActionAcknowledge = "acknowledge"
+	ReminderActionDismiss     = "dismiss"
+)
+
+// ErrReminderState действие недоступно в текущем состоянии напоминания
+var ErrReminderState = i18n.New("err.reminder_state")
+
+// Snooze запись истории откладывания напоминания: сработавшее в From напоминание
+// отложено до Until
+type Snooze struct {
+	From      time.Time `json:"from"`
+	Until     time.Time `json:"until"`
+	Actor     string    `json:"actor"`
+	SnoozedAt time.Time `json:"snoozedAt"`
+}
+
+// MigrateReminderState приводит пустое состояние записей, созданных до появления
+// состояний напоминаний, к ReminderPending
+func MigrateReminderState(state ReminderState) ReminderState {
+	if state == "" {
+		return ReminderPending
+	}
+	return state
+}
+
+// Waiting сообщает, что напоминание ещё должно сработать
+func (state ReminderState) Waiting() bool {
+	return state == ReminderPending || state == ReminderSnoozed || state == ""
+}
+
+// Localize реализует i18n.Localizer: подпись состояния на языке lang
+func (state ReminderState) Localize(lang i18n.Lang) string {
+	return i18n.T(lang, "reminder_state."+string(MigrateReminderState(state)))
+}
+
+// allow возвращает ErrReminderState, если действие action недоступно в состоянии state
+func (state ReminderState) allow(action string, allowed ...ReminderState) error {
+	state = MigrateReminderState(state)
+	if !slices.Contains(allowed, state) {
+		return i18n.Wrap(ErrReminderState, "ctx.reminder_state", action, state)
+	}
+	return nil
+}
+
+// snoozeUntil проверяет время, до которого откладывается напоминание
+func snoozeUntil(until, now time.Time) error {
+	var v validator
+	if !until.After(now) {
+		v.add("until", RuleNotFuture)
+	}
+	return v.err()
+}
+
+// snoozedAt приводит время, до которого отложено напоминание, к виду разобранных
+// дат: UTC с точностью до секунды
+func snoozedAt(until time.Time) time.Time {
+	return until.UTC().Truncate(time.Second)
+}
+
+// snoozeStep возвращает запись истории, если изменение перевело напоминание
+// в состояние ReminderSnoozed с новым временем срабатывания
+func snoozeStep(beforeAt time.Time, after ReminderState, afterAt time.Time) (Snooze, bool) {
+	if after != ReminderSnoozed || afterAt.Equal(beforeAt) {
+		return Snooze{}, false
+	}
+	return Snooze{From: beforeAt, Until: afterAt}, true
+}
+
+// TaskSnooze возвращает запись истории откладывания, если изменение before -> after
+// отложило напоминание о сроке задачи
+func TaskSnooze(before, after Task) (Snooze, bool) {
+	return snoozeStep(before.DueDate, after.ReminderState, after.DueDate)
+}
+
+// NoteSnooze возвращает запись истории откладывания, если изменение before -> after
+// отложило напоминание заметки
+func NoteSnooze(before, after Note) (Snooze, bool) {
+	return snoozeStep(before.AlarmTimeStamp, after.ReminderState, after.AlarmTimeStamp)
+}
+
+// Fire отмечает напоминание о сроке задачи, назначенное на at, сработавшим.
+// Возвращает false, если срок изменился или напоминание уже не ожидает срабатывания
+func (myTask *Task) Fire(at time.Time) bool {
+	if !myTask.DueDate.Equal(at) || !myTask.ReminderState.Waiting() {
+		return false
+	}
+	myTask.ReminderState = ReminderFired
+	return true
+}
+
+// Snooze откладывает сработавшее напоминание о сроке задачи до until, перенося срок.
+// Статус и остальные поля задачи не меняются. Исходный срок сохраняется в SnoozedFrom:
+// от него отсчитывается следующее повторение повторяющейся задачи
+func (myTask *Task) Snooze(until time.Time) error {
+	if myTask.Closed() {
+		return i18n.Wrap(ErrTaskClosed, "ctx.task_status", myTask.Status)
+	}
+	if err := myTask.ReminderState.allow(ReminderActionSnooze, ReminderFired, ReminderSnoozed); err != nil {
+		return err
+	}
+	if err := snoozeUntil(until, time.Now()); err != nil {
+		return err
+	}
+	from := myTask.alarmBase()
+	myTask.DueDate = snoozedAt(until)
+	myTask.ReminderState = ReminderSnoozed
+	myTask.SnoozedFrom = &from
+	return nil
+}
+
+// Acknowledge подтверждает сработавшее напоминание о сроке задачи
+func (myTask *Task) Acknowledge() error {
+	if err := myTask.ReminderState.allow(ReminderActionAcknowledge, ReminderFired, ReminderSnoozed); err != nil {
+		return err
+	}
+	myTask.ReminderState = ReminderAcknowledged
+	return nil
+}
+
+// Dismiss отклоняет напоминание о сроке задачи, в том числе ещё не сработавшее
+func (myTask *Task) Dismiss() error {
+	if err := myTask.ReminderState.allow(ReminderActionDismiss, ReminderPending, ReminderFired, ReminderSnoozed); err != nil {
+		return err
+	}
+	myTask.ReminderState = ReminderDismissed
+	return nil
+}
+
+// alarmBase возвращает срок задачи до откладывания напоминания
+func (myTask Task) alarmBase() time.Time {
+	if myTask.SnoozedFrom != nil {
+		return *myTask.SnoozedFrom
+	}
+	return myTask.DueDate
+}
+
+// Fire отмечает напоминание заметки, назначенное на at, сработавшим.
+// Возвращает false, если время напоминания изменилось или оно уже не ожидает срабатывания
+func (myNote *Note) Fire(at time.Time) bool {
+	if !myNote.AlarmTimeStamp.Equal(at) || !myNote.ReminderState.Waiting() {
+		return false
+	}
+	myNote.ReminderState = ReminderFired
+	return true
+}
+
+// Snooze откладывает сработавшее напоминание заметки до until. Остальные поля заметки
+// не меняются. Исходное время сохраняется в SnoozedFrom: от него отсчитывается следующее повторение
+func (myNote *Note) Snooze(until time.Time) error {
+	if err := myNote.ReminderState.allow(ReminderActionSnooze, ReminderFired, ReminderSnoozed); err != nil {
+		return err
+	}
+	if err := snoozeUntil(until, time.Now()); err != nil {
+		return err
+	}
+	from := myNote.alarmBase()
+	myNote.AlarmTimeStamp = snoozedAt(until)
+	myNote.ReminderState = ReminderSnoozed
+	myNote.SnoozedFrom = &from
+	return nil
+}
+
+// Acknowledge подтверждает сработавшее напоминание заметки. Напоминание повторяющейся
+// заметки переносится на ближайшее будущее повторение
+func (myNote *Note) Acknowledge() error {
+	if err := myNote.ReminderState.allow(ReminderActionAcknowledge, ReminderFired, ReminderSnoozed); err != nil {
+		return err
+	}
+	myNote.ReminderState = ReminderAcknowledged
+	myNote.Advance(time.Now())
+	return nil
+}
+
+// Dismiss отклоняет напоминание заметки, в том числе ещё не сработавшее. Напоминание
+// повторяющейся заметки переносится на ближайшее будущее повторение
+func (myNote *Note) Dismiss() error {
+	if err := myNote.ReminderState.allow(ReminderActionDismiss, ReminderPending, ReminderFired, ReminderSnoozed); err != nil {
+		return err
+	}
+	myNote.ReminderState = ReminderDismissed
+	myNote.Advance(time.Now())
+	return nil
+}
+
+// alarmBase возвращает время напоминания заметки до откладывания
+func (myNote Note) alarmBase() time.Time {
+	if myNote.SnoozedFrom != nil {
+		return *myNote.SnoozedFrom
+	}
+	return myNote.AlarmTimeStamp
+}
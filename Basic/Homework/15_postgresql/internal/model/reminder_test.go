@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskSnooze(t *testing.T) {
+	due := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	until := time.Now().Add(time.Hour)
+	tests := []struct {
+		name   string
+		status Status
+		state  ReminderState
+		until  time.Time
+		want   time.Time
+		err    error
+	}{
+		{name: "created task stays created", status: Created, state: ReminderFired, until: until, want: until.UTC().Truncate(time.Second)},
+		{name: "seen task stays seen", status: Seen, state: ReminderSnoozed, until: until, want: until.UTC().Truncate(time.Second)},
+		{name: "reminder has not fired", status: Created, state: ReminderPending, until: until, want: due, err: ErrReminderState},
+		{name: "time in the past", status: Created, state: ReminderFired, until: time.Now().Add(-time.Hour), want: due, err: ErrValidation},
+		{name: "closed task", status: Completed, state: ReminderFired, until: until, want: due, err: ErrTaskClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// пустое описание не мешает отложить напоминание: поля задачи не проверяются заново
+			task := Task{Name: "task", DueDate: due, Status: tt.status, ReminderState: tt.state}
+			err := task.Snooze(tt.until)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.status, task.Status)
+			assert.Equal(t, tt.want, task.DueDate)
+			if tt.err == nil {
+				assert.Equal(t, ReminderSnoozed, task.ReminderState)
+				assert.Equal(t, &due, task.SnoozedFrom)
+			}
+		})
+	}
+}
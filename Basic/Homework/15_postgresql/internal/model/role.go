@@ -0,0 +1,100 @@
+package model
+
+import (
+	"slices"
+	"unicode/utf8"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+)
+
+// Role роль пользователя по отношению к задаче, заметке или списку.
+// Коды хранятся в БД и передаются в API без перевода
+type Role string
+
+const (
+	// RoleViewer просмотр
+	RoleViewer Role = "viewer"
+	// RoleEditor просмотр и изменение
+	RoleEditor Role = "editor"
+	// RoleOwner владелец: также удаление и управление доступом; другим пользователям не выдаётся
+	RoleOwner Role = "owner"
+)
+
+// Roles роли, которые владелец может выдать другим пользователям, от младшей к старшей
+var Roles = []Role{RoleViewer, RoleEditor}
+
+// roleRanks старшинство ролей; отсутствие роли имеет нулевой ранг
+var roleRanks = map[Role]int{RoleViewer: 1, RoleEditor: 2, RoleOwner: 3}
+
+// Known проверяет, можно ли выдать роль role другому пользователю
+func (role Role) Known() bool {
+	return slices.Contains(Roles, role)
+}
+
+// Allows проверяет, разрешает ли роль role действия, требующие роли need
+func (role Role) Allows(need Role) bool {
+	return roleRanks[role] > 0 && roleRanks[role] >= roleRanks[need]
+}
+
+// Localize реализует i18n.Localizer: подпись роли на языке lang
+func (role Role) Localize(lang i18n.Lang) string {
+	return i18n.T(lang, "role."+string(role))
+}
+
+// MaxRole возвращает старшую из ролей a и b
+func MaxRole(a, b Role) Role {
+	if roleRanks[b] > roleRanks[a] {
+		return b
+	}
+	return a
+}
+
+// Grant выдача доступа пользователю с логином Login в роли Role
+type Grant struct {
+	Login string `json:"login" example:"bob"`
+	Role  Role   `json:"role" example:"editor"`
+}
+
+// NewGrant проверяет выдачу доступа и возвращает её с нормализованным логином.
+// Некорректные поля возвращаются одной ошибкой *ValidationError
+func NewGrant(login string, role Role) (Grant, error) {
+	var v validator
+	login = NormalizeLogin(login)
+	if login == "" {
+		v.add("login", RuleRequired)
+	}
+	switch {
+	case role == "":
+		v.add("role", RuleRequired)
+	case !role.Known():
+		v.add("role", RuleUnknownRole, string(role))
+	}
+	if err := v.err(); err != nil {
+		return Grant{}, err
+	}
+	return Grant{Login: login, Role: role}, nil
+}
+
+// SelfGrant возвращает ошибку *ValidationError о выдаче доступа самому себе
+func SelfGrant() error {
+	var v validator
+	v.add("login", RuleSelfGrant)
+	return v.err()
+}
+
+// NormalizeList проверяет имя списка - метку, которой отмечены его задачи и заметки,
+// и возвращает его в нормализованном виде
+func NormalizeList(list string) (string, error) {
+	var v validator
+	tags := NormalizeTags([]string{list})
+	switch {
+	case len(tags) == 0:
+		v.add("list", RuleRequired)
+	case utf8.RuneCountInString(tags[0]) > MaxTagLength:
+		v.add("list", RuleTooLong, MaxTagLength)
+	}
+	if err := v.err(); err != nil {
+		return "", err
+	}
+	return tags[0], nil
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+)
+
+var (
+	// ErrUnknownStatus статус задачи не входит в перечень допустимых
+	ErrUnknownStatus = i18n.New("err.unknown_status")
+	// ErrInvalidTransition переход из текущего статуса задачи в запрошенный не предусмотрен
+	ErrInvalidTransition = i18n.New("err.invalid_transition")
+	// ErrTaskClosed задача завершена или отменена и больше не изменяется
+	ErrTaskClosed = i18n.New("err.task_closed")
+)
+
+// transitions допустимые переходы между статусами задачи.
+// Завершённая и отменённая задачи переходов не имеют
+var transitions = map[Status]Statuses{
+	Created:   {Seen, InProcess, Backlog, Cancelled},
+	Updated:   {Seen, InProcess, Backlog, Cancelled},
+	Seen:      {InProcess, Backlog, Cancelled},
+	Backlog:   {InProcess, Cancelled},
+	InProcess: {Suspended, Submitted, Cancelled},
+	Suspended: {InProcess, Cancelled},
+	Submitted: {Completed, Returned},
+	Returned:  {InProcess, Cancelled},
+	Completed: nil,
+	Cancelled: nil,
+}
+
+// StatusChange запись истории статусов задачи
+type StatusChange struct {
+	From      Status    `json:"from"`
+	To        Status    `json:"to"`
+	Actor     string    `json:"actor"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+// Statuses перечень статусов задачи
+type Statuses []Status
+
+// Localize реализует i18n.Localizer: подписи статусов через запятую
+func (list Statuses) Localize(lang i18n.Lang) string {
+	if len(list) == 0 {
+		return i18n.T(lang, "status.none")
+	}
+	labels := make([]string, len(list))
+	for i, status := range list {
+		labels[i] = status.Localize(lang)
+	}
+	return strings.Join(labels, ", ")
+}
+
+// Known проверяет, входит ли status в перечень статусов задачи
+func (status Status) Known() bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// Localize реализует i18n.Localizer: подпись статуса на языке lang
+func (status Status) Localize(lang i18n.Lang) string {
+	if !status.Known() {
+		return string(status)
+	}
+	return i18n.T(lang, "status."+string(status))
+}
+
+// Next возвращает статусы, в которые задачу можно перевести из статуса status
+func (status Status) Next() Statuses {
+	return slices.Clone(transitions[status])
+}
+
+// ParseStatus разбирает код статуса или его подпись на одном из поддерживаемых языков
+func ParseStatus(value string) (Status, error) {
+	value = strings.TrimSpace(value)
+	if status := Status(value); status.Known() {
+		return status, nil
+	}
+	for status := range transitions {
+		for _, lang := range i18n.Supported() {
+			if strings.EqualFold(value, status.Localize(lang)) {
+				return status, nil
+			}
+		}
+	}
+	return "", i18n.Wrap(ErrUnknownStatus, "ctx.status", value)
+}
+
+// Closed сообщает, что задача завершена или отменена
+func (myTask Task) Closed() bool {
+	return myTask.Status.Known() && len(transitions[myTask.Status]) == 0
+}
+
+// Transition переводит задачу в статус to, если такой переход предусмотрен.
+// Завершённая повторяющаяся задача переносится на следующий срок (отсчитанный от срока до
+// откладывания напоминания) и снова получает статус Created
+func (myTask *Task) Transition(to Status) error {
+	if !to.Known() {
+		return i18n.Wrap(ErrUnknownStatus, "ctx.status", string(to))
+	}
+	if myTask.Closed() {
+		return i18n.Wrap(ErrTaskClosed, "ctx.task_status", myTask.Status)
+	}
+	if !slices.Contains(transitions[myTask.Status], to) {
+		return i18n.Wrap(ErrInvalidTransition, "ctx.transition", myTask.Status, to, myTask.Status.Next())
+	}
+	myTask.Status = to
+	if to == Completed {
+		if next, rest, ok := advance(myTask.Recurrence, myTask.Timezone, myTask.alarmBase()); ok {
+			myTask.DueDate = next
+			myTask.Recurrence = rest
+			myTask.Status = Created
+			myTask.ReminderState = ReminderPending
+			myTask.SnoozedFrom = nil
+		}
+	}
+	return nil
+}
+
+// StatusSteps раскладывает смену статуса from -> to на записи истории.
+// Переход в Created возможен только при завершении повторяющейся задачи, поэтому
+// он записывается двумя шагами: from -> Completed и Completed -> Created
+func StatusSteps(from, to Status) []StatusChange {
+	switch {
+	case from == to:
+		return nil
+	case from != "" && to == Created && from != Completed:
+		return []StatusChange{{From: from, To: Completed}, {From: Completed, To: Created}}
+	}
+	return []StatusChange{{From: from, To: to}}
+}
+
+// Edit проверяет, что задачу можно редактировать, и отмечает её изменённой,
+// если работа над ней ещё не началась
+func (myTask *Task) Edit() error {
+	if myTask.Closed() {
+		return i18n.Wrap(ErrTaskClosed, "ctx.task_status", myTask.Status)
+	}
+	if myTask.Status == Created || myTask.Status == Seen {
+		myTask.Status = Updated
+	}
+	return nil
+}
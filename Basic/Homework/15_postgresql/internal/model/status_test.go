@@ -0,0 +1,145 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		name string
+		from Status
+		to   Status
+		want Status
+		err  error
+	}{
+		{name: "created to in progress", from: Created, to: InProcess, want: InProcess},
+		{name: "updated to seen", from: Updated, to: Seen, want: Seen},
+		{name: "submitted to returned", from: Submitted, to: Returned, want: Returned},
+		{name: "created to completed is not allowed", from: Created, to: Completed, want: Created, err: ErrInvalidTransition},
+		{name: "in progress to seen is not allowed", from: InProcess, to: Seen, want: InProcess, err: ErrInvalidTransition},
+		{name: "completed task is closed", from: Completed, to: InProcess, want: Completed, err: ErrTaskClosed},
+		{name: "unknown target status", from: Created, to: "done", want: Created, err: ErrUnknownStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := Task{Status: tt.from}
+			err := task.Transition(tt.to)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, task.Status)
+		})
+	}
+}
+
+func TestTransitionCompletesRecurringTask(t *testing.T) {
+	due := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+	task := Task{Status: Submitted, DueDate: due, Recurrence: "FREQ=DAILY;COUNT=3", Timezone: "UTC"}
+
+	assert.NoError(t, task.Transition(Completed))
+	assert.Equal(t, Created, task.Status)
+	assert.Equal(t, due.AddDate(0, 0, 1), task.DueDate)
+	assert.Equal(t, "FREQ=DAILY;COUNT=2", task.Recurrence)
+
+	// следующее повторение отсчитывается от срока до откладывания напоминания
+	snoozed := due.Add(-time.Hour)
+	task = Task{
+		Status:        Submitted,
+		DueDate:       due,
+		Recurrence:    "FREQ=DAILY;COUNT=3",
+		Timezone:      "UTC",
+		ReminderState: ReminderSnoozed,
+		SnoozedFrom:   &snoozed,
+	}
+
+	assert.NoError(t, task.Transition(Completed))
+	assert.Equal(t, snoozed.AddDate(0, 0, 1), task.DueDate)
+	assert.Equal(t, ReminderPending, task.ReminderState)
+	assert.Nil(t, task.SnoozedFrom)
+
+	// последнее повторение завершает задачу
+	task = Task{Status: Submitted, DueDate: due, Recurrence: "FREQ=DAILY;COUNT=1", Timezone: "UTC"}
+
+	assert.NoError(t, task.Transition(Completed))
+	assert.Equal(t, Completed, task.Status)
+	assert.Equal(t, due, task.DueDate)
+}
+
+func TestStatusSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to Status
+		want     []StatusChange
+	}{
+		{name: "no change", from: Seen, to: Seen, want: nil},
+		{name: "creation", from: "", to: Created, want: []StatusChange{{To: Created}}},
+		{name: "single step", from: Created, to: InProcess, want: []StatusChange{{From: Created, To: InProcess}}},
+		{
+			name: "recurring task completion",
+			from: Submitted,
+			to:   Created,
+			want: []StatusChange{{From: Submitted, To: Completed}, {From: Completed, To: Created}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, StatusSteps(tt.from, tt.to))
+		})
+	}
+}
+
+func TestEdit(t *testing.T) {
+	tests := []struct {
+		name string
+		from Status
+		want Status
+		err  error
+	}{
+		{name: "new task becomes updated", from: Created, want: Updated},
+		{name: "seen task becomes updated", from: Seen, want: Updated},
+		{name: "task in progress keeps its status", from: InProcess, want: InProcess},
+		{name: "cancelled task is closed", from: Cancelled, want: Cancelled, err: ErrTaskClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := Task{Status: tt.from}
+			err := task.Edit()
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, task.Status)
+		})
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  Status
+		err   error
+	}{
+		{name: "code", value: "in_progress", want: InProcess},
+		{name: "code with spaces", value: " backlog ", want: Backlog},
+		{name: "russian label", value: "Завершена", want: Completed},
+		{name: "english label in another case", value: "CANCELLED", want: Cancelled},
+		{name: "unknown value", value: "done", err: ErrUnknownStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			status, err := ParseStatus(tt.value)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
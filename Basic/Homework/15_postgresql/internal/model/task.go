@@ -1,92 +1,116 @@
 package model
 
 import (
-	"database/sql"
-	"fmt"
-	"log"
 	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
 )
 
+// Status код статуса задачи. Коды хранятся в БД и передаются в API без перевода;
+// подписи на языке пользователя возвращает Localize
+type Status string
+
 const (
-	Created   = "Создана"
-	Updated   = "Изменена"
-	Seen      = "Просмотрена"
-	InProcess = "В работе"
-	Suspended = "Приостановлена"
-	Submitted = "Решена, ждет контроля"
-	Completed = "Завершена"
-	Cancelled = "Отменена"
-	Returned  = "Возвращена на доработку"
-	Backlog   = "Бэклог"
+	Created   Status = "created"
+	Updated   Status = "updated"
+	Seen      Status = "seen"
+	InProcess Status = "in_progress"
+	Suspended Status = "suspended"
+	Submitted Status = "submitted"
+	Completed Status = "completed"
+	Cancelled Status = "cancelled"
+	Returned  Status = "returned"
+	Backlog   Status = "backlog"
 )
 
 type Task struct {
-	Id            int       `json:"id"`
-	Name          string    `json:"name"`
-	Description   string    `json:"description"`
-	InitTimeStamp time.Time `json:"initTimeStamp"`
-	DueDate       time.Time `json:"dueDate"`
-	Status        string    `json:"status"`
+	Id            int           `json:"id"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	InitTimeStamp time.Time     `json:"initTimeStamp"`
+	DueDate       time.Time     `json:"dueDate"`
+	Status        Status        `json:"status"`
+	Recurrence    string        `json:"recurrence,omitempty"`  // Правило повторения RRULE
+	Timezone      string        `json:"timezone,omitempty"`    // Часовой пояс, в котором вычисляются повторения
+	ReminderState ReminderState `json:"reminderState"`         // Состояние напоминания о сроке
+	SnoozedFrom   *time.Time    `json:"snoozedFrom,omitempty"` // Срок до откладывания напоминания
+	Labels                      // Приоритет и метки
+	ParentId      *int          `json:"parentId,omitempty"` // Родительская задача
+	BlockedBy     []int         `json:"blockedBy"`          // Задачи, которыми заблокирована задача
+	OwnerId       int           `json:"ownerId"`            // Пользователь-владелец
+	UpdatedAt     *time.Time    `json:"updatedAt,omitempty"`
+	DeletedAt     *time.Time    `json:"deletedAt,omitempty"` // Время перемещения в корзину
+	Version       int           `json:"version"`             // Версия, увеличивается при каждом изменении
 }
 
-// NewTask генерирует и возвращает новую задачу
-func NewTask(rows_count sql.NullInt64, name, descr, dueDate string) (Task, error) {
-	userDueDate, err := time.Parse("02.01.2006", dueDate)
-	if err != nil {
-		log.Println("Введенная дата исполнения имеет не корректный формат.")
-		if rows_count.Valid {
-			return Task{
-				Id:            int(rows_count.Int64 + 1),
-				Name:          name,
-				Description:   descr,
-				InitTimeStamp: time.Now(),
-				Status:        Created,
-			}, nil
-		} else {
-			return Task{
-				Id:            1,
-				Name:          name,
-				Description:   descr,
-				InitTimeStamp: time.Now(),
-				Status:        Created,
-			}, nil
-		}
-
-	}
-	if rows_count.Valid {
-		return Task{
-			Id:            int(rows_count.Int64 + 1),
-			Name:          name,
-			Description:   descr,
-			InitTimeStamp: time.Now(),
-			DueDate:       userDueDate,
-			Status:        Created,
-		}, nil
+// NewTask генерирует и возвращает новую задачу; dueDate разбирается в часовом поясе loc,
+// в нём же вычисляются повторения по необязательному правилу recurrence.
+// Некорректные поля возвращаются одной ошибкой *ValidationError.
+// Id и дата постановки задачи назначаются БД при сохранении
+func NewTask(name, descr, dueDate, recurrence string, labels Labels, loc *time.Location) (Task, error) {
+	var v validator
+	due := v.task(name, descr, dueDate, nil, time.Now(), loc)
+	rule := v.rule(recurrence, "")
+	labels = v.labels(labels, nil)
+	if err := v.err(); err != nil {
+		return Task{}, err
 	}
 	return Task{
-		Id:            1,
 		Name:          name,
 		Description:   descr,
-		InitTimeStamp: time.Now(),
-		DueDate:       userDueDate,
+		DueDate:       due,
 		Status:        Created,
+		Recurrence:    rule,
+		Timezone:      timezone(rule, loc),
+		ReminderState: ReminderPending,
+		Labels:        labels,
+		BlockedBy:     make([]int, 0),
 	}, nil
 }
 
-// String реализует repository.Remindable
+// Change проверяет и применяет к задаче новые имя, описание, срок исполнения
+// (разбирается в часовом поясе loc), правило повторения, приоритет и метки; проверяются
+// только изменённые поля. Напоминание о новом сроке снова ожидает срабатывания.
+// При ошибке задача не изменяется
+func (myTask *Task) Change(name, descr, dueDate, recurrence string, labels Labels, loc *time.Location) error {
+	var v validator
+	due := v.task(name, descr, dueDate, myTask, time.Now(), loc)
+	rule := v.rule(recurrence, myTask.Recurrence)
+	labels = v.labels(labels, &myTask.Labels)
+	if err := v.err(); err != nil {
+		return err
+	}
+	changed := *myTask
+	changed.Name = name
+	changed.Description = descr
+	changed.DueDate = due
+	changed.Recurrence = rule
+	changed.Timezone = timezone(rule, loc)
+	changed.Labels = labels
+	if !due.Equal(myTask.DueDate) {
+		changed.ReminderState = ReminderPending
+		changed.SnoozedFrom = nil
+	}
+	if err := changed.Edit(); err != nil {
+		return err
+	}
+	*myTask = changed
+	return nil
+}
+
+// String реализует repository.Remindable; текст на языке i18n.Default
 func (myTask Task) String() string {
-	return fmt.Sprintf(
-		"Имя задачи: %v\nОписание задачи: %v\nДата постановки задачи: %v\nДата исполнения: %v\nСтатус: %v\n",
-		myTask.Name, myTask.Description, myTask.InitTimeStamp.Format("02.01.2006"), myTask.DueDate.Format("02.01.2006"), myTask.Status,
+	return myTask.Localize(i18n.Default)
+}
+
+// Localize возвращает текстовое представление задачи на языке lang
+func (myTask Task) Localize(lang i18n.Lang) string {
+	return i18n.T(lang, "task.text",
+		myTask.Name, myTask.Description, myTask.InitTimeStamp.Format(DueDateLayout), myTask.DueDate.Format(DueDateLayout), myTask.Status,
 	)
 }
 
 // ChangeAlarm реализует repository.Remindable
-func (myTask *Task) ChangeAlarm(new_date string) {
-	userDate, err := time.Parse("02.01.2006", new_date)
-	if err != nil {
-		fmt.Println("Введенная дата исполнения имеет не корректный формат.\nВведите требуемое значение даты в соответствии с форматом: ДД-ММ-ГГГГ.")
-	} else {
-		myTask.DueDate = userDate
-	}
+func (myTask *Task) ChangeAlarm(newDate string, loc *time.Location) error {
+	return myTask.Change(myTask.Name, myTask.Description, newDate, myTask.Recurrence, myTask.Labels, loc)
 }
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Ограничения учётных данных пользователя; длина пароля ограничена в байтах пределом bcrypt
+const (
+	MinLoginLength    = 3
+	MaxLoginLength    = 50
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// loginPattern допустимые символы логина
+var loginPattern = regexp.MustCompile(`^[a-z0-9._-]+$`)
+
+// User пользователь; задачи, заметки и записи журнала принадлежат пользователю
+type User struct {
+	Id        int       `json:"id"`
+	Login     string    `json:"login"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NormalizeLogin приводит логин к нижнему регистру без пробелов по краям
+func NormalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
+
+// NewUser проверяет логин и пароль нового пользователя и возвращает пользователя
+// с нормализованным логином. Некорректные поля возвращаются одной ошибкой *ValidationError.
+// Id и дата регистрации назначаются хранилищем, пароль хранится только в виде хеша
+func NewUser(login, password string) (User, error) {
+	var v validator
+	login = NormalizeLogin(login)
+	switch n := utf8.RuneCountInString(login); {
+	case n == 0:
+		v.add("login", RuleRequired)
+	case n < MinLoginLength:
+		v.add("login", RuleTooShort, MinLoginLength)
+	case n > MaxLoginLength:
+		v.add("login", RuleTooLong, MaxLoginLength)
+	case !loginPattern.MatchString(login):
+		v.add("login", RuleInvalidLogin)
+	}
+	switch {
+	case password == "":
+		v.add("password", RuleRequired)
+	case utf8.RuneCountInString(password) < MinPasswordLength:
+		v.add("password", RuleTooShort, MinPasswordLength)
+	case len(password) > MaxPasswordLength:
+		v.add("password", RuleTooLong, MaxPasswordLength)
+	}
+	if err := v.err(); err != nil {
+		return User{}, err
+	}
+	return User{Login: login}, nil
+}
@@ -0,0 +1,200 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/recurrence"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
+)
+
+// Ограничения длины текстовых полей задач и заметок, в символах
+const (
+	MaxNameLength        = 200
+	MaxDescriptionLength = 2000
+)
+
+// Коды правил проверки полей; передаются клиентам вместе с именем поля
+const (
+	RuleRequired          = "required"
+	RuleTooLong           = "too_long"
+	RuleInvalidDate       = "invalid_date"
+	RuleInPast            = "in_past"
+	RuleBeforeCreated     = "before_created"
+	RuleInvalidRecurrence = "invalid_recurrence"
+	RuleNotFuture         = "not_future"
+	RuleUnknownPriority   = "unknown_priority"
+	RuleTooManyTags       = "too_many_tags"
+	RuleTooShort          = "too_short"
+	RuleInvalidLogin      = "invalid_login"
+	RuleUnknownRole       = "unknown_role"
+	RuleSelfGrant         = "self_grant"
+)
+
+// ErrValidation задача или заметка не прошла проверку; подробности по полям в *ValidationError
+var ErrValidation = i18n.New("err.validation")
+
+// Violation нарушение правила проверки одного поля
+type Violation struct {
+	// Field имя поля в JSON
+	Field string
+	// Rule код нарушенного правила
+	Rule string
+	args []any
+}
+
+// Localize возвращает описание нарушения на языке lang
+func (v Violation) Localize(lang i18n.Lang) string {
+	return i18n.T(lang, "validation."+v.Rule, v.args...)
+}
+
+// ValidationError все нарушения, найденные при проверке задачи или заметки
+type ValidationError struct {
+	Violations []Violation
+}
+
+// Error возвращает текст ошибки на языке i18n.Default
+func (e *ValidationError) Error() string {
+	return e.Localize(i18n.Default)
+}
+
+// Unwrap позволяет проверять ошибку через errors.Is(err, ErrValidation)
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// Rename заменяет имя поля from на to во всех нарушениях - для запросов,
+// в которых поле модели передаётся под другим именем
+func (e *ValidationError) Rename(from, to string) {
+	for i := range e.Violations {
+		if e.Violations[i].Field == from {
+			e.Violations[i].Field = to
+		}
+	}
+}
+
+// Localize возвращает текст ошибки со списком нарушений на языке lang
+func (e *ValidationError) Localize(lang i18n.Lang) string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		parts = append(parts, v.Field+": "+v.Localize(lang))
+	}
+	return i18n.T(lang, "err.validation") + ": " + strings.Join(parts, "; ")
+}
+
+// validator накапливает нарушения, чтобы вернуть клиенту все ошибки сразу
+type validator struct {
+	violations []Violation
+}
+
+// add регистрирует нарушение правила rule для поля field
+func (v *validator) add(field, rule string, args ...any) {
+	v.violations = append(v.violations, Violation{Field: field, Rule: rule, args: args})
+}
+
+// text проверяет обязательное текстовое поле и его длину
+func (v *validator) text(field, value string, maxLen int) {
+	switch {
+	case strings.TrimSpace(value) == "":
+		v.add(field, RuleRequired)
+	case utf8.RuneCountInString(value) > maxLen:
+		v.add(field, RuleTooLong, maxLen)
+	}
+}
+
+// date разбирает обязательное поле даты относительно now в часовом поясе loc;
+// при ошибке возвращает нулевое время и регистрирует нарушение
+func (v *validator) date(field, value string, now time.Time, loc *time.Location) time.Time {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, RuleRequired)
+		return time.Time{}
+	}
+	date, err := dateinput.Parse(value, now, loc)
+	if err != nil {
+		v.add(field, RuleInvalidDate, value)
+		return time.Time{}
+	}
+	return date
+}
+
+// rule разбирает необязательное правило повторения и возвращает его канонический вид;
+// прежнее правило before не проверяется повторно
+func (v *validator) rule(value, before string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+	if before != "" && value == before {
+		return before
+	}
+	rule, err := recurrence.Parse(value)
+	if err != nil {
+		v.add("recurrence", RuleInvalidRecurrence, value)
+		return ""
+	}
+	return rule.String()
+}
+
+// err возвращает *ValidationError, если найдены нарушения
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: v.violations}
+}
+
+// task проверяет поля задачи и возвращает разобранный срок исполнения. У изменяемой задачи
+// (before != nil) проверяются только изменённые поля: прежние имя, описание и прошедший срок
+// допустимы, если они не менялись. Новый срок не может быть раньше начала текущего дня
+// в часовом поясе loc
+func (v *validator) task(name, descr, dueDate string, before *Task, now time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if before == nil || name != before.Name {
+		v.text("name", name, MaxNameLength)
+	}
+	if before == nil || descr != before.Description {
+		v.text("description", descr, MaxDescriptionLength)
+	}
+	due := v.date("dueDate", dueDate, now, loc)
+	if due.IsZero() || (before != nil && due.Equal(before.DueDate)) {
+		return due
+	}
+	local := now.In(loc)
+	if due.Before(time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)) {
+		v.add("dueDate", RuleInPast)
+	}
+	return due
+}
+
+// note проверяет поля заметки и возвращает разобранное время напоминания. У изменяемой
+// заметки (before != nil) проверяются только изменённые поля. Новое время напоминания
+// должно быть позже создания заметки, а для новой заметки - позже now
+func (v *validator) note(name, descr, alarm string, before *Note, now time.Time, loc *time.Location) time.Time {
+	if before == nil || name != before.Name {
+		v.text("name", name, MaxNameLength)
+	}
+	if before == nil || descr != before.Description {
+		v.text("description", descr, MaxDescriptionLength)
+	}
+	at := v.date("alarmTimeStamp", alarm, now, loc)
+	if at.IsZero() {
+		return at
+	}
+	var created time.Time
+	if before != nil {
+		if at.Equal(before.AlarmTimeStamp) {
+			return at
+		}
+		created = before.InitTimeStamp
+	}
+	if created.IsZero() {
+		created = now
+	}
+	if !at.After(created) {
+		v.add("alarmTimeStamp", RuleBeforeCreated)
+	}
+	return at
+}
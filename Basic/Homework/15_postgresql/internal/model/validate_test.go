@@ -0,0 +1,198 @@
+package model
+
+import (
+	"cmp"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rules возвращает пары "поле: правило" нарушений ошибки err
+func rules(err error) []string {
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		return nil
+	}
+	list := make([]string, 0, len(invalid.Violations))
+	for _, v := range invalid.Violations {
+		list = append(list, v.Field+": "+v.Rule)
+	}
+	return list
+}
+
+func TestValidateTask(t *testing.T) {
+	now := time.Date(2026, 11, 2, 12, 0, 0, 0, time.UTC)
+	moscow := time.FixedZone("MSK", 3*60*60)
+	before := Task{DueDate: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name    string
+		title   string
+		descr   string
+		dueDate string
+		before  *Task
+		loc     *time.Location
+		want    []string
+		due     time.Time
+	}{
+		{name: "valid", descr: "d", dueDate: "03.11.2026", due: time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)},
+		{name: "due today", descr: "d", dueDate: "2026-11-02", due: time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "relative due date", descr: "d", dueDate: "завтра", due: time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)},
+		{
+			name:    "due date in the user's time zone",
+			descr:   "d",
+			dueDate: "03.11.2026",
+			loc:     moscow,
+			due:     time.Date(2026, 11, 2, 21, 0, 0, 0, time.UTC),
+		},
+		{name: "empty fields", title: " ", want: []string{"name: required", "description: required", "dueDate: required"}},
+		{name: "too long description", descr: strings.Repeat("я", MaxDescriptionLength+1), dueDate: "03.11.2026",
+			want: []string{"description: too_long"}, due: time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)},
+		{name: "invalid due date", descr: "d", dueDate: "31.02.2026", want: []string{"dueDate: invalid_date"}},
+		{name: "due date in the past", descr: "d", dueDate: "01.11.2026",
+			want: []string{"dueDate: in_past"}, due: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "unchanged past due date of an existing task", descr: "d", dueDate: "01.10.2026",
+			before: &before, due: before.DueDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v validator
+			due := v.task(cmp.Or(tt.title, "task"), tt.descr, tt.dueDate, tt.before, now, tt.loc)
+
+			assert.Equal(t, tt.want, rules(v.err()))
+			assert.Equal(t, tt.due, due)
+		})
+	}
+}
+
+func TestValidateNote(t *testing.T) {
+	now := time.Date(2026, 11, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		alarm  string
+		before *Note
+		want   []string
+	}{
+		{name: "alarm after now", alarm: "02.11.2026 12:30"},
+		{name: "alarm before now", alarm: "02.11.2026 11:30", want: []string{"alarmTimeStamp: before_created"}},
+		{name: "alarm equal to now", alarm: "2026-11-02T12:00:00Z", want: []string{"alarmTimeStamp: before_created"}},
+		{name: "alarm after creation of an existing note", alarm: "02.11.2026 11:30", before: &Note{InitTimeStamp: now.Add(-2 * time.Hour)}},
+		{name: "unrecognized alarm", alarm: "когда-нибудь", want: []string{"alarmTimeStamp: invalid_date"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v validator
+			v.note("note", "d", tt.alarm, tt.before, now, time.UTC)
+
+			assert.Equal(t, tt.want, rules(v.err()))
+		})
+	}
+}
+
+func TestNewTaskViolations(t *testing.T) {
+	tests := []struct {
+		name       string
+		title      string
+		dueDate    string
+		recurrence string
+		labels     Labels
+		want       []string
+	}{
+		{name: "valid", title: "task", dueDate: "+1d"},
+		{name: "valid recurrence", title: "task", dueDate: "+1d", recurrence: "FREQ=WEEKLY;BYDAY=MO"},
+		{name: "invalid recurrence", title: "task", dueDate: "+1d", recurrence: "FREQ=SOMETIMES",
+			want: []string{"recurrence: invalid_recurrence"}},
+		{name: "too long name", title: strings.Repeat("я", MaxNameLength+1), dueDate: "+1d", want: []string{"name: too_long"}},
+		{name: "valid labels", title: "task", dueDate: "+1d", labels: Labels{Priority: PriorityHigh, Tags: []string{"work"}}},
+		{name: "unknown priority", title: "task", dueDate: "+1d", labels: Labels{Priority: "asap"},
+			want: []string{"priority: unknown_priority"}},
+		{name: "too long tag", title: "task", dueDate: "+1d", labels: Labels{Tags: []string{strings.Repeat("t", MaxTagLength+1)}},
+			want: []string{"tags: too_long"}},
+		{name: "all violations at once", title: "", dueDate: "когда-нибудь", recurrence: "FREQ=SOMETIMES",
+			want: []string{"name: required", "dueDate: invalid_date", "recurrence: invalid_recurrence"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewTask(tt.title, "d", tt.dueDate, tt.recurrence, tt.labels, time.UTC)
+
+			assert.Equal(t, tt.want, rules(err))
+			if tt.want != nil {
+				assert.ErrorIs(t, err, ErrValidation)
+			}
+		})
+	}
+}
+
+func TestTaskChangeValidatesChangedFields(t *testing.T) {
+	// задача с пустым описанием и прошедшим сроком, сохранённая до появления проверок
+	past := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	task := Task{
+		Name:      "task",
+		DueDate:   past,
+		Status:    Created,
+		Labels:    Labels{Priority: PriorityNormal, Tags: []string{}},
+		BlockedBy: []int{},
+	}
+	tests := []struct {
+		name    string
+		title   string
+		descr   string
+		dueDate string
+		labels  Labels
+		want    []string
+	}{
+		{name: "rename keeps empty description and past due date", title: "renamed", dueDate: "2026-10-01T00:00:00Z"},
+		{name: "tags only", title: "task", dueDate: "2026-10-01T00:00:00Z", labels: Labels{Tags: []string{"work"}}},
+		{name: "changed name is checked", title: " ", dueDate: "2026-10-01T00:00:00Z", want: []string{"name: required"}},
+		{name: "changed description is checked", title: "task", descr: strings.Repeat("я", MaxDescriptionLength+1),
+			dueDate: "2026-10-01T00:00:00Z", want: []string{"description: too_long"}},
+		{name: "changed due date is checked", title: "task", dueDate: "2026-10-02T00:00:00Z", want: []string{"dueDate: in_past"}},
+		{name: "changed priority is checked", title: "task", dueDate: "2026-10-01T00:00:00Z", labels: Labels{Priority: "asap"},
+			want: []string{"priority: unknown_priority"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changed := task
+			err := changed.Change(tt.title, tt.descr, tt.dueDate, "", tt.labels, time.UTC)
+
+			assert.Equal(t, tt.want, rules(err))
+			if tt.want == nil {
+				assert.Equal(t, Updated, changed.Status)
+			}
+		})
+	}
+}
+
+func TestNoteChangeValidatesChangedFields(t *testing.T) {
+	// время напоминания заметки прошло, описание пустое
+	created := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	note := Note{Name: "note", AlarmTimeStamp: created.Add(time.Hour), InitTimeStamp: created}
+
+	err := note.Change("renamed", "", "2026-09-01T01:00:00Z", "", Labels{}, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", note.Name)
+
+	err = note.Change("renamed", "", "2026-08-31T00:00:00Z", "", Labels{}, time.UTC)
+	assert.Equal(t, []string{"alarmTimeStamp: before_created"}, rules(err))
+}
+
+func TestValidationErrorRename(t *testing.T) {
+	err := &ValidationError{Violations: []Violation{
+		{Field: "alarmTimeStamp", Rule: RuleInvalidDate},
+		{Field: "name", Rule: RuleRequired},
+	}}
+
+	err.Rename("alarmTimeStamp", "alarmTime")
+
+	assert.Equal(t, []string{"alarmTime: invalid_date", "name: required"}, rules(err))
+}
@@ -0,0 +1,282 @@
+package patch
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+)
+
+// Операции JSON Patch
+const (
+	opAdd     = "add"
+	opRemove  = "remove"
+	opReplace = "replace"
+	opMove    = "move"
+	opCopy    = "copy"
+	opTest    = "test"
+)
+
+// operation операция JSON Patch
+type operation struct {
+	Op   string `json:"op"`
+	Path string `json:"path"`
+	From string `json:"from"`
+	// Value значение операции; отсутствующее поле отличается от null
+	Value json.RawMessage `json:"value"`
+
+	// path и from - разобранные указатели JSON Pointer (RFC 6901)
+	path, from []string
+	// value разобранное значение
+	value any
+}
+
+// parseOperations разбирает и проверяет массив операций JSON Patch
+func parseOperations(body []byte) ([]operation, error) {
+	var ops []operation
+	if err := json.Unmarshal(body, &ops); err != nil || ops == nil {
+		return nil, ErrMalformed
+	}
+	for i := range ops {
+		op := &ops[i]
+		var ok bool
+		if op.path, ok = pointer(op.Path); !ok {
+			return nil, i18n.Wrap(ErrMalformed, "ctx.patch_op", i, op.Op, op.Path)
+		}
+		switch op.Op {
+		case opAdd, opReplace, opTest:
+			if op.Value == nil || json.Unmarshal(op.Value, &op.value) != nil {
+				return nil, i18n.Wrap(ErrMalformed, "ctx.patch_op", i, op.Op, op.Path)
+			}
+		case opMove, opCopy:
+			if op.from, ok = pointer(op.From); !ok {
+				return nil, i18n.Wrap(ErrMalformed, "ctx.patch_op", i, op.Op, op.Path)
+			}
+		case opRemove:
+		default:
+			return nil, i18n.Wrap(ErrMalformed, "ctx.patch_op", i, op.Op, op.Path)
+		}
+	}
+	return ops, nil
+}
+
+// pointer разбирает указатель JSON Pointer на токены; пустой указатель означает весь документ
+func pointer(text string) ([]string, bool) {
+	if text == "" {
+		return nil, true
+	}
+	if !strings.HasPrefix(text, "/") {
+		return nil, false
+	}
+	tokens := strings.Split(text[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, true
+}
+
+// apply последовательно применяет операции ops к документу doc; при ошибке документ не возвращается.
+// Значения операций копируются, поэтому патч можно применять повторно
+func apply(doc any, ops []operation) (any, error) {
+	for i, op := range ops {
+		var err error
+		switch op.Op {
+		case opAdd:
+			doc, err = add(doc, op.path, clone(op.value))
+		case opRemove:
+			doc, _, err = remove(doc, op.path)
+		case opReplace:
+			if _, err = get(doc, op.path); err == nil {
+				doc, err = replace(doc, op.path, clone(op.value))
+			}
+		case opMove:
+			var value any
+			if isPrefix(op.from, op.path) {
+				err = ErrUnprocessable
+			} else if doc, value, err = remove(doc, op.from); err == nil {
+				doc, err = add(doc, op.path, value)
+			}
+		case opCopy:
+			var value any
+			if value, err = get(doc, op.from); err == nil {
+				doc, err = add(doc, op.path, clone(value))
+			}
+		case opTest:
+			var value any
+			if value, err = get(doc, op.path); err == nil && !reflect.DeepEqual(value, op.value) {
+				err = ErrTestFailed
+			}
+		}
+		if err != nil {
+			return nil, i18n.Wrap(err, "ctx.patch_op", i, op.Op, op.Path)
+		}
+	}
+	return doc, nil
+}
+
+// isPrefix сообщает, является ли указатель from собственным префиксом path:
+// значение нельзя переместить внутрь него самого
+func isPrefix(from, path []string) bool {
+	return len(from) < len(path) && slices.Equal(from, path[:len(from)])
+}
+
+// get возвращает значение по указателю path
+func get(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, ErrUnprocessable
+			}
+			doc = value
+		case []any:
+			i, ok := index(token, len(node))
+			if !ok {
+				return nil, ErrUnprocessable
+			}
+			doc = node[i]
+		default:
+			return nil, ErrUnprocessable
+		}
+	}
+	return doc, nil
+}
+
+// edit заменяет контейнер (объект или массив), в котором находится значение по указателю path,
+// результатом change; change получает контейнер и последний токен указателя.
+// Возвращает изменённый документ; path не должен быть пустым
+func edit(doc any, path []string, change func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return change(doc, path[0])
+	}
+	switch node := doc.(type) {
+	case map[string]any:
+		child, ok := node[path[0]]
+		if !ok {
+			return nil, ErrUnprocessable
+		}
+		changed, err := edit(child, path[1:], change)
+		if err != nil {
+			return nil, err
+		}
+		node[path[0]] = changed
+		return node, nil
+	case []any:
+		i, ok := index(path[0], len(node))
+		if !ok {
+			return nil, ErrUnprocessable
+		}
+		changed, err := edit(node[i], path[1:], change)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = changed
+		return node, nil
+	}
+	return nil, ErrUnprocessable
+}
+
+// add добавляет value по указателю path: поле объекта создаётся или заменяется,
+// в массив элемент вставляется перед индексом, "-" добавляет элемент в конец
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return edit(doc, path, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			if token == "-" {
+				return append(node, value), nil
+			}
+			i, ok := index(token, len(node)+1)
+			if !ok {
+				return nil, ErrUnprocessable
+			}
+			return slices.Insert(node, i, value), nil
+		}
+		return nil, ErrUnprocessable
+	})
+}
+
+// replace заменяет существующее значение по указателю path на value
+func replace(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return edit(doc, path, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			i, _ := index(token, len(node))
+			node[i] = value
+			return node, nil
+		}
+		return nil, ErrUnprocessable
+	})
+}
+
+// remove удаляет значение по указателю path и возвращает документ и удалённое значение
+func remove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, ErrUnprocessable
+	}
+	var removed any
+	doc, err := edit(doc, path, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, ErrUnprocessable
+			}
+			removed = value
+			delete(node, token)
+			return node, nil
+		case []any:
+			i, ok := index(token, len(node))
+			if !ok {
+				return nil, ErrUnprocessable
+			}
+			removed = node[i]
+			return slices.Delete(node, i, i+1), nil
+		}
+		return nil, ErrUnprocessable
+	})
+	return doc, removed, err
+}
+
+// index разбирает токен как индекс массива из диапазона [0, size); ведущие нули не допускаются
+func index(token string, size int) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, false
+	}
+	i, err := strconv.Atoi(token)
+	return i, err == nil && i < size
+}
+
+// clone возвращает глубокую копию значения документа
+func clone(value any) any {
+	switch node := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(node))
+		for name, child := range node {
+			copied[name] = clone(child)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(node))
+		for i, child := range node {
+			copied[i] = clone(child)
+		}
+		return copied
+	}
+	return value
+}
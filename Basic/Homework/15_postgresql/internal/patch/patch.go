@@ -0,0 +1,112 @@
+// Package patch применяет частичные изменения к JSON-представлению записей в форматах
+// JSON Merge Patch (RFC 7396) и JSON Patch (RFC 6902).
+//
+// Патч применяется к документу, полученному сериализацией значения, и результат
+// разбирается обратно в значение того же типа; поля, отсутствующие в результате, получают
+// нулевые значения
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+)
+
+// Типы содержимого патчей
+const (
+	MergeType = "application/merge-patch+json"
+	JSONType  = "application/json-patch+json"
+)
+
+// Accept значение заголовка Accept-Patch: поддерживаемые типы патчей
+const Accept = MergeType + ", " + JSONType
+
+var (
+	// ErrUnsupportedType тип содержимого запроса не является типом патча
+	ErrUnsupportedType = i18n.New("err.patch_type")
+	// ErrMalformed тело запроса не является патчем указанного типа
+	ErrMalformed = i18n.New("err.patch_malformed")
+	// ErrUnprocessable патч не применим к записи: путь не существует или результат не является записью
+	ErrUnprocessable = i18n.New("err.patch_unprocessable")
+	// ErrTestFailed операция test патча JSON Patch не выполнена
+	ErrTestFailed = i18n.New("err.patch_test_failed")
+)
+
+// Patch разобранный патч
+type Patch struct {
+	// contentType тип патча: MergeType или JSONType
+	contentType string
+	// merge документ JSON Merge Patch
+	merge any
+	// ops операции JSON Patch
+	ops []operation
+}
+
+// Parse разбирает тело запроса body с типом содержимого contentType (без параметров)
+func Parse(contentType string, body []byte) (*Patch, error) {
+	switch contentType {
+	case MergeType:
+		var merge any
+		if err := json.Unmarshal(body, &merge); err != nil {
+			return nil, ErrMalformed
+		}
+		return &Patch{contentType: contentType, merge: merge}, nil
+	case JSONType:
+		ops, err := parseOperations(body)
+		if err != nil {
+			return nil, err
+		}
+		return &Patch{contentType: contentType, ops: ops}, nil
+	}
+	return nil, ErrUnsupportedType
+}
+
+// Apply применяет патч p к значению v и возвращает изменённую копию.
+// Результат, не разбирающийся в T, в том числе с неизвестными полями, возвращает ErrUnprocessable
+func Apply[T any](p *Patch, v T) (T, error) {
+	var changed T
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return changed, err
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return changed, err
+	}
+	if p.contentType == MergeType {
+		doc = merge(doc, p.merge)
+	} else if doc, err = apply(doc, p.ops); err != nil {
+		return changed, err
+	}
+	if raw, err = json.Marshal(doc); err != nil {
+		return changed, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&changed); err != nil {
+		return changed, i18n.Wrap(ErrUnprocessable, "ctx.patch_result", err.Error())
+	}
+	return changed, nil
+}
+
+// merge применяет JSON Merge Patch patch к документу target по алгоритму RFC 7396:
+// null удаляет поле, объект изменяет поля рекурсивно, прочие значения заменяют поле целиком
+func merge(target, patch any) any {
+	fields, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]any)
+	if !ok {
+		object = map[string]any{}
+	}
+	for name, value := range fields {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+		object[name] = merge(object[name], value)
+	}
+	return object
+}
@@ -0,0 +1,146 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// record запись, к которой применяются патчи
+type record struct {
+	Name  string            `json:"name"`
+	Count int               `json:"count"`
+	Tags  []string          `json:"tags"`
+	Attrs map[string]string `json:"attrs,omitempty"`
+}
+
+func fixture() record {
+	return record{Name: "task", Count: 1, Tags: []string{"a", "b"}, Attrs: map[string]string{"k": "v"}}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		err         error
+	}{
+		{name: "merge patch", contentType: MergeType, body: `{"name": "x"}`},
+		{name: "json patch", contentType: JSONType, body: `[{"op": "remove", "path": "/tags/0"}]`},
+		{name: "unsupported type", contentType: "application/json", body: `{}`, err: ErrUnsupportedType},
+		{name: "malformed merge patch", contentType: MergeType, body: `{`, err: ErrMalformed},
+		{name: "json patch is not an array", contentType: JSONType, body: `{"op": "add"}`, err: ErrMalformed},
+		{name: "json patch null", contentType: JSONType, body: `null`, err: ErrMalformed},
+		{name: "unknown operation", contentType: JSONType, body: `[{"op": "inc", "path": "/count"}]`, err: ErrMalformed},
+		{name: "path without slash", contentType: JSONType, body: `[{"op": "remove", "path": "name"}]`, err: ErrMalformed},
+		{name: "add without value", contentType: JSONType, body: `[{"op": "add", "path": "/name"}]`, err: ErrMalformed},
+		{name: "move without from", contentType: JSONType, body: `[{"op": "move", "path": "/name", "from": "x"}]`, err: ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := Parse(tt.contentType, []byte(tt.body))
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.err == nil, p != nil)
+		})
+	}
+}
+
+func TestApplyMerge(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want record
+	}{
+		{name: "replace field", body: `{"name": "renamed"}`, want: record{Name: "renamed", Count: 1, Tags: []string{"a", "b"}, Attrs: map[string]string{"k": "v"}}},
+		{name: "null removes field", body: `{"count": null, "attrs": null}`, want: record{Name: "task", Tags: []string{"a", "b"}}},
+		{name: "arrays are replaced", body: `{"tags": ["c"]}`, want: record{Name: "task", Count: 1, Tags: []string{"c"}, Attrs: map[string]string{"k": "v"}}},
+		{name: "objects are merged", body: `{"attrs": {"n": "m"}}`, want: record{Name: "task", Count: 1, Tags: []string{"a", "b"}, Attrs: map[string]string{"k": "v", "n": "m"}}},
+		{name: "empty patch", body: `{}`, want: fixture()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := Parse(MergeType, []byte(tt.body))
+			assert.NoError(t, err)
+
+			got, err := Apply(p, fixture())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want record
+		err  error
+	}{
+		{
+			name: "add to array end and insert before index",
+			body: `[{"op": "add", "path": "/tags/-", "value": "c"}, {"op": "add", "path": "/tags/0", "value": "z"}]`,
+			want: record{Name: "task", Count: 1, Tags: []string{"z", "a", "b", "c"}, Attrs: map[string]string{"k": "v"}},
+		},
+		{
+			name: "replace and remove",
+			body: `[{"op": "replace", "path": "/count", "value": 5}, {"op": "remove", "path": "/tags/1"}]`,
+			want: record{Name: "task", Count: 5, Tags: []string{"a"}, Attrs: map[string]string{"k": "v"}},
+		},
+		{
+			name: "move and copy",
+			body: `[{"op": "copy", "from": "/name", "path": "/attrs/name"}, {"op": "move", "from": "/tags/0", "path": "/tags/-"}]`,
+			want: record{Name: "task", Count: 1, Tags: []string{"b", "a"}, Attrs: map[string]string{"k": "v", "name": "task"}},
+		},
+		{
+			name: "escaped pointer tokens",
+			body: `[{"op": "add", "path": "/attrs/a~1b~0c", "value": "x"}]`,
+			want: record{Name: "task", Count: 1, Tags: []string{"a", "b"}, Attrs: map[string]string{"k": "v", "a/b~c": "x"}},
+		},
+		{
+			name: "passed test",
+			body: `[{"op": "test", "path": "/name", "value": "task"}, {"op": "replace", "path": "/name", "value": "x"}]`,
+			want: record{Name: "x", Count: 1, Tags: []string{"a", "b"}, Attrs: map[string]string{"k": "v"}},
+		},
+		{name: "failed test", body: `[{"op": "test", "path": "/count", "value": 2}]`, err: ErrTestFailed},
+		{name: "replace missing field", body: `[{"op": "replace", "path": "/attrs/x", "value": "y"}]`, err: ErrUnprocessable},
+		{name: "remove out of range", body: `[{"op": "remove", "path": "/tags/2"}]`, err: ErrUnprocessable},
+		{name: "index with leading zero", body: `[{"op": "remove", "path": "/tags/01"}]`, err: ErrUnprocessable},
+		{name: "move into itself", body: `[{"op": "move", "from": "/attrs", "path": "/attrs/x"}]`, err: ErrUnprocessable},
+		{name: "unknown field in result", body: `[{"op": "add", "path": "/extra", "value": 1}]`, err: ErrUnprocessable},
+		{name: "wrong type in result", body: `[{"op": "replace", "path": "/count", "value": "many"}]`, err: ErrUnprocessable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := Parse(JSONType, []byte(tt.body))
+			assert.NoError(t, err)
+
+			got, err := Apply(p, fixture())
+
+			assert.ErrorIs(t, err, tt.err)
+			if tt.err == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestApplyIsRepeatable(t *testing.T) {
+	p, err := Parse(JSONType, []byte(`[{"op": "add", "path": "/attrs", "value": {"n": "m"}}]`))
+	assert.NoError(t, err)
+
+	first, err := Apply(p, fixture())
+	assert.NoError(t, err)
+	first.Attrs["n"] = "changed"
+
+	second, err := Apply(p, fixture())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"n": "m"}, second.Attrs)
+}
@@ -0,0 +1,186 @@
+// Package problem формирует ответы об ошибках в формате RFC 7807 (application/problem+json)
+// со стабильными машиночитаемыми кодами
+package problem
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/access"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/auth"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/patch"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType тип содержимого ответа об ошибке
+const ContentType = "application/problem+json"
+
+// typePrefix префикс URI типа ошибки; полный тип - typePrefix + код
+const typePrefix = "urn:remindables:problem:"
+
+// challenge значение заголовка WWW-Authenticate ответов 401
+const challenge = `Bearer realm="remindables"`
+
+// Коды ошибок, на которые могут полагаться клиенты API
+const (
+	CodeNotFound            = "not_found"
+	CodeDuplicateName       = "duplicate_name"
+	CodeValidationFailed    = "validation_failed"
+	CodeConflict            = "conflict"
+	CodePreconditionFailed  = "precondition_failed"
+	CodeInvalidRequest      = "invalid_request"
+	CodeUnsupportedMedia    = "unsupported_media_type"
+	CodeInvalidPatch        = "invalid_patch"
+	CodeUnprocessablePatch  = "unprocessable_patch"
+	CodePatchTestFailed     = "patch_test_failed"
+	CodeInvalidCursor       = "invalid_cursor"
+	CodeUnknownStatus       = "unknown_status"
+	CodeInvalidTransition   = "invalid_transition"
+	CodeTaskClosed          = "task_closed"
+	CodeReminderState       = "reminder_state"
+	CodeDependencyCycle     = "dependency_cycle"
+	CodeOpenSubtasks        = "open_subtasks"
+	CodeHasSubtasks         = "has_subtasks"
+	CodeRelatedNotFound     = "related_not_found"
+	CodeUnauthorized        = "unauthorized"
+	CodeInvalidCredentials  = "invalid_credentials"
+	CodeForbidden           = "forbidden"
+	CodeTimeout             = "timeout"
+	CodeClientClosedRequest = "client_closed_request"
+	CodeInternal            = "internal"
+)
+
+// Problem тело ответа об ошибке
+type Problem struct {
+	Type     string `json:"type" example:"urn:remindables:problem:not_found"`
+	Title    string `json:"title" example:"Not Found"`
+	Status   int    `json:"status" example:"404"`
+	Code     string `json:"code" example:"not_found"`
+	Detail   string `json:"detail,omitempty" example:"task id=7: record not found"`
+	Instance string `json:"instance,omitempty" example:"/api/tasks/item/id?id=7"`
+	// Errors нарушения правил проверки по полям; только для validation_failed
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError нарушение правила проверки одного поля запроса
+type FieldError struct {
+	Field   string `json:"field" example:"dueDate"`
+	Code    string `json:"code" example:"in_past"`
+	Message string `json:"message" example:"due date must not be in the past"`
+}
+
+// New создаёт описание ошибки со статусом status и кодом code
+func New(status int, code, detail string) Problem {
+	title := http.StatusText(status)
+	if title == "" {
+		title = code
+	}
+	return Problem{
+		Type:   typePrefix + code,
+		Title:  title,
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+// Write прерывает обработку запроса и отправляет ответ об ошибке
+func Write(c *gin.Context, status int, code, detail string) {
+	send(c, New(status, code, detail))
+}
+
+// send дополняет описание ошибки адресом запроса, прерывает обработку и отправляет ответ
+func send(c *gin.Context, p Problem) {
+	p.Instance = c.Request.URL.RequestURI()
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+// Message возвращает сообщение key на языке запроса
+func Message(c *gin.Context, key string, args ...any) string {
+	return i18n.T(i18n.LangFrom(c.Request.Context()), key, args...)
+}
+
+// Error отправляет ответ, соответствующий доменной ошибке err, с текстом на языке запроса.
+// Непредвиденные ошибки журналируются, а клиент получает 500 без подробностей
+func Error(c *gin.Context, err error) {
+	lang := i18n.LangFrom(c.Request.Context())
+	detail := i18n.Localize(lang, err)
+	var invalid *model.ValidationError
+	switch {
+	case errors.As(err, &invalid):
+		p := New(http.StatusUnprocessableEntity, CodeValidationFailed, detail)
+		for _, v := range invalid.Violations {
+			p.Errors = append(p.Errors, FieldError{Field: v.Field, Code: v.Rule, Message: v.Localize(lang)})
+		}
+		send(c, p)
+	case errors.Is(err, storage.ErrNotFound):
+		Write(c, http.StatusNotFound, CodeNotFound, detail)
+	case errors.Is(err, storage.ErrDuplicateName):
+		Write(c, http.StatusConflict, CodeDuplicateName, detail)
+	case errors.Is(err, storage.ErrConflict):
+		Write(c, http.StatusConflict, CodeConflict, detail)
+	case errors.Is(err, storage.ErrPreconditionFailed):
+		Write(c, http.StatusPreconditionFailed, CodePreconditionFailed, detail)
+	case errors.Is(err, storage.ErrInvalidCursor):
+		Write(c, http.StatusBadRequest, CodeInvalidCursor, detail)
+	case errors.Is(err, model.ErrUnknownStatus):
+		Write(c, http.StatusBadRequest, CodeUnknownStatus, detail)
+	case errors.Is(err, model.ErrInvalidPeriod):
+		Write(c, http.StatusBadRequest, CodeInvalidRequest, detail)
+	case errors.Is(err, model.ErrInvalidTransition):
+		Write(c, http.StatusConflict, CodeInvalidTransition, detail)
+	case errors.Is(err, model.ErrTaskClosed):
+		Write(c, http.StatusConflict, CodeTaskClosed, detail)
+	case errors.Is(err, model.ErrReminderState):
+		Write(c, http.StatusConflict, CodeReminderState, detail)
+	case errors.Is(err, model.ErrDependencyCycle):
+		Write(c, http.StatusConflict, CodeDependencyCycle, detail)
+	case errors.Is(err, model.ErrOpenSubtasks):
+		Write(c, http.StatusConflict, CodeOpenSubtasks, detail)
+	case errors.Is(err, storage.ErrHasSubtasks):
+		Write(c, http.StatusConflict, CodeHasSubtasks, detail)
+	case errors.Is(err, storage.ErrRelatedNotFound):
+		Write(c, http.StatusUnprocessableEntity, CodeRelatedNotFound, detail)
+	case errors.Is(err, patch.ErrUnsupportedType):
+		c.Header("Accept-Patch", patch.Accept)
+		Write(c, http.StatusUnsupportedMediaType, CodeUnsupportedMedia, detail)
+	case errors.Is(err, patch.ErrMalformed):
+		Write(c, http.StatusBadRequest, CodeInvalidPatch, detail)
+	case errors.Is(err, patch.ErrUnprocessable):
+		Write(c, http.StatusUnprocessableEntity, CodeUnprocessablePatch, detail)
+	case errors.Is(err, patch.ErrTestFailed):
+		Write(c, http.StatusConflict, CodePatchTestFailed, detail)
+	case errors.Is(err, auth.ErrUnauthorized):
+		c.Header("WWW-Authenticate", challenge)
+		Write(c, http.StatusUnauthorized, CodeUnauthorized, detail)
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		c.Header("WWW-Authenticate", challenge)
+		Write(c, http.StatusUnauthorized, CodeInvalidCredentials, detail)
+	case errors.Is(err, access.ErrForbidden):
+		Write(c, http.StatusForbidden, CodeForbidden, detail)
+	default:
+		slog.Error("request failed",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"error", err,
+		)
+		Write(c, http.StatusInternalServerError, CodeInternal, Message(c, "err.internal"))
+	}
+}
+
+// Recovery перехватывает панику в обработчике и отвечает 500 вместо падения процесса
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		slog.Error("panic recovered",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"panic", recovered,
+		)
+		Write(c, http.StatusInternalServerError, CodeInternal, Message(c, "err.internal"))
+	})
+}
@@ -0,0 +1,325 @@
+// Package recurrence разбирает правила повторения в формате RRULE (подмножество RFC 5545)
+// и вычисляет даты повторений задач и заметок.
+//
+// Поддерживаются FREQ=DAILY|WEEKLY|MONTHLY|YEARLY, INTERVAL, BYDAY (дни недели без номера,
+// кроме YEARLY), UNTIL и COUNT. Повторения вычисляются по местному времени пояса
+// пользователя, поэтому переход на летнее время не сдвигает время суток
+package recurrence
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+)
+
+// ErrInvalidRule правило не соответствует поддерживаемому подмножеству RRULE
+var ErrInvalidRule = i18n.New("err.invalid_rule")
+
+// Freq частота повторения
+type Freq string
+
+const (
+	Daily   Freq = "DAILY"
+	Weekly  Freq = "WEEKLY"
+	Monthly Freq = "MONTHLY"
+	Yearly  Freq = "YEARLY"
+)
+
+// Форматы UNTIL: момент в UTC или дата (до конца суток UTC)
+const (
+	untilLayout     = "20060102T150405Z"
+	untilDateLayout = "20060102"
+)
+
+// maxPeriods предел перебираемых периодов, защищающий от бесконечного цикла
+const maxPeriods = 100000
+
+// Коды дней недели RRULE в порядке от понедельника (WKST=MO)
+var weekdays = []struct {
+	code string
+	day  time.Weekday
+}{
+	{"MO", time.Monday},
+	{"TU", time.Tuesday},
+	{"WE", time.Wednesday},
+	{"TH", time.Thursday},
+	{"FR", time.Friday},
+	{"SA", time.Saturday},
+	{"SU", time.Sunday},
+}
+
+// Rule правило повторения
+type Rule struct {
+	Freq     Freq
+	Interval int
+	// ByDay дни недели, по которым происходят повторения; пусто - день недели начала серии
+	ByDay []time.Weekday
+	// Until последний допустимый момент повторения; нулевое значение - без ограничения
+	Until time.Time
+	// Count число повторений серии, включая первое; 0 - без ограничения
+	Count int
+}
+
+// Parse разбирает правило вида "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10"; префикс "RRULE:"
+// и регистр букв не важны
+func Parse(value string) (Rule, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	value = strings.TrimPrefix(value, "RRULE:")
+	rule := Rule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return Rule{}, i18n.Wrap(ErrInvalidRule, "ctx.rrule_part", part)
+		}
+		if seen[key] {
+			return Rule{}, i18n.Wrap(ErrInvalidRule, "ctx.rrule_repeated", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Freq = Freq(val)
+			if !slices.Contains([]Freq{Daily, Weekly, Monthly, Yearly}, rule.Freq) {
+				err = i18n.Wrap(ErrInvalidRule, "ctx.rrule_freq", val)
+			}
+		case "INTERVAL":
+			rule.Interval, err = positive(key, val)
+		case "COUNT":
+			rule.Count, err = positive(key, val)
+		case "UNTIL":
+			rule.Until, err = parseUntil(val)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "WKST":
+			if val != "MO" {
+				err = i18n.Wrap(ErrInvalidRule, "ctx.rrule_wkst", val)
+			}
+		default:
+			err = i18n.Wrap(ErrInvalidRule, "ctx.rrule_key", key)
+		}
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+
+	switch {
+	case rule.Freq == "":
+		return Rule{}, i18n.Wrap(ErrInvalidRule, "ctx.rrule_no_freq")
+	case rule.Count > 0 && !rule.Until.IsZero():
+		return Rule{}, i18n.Wrap(ErrInvalidRule, "ctx.rrule_count")
+	case rule.Freq == Yearly && len(rule.ByDay) > 0:
+		return Rule{}, i18n.Wrap(ErrInvalidRule, "ctx.rrule_yearly")
+	}
+	return rule, nil
+}
+
+// positive разбирает целое положительное значение части key
+func positive(key, val string) (int, error) {
+	n, err := strconv.Atoi(val)
+	if err != nil || n <= 0 {
+		return 0, i18n.Wrap(ErrInvalidRule, "ctx.rrule_positive", key, val)
+	}
+	return n, nil
+}
+
+// parseUntil разбирает UNTIL; дата без времени означает конец этих суток по UTC
+func parseUntil(val string) (time.Time, error) {
+	if t, err := time.Parse(untilLayout, val); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(untilDateLayout, val); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, i18n.Wrap(ErrInvalidRule, "ctx.rrule_until", val)
+}
+
+// parseByDay разбирает список дней недели BYDAY; результат упорядочен от понедельника
+func parseByDay(val string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, code := range strings.Split(val, ",") {
+		day, ok := weekdayByCode(code)
+		if !ok {
+			return nil, i18n.Wrap(ErrInvalidRule, "ctx.rrule_weekday", code)
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	slices.SortFunc(days, func(a, b time.Weekday) int {
+		return weekdayIndex(a) - weekdayIndex(b)
+	})
+	return days, nil
+}
+
+// weekdayByCode возвращает день недели по коду RRULE
+func weekdayByCode(code string) (time.Weekday, bool) {
+	for _, w := range weekdays {
+		if w.code == code {
+			return w.day, true
+		}
+	}
+	return 0, false
+}
+
+// weekdayIndex номер дня недели, считая от понедельника
+func weekdayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// String возвращает правило в каноническом виде RRULE без префикса
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			codes[i] = weekdays[weekdayIndex(day)].code
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next возвращает первое повторение серии, начинающейся в start, строго позже after
+func (r Rule) Next(start, after time.Time, loc *time.Location) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.each(start, loc, func(t time.Time) bool {
+		if t.After(after) {
+			next, found = t, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// Advance возвращает повторение, следующее за текущим current, и правило для оставшейся
+// части серии: серия начинается заново с нового повторения, поэтому COUNT уменьшается на 1
+func (r Rule) Advance(current time.Time, loc *time.Location) (time.Time, Rule, bool) {
+	next, ok := r.Next(current, current, loc)
+	if !ok {
+		return time.Time{}, r, false
+	}
+	rest := r
+	if rest.Count > 0 {
+		rest.Count--
+	}
+	return next, rest, true
+}
+
+// Between возвращает не больше limit повторений серии, начинающейся в start,
+// попадающих в интервал [from, to)
+func (r Rule) Between(start, from, to time.Time, loc *time.Location, limit int) []time.Time {
+	var list []time.Time
+	r.each(start, loc, func(t time.Time) bool {
+		if !t.Before(to) || len(list) >= limit {
+			return false
+		}
+		if !t.Before(from) {
+			list = append(list, t)
+		}
+		return len(list) < limit
+	})
+	return list
+}
+
+// each перебирает повторения серии, начинающейся в start, по возрастанию, пока yield
+// возвращает true, с учётом COUNT и UNTIL
+func (r Rule) each(start time.Time, loc *time.Location, yield func(time.Time) bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := start.In(loc)
+	interval := max(r.Interval, 1)
+	emitted := 0
+	for period := 0; period < maxPeriods; period++ {
+		for _, t := range r.candidates(local, period*interval) {
+			if t.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && t.After(r.Until) {
+				return
+			}
+			if !yield(t) {
+				return
+			}
+			emitted++
+			if r.Count > 0 && emitted >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// candidates возвращает по возрастанию моменты повторений в периоде, отстоящем от
+// начала серии local на offset единиц частоты
+func (r Rule) candidates(local time.Time, offset int) []time.Time {
+	hour, minute, sec := local.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, sec, local.Nanosecond(), local.Location())
+	}
+	year, month, day := local.Date()
+
+	switch r.Freq {
+	case Daily:
+		t := at(year, month, day+offset)
+		if len(r.ByDay) > 0 && !slices.Contains(r.ByDay, t.Weekday()) {
+			return nil
+		}
+		return []time.Time{t}
+	case Weekly:
+		monday := day - weekdayIndex(local.Weekday()) + 7*offset
+		if len(r.ByDay) == 0 {
+			return []time.Time{at(year, month, day+7*offset)}
+		}
+		list := make([]time.Time, 0, len(r.ByDay))
+		for _, wd := range r.ByDay {
+			list = append(list, at(year, month, monday+weekdayIndex(wd)))
+		}
+		return list
+	case Monthly:
+		first := time.Date(year, month+time.Month(offset), 1, 0, 0, 0, 0, local.Location())
+		if len(r.ByDay) == 0 {
+			if day > daysIn(first) {
+				return nil
+			}
+			return []time.Time{at(first.Year(), first.Month(), day)}
+		}
+		var list []time.Time
+		for d := 1; d <= daysIn(first); d++ {
+			t := at(first.Year(), first.Month(), d)
+			if slices.Contains(r.ByDay, t.Weekday()) {
+				list = append(list, t)
+			}
+		}
+		return list
+	case Yearly:
+		if day > daysIn(time.Date(year+offset, month, 1, 0, 0, 0, 0, local.Location())) {
+			return nil
+		}
+		return []time.Time{at(year+offset, month, day)}
+	}
+	return nil
+}
+
+// daysIn число дней в месяце, которому принадлежит first
+func daysIn(first time.Time) int {
+	return time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, first.Location()).Day()
+}
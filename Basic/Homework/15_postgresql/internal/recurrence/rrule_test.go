@@ -0,0 +1,169 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // часовые пояса для проверки перехода на летнее время
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "daily", value: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{name: "prefix and lower case", value: " rrule:freq=weekly;interval=2 ", want: "FREQ=WEEKLY;INTERVAL=2"},
+		{name: "interval of one is omitted", value: "FREQ=MONTHLY;INTERVAL=1", want: "FREQ=MONTHLY"},
+		{name: "weekdays are ordered from monday", value: "FREQ=WEEKLY;BYDAY=SU,MO,WE,MO", want: "FREQ=WEEKLY;BYDAY=MO,WE,SU"},
+		{name: "count", value: "FREQ=YEARLY;COUNT=5;WKST=MO", want: "FREQ=YEARLY;COUNT=5"},
+		{name: "until date means end of day", value: "FREQ=DAILY;UNTIL=20261231", want: "FREQ=DAILY;UNTIL=20261231T235959Z"},
+		{name: "empty parts are skipped", value: "FREQ=DAILY;;", want: "FREQ=DAILY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := Parse(tt.value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rule.String())
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "empty rule", value: ""},
+		{name: "part without value", value: "FREQ=DAILY;COUNT"},
+		{name: "unknown frequency", value: "FREQ=HOURLY"},
+		{name: "repeated key", value: "FREQ=DAILY;FREQ=WEEKLY"},
+		{name: "unknown key", value: "FREQ=DAILY;BYMONTH=1"},
+		{name: "zero interval", value: "FREQ=DAILY;INTERVAL=0"},
+		{name: "negative count", value: "FREQ=DAILY;COUNT=-1"},
+		{name: "invalid until", value: "FREQ=DAILY;UNTIL=2026-12-31"},
+		{name: "numbered weekday", value: "FREQ=MONTHLY;BYDAY=1MO"},
+		{name: "week start other than monday", value: "FREQ=WEEKLY;WKST=SU"},
+		{name: "count with until", value: "FREQ=DAILY;COUNT=2;UNTIL=20261231"},
+		{name: "yearly by weekday", value: "FREQ=YEARLY;BYDAY=MO"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(tt.value)
+
+			assert.ErrorIs(t, err, ErrInvalidRule)
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	utc := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		loc   *time.Location
+		limit int
+		want  []time.Time
+	}{
+		{
+			name:  "daily with count",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: utc(time.November, 2, 9),
+			limit: 10,
+			want:  []time.Time{utc(time.November, 2, 9), utc(time.November, 3, 9), utc(time.November, 4, 9)},
+		},
+		{
+			name:  "every other day within limit",
+			rule:  "FREQ=DAILY;INTERVAL=2",
+			start: utc(time.November, 2, 9),
+			limit: 2,
+			want:  []time.Time{utc(time.November, 2, 9), utc(time.November, 4, 9)},
+		},
+		{
+			// 2 ноября 2026 - понедельник
+			name:  "weekly on weekdays",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,FR;UNTIL=20261110",
+			start: utc(time.November, 2, 9),
+			limit: 10,
+			want:  []time.Time{utc(time.November, 2, 9), utc(time.November, 6, 9), utc(time.November, 9, 9)},
+		},
+		{
+			name:  "monthly skips months without the day",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			start: time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC),
+			limit: 10,
+			want: []time.Time{
+				time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, time.March, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, time.May, 31, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// 29 марта 2026 Берлин переходит на летнее время: 9:00 по местному времени сохраняется
+			name:  "local time is kept across daylight saving change",
+			rule:  "FREQ=DAILY;COUNT=2",
+			start: utc(time.March, 28, 8),
+			loc:   berlin,
+			limit: 10,
+			want:  []time.Time{utc(time.March, 28, 8), utc(time.March, 29, 7)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := Parse(tt.rule)
+			assert.NoError(t, err)
+
+			got := rule.Between(tt.start, tt.start, tt.start.AddDate(1, 0, 0), tt.loc, tt.limit)
+
+			assert.Len(t, got, len(tt.want))
+			for i := range min(len(got), len(tt.want)) {
+				assert.True(t, tt.want[i].Equal(got[i]), "повторение %d: ожидалось %v, получено %v", i, tt.want[i], got[i])
+			}
+		})
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	current := time.Date(2026, time.November, 2, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		rule string
+		next time.Time
+		rest string
+		ok   bool
+	}{
+		{name: "unbounded series", rule: "FREQ=WEEKLY", next: current.AddDate(0, 0, 7), rest: "FREQ=WEEKLY", ok: true},
+		{name: "count is decreased", rule: "FREQ=DAILY;COUNT=3", next: current.AddDate(0, 0, 1), rest: "FREQ=DAILY;COUNT=2", ok: true},
+		{name: "last occurrence", rule: "FREQ=DAILY;COUNT=1", rest: "FREQ=DAILY;COUNT=1"},
+		{name: "until reached", rule: "FREQ=DAILY;UNTIL=20261102", rest: "FREQ=DAILY;UNTIL=20261102T235959Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := Parse(tt.rule)
+			assert.NoError(t, err)
+
+			next, rest, ok := rule.Advance(current, time.UTC)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.True(t, tt.next.Equal(next))
+			assert.Equal(t, tt.rest, rest.String())
+		})
+	}
+}
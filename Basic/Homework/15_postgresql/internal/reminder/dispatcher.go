@@ -0,0 +1,431 @@
+package reminder
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// Actor автор изменений, которые диспетчер вносит в хранилище (отметка о срабатывании напоминания)
+const Actor = "reminder"
+
+// loadPageSize размер страницы при загрузке задач и заметок из хранилища
+const loadPageSize = 500
+
+// idleWait время ожидания при пустой очереди; диспетчер просыпается раньше при планировании
+const idleWait = time.Hour
+
+// errStale задача или заметка изменилась после того, как напоминание было запланировано
+var errStale = errors.New("напоминание устарело")
+
+// Options настройки диспетчера
+type Options struct {
+	// Timeout предельное время доставки напоминания одним способом
+	Timeout time.Duration
+	// RetryMin и RetryMax границы задержки повторной доставки; задержка удваивается с каждой попыткой
+	RetryMin time.Duration
+	RetryMax time.Duration
+	// CatchUp наибольшее опоздание доставки; 0 - без ограничения
+	CatchUp time.Duration
+	// Lang и Location язык и часовой пояс текста напоминаний
+	Lang     i18n.Lang
+	Location *time.Location
+}
+
+// key идентификатор задачи или заметки в очереди
+type key struct {
+	entityType string
+	id         int
+}
+
+// item запланированное напоминание
+type item struct {
+	reminder Reminder
+	// due момент очередной попытки доставки: FireAt, а после ошибок - время повтора
+	due     time.Time
+	attempt int
+	// notified способы доставки, которым напоминание уже доставлено в текущей серии попыток
+	notified map[string]bool
+	index    int
+}
+
+// queue очередь напоминаний, упорядоченная по due; реализует heap.Interface
+type queue []*item
+
+func (q queue) Len() int           { return len(q) }
+func (q queue) Less(i, j int) bool { return q[i].due.Before(q[j].due) }
+func (q queue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *queue) Push(x any) {
+	it := x.(*item)
+	it.index = len(*q)
+	*q = append(*q, it)
+}
+
+func (q *queue) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	old[len(old)-1] = nil
+	it.index = -1
+	*q = old[:len(old)-1]
+	return it
+}
+
+// Dispatcher планирует и доставляет напоминания о задачах и заметках
+type Dispatcher struct {
+	store     storage.Store
+	notifiers []Notifier
+	opts      Options
+	now       func() time.Time
+
+	mu    sync.Mutex
+	queue queue
+	items map[key]*item
+	wake  chan struct{}
+}
+
+// New создаёт диспетчер напоминаний; store используется для отметок о доставке
+// и о срабатывании напоминаний
+func New(store storage.Store, notifiers []Notifier, opts Options) *Dispatcher {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	return &Dispatcher{
+		store:     store,
+		notifiers: notifiers,
+		opts:      opts,
+		now:       time.Now,
+		items:     make(map[key]*item),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Load планирует ожидающие срабатывания напоминания незавершённых задач и заметок хранилища.
+// Задачи и заметки, запланированные до окончания загрузки, не перезаписываются
+func (d *Dispatcher) Load(ctx context.Context) error {
+	count := 0
+	for cursor := ""; ; {
+		page, err := d.store.ListTasks(ctx, storage.TaskFilter{Sort: "id", Limit: loadPageSize, Cursor: cursor})
+		if err != nil {
+			return err
+		}
+		for _, task := range page.Items {
+			if !task.Closed() && task.ReminderState.Waiting() && d.schedule(taskReminder(task, d.opts.Lang, d.opts.Location), false) {
+				count++
+			}
+		}
+		if cursor = page.NextCursor; cursor == "" {
+			break
+		}
+	}
+	for cursor := ""; ; {
+		page, err := d.store.ListNotes(ctx, storage.NoteFilter{Sort: "id", Limit: loadPageSize, Cursor: cursor})
+		if err != nil {
+			return err
+		}
+		for _, note := range page.Items {
+			if note.ReminderState.Waiting() && d.schedule(noteReminder(note, d.opts.Lang, d.opts.Location), false) {
+				count++
+			}
+		}
+		if cursor = page.NextCursor; cursor == "" {
+			break
+		}
+	}
+	slog.Info("reminders loaded", "count", count)
+	return nil
+}
+
+// ScheduleTask планирует напоминание о сроке задачи; напоминание завершённой
+// или отменённой задачи, а также сработавшее, подтверждённое или отклонённое снимается
+func (d *Dispatcher) ScheduleTask(task model.Task) {
+	if task.Closed() || !task.ReminderState.Waiting() {
+		d.Cancel(storage.EntityTask, task.Id)
+		return
+	}
+	d.schedule(taskReminder(task, d.opts.Lang, d.opts.Location), true)
+}
+
+// ScheduleNote планирует напоминание заметки; напоминание, которое уже не ожидает
+// срабатывания, снимается
+func (d *Dispatcher) ScheduleNote(note model.Note) {
+	if !note.ReminderState.Waiting() {
+		d.Cancel(storage.EntityNote, note.Id)
+		return
+	}
+	d.schedule(noteReminder(note, d.opts.Lang, d.opts.Location), true)
+}
+
+// Cancel снимает напоминание задачи или заметки
+func (d *Dispatcher) Cancel(entityType string, id int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	k := key{entityType, id}
+	if it, ok := d.items[k]; ok {
+		heap.Remove(&d.queue, it.index)
+		delete(d.items, k)
+	}
+}
+
+// schedule ставит напоминание в очередь. Если напоминание сущности уже запланировано,
+// при replace оно заменяется; при неизменном FireAt сохраняется состояние повторов.
+// Возвращает true, если напоминание поставлено в очередь
+func (d *Dispatcher) schedule(r Reminder, replace bool) bool {
+	if r.FireAt.IsZero() {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	k := key{r.EntityType, r.EntityId}
+	if it, ok := d.items[k]; ok {
+		if !replace {
+			return false
+		}
+		if it.reminder.FireAt.Equal(r.FireAt) {
+			it.reminder = r
+			return true
+		}
+		it.reminder, it.due, it.attempt, it.notified = r, r.FireAt, 0, nil
+		heap.Fix(&d.queue, it.index)
+	} else {
+		it = &item{reminder: r, due: r.FireAt}
+		heap.Push(&d.queue, it)
+		d.items[k] = it
+	}
+	d.nudge()
+	return true
+}
+
+// nudge будит цикл Run, чтобы тот пересчитал время ожидания; вызывается под d.mu
+func (d *Dispatcher) nudge() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run доставляет наступившие напоминания до отмены ctx
+func (d *Dispatcher) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		case <-timer.C:
+		}
+		for _, it := range d.takeDue() {
+			if ctx.Err() != nil {
+				return
+			}
+			d.fire(ctx, it)
+		}
+		timer.Reset(d.untilNext())
+	}
+}
+
+// takeDue извлекает из очереди напоминания, время которых наступило
+func (d *Dispatcher) takeDue() []*item {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	var due []*item
+	for len(d.queue) > 0 && !d.queue[0].due.After(now) {
+		it := heap.Pop(&d.queue).(*item)
+		delete(d.items, key{it.reminder.EntityType, it.reminder.EntityId})
+		due = append(due, it)
+	}
+	return due
+}
+
+// untilNext возвращает время до ближайшего напоминания
+func (d *Dispatcher) untilNext() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.queue) == 0 {
+		return idleWait
+	}
+	return max(d.queue[0].due.Sub(d.now()), 0)
+}
+
+// fire доставляет напоминание, отмечает его доставленным и переводит задачу или заметку
+// в состояние ReminderFired. При ошибке доставка повторяется позже
+func (d *Dispatcher) fire(ctx context.Context, it *item) {
+	r := it.reminder
+	log := slog.With("entity", r.EntityType, "id", r.EntityId, "fireAt", r.FireAt)
+
+	r, ok, err := d.current(ctx, r)
+	if err != nil {
+		d.retry(it, err)
+		return
+	}
+	if !ok {
+		log.Debug("reminder is no longer relevant")
+		return
+	}
+	it.reminder = r
+
+	delivered, err := d.store.Delivered(ctx, r.EntityType, r.EntityId, r.FireAt)
+	if err != nil {
+		d.retry(it, err)
+		return
+	}
+	switch late := d.now().Sub(r.FireAt); {
+	case delivered:
+		log.Debug("reminder already delivered")
+	case d.opts.CatchUp > 0 && late > d.opts.CatchUp:
+		log.Warn("reminder missed", "late", late.Round(time.Second))
+	default:
+		if err := d.deliver(ctx, it); err != nil {
+			d.retry(it, err)
+			return
+		}
+		err := d.store.MarkDelivered(ctx, storage.Delivery{
+			EntityType:  r.EntityType,
+			EntityId:    r.EntityId,
+			FireAt:      r.FireAt,
+			DeliveredAt: d.now().UTC(),
+		})
+		if err != nil {
+			d.retry(it, err)
+			return
+		}
+		log.Info("reminder delivered", "attempts", it.attempt+1)
+	}
+
+	d.markFired(ctx, r)
+}
+
+// current перечитывает задачу или заметку и сообщает, актуально ли напоминание r:
+// сущность существует, задача не завершена, напоминание ожидает срабатывания
+// и время срабатывания не изменилось
+func (d *Dispatcher) current(ctx context.Context, r Reminder) (Reminder, bool, error) {
+	var fresh Reminder
+	switch r.EntityType {
+	case storage.EntityTask:
+		task, err := d.store.GetTask(ctx, r.EntityId)
+		if errors.Is(err, storage.ErrNotFound) {
+			return r, false, nil
+		}
+		if err != nil {
+			return r, false, err
+		}
+		if task.Closed() || !task.ReminderState.Waiting() {
+			return r, false, nil
+		}
+		fresh = taskReminder(task, d.opts.Lang, d.opts.Location)
+	case storage.EntityNote:
+		note, err := d.store.GetNote(ctx, r.EntityId)
+		if errors.Is(err, storage.ErrNotFound) {
+			return r, false, nil
+		}
+		if err != nil {
+			return r, false, err
+		}
+		if !note.ReminderState.Waiting() {
+			return r, false, nil
+		}
+		fresh = noteReminder(note, d.opts.Lang, d.opts.Location)
+	default:
+		return r, false, nil
+	}
+	if !fresh.FireAt.Equal(r.FireAt) {
+		// Напоминание перенесено: актуальное время ставится в очередь, если его там ещё нет
+		d.schedule(fresh, false)
+		return r, false, nil
+	}
+	return fresh, true, nil
+}
+
+// deliver передаёт напоминание способам доставки, которым оно ещё не доставлено
+func (d *Dispatcher) deliver(ctx context.Context, it *item) error {
+	var errs []error
+	for _, n := range d.notifiers {
+		if it.notified[n.Name()] {
+			continue
+		}
+		nctx, cancel := context.WithTimeout(ctx, d.opts.Timeout)
+		err := n.Notify(nctx, it.reminder)
+		cancel()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if it.notified == nil {
+			it.notified = make(map[string]bool)
+		}
+		it.notified[n.Name()] = true
+	}
+	return errors.Join(errs...)
+}
+
+// retry возвращает напоминание в очередь с экспоненциальной задержкой,
+// если за время доставки его не перепланировали
+func (d *Dispatcher) retry(it *item, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	r := it.reminder
+	k := key{r.EntityType, r.EntityId}
+	if _, ok := d.items[k]; ok {
+		return
+	}
+	delay := d.opts.RetryMin << min(it.attempt, 30)
+	if delay <= 0 || delay > d.opts.RetryMax {
+		delay = d.opts.RetryMax
+	}
+	it.attempt++
+	it.due = d.now().Add(delay)
+	heap.Push(&d.queue, it)
+	d.items[k] = it
+	d.nudge()
+
+	slog.Warn("reminder delivery failed",
+		"entity", r.EntityType, "id", r.EntityId, "fireAt", r.FireAt,
+		"attempt", it.attempt, "retryIn", delay, "error", err)
+}
+
+// markFired переводит задачу или заметку в состояние ReminderFired, если напоминание
+// за время доставки не изменилось. Сработавшее напоминание ждёт реакции пользователя:
+// откладывания, подтверждения или отклонения
+func (d *Dispatcher) markFired(ctx context.Context, r Reminder) {
+	ctx = storage.WithActor(ctx, Actor)
+	var err error
+	switch r.EntityType {
+	case storage.EntityTask:
+		_, err = d.store.UpdateTask(ctx, r.EntityId, func(task *model.Task) error {
+			if !task.Fire(r.FireAt) {
+				return errStale
+			}
+			return nil
+		})
+	case storage.EntityNote:
+		_, err = d.store.UpdateNote(ctx, r.EntityId, func(note *model.Note) error {
+			if !note.Fire(r.FireAt) {
+				return errStale
+			}
+			return nil
+		})
+	}
+	if err != nil && !errors.Is(err, errStale) && !errors.Is(err, storage.ErrNotFound) {
+		// Напоминание уже отмечено доставленным: при следующей загрузке отметка повторится
+		slog.Error("mark reminder fired", "entity", r.EntityType, "id", r.EntityId, "error", err)
+	}
+}
@@ -0,0 +1,175 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/config"
+)
+
+// Notifier способ доставки напоминаний
+type Notifier interface {
+	// Name имя способа доставки для журнала и учёта доставленных напоминаний
+	Name() string
+	// Notify доставляет напоминание; ошибка означает, что доставку нужно повторить
+	Notify(ctx context.Context, r Reminder) error
+}
+
+// Notifiers создаёт способы доставки, перечисленные в cfg.Notifiers;
+// способ stdout пишет в out
+func Notifiers(cfg config.RemindersConfig, out io.Writer) ([]Notifier, error) {
+	var list []Notifier
+	for _, name := range cfg.NotifierList() {
+		switch name {
+		case config.NotifierStdout:
+			list = append(list, &WriterNotifier{w: out})
+		case config.NotifierFile:
+			list = append(list, FileNotifier{Path: cfg.File})
+		case config.NotifierWebhook:
+			list = append(list, WebhookNotifier{URL: cfg.WebhookURL, Client: http.DefaultClient})
+		case config.NotifierSocket:
+			list = append(list, SocketNotifier{Path: cfg.Socket})
+		default:
+			return nil, fmt.Errorf("неизвестный способ доставки напоминаний %q", name)
+		}
+	}
+	return list, nil
+}
+
+// WriterNotifier выводит текст напоминания строкой, напр. в stdout
+type WriterNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Name реализует Notifier
+func (n *WriterNotifier) Name() string {
+	return config.NotifierStdout
+}
+
+// Notify реализует Notifier
+func (n *WriterNotifier) Notify(_ context.Context, r Reminder) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	_, err := fmt.Fprintf(n.w, "%s %s\n", r.FireAt.Format("2006-01-02T15:04:05Z07:00"), r.Text)
+	return err
+}
+
+// FileNotifier дописывает напоминания в файл журнала по одному JSON-объекту в строке
+type FileNotifier struct {
+	Path string
+}
+
+// Name реализует Notifier
+func (n FileNotifier) Name() string {
+	return config.NotifierFile
+}
+
+// Notify реализует Notifier
+func (n FileNotifier) Notify(_ context.Context, r Reminder) error {
+	line, err := jsonLine(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(n.Path), 0o755); err != nil {
+		return fmt.Errorf("ошибка создания каталога файла напоминаний: %w", err)
+	}
+	file, err := os.OpenFile(n.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("ошибка открытия файла напоминаний: %w", err)
+	}
+	if _, err := file.Write(line); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("ошибка записи в файл напоминаний: %w", err)
+	}
+	return file.Close()
+}
+
+// WebhookNotifier отправляет напоминание POST-запросом с телом JSON.
+// Заголовок Idempotency-Key одинаков при повторных доставках одного напоминания
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// Name реализует Notifier
+func (n WebhookNotifier) Name() string {
+	return config.NotifierWebhook
+}
+
+// Notify реализует Notifier; ответ со статусом вне 2xx считается ошибкой
+func (n WebhookNotifier) Notify(ctx context.Context, r Reminder) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации напоминания: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", r.Key())
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook ответил %s", resp.Status)
+	}
+	return nil
+}
+
+// SocketNotifier пишет напоминание строкой JSON в локальный Unix-сокет;
+// для каждого напоминания открывается отдельное соединение
+type SocketNotifier struct {
+	Path string
+}
+
+// Name реализует Notifier
+func (n SocketNotifier) Name() string {
+	return config.NotifierSocket
+}
+
+// Notify реализует Notifier
+func (n SocketNotifier) Notify(ctx context.Context, r Reminder) error {
+	line, err := jsonLine(r)
+	if err != nil {
+		return err
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", n.Path)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+	}
+	if _, err := conn.Write(line); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	return conn.Close()
+}
+
+// jsonLine сериализует напоминание в строку JSON с переводом строки
+func jsonLine(r Reminder) ([]byte, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации напоминания: %w", err)
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,73 @@
+// Package reminder доставляет напоминания о наступлении сроков задач и времени напоминаний заметок.
+//
+// Dispatcher держит в памяти очередь напоминаний, упорядоченную по времени срабатывания,
+// восстанавливает её из хранилища при запуске и перепланирует при изменении задач и заметок
+// (см. Watch). Наступившее напоминание передаётся всем способам доставки Notifier; при ошибке
+// доставка повторяется с экспоненциальной задержкой (доставка «хотя бы один раз»).
+// Доставленное напоминание отмечается в хранилище и после перезапуска не срабатывает повторно
+package reminder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// Reminder напоминание, передаваемое способам доставки
+type Reminder struct {
+	EntityType  string    `json:"entityType"`
+	EntityId    int       `json:"entityId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	FireAt      time.Time `json:"fireAt"`
+	Recurrence  string    `json:"recurrence,omitempty"`
+	// Text текст напоминания на языке и в часовом поясе по умолчанию
+	Text string `json:"text"`
+}
+
+// Key идентификатор напоминания, одинаковый при повторных доставках; получатели
+// могут отбрасывать по нему дубликаты
+func (r Reminder) Key() string {
+	return fmt.Sprintf("%s:%d:%d", r.EntityType, r.EntityId, r.FireAt.UnixMilli())
+}
+
+// taskReminder формирует напоминание о сроке задачи
+func taskReminder(task model.Task, lang i18n.Lang, loc *time.Location) Reminder {
+	return Reminder{
+		EntityType:  storage.EntityTask,
+		EntityId:    task.Id,
+		Name:        task.Name,
+		Description: task.Description,
+		FireAt:      task.DueDate,
+		Recurrence:  task.Recurrence,
+		Text: i18n.T(lang, "reminder.task",
+			task.Name, task.Id, task.DueDate.In(zone(task.Timezone, loc)).Format(model.DueDateLayout), task.Description),
+	}
+}
+
+// noteReminder формирует напоминание заметки
+func noteReminder(note model.Note, lang i18n.Lang, loc *time.Location) Reminder {
+	return Reminder{
+		EntityType:  storage.EntityNote,
+		EntityId:    note.Id,
+		Name:        note.Name,
+		Description: note.Description,
+		FireAt:      note.AlarmTimeStamp,
+		Recurrence:  note.Recurrence,
+		Text: i18n.T(lang, "reminder.note",
+			note.Name, note.Id, note.AlarmTimeStamp.In(zone(note.Timezone, loc)).Format(model.AlarmTimeLayout), note.Description),
+	}
+}
+
+// zone возвращает часовой пояс повторяющейся задачи или заметки, иначе пояс по умолчанию loc
+func zone(name string, loc *time.Location) *time.Location {
+	if name != "" {
+		if tz, err := time.LoadLocation(name); err == nil {
+			return tz
+		}
+	}
+	return loc
+}
@@ -0,0 +1,93 @@
+package reminder
+
+import (
+	"context"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// watchedStore хранилище, сообщающее диспетчеру об успешных изменениях задач и заметок
+type watchedStore struct {
+	storage.Store
+	dispatcher *Dispatcher
+}
+
+// Watch возвращает хранилище store, созданные, изменённые, удалённые и восстановленные из корзины
+// задачи и заметки которого перепланируются в диспетчере d
+func Watch(store storage.Store, d *Dispatcher) storage.Store {
+	return watchedStore{Store: store, dispatcher: d}
+}
+
+// CreateTask реализует storage.TaskStore
+func (s watchedStore) CreateTask(ctx context.Context, task model.Task) (model.Task, error) {
+	task, err := s.Store.CreateTask(ctx, task)
+	if err == nil {
+		s.dispatcher.ScheduleTask(task)
+	}
+	return task, err
+}
+
+// UpdateTask реализует storage.TaskStore
+func (s watchedStore) UpdateTask(ctx context.Context, id int, change func(task *model.Task) error) (model.Task, error) {
+	task, err := s.Store.UpdateTask(ctx, id, change)
+	if err == nil {
+		s.dispatcher.ScheduleTask(task)
+	}
+	return task, err
+}
+
+// DeleteTask реализует storage.TaskStore. Напоминания удалённых вместе с задачей подзадач
+// и заметок диспетчер отбрасывает сам, не найдя их в хранилище
+func (s watchedStore) DeleteTask(ctx context.Context, id int, opts storage.DeleteOptions) (model.Task, error) {
+	task, err := s.Store.DeleteTask(ctx, id, opts)
+	if err == nil {
+		s.dispatcher.Cancel(storage.EntityTask, id)
+	}
+	return task, err
+}
+
+// CreateNote реализует storage.NoteStore
+func (s watchedStore) CreateNote(ctx context.Context, note model.Note) (model.Note, error) {
+	note, err := s.Store.CreateNote(ctx, note)
+	if err == nil {
+		s.dispatcher.ScheduleNote(note)
+	}
+	return note, err
+}
+
+// UpdateNote реализует storage.NoteStore
+func (s watchedStore) UpdateNote(ctx context.Context, id int, change func(note *model.Note) error) (model.Note, error) {
+	note, err := s.Store.UpdateNote(ctx, id, change)
+	if err == nil {
+		s.dispatcher.ScheduleNote(note)
+	}
+	return note, err
+}
+
+// DeleteNote реализует storage.NoteStore
+func (s watchedStore) DeleteNote(ctx context.Context, id int) (model.Note, error) {
+	note, err := s.Store.DeleteNote(ctx, id)
+	if err == nil {
+		s.dispatcher.Cancel(storage.EntityNote, id)
+	}
+	return note, err
+}
+
+// RestoreTask реализует storage.TrashStore
+func (s watchedStore) RestoreTask(ctx context.Context, id int) (model.Task, error) {
+	task, err := s.Store.RestoreTask(ctx, id)
+	if err == nil {
+		s.dispatcher.ScheduleTask(task)
+	}
+	return task, err
+}
+
+// RestoreNote реализует storage.TrashStore
+func (s watchedStore) RestoreNote(ctx context.Context, id int) (model.Note, error) {
+	note, err := s.Store.RestoreNote(ctx, id)
+	if err == nil {
+		s.dispatcher.ScheduleNote(note)
+	}
+	return note, err
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/auth"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// LogQuery параметры запроса к журналу изменений
+type LogQuery struct {
+	Entity   string    `form:"entity" binding:"omitempty,oneof=task note list"`
+	EntityId int       `form:"entity_id" binding:"omitempty,gt=0"`
+	Action   string    `form:"action" binding:"omitempty,oneof=create update delete share unshare restore purge"`
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit    int       `form:"limit,default=50" binding:"gte=1,lte=500"`
+	Offset   int       `form:"offset,default=0" binding:"gte=0"`
+}
+
+// actor возвращает автора изменения: логин аутентифицированного пользователя,
+// а без него - значение заголовка X-Actor или IP-адрес клиента
+func actor(c *gin.Context) string {
+	if user, ok := auth.UserFrom(c.Request.Context()); ok {
+		return user.Login
+	}
+	if a := strings.TrimSpace(c.GetHeader("X-Actor")); a != "" {
+		return a
+	}
+	return c.ClientIP()
+}
+
+// GetLog
+// @Summary Получить журнал изменений задач и заметок
+// @Tags Журнал
+// @Produce	json
+// @Param entity query string false "Entity type: task, note or list"
+// @Param entity_id query int false "Entity ID"
+// @Param action query string false "Action: create, update, delete, share, unshare, restore or purge"
+// @Param from query string false "Lower bound of the record time, RFC 3339"
+// @Param to query string false "Upper bound of the record time, RFC 3339"
+// @Param limit query int false "Page size, 1..500" default(50)
+// @Param offset query int false "Number of records to skip" default(0)
+// @Success 200 {object} storage.LogPage "Getting the log is successful"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 500 {object} problem.Problem "Getting the log failed due to internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/log [get]
+// Обработка Get-запроса типа /api/log, напр.:
+// /api/log?entity=task&action=update&from=2026-10-01T00:00:00Z&limit=20&offset=40
+func GetLog(timeout time.Duration, logReader storage.LogReader) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var query LogQuery
+		if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+
+		page, err := logReader.ReadLog(ctx, storage.LogFilter(query))
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.log"))
+			return
+		}
+		c.JSON(http.StatusOK, page)
+	}
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/auth"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/gin-gonic/gin"
+)
+
+// Credentials логин и пароль пользователя
+type Credentials struct {
+	Login    string `json:"login" example:"alice"`
+	Password string `json:"password" example:"correct horse"`
+}
+
+// Authenticate проверяет токен доступа из заголовка Authorization ("Bearer <токен>")
+// и передаёт пользователя обработчикам в контексте запроса; хранилища видят в этом
+// контексте только записи пользователя. Без действующего токена отвечает 401
+func Authenticate(timeout time.Duration, accounts *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		user, err := accounts.Authenticate(ctx, auth.BearerToken(c.GetHeader("Authorization")))
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.authenticate"))
+			return
+		}
+		c.Request = c.Request.WithContext(auth.WithUser(c.Request.Context(), user))
+		c.Next()
+	}
+}
+
+// Register
+// @Summary Зарегистрировать пользователя
+// @Tags Пользователи
+// @Accept	json
+// @Produce	json
+// @Param credentials body Credentials true "Login and password"
+// @Success 201 {object} model.User "The user has been registered"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 409 {object} problem.Problem "The login is already taken"
+// @Failure 422 {object} problem.Problem "Validation failed: see errors for field-level details"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/auth/register [post]
+// Обработка Post-запроса /api/auth/register с телом {"login": "alice", "password": "..."}
+func Register(timeout time.Duration, accounts *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var credentials Credentials
+		if err := c.ShouldBindJSON(&credentials); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+		user, err := accounts.Register(ctx, credentials.Login, credentials.Password)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.register", credentials.Login))
+			return
+		}
+		c.JSON(http.StatusCreated, user)
+	}
+}
+
+// Login
+// @Summary Войти и получить токен доступа
+// @Tags Пользователи
+// @Accept	json
+// @Produce	json
+// @Param credentials body Credentials true "Login and password"
+// @Success 200 {object} auth.Token "Access token for the Authorization: Bearer header"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 401 {object} problem.Problem "Invalid login or password"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/auth/login [post]
+// Обработка Post-запроса /api/auth/login с телом {"login": "alice", "password": "..."}
+func Login(timeout time.Duration, accounts *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var credentials Credentials
+		if err := c.ShouldBindJSON(&credentials); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+		token, err := accounts.Login(ctx, credentials.Login, credentials.Password)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.login", credentials.Login))
+			return
+		}
+		c.JSON(http.StatusOK, token)
+	}
+}
+
+// Logout
+// @Summary Выйти: отозвать токен доступа запроса
+// @Tags Пользователи
+// @Security BearerAuth
+// @Success 204 "The token has been revoked"
+// @Failure 401 {object} problem.Problem "The access token is missing, unknown or expired"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/auth/logout [post]
+// Обработка Post-запроса /api/auth/logout; токен проверяется middleware Authenticate
+func Logout(timeout time.Duration, accounts *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		err := accounts.Logout(ctx, auth.BearerToken(c.GetHeader("Authorization")))
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.logout"))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// GetMe
+// @Summary Получить текущего пользователя
+// @Tags Пользователи
+// @Security BearerAuth
+// @Produce	json
+// @Success 200 {object} model.User "The user the access token was issued to"
+// @Failure 401 {object} problem.Problem "The access token is missing, unknown or expired"
+// @Router /api/auth/me [get]
+// Обработка Get-запроса /api/auth/me; пользователя определяет middleware Authenticate
+func GetMe() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.UserFrom(c.Request.Context())
+		if !ok {
+			problem.Error(c, auth.ErrUnauthorized)
+			return
+		}
+		c.JSON(http.StatusOK, user)
+	}
+}
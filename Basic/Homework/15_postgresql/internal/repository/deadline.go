@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/gin-gonic/gin"
+)
+
+// StatusClientClosedRequest клиент закрыл соединение, не дождавшись ответа (код nginx)
+const StatusClientClosedRequest = 499
+
+// opContext возвращает контекст операции с хранилищем: он отменяется вместе с запросом клиента
+// и по истечении timeout; при timeout <= 0 ограничение по времени не устанавливается
+func opContext(c *gin.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(c.Request.Context())
+	}
+	return context.WithTimeout(c.Request.Context(), timeout)
+}
+
+// abortOnContext отвечает 504, если операция не уложилась в отведённое время,
+// и 499, если клиент отключился; возвращает true, если ответ отправлен
+func abortOnContext(c *gin.Context, ctx context.Context) bool {
+	switch err := ctx.Err(); {
+	case errors.Is(err, context.DeadlineExceeded):
+		problem.Write(c, http.StatusGatewayTimeout, problem.CodeTimeout, problem.Message(c, "err.timeout"))
+		return true
+	case errors.Is(err, context.Canceled):
+		problem.Write(c, StatusClientClosedRequest, problem.CodeClientClosedRequest, problem.Message(c, "err.client_closed"))
+		return true
+	}
+	return false
+}
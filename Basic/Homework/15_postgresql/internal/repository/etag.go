@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// setETag отправляет версию записи в заголовке ETag в виде строгого тега "<версия>"
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// bindIfMatch разбирает заголовок If-Match и возвращает контекст изменения, которое выполняется,
+// только если текущая версия записи совпадает с одним из перечисленных тегов (см. storage.WithExpectedVersion).
+// Без заголовка и с If-Match: * запись изменяется независимо от версии. Слабые теги (W/"...")
+// при строгом сравнении не совпадают ни с чем; если в заголовке нет ни одного тега-версии,
+// отправляет ответ 412 и возвращает false
+func bindIfMatch(c *gin.Context, ctx context.Context) (context.Context, bool) {
+	header := c.Request.Header.Values("If-Match")
+	if len(header) == 0 {
+		return ctx, true
+	}
+	var versions []int
+	for _, tag := range strings.Split(strings.Join(header, ","), ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return ctx, true
+		}
+		unquoted, err := strconv.Unquote(tag)
+		if err != nil || !strings.HasPrefix(tag, `"`) {
+			continue
+		}
+		if version, err := strconv.Atoi(unquoted); err == nil && version > 0 {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		problem.Write(c, http.StatusPreconditionFailed, problem.CodePreconditionFailed, problem.Message(c, "err.precondition_failed"))
+		return ctx, false
+	}
+	return storage.WithExpectedVersion(ctx, versions...), true
+}
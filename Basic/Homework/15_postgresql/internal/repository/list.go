@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// TaskListQuery параметры фильтрации, сортировки и пагинации списка задач
+type TaskListQuery struct {
+	Status   model.Status     `form:"status"`
+	DueFrom  time.Time        `form:"due_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	DueTo    time.Time        `form:"due_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Name     string           `form:"name"`
+	Priority []model.Priority `form:"priority" binding:"dive,oneof=low normal high urgent"`
+	Tags     []string         `form:"tag"`
+	Sort     string           `form:"sort,default=id" binding:"oneof=id -id name -name dueDate -dueDate"`
+	Limit    int              `form:"limit,default=50" binding:"gte=1,lte=500"`
+	Cursor   string           `form:"cursor"`
+}
+
+// Filter преобразует параметры запроса в фильтр хранилища; метки нормализуются так же,
+// как при сохранении
+func (q TaskListQuery) Filter() storage.TaskFilter {
+	filter := storage.TaskFilter(q)
+	filter.Tags = model.NormalizeTags(filter.Tags)
+	return filter
+}
+
+// NoteListQuery параметры фильтрации, сортировки и пагинации списка заметок
+type NoteListQuery struct {
+	AlarmFrom time.Time        `form:"alarm_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	AlarmTo   time.Time        `form:"alarm_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Name      string           `form:"name"`
+	Priority  []model.Priority `form:"priority" binding:"dive,oneof=low normal high urgent"`
+	Tags      []string         `form:"tag"`
+	Sort      string           `form:"sort,default=id" binding:"oneof=id -id name -name alarmTimeStamp -alarmTimeStamp"`
+	Limit     int              `form:"limit,default=50" binding:"gte=1,lte=500"`
+	Cursor    string           `form:"cursor"`
+}
+
+// Filter преобразует параметры запроса в фильтр хранилища; соглашения те же, что у TaskListQuery
+func (q NoteListQuery) Filter() storage.NoteFilter {
+	filter := storage.NoteFilter(q)
+	filter.Tags = model.NormalizeTags(filter.Tags)
+	return filter
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/gin-gonic/gin"
+)
+
+// Locale определяет язык ответа по заголовку Accept-Language и часовой пояс
+// пользователя по заголовку X-Timezone (имя IANA) и передаёт их обработчикам
+// в контексте запроса; без заголовков используются fallbackLang и fallbackLoc
+func Locale(fallbackLang i18n.Lang, fallbackLoc *time.Location) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Negotiate(c.GetHeader("Accept-Language"), fallbackLang)
+		ctx := i18n.WithLang(c.Request.Context(), lang)
+		c.Request = c.Request.WithContext(ctx)
+		c.Header("Content-Language", string(lang))
+		c.Header("Vary", "Accept-Language, X-Timezone")
+
+		loc := fallbackLoc
+		if tz := c.GetHeader("X-Timezone"); tz != "" {
+			var err error
+			if loc, err = time.LoadLocation(tz); err != nil {
+				problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_timezone", tz))
+				return
+			}
+		}
+		c.Request = c.Request.WithContext(i18n.WithLocation(ctx, loc))
+		c.Next()
+	}
+}
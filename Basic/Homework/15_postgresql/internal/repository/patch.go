@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/patch"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// readPatch разбирает тело запроса PATCH по его типу содержимого (см. patch.Parse)
+func readPatch(c *gin.Context) (*patch.Patch, error) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, patch.ErrMalformed
+	}
+	return patch.Parse(c.ContentType(), body)
+}
+
+// changingTask возвращает изменяемые поля задачи в виде тела PUT-запроса, к которому применяется патч:
+// срок - в формате RFC 3339 (UTC), метки - всегда массивом, чтобы в него можно было добавить /tags/-
+func changingTask(task model.Task) ChangingTask {
+	return ChangingTask{
+		Name:        task.Name,
+		Description: task.Description,
+		DueDate:     task.DueDate.UTC().Format(time.RFC3339Nano),
+		Recurrence:  task.Recurrence,
+		Priority:    task.Priority,
+		Tags:        append([]string{}, task.Tags...),
+	}
+}
+
+// changingNote возвращает изменяемые поля заметки; соглашения те же, что у changingTask
+func changingNote(note model.Note) ChangingNote {
+	return ChangingNote{
+		Name:           note.Name,
+		Description:    note.Description,
+		AlarmTimeStamp: note.AlarmTimeStamp.UTC().Format(time.RFC3339Nano),
+		Recurrence:     note.Recurrence,
+		Priority:       note.Priority,
+		Tags:           append([]string{}, note.Tags...),
+	}
+}
+
+// PatchTaskById
+// @Summary Частично изменить задачу по ее ID
+// @Description Изменяются только поля, переданные в патче; тело - JSON Merge Patch (RFC 7396,
+// @Description Content-Type: application/merge-patch+json) или JSON Patch (RFC 6902,
+// @Description Content-Type: application/json-patch+json) к документу ChangingTask.
+// @Description В merge patch null очищает поле. Пока срок и правило повторения не меняются,
+// @Description повторения остаются в прежнем часовом поясе
+// @Tags Изменить задачу
+// @Accept	application/merge-patch+json,application/json-patch+json
+// @Produce	json
+// @Param id query int true "Task ID"
+// @Param If-Match header string false "ETag of the task version being changed"
+// @Param patch body ChangingTask true "Patch of the task data" body is a merge patch or a JSON Patch array
+// @Success 200 {object} model.Task "The task has been successfully updated"
+// @Header 200 {string} ETag "New version of the task"
+// @Failure 400 {object} problem.Problem "Invalid request or malformed patch: the task hasn't been updated"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 409 {object} problem.Problem "A task with this name already exists, the task is closed, was changed concurrently or a test operation failed"
+// @Failure 412 {object} problem.Problem "The task version doesn't match If-Match"
+// @Failure 415 {object} problem.Problem "Unsupported patch type; supported types are listed in Accept-Patch"
+// @Failure 422 {object} problem.Problem "The patch cannot be applied or the patched task failed validation"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/item/id [patch]
+// Обработка Patch-запроса типа /api/item/id для задач
+// id в запросе передается в виде целого неотрицательного числа, большего нуля, напр.:
+// /api/tasks/item/id?id=1
+func PatchTaskById(
+	timeout time.Duration,
+	tasks storage.TaskStore,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var taskId RemindableId
+		if err := c.ShouldBindWith(&taskId, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+			return
+		}
+		p, err := readPatch(c)
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_update", taskId.Id))
+			return
+		}
+		ctx, ok := bindIfMatch(c, ctx)
+		if !ok {
+			return
+		}
+
+		task, err := tasks.UpdateTask(withActor(ctx, c), taskId.Id, func(task *model.Task) error {
+			current := changingTask(*task)
+			changed, err := patch.Apply(p, current)
+			if err != nil {
+				return err
+			}
+			loc := i18n.LocationFrom(ctx)
+			if changed.DueDate == current.DueDate && changed.Recurrence == current.Recurrence {
+				loc = model.Zone(task.Timezone, loc)
+			}
+			// Change проверяет только поля, которые изменил патч
+			return task.Change(
+				changed.Name, changed.Description, changed.DueDate, changed.Recurrence,
+				model.Labels{Priority: changed.Priority, Tags: changed.Tags}, loc,
+			)
+		})
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_update", taskId.Id))
+			return
+		}
+		setETag(c, task.Version)
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+// PatchNoteById
+// @Summary Частично изменить заметку по ее ID
+// @Description Изменяются только поля, переданные в патче; соглашения те же, что у PATCH /api/tasks/item/id,
+// @Description патч применяется к документу ChangingNote
+// @Tags Изменить заметку
+// @Accept	application/merge-patch+json,application/json-patch+json
+// @Produce	json
+// @Param id query int true "Note ID"
+// @Param If-Match header string false "ETag of the note version being changed"
+// @Param patch body ChangingNote true "Patch of the note data" body is a merge patch or a JSON Patch array
+// @Success 200 {object} model.Note "The note has been successfully updated"
+// @Header 200 {string} ETag "New version of the note"
+// @Failure 400 {object} problem.Problem "Invalid request or malformed patch: the note hasn't been updated"
+// @Failure 404 {object} problem.Problem "Not found: such a note doesn't exist"
+// @Failure 409 {object} problem.Problem "A note with this name already exists, the note was changed concurrently or a test operation failed"
+// @Failure 412 {object} problem.Problem "The note version doesn't match If-Match"
+// @Failure 415 {object} problem.Problem "Unsupported patch type; supported types are listed in Accept-Patch"
+// @Failure 422 {object} problem.Problem "The patch cannot be applied or the patched note failed validation"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/notes/item/id [patch]
+// Обработка Patch-запроса типа /api/item/id для заметок
+// id в запросе передается в виде целого неотрицательного числа, большего нуля, напр.:
+// /api/notes/item/id?id=1
+func PatchNoteById(
+	timeout time.Duration,
+	notes storage.NoteStore,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var noteId RemindableId
+		if err := c.ShouldBindWith(&noteId, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_note_id"))
+			return
+		}
+		p, err := readPatch(c)
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note_update", noteId.Id))
+			return
+		}
+		ctx, ok := bindIfMatch(c, ctx)
+		if !ok {
+			return
+		}
+
+		note, err := notes.UpdateNote(withActor(ctx, c), noteId.Id, func(note *model.Note) error {
+			current := changingNote(*note)
+			changed, err := patch.Apply(p, current)
+			if err != nil {
+				return err
+			}
+			loc := i18n.LocationFrom(ctx)
+			if changed.AlarmTimeStamp == current.AlarmTimeStamp && changed.Recurrence == current.Recurrence {
+				loc = model.Zone(note.Timezone, loc)
+			}
+			// Change проверяет только поля, которые изменил патч
+			return note.Change(
+				changed.Name, changed.Description, changed.AlarmTimeStamp, changed.Recurrence,
+				model.Labels{Priority: changed.Priority, Tags: changed.Tags}, loc,
+			)
+		})
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note_update", noteId.Id))
+			return
+		}
+		setETag(c, note.Version)
+		c.JSON(http.StatusOK, note)
+	}
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// NotePath идентификатор заметки в пути запроса
+type NotePath struct {
+	Id int `uri:"id" binding:"required,gt=0"`
+}
+
+// OccurrencesQuery параметры запроса повторений задачи или заметки
+type OccurrencesQuery struct {
+	From  string `form:"from"`
+	To    string `form:"to"`
+	Limit int    `form:"limit" binding:"omitempty,gte=1,lte=1000"`
+}
+
+// Occurrences повторения задачи или заметки в интервале [from, to)
+type Occurrences struct {
+	Id         int         `json:"id"`
+	Recurrence string      `json:"recurrence,omitempty" example:"FREQ=WEEKLY;BYDAY=MO,WE"`
+	Timezone   string      `json:"timezone,omitempty" example:"Europe/Moscow"`
+	From       time.Time   `json:"from"`
+	To         time.Time   `json:"to"`
+	Items      []time.Time `json:"items"`
+}
+
+// bindOccurrences разбирает параметры запроса повторений. Даты from и to принимаются
+// в тех же форматах, что и даты задач, и отсчитываются в часовом поясе запроса.
+// При ошибке отправляет ответ 400 и возвращает false
+func bindOccurrences(c *gin.Context) (from, to time.Time, limit int, ok bool) {
+	var query OccurrencesQuery
+	if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+		return from, to, 0, false
+	}
+	now := time.Now()
+	loc := i18n.LocationFrom(c.Request.Context())
+	for _, p := range []struct {
+		name  string
+		value string
+		dest  *time.Time
+	}{
+		{"from", query.From, &from},
+		{"to", query.To, &to},
+	} {
+		if strings.TrimSpace(p.value) == "" {
+			continue
+		}
+		t, err := dateinput.Parse(p.value, now, loc)
+		if err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest,
+				problem.Message(c, "err.invalid_query_date", p.value, p.name))
+			return from, to, 0, false
+		}
+		*p.dest = t
+	}
+
+	from, to, limit, err := model.OccurrencesWindow(from, to, query.Limit, now.UTC())
+	if err != nil {
+		problem.Error(c, err)
+		return from, to, 0, false
+	}
+	return from, to, limit, true
+}
+
+// GetTaskOccurrences
+// @Summary Получить сроки повторений задачи
+// @Tags Повторения
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Param from query string false "Start of the period, any supported date format" default(now)
+// @Param to query string false "End of the period (exclusive)" default(from + 90 days)
+// @Param limit query int false "Maximum number of occurrences, 1..1000" default(100)
+// @Success 200 {object} Occurrences "Getting the occurrences is successful"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/occurrences [get]
+// Обработка Get-запроса типа /api/tasks/{id}/occurrences, напр.:
+// /api/tasks/1/occurrences?from=сегодня&to=через 2 недели&limit=10
+func GetTaskOccurrences(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var path TaskPath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+			return
+		}
+		from, to, limit, ok := bindOccurrences(c)
+		if !ok {
+			return
+		}
+
+		task, err := tasks.GetTask(ctx, path.Id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_occurrences", path.Id))
+			return
+		}
+		c.JSON(http.StatusOK, Occurrences{
+			Id:         task.Id,
+			Recurrence: task.Recurrence,
+			Timezone:   task.Timezone,
+			From:       from,
+			To:         to,
+			Items:      task.Occurrences(from, to, limit),
+		})
+	}
+}
+
+// GetNoteOccurrences
+// @Summary Получить срабатывания напоминания заметки
+// @Tags Повторения
+// @Produce	json
+// @Param id path int true "Note ID"
+// @Param from query string false "Start of the period, any supported date format" default(now)
+// @Param to query string false "End of the period (exclusive)" default(from + 90 days)
+// @Param limit query int false "Maximum number of occurrences, 1..1000" default(100)
+// @Success 200 {object} Occurrences "Getting the occurrences is successful"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a note doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/notes/{id}/occurrences [get]
+// Обработка Get-запроса типа /api/notes/{id}/occurrences, напр.:
+// /api/notes/1/occurrences?from=2026-11-01&to=2026-12-01
+func GetNoteOccurrences(timeout time.Duration, notes storage.NoteStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var path NotePath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_note_id"))
+			return
+		}
+		from, to, limit, ok := bindOccurrences(c)
+		if !ok {
+			return
+		}
+
+		note, err := notes.GetNote(ctx, path.Id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note_occurrences", path.Id))
+			return
+		}
+		c.JSON(http.StatusOK, Occurrences{
+			Id:         note.Id,
+			Recurrence: note.Recurrence,
+			Timezone:   note.Timezone,
+			From:       from,
+			To:         to,
+			Items:      note.Occurrences(from, to, limit),
+		})
+	}
+}
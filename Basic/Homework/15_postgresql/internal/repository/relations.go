@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// BlockerPath идентификаторы задачи и блокирующей её задачи в пути запроса
+type BlockerPath struct {
+	Id        int `uri:"id" binding:"required,gt=0"`
+	BlockerId int `uri:"blockerId" binding:"required,gt=0"`
+}
+
+// ParentRequest запрос на смену родительской задачи; null отвязывает задачу от родителя
+type ParentRequest struct {
+	ParentId *int `json:"parentId" binding:"omitempty,gt=0" example:"1"`
+}
+
+// BlockerRequest запрос на блокировку задачи другой задачей
+type BlockerRequest struct {
+	Id int `json:"id" binding:"required,gt=0" example:"2"`
+}
+
+// DeleteTaskQuery параметры удаления задачи
+type DeleteTaskQuery struct {
+	// Cascade политика удаления подзадач: restrict (по умолчанию), cascade или orphan
+	Cascade storage.DeletePolicy `form:"cascade" binding:"omitempty,oneof=restrict cascade orphan"`
+	// Notes политика удаления прикреплённых заметок: detach (по умолчанию) или delete
+	Notes storage.NotesPolicy `form:"notes" binding:"omitempty,oneof=detach delete"`
+}
+
+// TaskQuery дополнительные параметры получения задачи
+type TaskQuery struct {
+	// Include notes - включить в ответ прикреплённые к задаче заметки
+	Include string `form:"include" binding:"omitempty,oneof=notes"`
+}
+
+// TaskWithNotes задача с прикреплёнными к ней заметками
+type TaskWithNotes struct {
+	model.Task
+	Notes []model.Note `json:"notes"`
+}
+
+// NoteTaskRequest запрос на прикрепление заметки к задаче; null открепляет заметку
+type NoteTaskRequest struct {
+	TaskId *int `json:"taskId" binding:"omitempty,gt=0" example:"1"`
+}
+
+// taskRelationHandler возвращает обработчик изменения связей задачи; key - ключ контекста ошибки.
+// bind разбирает запрос и возвращает Id задачи и её изменение либо false, если ответ об ошибке
+// уже отправлен
+func taskRelationHandler(
+	timeout time.Duration,
+	tasks storage.TaskStore,
+	key string,
+	bind func(c *gin.Context) (int, func(task *model.Task) error, bool),
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		id, change, ok := bind(c)
+		if !ok {
+			return
+		}
+
+		task, err := tasks.UpdateTask(withActor(ctx, c), id, change)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, key, id))
+			return
+		}
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+// bindTaskPath разбирает Id задачи из пути запроса. При ошибке отправляет ответ 400 и возвращает false
+func bindTaskPath(c *gin.Context) (int, bool) {
+	var path TaskPath
+	if err := c.ShouldBindUri(&path); err != nil {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+		return 0, false
+	}
+	return path.Id, true
+}
+
+// SetTaskParent
+// @Summary Сделать задачу подзадачей другой задачи или отвязать её от родителя
+// @Tags Подзадачи и зависимости
+// @Accept	json
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Param parent body ParentRequest true "Parent task ID or null"
+// @Success 200 {object} model.Task "The parent task has been changed"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 409 {object} problem.Problem "The parent would form a cycle or the parent task is closed"
+// @Failure 422 {object} problem.Problem "The parent task doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/parent [put]
+// Обработка Put-запроса типа /api/tasks/{id}/parent, напр.:
+// /api/tasks/2/parent с телом {"parentId": 1} или {"parentId": null}
+func SetTaskParent(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
+	return taskRelationHandler(timeout, tasks, "ctx.task_parent", func(c *gin.Context) (int, func(task *model.Task) error, bool) {
+		id, ok := bindTaskPath(c)
+		if !ok {
+			return 0, nil, false
+		}
+		var req ParentRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return 0, nil, false
+		}
+		return id, func(task *model.Task) error { return task.SetParent(req.ParentId) }, true
+	})
+}
+
+// AddTaskBlocker
+// @Summary Отметить задачу заблокированной другой задачей
+// @Tags Подзадачи и зависимости
+// @Accept	json
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Param blocker body BlockerRequest true "Blocking task ID"
+// @Success 200 {object} model.Task "The blocker has been added"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 409 {object} problem.Problem "The blocker would form a cycle"
+// @Failure 422 {object} problem.Problem "The blocking task doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/blockers [post]
+// Обработка Post-запроса типа /api/tasks/{id}/blockers, напр.:
+// /api/tasks/2/blockers с телом {"id": 3}
+func AddTaskBlocker(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
+	return taskRelationHandler(timeout, tasks, "ctx.task_blockers", func(c *gin.Context) (int, func(task *model.Task) error, bool) {
+		id, ok := bindTaskPath(c)
+		if !ok {
+			return 0, nil, false
+		}
+		var req BlockerRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return 0, nil, false
+		}
+		return id, func(task *model.Task) error { return task.Block(req.Id) }, true
+	})
+}
+
+// RemoveTaskBlocker
+// @Summary Снять блокировку задачи другой задачей
+// @Tags Подзадачи и зависимости
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Param blockerId path int true "Blocking task ID"
+// @Success 200 {object} model.Task "The blocker has been removed"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/blockers/{blockerId} [delete]
+// Обработка Delete-запроса типа /api/tasks/{id}/blockers/{blockerId}, напр.:
+// /api/tasks/2/blockers/3
+func RemoveTaskBlocker(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
+	return taskRelationHandler(timeout, tasks, "ctx.task_blockers", func(c *gin.Context) (int, func(task *model.Task) error, bool) {
+		var path BlockerPath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+			return 0, nil, false
+		}
+		return path.Id, func(task *model.Task) error {
+			task.Unblock(path.BlockerId)
+			return nil
+		}, true
+	})
+}
+
+// GetTaskTree
+// @Summary Получить дерево подзадач и блокирующих задач
+// @Tags Подзадачи и зависимости
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Success 200 {object} model.TaskNode "Getting the tree is successful"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/tree [get]
+// Обработка Get-запроса типа /api/tasks/{id}/tree, напр.:
+// /api/tasks/1/tree
+func GetTaskTree(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		id, ok := bindTaskPath(c)
+		if !ok {
+			return
+		}
+
+		node, err := tasks.TaskTree(ctx, id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_tree", id))
+			return
+		}
+		c.JSON(http.StatusOK, node)
+	}
+}
+
+// bindDeleteTask разбирает параметры удаления задачи. При ошибке отправляет ответ 400 и возвращает false
+func bindDeleteTask(c *gin.Context) (storage.DeleteOptions, bool) {
+	var query DeleteTaskQuery
+	if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+		key, value := "err.invalid_cascade", c.Query("cascade")
+		if !storage.NotesPolicy(c.Query("notes")).Known() {
+			key, value = "err.invalid_notes_policy", c.Query("notes")
+		}
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, key, value))
+		return storage.DeleteOptions{}, false
+	}
+	return storage.DeleteOptions{Subtasks: query.Cascade, Notes: query.Notes}, true
+}
+
+// SetNoteTask
+// @Summary Прикрепить заметку к задаче или открепить её
+// @Tags Подзадачи и зависимости
+// @Accept	json
+// @Produce	json
+// @Param id path int true "Note ID"
+// @Param task body NoteTaskRequest true "Task ID or null"
+// @Success 200 {object} model.Note "The note has been attached or detached"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a note doesn't exist"
+// @Failure 422 {object} problem.Problem "The task doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/notes/{id}/task [put]
+// Обработка Put-запроса типа /api/notes/{id}/task, напр.:
+// /api/notes/1/task с телом {"taskId": 1} или {"taskId": null}
+func SetNoteTask(timeout time.Duration, notes storage.NoteStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var path NotePath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_note_id"))
+			return
+		}
+		var req NoteTaskRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+
+		note, err := notes.UpdateNote(withActor(ctx, c), path.Id, func(note *model.Note) error {
+			note.AttachTo(req.TaskId)
+			return nil
+		})
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note_task", path.Id))
+			return
+		}
+		c.JSON(http.StatusOK, note)
+	}
+}
@@ -2,24 +2,22 @@ package repository
 
 import (
 	"context"
-	"database/sql"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"time"
 
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
-	"github.com/jackc/pgerrcode"
-	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type Remindable interface {
 	String() string
-	ChangeAlarm(string)
+	ChangeAlarm(value string, loc *time.Location) error
 }
 
 type RemindableId struct {
@@ -27,182 +25,94 @@ type RemindableId struct {
 }
 
 type NewTask struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description" binding:"required"`
-	DueDate     string `json:"dueDate" binding:"required"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	DueDate     string         `json:"dueDate"`
+	Recurrence  string         `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,WE"`
+	Priority    model.Priority `json:"priority" example:"high"`
+	Tags        []string       `json:"tags" example:"work,home"`
 }
 
 type ChangingTask struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	DueDate     string `json:"dueDate"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	DueDate     string         `json:"dueDate"`
+	Recurrence  string         `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,WE"`
+	Priority    model.Priority `json:"priority" example:"high"`
+	Tags        []string       `json:"tags" example:"work,home"`
 }
 
 type NewNote struct {
-	Name           string `json:"name" binding:"required"`
-	Description    string `json:"description" binding:"required"`
-	AlarmTimeStamp string `json:"alarmTime" binding:"required"`
-}
-
-type ChangingNote struct {
 	Name           string `json:"name"`
 	Description    string `json:"description"`
 	AlarmTimeStamp string `json:"alarmTimeStamp"`
+	// AlarmTime прежнее имя поля alarmTimeStamp; используется, если alarmTimeStamp не задан
+	AlarmTime  string         `json:"alarmTime,omitempty" swaggerignore:"true"`
+	Recurrence string         `json:"recurrence" example:"FREQ=DAILY;COUNT=5"`
+	Priority   model.Priority `json:"priority" example:"high"`
+	Tags       []string       `json:"tags" example:"work,home"`
 }
 
-// CreateNewRemindable Создать объект типа, реализующего Remindable
-func CreateNewRemindable(
-	ctx context.Context,
-	db *sql.DB,
-	name,
-	descr,
-	futurePoint string,
-	isTask bool,
-) error {
-	var remindable Remindable
-	var rows_count sql.NullInt64
-	if isTask {
-		err := db.QueryRowContext(ctx, "SELECT MAX((task #>> '{id}')::int) from tasks").Scan(&rows_count)
-		if err != nil {
-			return fmt.Errorf("ошибка считывания количества строк из БД: %v", err)
-		}
-		task, err := model.NewTask(rows_count, name, descr, futurePoint)
-		if err != nil {
-			return err
-		}
-		remindable = &task
-	} else {
-		err := db.QueryRowContext(ctx, "SELECT MAX((note #>> '{id}')::int) from notes").Scan(&rows_count)
-		if err != nil {
-			return fmt.Errorf("ошибка считывания количества строк из БД: %v", err)
-		}
-		note, err := model.NewNote(rows_count, name, descr, futurePoint)
-		if err != nil {
-			return err
-		}
-		remindable = &note
-	}
-	if err := SaveRemindable(ctx, db, remindable); err != nil {
-		return err
+// alarm возвращает время напоминания и имя поля запроса, в котором оно передано
+func (n NewNote) alarm() (string, string) {
+	if n.AlarmTimeStamp == "" && n.AlarmTime != "" {
+		return n.AlarmTime, "alarmTime"
 	}
-	return nil
+	return n.AlarmTimeStamp, "alarmTimeStamp"
 }
 
-// Обработка ошибки вставки в БД с ограничением на уникальность
-func handleUnique(value any, err error) error {
-	var pgErr *pgconn.PgError
-	var s1, s2 string
-	switch v := value.(type) {
-	case *model.Task:
-		s1 = fmt.Sprintf("Ошибка создания/изменения задачи - задача с именем '%s' уже существует", v.Name)
-		s2 = "Ошибка создания/изменения задачи"
-	case *model.Note:
-		s1 = fmt.Sprintf("Ошибка создания/изменения заметки - заметка с именем '%s' уже существует", v.Name)
-		s2 = "Ошибка создания/изменения заметки"
-	}
-	if errors.As(err, &pgErr) {
-		if pgErr.Code == pgerrcode.UniqueViolation {
-			// Обработка ошибки вставки в поле с ограничением на уникальность
-			return fmt.Errorf("%s", s1)
-		}
-	}
-	// Обработка других типов ошибок
-	return fmt.Errorf("%s", s2)
+type ChangingNote struct {
+	Name           string         `json:"name"`
+	Description    string         `json:"description"`
+	AlarmTimeStamp string         `json:"alarmTimeStamp"`
+	Recurrence     string         `json:"recurrence" example:"FREQ=DAILY;COUNT=5"`
+	Priority       model.Priority `json:"priority" example:"high"`
+	Tags           []string       `json:"tags" example:"work,home"`
 }
 
-// SaveRemindable Сохранить объект типа, реализующего Remindable в БД PostgreSQL
-func SaveRemindable(
-	ctx context.Context,
-	db *sql.DB,
-	remindable Remindable,
-) error {
-	r := remindable
-	var rows int64
-	var result sql.Result
-
-	switch value := r.(type) {
-	case *model.Task:
-		task, err := json.Marshal(value)
-		if err != nil {
-			return err
-		}
-		result, err = db.ExecContext(
-			ctx,
-			`INSERT INTO tasks(task) VALUES($1)`,
-			task,
-		)
-		if err != nil {
-			err = handleUnique(value, err)
-			if err != nil {
-				return err
-			}
-		}
-	case *model.Note:
-		note, err := json.Marshal(value)
-		if err != nil {
-			return err
-		}
-		result, err = db.ExecContext(
-			ctx,
-			`INSERT INTO notes(note) VALUES($1)`,
-			note,
-		)
-		if err != nil {
-			err = handleUnique(value, err)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rows != 1 {
-		return err
-	}
-	return nil
+// withActor возвращает контекст хранилища с автором изменения из запроса
+func withActor(ctx context.Context, c *gin.Context) context.Context {
+	return storage.WithActor(ctx, actor(c))
 }
 
 // GetTasks
 // @Summary Получить все задачи
 // @Tags Задачи
 // @Produce	json
-// @Success 200 {string} string "Getting tasks is successful"
-// @Failure 500 {string} string "Getting notes failed due to internal server error"
+// @Param status query string false "Task status"
+// @Param due_from query string false "Lower bound of the due date, RFC 3339"
+// @Param due_to query string false "Upper bound of the due date, RFC 3339"
+// @Param name query string false "Substring of the name, case-insensitive"
+// @Param priority query []string false "Priority: low, normal, high, urgent; any of the listed" collectionFormat(multi)
+// @Param tag query []string false "Tag; all of the listed" collectionFormat(multi)
+// @Param sort query string false "Sort order: id, name, dueDate; prefix '-' for descending" default(id)
+// @Param limit query int false "Page size, 1..500" default(50)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Success 200 {object} storage.Page[model.Task] "Getting tasks is successful"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 500 {object} problem.Problem "Getting tasks failed due to internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
 // @Router /api/tasks/items [get]
 // Обработка Get-запроса типа /api/items для задач
-func GetTasks(ctx context.Context, db *sql.DB) gin.HandlerFunc {
+func GetTasks(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tasks := make([]model.Task, 0)
-		rows, err := db.QueryContext(ctx, "SELECT * FROM tasks")
-		defer func(rows *sql.Rows) {
-			err := rows.Close()
-			if err != nil {
-				log.Fatalf("Ошибка закрытия объекта sql.Rows: %v\n", err)
-			}
-		}(rows)
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var query TaskListQuery
+		if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+		page, err := tasks.ListTasks(ctx, query.Filter())
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"InternalServerError": "Ошибка считывания задач из БД"})
-			return
-		}
-
-		for rows.Next() {
-			var task model.Task
-			var id int64
-			var taskByte []byte
-			var createdAt time.Time
-			var updatedAt sql.NullTime
-			if err := rows.Scan(&id, &taskByte, &createdAt, &updatedAt); err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"InternalServerError": err.Error()})
-				return
-			}
-			if err = json.Unmarshal(taskByte, &task); err != nil {
-				log.Fatalf("Ошибка десериализации JSON: %s\n", err)
-			}
-			tasks = append(tasks, task)
-		}
-		c.JSON(http.StatusOK, tasks)
+			problem.Error(c, i18n.Wrap(err, "ctx.task_list"))
+			return
+		}
+		c.JSON(http.StatusOK, page)
 	}
 }
 
@@ -211,44 +121,55 @@ func GetTasks(ctx context.Context, db *sql.DB) gin.HandlerFunc {
 // @Tags Задача по ID
 // @Produce	json
 // @Param id query int true "Task ID"
-// @Success 200 {string} string "Getting the task is successful"
-// @Failure 400 {string} string "Invalid request"
-// @Failure 404 {string} string "Not found: such a task doesn't exist"
+// @Param include query string false "Embed related records: notes" Enums(notes)
+// @Success 200 {object} TaskWithNotes "Getting the task is successful; notes only with include=notes"
+// @Header 200 {string} ETag "Version of the task"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
 // @Router /api/tasks/item/id [get]
 // Обработка Get-запрос типа /api/item/id для задач
 // id в запросе передается в виде целого неотрицательного числа, большего нуля, напр.:
-// /api/tasks/item/id?id=1
-func GetTasksById(ctx context.Context, db *sql.DB) gin.HandlerFunc {
+// /api/tasks/item/id?id=1 или /api/tasks/item/id?id=1&include=notes
+func GetTasksById(timeout time.Duration, tasks storage.TaskStore, notes storage.NoteStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
 		var taskId RemindableId
-		var task model.Task
-		var taskByte []byte
-		if err := c.ShouldBindWith(&taskId, binding.Query); err == nil {
-			err := db.QueryRowContext(
-				ctx,
-				"SELECT task FROM tasks WHERE (task #>> '{id}')::int=$1",
-				taskId.Id,
-			).Scan(&taskByte)
-			switch {
-			case errors.Is(err, sql.ErrNoRows):
-				c.JSON(
-					http.StatusNotFound,
-					gin.H{"NotFound": fmt.Sprintf("Задачи с id=%d не существует.", taskId.Id)},
-				)
-				return
-			case err != nil:
-				log.Fatalf("Ошибка запроса: %v\n", err)
-			default:
-				if err = json.Unmarshal(taskByte, &task); err != nil {
-					log.Fatalf("Ошибка десериализации JSON: %s\n", err)
-				}
-				c.JSON(http.StatusOK, task)
-				return
-			}
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"Ошибка": "Некорректный ID задачи"})
+		if err := c.ShouldBindWith(&taskId, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+			return
+		}
+		var query TaskQuery
+		if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+		task, err := tasks.GetTask(ctx, taskId.Id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task", taskId.Id))
+			return
+		}
+		setETag(c, task.Version)
+		if query.Include == "" {
+			c.JSON(http.StatusOK, task)
+			return
+		}
+
+		linked, err := notes.TaskNotes(ctx, task.Id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_notes", task.Id))
 			return
 		}
+		c.JSON(http.StatusOK, TaskWithNotes{Task: task, Notes: linked})
 	}
 }
 
@@ -256,41 +177,39 @@ func GetTasksById(ctx context.Context, db *sql.DB) gin.HandlerFunc {
 // @Summary Получить все заметки
 // @Tags Заметки
 // @Produce	json
-// @Success 200 {string} string "Getting notes is successful"
-// @Failure 500 {string} string "Getting notes failed due to internal server error"
+// @Param alarm_from query string false "Lower bound of the alarm time, RFC 3339"
+// @Param alarm_to query string false "Upper bound of the alarm time, RFC 3339"
+// @Param name query string false "Substring of the name, case-insensitive"
+// @Param priority query []string false "Priority: low, normal, high, urgent; any of the listed" collectionFormat(multi)
+// @Param tag query []string false "Tag; all of the listed" collectionFormat(multi)
+// @Param sort query string false "Sort order: id, name, alarmTimeStamp; prefix '-' for descending" default(id)
+// @Param limit query int false "Page size, 1..500" default(50)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Success 200 {object} storage.Page[model.Note] "Getting notes is successful"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 500 {object} problem.Problem "Getting notes failed due to internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
 // @Router /api/notes/items [get]
 // Обработка Get-запроса типа /api/items для заметок
-func GetNotes(ctx context.Context, db *sql.DB) gin.HandlerFunc {
+func GetNotes(timeout time.Duration, notes storage.NoteStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		notes := make([]model.Note, 0)
-		rows, err := db.QueryContext(ctx, "SELECT * FROM notes")
-		defer func(rows *sql.Rows) {
-			err := rows.Close()
-			if err != nil {
-				log.Fatalf("Ошибка закрытия объекта sql.Rows: %v\n", err)
-			}
-		}(rows)
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var query NoteListQuery
+		if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+		page, err := notes.ListNotes(ctx, query.Filter())
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"InternalServerError": "Ошибка считывания заметок из БД"})
-			return
-		}
-
-		for rows.Next() {
-			var note model.Note
-			var id int64
-			var noteByte []byte
-			var createdAt time.Time
-			var updatedAt sql.NullTime
-			if err := rows.Scan(&id, &noteByte, &createdAt, &updatedAt); err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"InternalServerError": err.Error()})
-				return
-			}
-			if err = json.Unmarshal(noteByte, &note); err != nil {
-				log.Fatalf("Ошибка десериализации JSON: %s\n", err)
-			}
-			notes = append(notes, note)
-		}
-		c.JSON(http.StatusOK, notes)
+			problem.Error(c, i18n.Wrap(err, "ctx.note_list"))
+			return
+		}
+		c.JSON(http.StatusOK, page)
 	}
 }
 
@@ -299,44 +218,36 @@ func GetNotes(ctx context.Context, db *sql.DB) gin.HandlerFunc {
 // @Tags Заметка по ID
 // @Produce	json
 // @Param id query int true "Note ID"
-// @Success 200 {string} string "Getting the note is successful"
-// @Failure 400 {string} string "Invalid request"
-// @Failure 404 {string} string "Not found: such a note doesn't exist"
+// @Success 200 {object} model.Note "Getting the note is successful"
+// @Header 200 {string} ETag "Version of the note"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a note doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
 // @Router /api/notes/item/id [get]
 // Обработка Get-запроса типа /api/item/id для заметок
 // id в запросе передается в виде целого неотрицательного числа, большего нуля, напр.:
 // /api/notes/item/id?id=1
-func GetNotesById(ctx context.Context, db *sql.DB) gin.HandlerFunc {
+func GetNotesById(timeout time.Duration, notes storage.NoteStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
 		var noteId RemindableId
-		var note model.Note
-		var noteByte []byte
-		if err := c.ShouldBindWith(&noteId, binding.Query); err == nil {
-			err := db.QueryRowContext(
-				ctx,
-				"SELECT note FROM notes WHERE (note #>> '{id}')::int=$1",
-				noteId.Id,
-			).Scan(&noteByte)
-			switch {
-			case errors.Is(err, sql.ErrNoRows):
-				c.JSON(
-					http.StatusNotFound,
-					gin.H{"NotFound": fmt.Sprintf("Заметки с id=%d не существует.", noteId.Id)},
-				)
-				return
-			case err != nil:
-				log.Fatalf("Ошибка запроса: %v\n", err)
-			default:
-				if err = json.Unmarshal(noteByte, &note); err != nil {
-					log.Fatalf("Ошибка десериализации JSON: %s\n", err)
-				}
-				c.JSON(http.StatusOK, note)
-				return
-			}
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"Ошибка": "Некорректный ID заметки"})
+		if err := c.ShouldBindWith(&noteId, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_note_id"))
+			return
+		}
+		note, err := notes.GetNote(ctx, noteId.Id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note", noteId.Id))
 			return
 		}
+		setETag(c, note.Version)
+		c.JSON(http.StatusOK, note)
 	}
 }
 
@@ -346,56 +257,47 @@ func GetNotesById(ctx context.Context, db *sql.DB) gin.HandlerFunc {
 // @Accept	json
 // @Produce	json
 // @Param newTask body NewTask true "Task data" body is the new task attributes
-// @Success 200 {string} string "The task has been successfully created"
-// @Failure 400 {string} string "Invalid request: the task hasn't been created"
+// @Success 201 {object} model.Task "The task has been successfully created"
+// @Header 201 {string} Location "URL of the created task"
+// @Header 201 {string} ETag "Version of the task"
+// @Failure 400 {object} problem.Problem "Invalid request: the task hasn't been created"
+// @Failure 409 {object} problem.Problem "A task with this name already exists"
+// @Failure 422 {object} problem.Problem "Validation failed: see errors for field-level details"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
 // @Router /api/tasks/item [post]
 // Обработка Post-запроса типа /api/item для задач
 func PostNewTask(
-	ctx context.Context,
-	db *sql.DB,
+	timeout time.Duration,
+	tasks storage.TaskStore,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
 		newTask := NewTask{}
 		err := c.ShouldBindJSON(&newTask)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
 			return
 		}
 
-		err = CreateNewRemindable(
-			ctx,
-			db,
-			newTask.Name,
-			newTask.Description,
-			newTask.DueDate,
-			true,
+		task, err := model.NewTask(newTask.Name, newTask.Description, newTask.DueDate, newTask.Recurrence,
+			model.Labels{Priority: newTask.Priority, Tags: newTask.Tags}, i18n.LocationFrom(ctx),
 		)
-		if err != nil {
-			fmt.Println(err)
-			c.JSON(http.StatusBadRequest, gin.H{"BadRequest": err.Error()})
-			return
+		if err == nil {
+			task, err = tasks.CreateTask(withActor(ctx, c), task)
 		}
-		c.JSON(http.StatusOK, gin.H{"OK": "Создана новая задача"})
-		result, err := db.ExecContext(
-			ctx,
-			`INSERT INTO remindables_log(description) VALUES($1)`,
-			fmt.Sprintf("Создана новая задача %v", newTask.Name),
-		)
-		if err != nil {
-			c.JSON(
-				http.StatusBadRequest,
-				gin.H{"BadRequest": "Ошибка логирования в PostgreSQL создания новой задачи"},
-			)
+		if err != nil && abortOnContext(c, ctx) {
 			return
 		}
-		rows, err := result.RowsAffected()
-		if err != nil || rows != 1 {
-			c.JSON(
-				http.StatusBadRequest,
-				gin.H{"BadRequest": "Ошибка логирования в PostgreSQL создания новой задачи"},
-			)
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_create", newTask.Name))
 			return
 		}
+		c.Header("Location", fmt.Sprintf("/api/tasks/item/id?id=%d", task.Id))
+		setETag(c, task.Version)
+		c.JSON(http.StatusCreated, task)
 	}
 }
 
@@ -405,56 +307,52 @@ func PostNewTask(
 // @Accept	json
 // @Produce	json
 // @Param newNote body NewNote true "Note data" body is the new note attributes
-// @Success 200 {string} string "The note has been successfully created"
-// @Failure 400 {string} string "Invalid request: the note hasn't been created"
+// @Success 201 {object} model.Note "The note has been successfully created"
+// @Header 201 {string} Location "URL of the created note"
+// @Header 201 {string} ETag "Version of the note"
+// @Failure 400 {object} problem.Problem "Invalid request: the note hasn't been created"
+// @Failure 409 {object} problem.Problem "A note with this name already exists"
+// @Failure 422 {object} problem.Problem "Validation failed: see errors for field-level details"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
 // @Router /api/notes/item [post]
 // Обработка Post-запроса типа /api/item для заметок
 func PostNewNote(
-	ctx context.Context,
-	db *sql.DB,
+	timeout time.Duration,
+	notes storage.NoteStore,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
 		newNote := NewNote{}
 		err := c.ShouldBindJSON(&newNote)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
 			return
 		}
 
-		err = CreateNewRemindable(
-			ctx,
-			db,
-			newNote.Name,
-			newNote.Description,
-			newNote.AlarmTimeStamp,
-			false,
+		alarm, field := newNote.alarm()
+		note, err := model.NewNote(newNote.Name, newNote.Description, alarm, newNote.Recurrence,
+			model.Labels{Priority: newNote.Priority, Tags: newNote.Tags}, i18n.LocationFrom(ctx),
 		)
-		if err != nil {
-			fmt.Println(err)
-			c.JSON(http.StatusBadRequest, gin.H{"BadRequest": err.Error()})
-			return
+		var invalid *model.ValidationError
+		if errors.As(err, &invalid) {
+			invalid.Rename("alarmTimeStamp", field)
 		}
-		c.JSON(http.StatusOK, gin.H{"OK": "Создана новая заметка"})
-		result, err := db.ExecContext(
-			ctx,
-			`INSERT INTO remindables_log(description) VALUES($1)`,
-			fmt.Sprintf("Создана новая заметка %v", newNote.Name),
-		)
-		if err != nil {
-			c.JSON(
-				http.StatusBadRequest,
-				gin.H{"BadRequest": "Ошибка логирования в PostgreSQL создания новой заметки"},
-			)
+		if err == nil {
+			note, err = notes.CreateNote(withActor(ctx, c), note)
+		}
+		if err != nil && abortOnContext(c, ctx) {
 			return
 		}
-		rows, err := result.RowsAffected()
-		if err != nil || rows != 1 {
-			c.JSON(
-				http.StatusBadRequest,
-				gin.H{"BadRequest": "Ошибка логирования в PostgreSQL создания новой заметки"},
-			)
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note_create", newNote.Name))
 			return
 		}
+		c.Header("Location", fmt.Sprintf("/api/notes/item/id?id=%d", note.Id))
+		setETag(c, note.Version)
+		c.JSON(http.StatusCreated, note)
 	}
 }
 
@@ -464,104 +362,59 @@ func PostNewNote(
 // @Accept	json
 // @Produce	json
 // @Param id query int true "Task ID"
+// @Param If-Match header string false "ETag of the task version being changed"
 // @Param updatedTask body ChangingTask true "Task data" body is the updating task attributes
-// @Success 200 {string} string "The task has been successfully updated"
-// @Failure 400 {string} string "Invalid request: the task hasn't been updated"
+// @Success 200 {object} model.Task "The task has been successfully updated"
+// @Header 200 {string} ETag "New version of the task"
+// @Failure 400 {object} problem.Problem "Invalid request: the task hasn't been updated"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 409 {object} problem.Problem "A task with this name already exists, the task is closed or was changed concurrently"
+// @Failure 412 {object} problem.Problem "The task version doesn't match If-Match"
+// @Failure 422 {object} problem.Problem "Validation failed: see errors for field-level details"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
 // @Router /api/tasks/item/id [put]
 // Обработка Put-запроса типа /api/item/id для задач
 // id в запросе передается в виде целого неотрицательного числа, большего нуля, напр.:
 // /api/tasks/item/id?id=1
 func PutTaskById(
-	ctx context.Context,
-	db *sql.DB,
+	timeout time.Duration,
+	tasks storage.TaskStore,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
 		var taskId RemindableId
-		var task model.Task
-		var taskByte []byte
-		if err := c.ShouldBindWith(&taskId, binding.Query); err == nil {
-			err := db.QueryRowContext(
-				ctx,
-				"SELECT task FROM tasks WHERE (task #>> '{id}')::int=$1",
-				taskId.Id,
-			).Scan(&taskByte)
-			switch {
-			case errors.Is(err, sql.ErrNoRows):
-				c.JSON(
-					http.StatusNotFound,
-					gin.H{"NotFound": fmt.Sprintf("Задачи с id=%d не существует.", taskId.Id)},
-				)
-				return
-			case err != nil:
-				log.Fatalf("Ошибка запроса: %v\n", err)
-			default:
-				if err = json.Unmarshal(taskByte, &task); err != nil {
-					log.Fatalf("Ошибка десериализации JSON: %s\n", err)
-				}
-				changingTask := ChangingTask{}
-				err = c.ShouldBindJSON(&changingTask)
-				if err != nil {
-					c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-					return
-				}
-				task.Name = changingTask.Name
-				task.Description = changingTask.Description
-				newDueDate, err := time.Parse("02.01.2006", changingTask.DueDate)
-				if err != nil {
-					c.JSON(http.StatusBadRequest, gin.H{"Ошибка": "Введенная дата исполнения имеет не корректный формат."})
-					return
-				}
-				task.DueDate = newDueDate
-				task.Status = model.Updated
-
-				taskJSON, err := json.Marshal(task)
-				if err != nil {
-					log.Fatalf("Ошибка сериализации в JSON %s\n", err)
-				}
-
-				result, err := db.ExecContext(ctx, `
-					UPDATE tasks
-					SET task = $1, updated_at = now()
-					WHERE (task #>> '{id}')::int = $2`,
-					taskJSON, task.Id,
-				)
-				if err != nil {
-					if err != nil {
-						c.JSON(http.StatusBadRequest, gin.H{"Ошибка": fmt.Sprintf("Во время обновления задачи с id=%d произошла ошибка: %v\n", task.Id, err.Error())})
-						return
-					}
-					rows, err := result.RowsAffected()
-					if err != nil || rows != 1 {
-						c.JSON(http.StatusBadRequest, gin.H{"Ошибка": fmt.Sprintf("Во время обновления задачи с id=%d произошла ошибка: %v\n", task.Id, err.Error())})
-						return
-					}
-				}
-				c.JSON(http.StatusOK, gin.H{"Изменена задача": task})
-				result, err = db.ExecContext(
-					ctx,
-					`INSERT INTO remindables_log(description) VALUES($1)`,
-					fmt.Sprintf("Изменена задача %v", task.Name),
-				)
-				if err != nil {
-					c.JSON(
-						http.StatusBadRequest,
-						gin.H{"BadRequest": "Ошибка логирования в PostgreSQL изменения задачи"},
-					)
-					return
-				}
-				rows, err := result.RowsAffected()
-				if err != nil || rows != 1 {
-					c.JSON(
-						http.StatusBadRequest,
-						gin.H{"BadRequest": "Ошибка логирования в PostgreSQL изменения задачи"},
-					)
-					return
-				}
-			}
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"Ошибка": "Некорректный ID задачи"})
+		if err := c.ShouldBindWith(&taskId, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+			return
+		}
+		changingTask := ChangingTask{}
+		if err := c.ShouldBindJSON(&changingTask); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+		ctx, ok := bindIfMatch(c, ctx)
+		if !ok {
 			return
 		}
+
+		task, err := tasks.UpdateTask(withActor(ctx, c), taskId.Id, func(task *model.Task) error {
+			return task.Change(
+				changingTask.Name, changingTask.Description, changingTask.DueDate, changingTask.Recurrence,
+				model.Labels{Priority: changingTask.Priority, Tags: changingTask.Tags}, i18n.LocationFrom(ctx),
+			)
+		})
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_update", taskId.Id))
+			return
+		}
+		setETag(c, task.Version)
+		c.JSON(http.StatusOK, task)
 	}
 }
 
@@ -571,266 +424,160 @@ func PutTaskById(
 // @Accept	json
 // @Produce	json
 // @Param id query int true "Note ID"
+// @Param If-Match header string false "ETag of the note version being changed"
 // @Param updatedNote body ChangingNote true "Note data" body is the updating note attributes
-// @Success 200 {string} string "The note has been successfully updated"
-// @Failure 400 {string} string "Invalid request: the note hasn't been updated"
+// @Success 200 {object} model.Note "The note has been successfully updated"
+// @Header 200 {string} ETag "New version of the note"
+// @Failure 400 {object} problem.Problem "Invalid request: the note hasn't been updated"
+// @Failure 404 {object} problem.Problem "Not found: such a note doesn't exist"
+// @Failure 409 {object} problem.Problem "A note with this name already exists or the note was changed concurrently"
+// @Failure 412 {object} problem.Problem "The note version doesn't match If-Match"
+// @Failure 422 {object} problem.Problem "Validation failed: see errors for field-level details"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
 // @Router /api/notes/item/id [put]
 // Обработка Put-запроса типа /api/item/id для заметок
 // id в запросе передается в виде целого неотрицательного числа, большего нуля, напр.:
 // /api/notes/item/id?id=1
 func PutNoteById(
-	ctx context.Context,
-	db *sql.DB,
+	timeout time.Duration,
+	notes storage.NoteStore,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
 		var noteId RemindableId
-		var note model.Note
-		var noteByte []byte
-		if err := c.ShouldBindWith(&noteId, binding.Query); err == nil {
-			err := db.QueryRowContext(
-				ctx,
-				"SELECT note FROM notes WHERE (note #>> '{id}')::int=$1",
-				noteId.Id,
-			).Scan(&noteByte)
-			switch {
-			case errors.Is(err, sql.ErrNoRows):
-				c.JSON(
-					http.StatusNotFound,
-					gin.H{"NotFound": fmt.Sprintf("Заметки с id=%d не существует.", noteId.Id)},
-				)
-				return
-			case err != nil:
-				log.Fatalf("Ошибка запроса: %v\n", err)
-			default:
-				if err = json.Unmarshal(noteByte, &note); err != nil {
-					log.Fatalf("Ошибка десериализации JSON: %s\n", err)
-				}
-				changingNote := ChangingNote{}
-				err = c.ShouldBindJSON(&changingNote)
-				if err != nil {
-					c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-					return
-				}
-				note.Name = changingNote.Name
-				note.Description = changingNote.Description
-				newAlarmTimeStamp, err := time.Parse("02.01.2006 15:04", changingNote.AlarmTimeStamp)
-				if err != nil {
-					c.JSON(http.StatusBadRequest, gin.H{"Ошибка": "Введенные дата-время напоминания имеют не корректный формат."})
-					return
-				}
-				note.AlarmTimeStamp = newAlarmTimeStamp
-
-				noteJSON, err := json.Marshal(note)
-				if err != nil {
-					log.Fatalf("Ошибка сериализации в JSON %s\n", err)
-				}
-
-				result, err := db.ExecContext(ctx, `
-					UPDATE notes
-					SET note = $1, updated_at = now()
-					WHERE (note #>> '{id}')::int = $2`,
-					noteJSON, note.Id,
-				)
-				if err != nil {
-					if err != nil {
-						c.JSON(http.StatusBadRequest, gin.H{"Ошибка": fmt.Sprintf("Во время обновления заметки с id=%d произошла ошибка: %v\n", note.Id, err.Error())})
-						return
-					}
-					rows, err := result.RowsAffected()
-					if err != nil || rows != 1 {
-						c.JSON(http.StatusBadRequest, gin.H{"Ошибка": fmt.Sprintf("Во время обновления заметки с id=%d произошла ошибка: %v\n", note.Id, err.Error())})
-						return
-					}
-				}
-				c.JSON(http.StatusOK, gin.H{"Изменена заметка": note})
-				result, err = db.ExecContext(
-					ctx,
-					`INSERT INTO remindables_log(description) VALUES($1)`,
-					fmt.Sprintf("Изменена заметка %v", note.Name),
-				)
-				if err != nil {
-					c.JSON(
-						http.StatusBadRequest,
-						gin.H{"BadRequest": "Ошибка логирования в PostgreSQL изменения заметка"},
-					)
-					return
-				}
-				rows, err := result.RowsAffected()
-				if err != nil || rows != 1 {
-					c.JSON(
-						http.StatusBadRequest,
-						gin.H{"BadRequest": "Ошибка логирования в PostgreSQL изменения заметка"},
-					)
-					return
-				}
-			}
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"Ошибка": "Некорректный ID заметки"})
+		if err := c.ShouldBindWith(&noteId, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_note_id"))
 			return
 		}
+		changingNote := ChangingNote{}
+		if err := c.ShouldBindJSON(&changingNote); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+		ctx, ok := bindIfMatch(c, ctx)
+		if !ok {
+			return
+		}
+
+		note, err := notes.UpdateNote(withActor(ctx, c), noteId.Id, func(note *model.Note) error {
+			return note.Change(
+				changingNote.Name, changingNote.Description, changingNote.AlarmTimeStamp, changingNote.Recurrence,
+				model.Labels{Priority: changingNote.Priority, Tags: changingNote.Tags}, i18n.LocationFrom(ctx),
+			)
+		})
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note_update", noteId.Id))
+			return
+		}
+		setETag(c, note.Version)
+		c.JSON(http.StatusOK, note)
 	}
 }
 
 // DeleteTaskById
 // @Summary Удалить задачу по ее ID
+// @Description Задача перемещается в корзину, откуда её можно восстановить до истечения срока хранения
 // @Tags Удалить задачу
 // @Produce	json
 // @Param id query int true "Task ID"
-// @Success 200 {string} string "The task has been successfully deleted"
-// @Failure 400 {string} string "Invalid request: the task hasn't been deleted"
-// @Failure 404 {string} string "Not found: such a task doesn't exist"
+// @Param cascade query string false "Subtasks policy: restrict, cascade or orphan" Enums(restrict, cascade, orphan) default(restrict)
+// @Param notes query string false "Attached notes policy: detach or delete" Enums(detach, delete) default(detach)
+// @Param If-Match header string false "ETag of the task version being deleted"
+// @Success 200 {object} model.Task "The task has been successfully moved to the trash"
+// @Failure 400 {object} problem.Problem "Invalid request: the task hasn't been deleted"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 409 {object} problem.Problem "The task has subtasks and the policy is restrict"
+// @Failure 412 {object} problem.Problem "The task version doesn't match If-Match"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
 // @Router /api/tasks/item/id [delete]
 // Обработка Delete-запроса типа /api/item/id для задач
 // id в запросе передается в виде целого неотрицательного числа, большего нуля, напр.:
-// /api/tasks/item/id?id=1
-func DeleteTaskById(ctx context.Context, db *sql.DB,
+// /api/tasks/item/id?id=1&cascade=orphan&notes=delete
+func DeleteTaskById(
+	timeout time.Duration,
+	tasks storage.TaskStore,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
 		var taskId RemindableId
-		var task model.Task
-		var taskByte []byte
-		if err := c.ShouldBindWith(&taskId, binding.Query); err == nil {
-			err := db.QueryRowContext(
-				ctx,
-				"SELECT task FROM tasks WHERE (task #>> '{id}')::int=$1",
-				taskId.Id,
-			).Scan(&taskByte)
-			switch {
-			case errors.Is(err, sql.ErrNoRows):
-				c.JSON(
-					http.StatusNotFound,
-					gin.H{"NotFound": fmt.Sprintf("Задачи с id=%d не существует.", taskId.Id)},
-				)
-				return
-			case err != nil:
-				log.Fatalf("Ошибка запроса: %v\n", err)
-			default:
-				if err = json.Unmarshal(taskByte, &task); err != nil {
-					log.Fatalf("Ошибка десериализации JSON: %s\n", err)
-				}
-				result, err := db.ExecContext(ctx, `
-					DELETE from tasks
-					WHERE (task #>> '{id}')::int = $1`,
-					task.Id,
-				)
-				if err != nil {
-					if err != nil {
-						c.JSON(http.StatusBadRequest, gin.H{"Ошибка": fmt.Sprintf("Во время удаления задачи с id=%d произошла ошибка: %v\n", task.Id, err.Error())})
-						return
-					}
-					rows, err := result.RowsAffected()
-					if err != nil || rows != 1 {
-						c.JSON(http.StatusBadRequest, gin.H{"Ошибка": fmt.Sprintf("Во время удаления задачи с id=%d произошла ошибка: %v\n", task.Id, err.Error())})
-						return
-					}
-				}
-				c.JSON(http.StatusOK, gin.H{"Удалена задача": task})
-				result, err = db.ExecContext(
-					ctx,
-					`INSERT INTO remindables_log(description) VALUES($1)`,
-					fmt.Sprintf("Удалена задача %v", task.Name),
-				)
-				if err != nil {
-					c.JSON(
-						http.StatusBadRequest,
-						gin.H{"BadRequest": "Ошибка логирования в PostgreSQL удаления задачи"},
-					)
-					return
-				}
-				rows, err := result.RowsAffected()
-				if err != nil || rows != 1 {
-					c.JSON(
-						http.StatusBadRequest,
-						gin.H{"BadRequest": "Ошибка логирования в PostgreSQL удаления задачи"},
-					)
-					return
-				}
-			}
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"Ошибка": "Некорректный ID задачи"})
+		if err := c.ShouldBindWith(&taskId, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+			return
+		}
+		opts, ok := bindDeleteTask(c)
+		if !ok {
+			return
+		}
+		ctx, ok = bindIfMatch(c, ctx)
+		if !ok {
+			return
+		}
+
+		task, err := tasks.DeleteTask(withActor(ctx, c), taskId.Id, opts)
+		if err != nil && abortOnContext(c, ctx) {
 			return
 		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_delete", taskId.Id))
+			return
+		}
+		c.JSON(http.StatusOK, task)
 	}
 }
 
 // DeleteNoteById
 // @Summary Удалить заметку по ее ID
+// @Description Заметка перемещается в корзину, откуда её можно восстановить до истечения срока хранения
 // @Tags Удалить заметку
 // @Produce	json
 // @Param id query int true "Note ID"
-// @Success 200 {string} string "The note has been successfully deleted"
-// @Failure 400 {string} string "Invalid request: the note hasn't been deleted"
-// @Failure 404 {string} string "Not found: such a note doesn't exist"
+// @Param If-Match header string false "ETag of the note version being deleted"
+// @Success 200 {object} model.Note "The note has been successfully moved to the trash"
+// @Failure 400 {object} problem.Problem "Invalid request: the note hasn't been deleted"
+// @Failure 404 {object} problem.Problem "Not found: such a note doesn't exist"
+// @Failure 409 {object} problem.Problem "The note was changed concurrently"
+// @Failure 412 {object} problem.Problem "The note version doesn't match If-Match"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
 // @Router /api/notes/item/id [delete]
 // Обработка Delete-запроса типа /api/item/id для заметок
 // id в запросе передается в виде целого неотрицательного числа, большего нуля, напр.:
 // /api/notes/item/id?id=1
-func DeleteNoteById(ctx context.Context, db *sql.DB,
+func DeleteNoteById(
+	timeout time.Duration,
+	notes storage.NoteStore,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
 		var noteId RemindableId
-		var note model.Note
-		var noteByte []byte
-		if err := c.ShouldBindWith(&noteId, binding.Query); err == nil {
-			err := db.QueryRowContext(
-				ctx,
-				"SELECT note FROM notes WHERE (note #>> '{id}')::int=$1",
-				noteId.Id,
-			).Scan(&noteByte)
-			switch {
-			case errors.Is(err, sql.ErrNoRows):
-				c.JSON(
-					http.StatusNotFound,
-					gin.H{"NotFound": fmt.Sprintf("Заметки с id=%d не существует.", noteId.Id)},
-				)
-				return
-			case err != nil:
-				log.Fatalf("Ошибка запроса: %v\n", err)
-			default:
-				if err = json.Unmarshal(noteByte, &note); err != nil {
-					log.Fatalf("Ошибка десериализации JSON: %s\n", err)
-				}
-				result, err := db.ExecContext(ctx, `
-					DELETE from notes
-					WHERE (note #>> '{id}')::int = $1`,
-					note.Id,
-				)
-				if err != nil {
-					if err != nil {
-						c.JSON(http.StatusBadRequest, gin.H{"Ошибка": fmt.Sprintf("Во время удаления заметки с id=%d произошла ошибка: %v\n", note.Id, err.Error())})
-						return
-					}
-					rows, err := result.RowsAffected()
-					if err != nil || rows != 1 {
-						c.JSON(http.StatusBadRequest, gin.H{"Ошибка": fmt.Sprintf("Во время удаления заметки с id=%d произошла ошибка: %v\n", note.Id, err.Error())})
-						return
-					}
-				}
-				c.JSON(http.StatusOK, gin.H{"Удалена заметка": note})
-				result, err = db.ExecContext(
-					ctx,
-					`INSERT INTO remindables_log(description) VALUES($1)`,
-					fmt.Sprintf("Удалена заметка %v", note.Name),
-				)
-				if err != nil {
-					c.JSON(
-						http.StatusBadRequest,
-						gin.H{"BadRequest": "Ошибка логирования в PostgreSQL удаления заметки"},
-					)
-					return
-				}
-				rows, err := result.RowsAffected()
-				if err != nil || rows != 1 {
-					c.JSON(
-						http.StatusBadRequest,
-						gin.H{"BadRequest": "Ошибка логирования в PostgreSQL удаления заметки"},
-					)
-					return
-				}
-			}
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"Ошибка": "Некорректный ID заметки"})
+		if err := c.ShouldBindWith(&noteId, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_note_id"))
+			return
+		}
+		ctx, ok := bindIfMatch(c, ctx)
+		if !ok {
+			return
+		}
+
+		note, err := notes.DeleteNote(withActor(ctx, c), noteId.Id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note_delete", noteId.Id))
 			return
 		}
+		c.JSON(http.StatusOK, note)
 	}
 }
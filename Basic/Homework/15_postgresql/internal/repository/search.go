@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// SearchQuery параметры полнотекстового поиска по задачам и заметкам
+type SearchQuery struct {
+	Q      string `form:"q" binding:"required"`
+	Type   string `form:"type" binding:"omitempty,oneof=task note"`
+	Limit  int    `form:"limit,default=20" binding:"gte=1,lte=100"`
+	Offset int    `form:"offset,default=0" binding:"gte=0"`
+}
+
+// GetSearch
+// @Summary Полнотекстовый поиск по задачам и заметкам
+// @Tags Поиск
+// @Produce	json
+// @Param q query string true "Search query in websearch syntax: words, \"phrases\", -exclusions, or"
+// @Param type query string false "Entity type: task or note"
+// @Param limit query int false "Page size, 1..100" default(20)
+// @Param offset query int false "Number of results to skip" default(0)
+// @Success 200 {object} storage.SearchPage "Search is successful"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 500 {object} problem.Problem "Search failed due to internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/search [get]
+// Обработка Get-запроса типа /api/search, напр.:
+// /api/search?q=квартальный отчет&type=task&limit=10
+func GetSearch(timeout time.Duration, searcher storage.Searcher) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var query SearchQuery
+		if err := c.ShouldBindWith(&query, binding.Query); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+
+		page, err := searcher.Search(ctx, storage.SearchFilter{
+			Query:  query.Q,
+			Type:   query.Type,
+			Limit:  query.Limit,
+			Offset: query.Offset,
+		})
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.search"))
+			return
+		}
+		c.JSON(http.StatusOK, page)
+	}
+}
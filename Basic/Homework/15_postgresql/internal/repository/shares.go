@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/access"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// Shares доступы, выданные к задаче, заметке или списку
+type Shares struct {
+	Items []storage.Share `json:"items"`
+}
+
+// entityKeys ключи контекста ошибки для записи каждого типа
+var entityKeys = map[string]string{
+	storage.EntityTask: "ctx.task",
+	storage.EntityNote: "ctx.note",
+}
+
+// Require проверяет, что роль пользователя по отношению к задаче или заметке entityType
+// разрешает действия роли need. Id записи берётся из пути (:id) или параметра запроса id;
+// некорректный Id пропускается к обработчику, который ответит 400. Без нужной роли отвечает 403,
+// а для невидимой пользователю записи - 404
+func Require(timeout time.Duration, acl *access.Service, entityType string, need model.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := c.Param("id")
+		if raw == "" {
+			raw = c.Query("id")
+		}
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		err = acl.Require(ctx, entityType, id, need)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, entityKeys[entityType], id))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// bindShareTarget разбирает из пути запроса объект доступа типа entityType: Id задачи или заметки
+// либо имя списка. При ошибке отправляет ответ 400 и возвращает false
+func bindShareTarget(c *gin.Context, entityType string) (storage.ShareTarget, bool) {
+	target := storage.ShareTarget{EntityType: entityType}
+	switch entityType {
+	case storage.EntityList:
+		target.List = c.Param("list")
+	case storage.EntityNote:
+		var path NotePath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_note_id"))
+			return target, false
+		}
+		target.EntityId = path.Id
+	default:
+		var path TaskPath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+			return target, false
+		}
+		target.EntityId = path.Id
+	}
+	return target, true
+}
+
+// PostShare
+// @Summary Выдать пользователю доступ к задаче, заметке или списку
+// @Description Роль viewer разрешает просмотр, editor - также изменение. Повторная выдача тому же
+// @Description пользователю заменяет роль. Список - все задачи и заметки владельца с меткой {list}
+// @Tags Совместный доступ
+// @Security BearerAuth
+// @Accept	json
+// @Produce	json
+// @Param id path int true "Task or note ID"
+// @Param list path string true "List name: the tag of its tasks and notes"
+// @Param grant body model.Grant true "Recipient login and role"
+// @Success 200 {object} storage.Share "The access has been granted"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 403 {object} problem.Problem "Only the owner may share the record"
+// @Failure 404 {object} problem.Problem "Not found: such a record or user doesn't exist"
+// @Failure 422 {object} problem.Problem "Validation failed: see errors for field-level details"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/shares [post]
+// @Router /api/notes/{id}/shares [post]
+// @Router /api/lists/{list}/shares [post]
+// Обработка Post-запроса типа /api/tasks/{id}/shares, напр.:
+// /api/lists/work/shares с телом {"login": "bob", "role": "editor"}
+func PostShare(timeout time.Duration, acl *access.Service, entityType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		target, ok := bindShareTarget(c, entityType)
+		if !ok {
+			return
+		}
+		var grant model.Grant
+		if err := c.ShouldBindJSON(&grant); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+
+		share, err := acl.Share(withActor(ctx, c), target, grant)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.share", grant.Login))
+			return
+		}
+		c.JSON(http.StatusOK, share)
+	}
+}
+
+// GetShares
+// @Summary Получить доступы, выданные к задаче, заметке или списку
+// @Tags Совместный доступ
+// @Security BearerAuth
+// @Produce	json
+// @Param id path int true "Task or note ID"
+// @Param list path string true "List name: the tag of its tasks and notes"
+// @Success 200 {object} Shares "Getting the shares is successful"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 403 {object} problem.Problem "Only the owner may see the shares"
+// @Failure 404 {object} problem.Problem "Not found: such a record doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/shares [get]
+// @Router /api/notes/{id}/shares [get]
+// @Router /api/lists/{list}/shares [get]
+// Обработка Get-запроса типа /api/tasks/{id}/shares, напр.:
+// /api/lists/work/shares
+func GetShares(timeout time.Duration, acl *access.Service, entityType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		target, ok := bindShareTarget(c, entityType)
+		if !ok {
+			return
+		}
+
+		shares, err := acl.Shares(ctx, target)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.shares"))
+			return
+		}
+		c.JSON(http.StatusOK, Shares{Items: shares})
+	}
+}
+
+// DeleteShare
+// @Summary Отозвать доступ пользователя к задаче, заметке или списку
+// @Tags Совместный доступ
+// @Security BearerAuth
+// @Produce	json
+// @Param id path int true "Task or note ID"
+// @Param list path string true "List name: the tag of its tasks and notes"
+// @Param login path string true "Recipient login"
+// @Success 200 {object} storage.Share "The access has been revoked"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 403 {object} problem.Problem "Only the owner may revoke the access"
+// @Failure 404 {object} problem.Problem "Not found: such a record, user or share doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/shares/{login} [delete]
+// @Router /api/notes/{id}/shares/{login} [delete]
+// @Router /api/lists/{list}/shares/{login} [delete]
+// Обработка Delete-запроса типа /api/tasks/{id}/shares/{login}, напр.:
+// /api/lists/work/shares/bob
+func DeleteShare(timeout time.Duration, acl *access.Service, entityType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		target, ok := bindShareTarget(c, entityType)
+		if !ok {
+			return
+		}
+		login := c.Param("login")
+
+		share, err := acl.Unshare(withActor(ctx, c), target, login)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.unshare", login))
+			return
+		}
+		c.JSON(http.StatusOK, share)
+	}
+}
@@ -0,0 +1,327 @@
+package repository
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/shared/dateinput"
+	"github.com/gin-gonic/gin"
+)
+
+// SnoozeRequest запрос на откладывание сработавшего напоминания: ровно одно из полей
+type SnoozeRequest struct {
+	// For длительность в формате Go, напр. 15m, 1h30m
+	For string `json:"for,omitempty" example:"15m"`
+	// Until время в тех же форматах, что и даты задач
+	Until string `json:"until,omitempty" example:"завтра 9:00"`
+}
+
+// Snoozes история откладывания напоминаний задачи или заметки
+type Snoozes struct {
+	Id    int            `json:"id"`
+	Items []model.Snooze `json:"items"`
+}
+
+// bindSnooze разбирает запрос на откладывание и возвращает время, до которого откладывается
+// напоминание. При ошибке отправляет ответ 400 и возвращает false
+func bindSnooze(c *gin.Context) (time.Time, bool) {
+	var req SnoozeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+		return time.Time{}, false
+	}
+	req.For, req.Until = strings.TrimSpace(req.For), strings.TrimSpace(req.Until)
+	if (req.For == "") == (req.Until == "") {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_snooze"))
+		return time.Time{}, false
+	}
+
+	now := time.Now()
+	if req.For != "" {
+		d, err := time.ParseDuration(req.For)
+		if err != nil || d <= 0 {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest,
+				problem.Message(c, "err.invalid_query_date", req.For, "for"))
+			return time.Time{}, false
+		}
+		return now.Add(d), true
+	}
+	until, err := dateinput.Parse(req.Until, now, i18n.LocationFrom(c.Request.Context()))
+	if err != nil {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest,
+			problem.Message(c, "err.invalid_query_date", req.Until, "until"))
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// taskReminderHandler возвращает обработчик действия с напоминанием задачи:
+// bind разбирает запрос и возвращает изменение задачи либо false, если ответ об ошибке уже отправлен
+func taskReminderHandler(
+	timeout time.Duration,
+	tasks storage.TaskStore,
+	bind func(c *gin.Context) (func(task *model.Task) error, bool),
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var path TaskPath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+			return
+		}
+		change, ok := bind(c)
+		if !ok {
+			return
+		}
+
+		task, err := tasks.UpdateTask(withActor(ctx, c), path.Id, change)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_reminder", path.Id))
+			return
+		}
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+// noteReminderHandler возвращает обработчик действия с напоминанием заметки;
+// соглашения те же, что у taskReminderHandler
+func noteReminderHandler(
+	timeout time.Duration,
+	notes storage.NoteStore,
+	bind func(c *gin.Context) (func(note *model.Note) error, bool),
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var path NotePath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_note_id"))
+			return
+		}
+		change, ok := bind(c)
+		if !ok {
+			return
+		}
+
+		note, err := notes.UpdateNote(withActor(ctx, c), path.Id, change)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note_reminder", path.Id))
+			return
+		}
+		c.JSON(http.StatusOK, note)
+	}
+}
+
+// SnoozeTask
+// @Summary Отложить сработавшее напоминание о сроке задачи
+// @Tags Напоминания
+// @Accept	json
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Param snooze body SnoozeRequest true "Snooze for a duration or until a time"
+// @Success 200 {object} model.Task "The reminder has been snoozed, the due date is moved"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 409 {object} problem.Problem "The reminder has not fired or the task is closed"
+// @Failure 422 {object} problem.Problem "Validation failed: the time is not in the future"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/snooze [post]
+// Обработка Post-запроса типа /api/tasks/{id}/snooze, напр.:
+// /api/tasks/1/snooze с телом {"for": "15m"} или {"until": "завтра 9:00"}
+func SnoozeTask(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
+	return taskReminderHandler(timeout, tasks, func(c *gin.Context) (func(task *model.Task) error, bool) {
+		until, ok := bindSnooze(c)
+		return func(task *model.Task) error { return task.Snooze(until) }, ok
+	})
+}
+
+// AcknowledgeTask
+// @Summary Подтвердить сработавшее напоминание о сроке задачи
+// @Tags Напоминания
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Success 200 {object} model.Task "The reminder has been acknowledged"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 409 {object} problem.Problem "The reminder has not fired"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/acknowledge [post]
+// Обработка Post-запроса типа /api/tasks/{id}/acknowledge, напр.:
+// /api/tasks/1/acknowledge
+func AcknowledgeTask(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
+	return taskReminderHandler(timeout, tasks, func(*gin.Context) (func(task *model.Task) error, bool) {
+		return (*model.Task).Acknowledge, true
+	})
+}
+
+// DismissTask
+// @Summary Отклонить напоминание о сроке задачи
+// @Tags Напоминания
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Success 200 {object} model.Task "The reminder has been dismissed"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 409 {object} problem.Problem "The reminder is already acknowledged or dismissed"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/dismiss [post]
+// Обработка Post-запроса типа /api/tasks/{id}/dismiss, напр.:
+// /api/tasks/1/dismiss
+func DismissTask(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
+	return taskReminderHandler(timeout, tasks, func(*gin.Context) (func(task *model.Task) error, bool) {
+		return (*model.Task).Dismiss, true
+	})
+}
+
+// GetTaskSnoozes
+// @Summary Получить историю откладывания напоминаний задачи
+// @Tags Напоминания
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Success 200 {object} Snoozes "Getting the snooze history is successful"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/snoozes [get]
+// Обработка Get-запроса типа /api/tasks/{id}/snoozes, напр.:
+// /api/tasks/1/snoozes
+func GetTaskSnoozes(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var path TaskPath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+			return
+		}
+
+		snoozes, err := tasks.TaskSnoozes(ctx, path.Id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_snoozes", path.Id))
+			return
+		}
+		c.JSON(http.StatusOK, Snoozes{Id: path.Id, Items: snoozes})
+	}
+}
+
+// SnoozeNote
+// @Summary Отложить сработавшее напоминание заметки
+// @Tags Напоминания
+// @Accept	json
+// @Produce	json
+// @Param id path int true "Note ID"
+// @Param snooze body SnoozeRequest true "Snooze for a duration or until a time"
+// @Success 200 {object} model.Note "The reminder has been snoozed, the alarm is moved"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a note doesn't exist"
+// @Failure 409 {object} problem.Problem "The reminder has not fired"
+// @Failure 422 {object} problem.Problem "Validation failed: the time is not in the future"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/notes/{id}/snooze [post]
+// Обработка Post-запроса типа /api/notes/{id}/snooze, напр.:
+// /api/notes/1/snooze с телом {"for": "10m"}
+func SnoozeNote(timeout time.Duration, notes storage.NoteStore) gin.HandlerFunc {
+	return noteReminderHandler(timeout, notes, func(c *gin.Context) (func(note *model.Note) error, bool) {
+		until, ok := bindSnooze(c)
+		return func(note *model.Note) error { return note.Snooze(until) }, ok
+	})
+}
+
+// AcknowledgeNote
+// @Summary Подтвердить сработавшее напоминание заметки
+// @Tags Напоминания
+// @Produce	json
+// @Param id path int true "Note ID"
+// @Success 200 {object} model.Note "The reminder has been acknowledged; a recurring one is moved to the next occurrence"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a note doesn't exist"
+// @Failure 409 {object} problem.Problem "The reminder has not fired"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/notes/{id}/acknowledge [post]
+// Обработка Post-запроса типа /api/notes/{id}/acknowledge, напр.:
+// /api/notes/1/acknowledge
+func AcknowledgeNote(timeout time.Duration, notes storage.NoteStore) gin.HandlerFunc {
+	return noteReminderHandler(timeout, notes, func(*gin.Context) (func(note *model.Note) error, bool) {
+		return (*model.Note).Acknowledge, true
+	})
+}
+
+// DismissNote
+// @Summary Отклонить напоминание заметки
+// @Tags Напоминания
+// @Produce	json
+// @Param id path int true "Note ID"
+// @Success 200 {object} model.Note "The reminder has been dismissed; a recurring one is moved to the next occurrence"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a note doesn't exist"
+// @Failure 409 {object} problem.Problem "The reminder is already acknowledged or dismissed"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/notes/{id}/dismiss [post]
+// Обработка Post-запроса типа /api/notes/{id}/dismiss, напр.:
+// /api/notes/1/dismiss
+func DismissNote(timeout time.Duration, notes storage.NoteStore) gin.HandlerFunc {
+	return noteReminderHandler(timeout, notes, func(*gin.Context) (func(note *model.Note) error, bool) {
+		return (*model.Note).Dismiss, true
+	})
+}
+
+// GetNoteSnoozes
+// @Summary Получить историю откладывания напоминаний заметки
+// @Tags Напоминания
+// @Produce	json
+// @Param id path int true "Note ID"
+// @Success 200 {object} Snoozes "Getting the snooze history is successful"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a note doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/notes/{id}/snoozes [get]
+// Обработка Get-запроса типа /api/notes/{id}/snoozes, напр.:
+// /api/notes/1/snoozes
+func GetNoteSnoozes(timeout time.Duration, notes storage.NoteStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var path NotePath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_note_id"))
+			return
+		}
+
+		snoozes, err := notes.NoteSnoozes(ctx, path.Id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note_snoozes", path.Id))
+			return
+		}
+		c.JSON(http.StatusOK, Snoozes{Id: path.Id, Items: snoozes})
+	}
+}
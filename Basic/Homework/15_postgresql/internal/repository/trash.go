@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// bindNotePath разбирает Id заметки из пути запроса. При ошибке отправляет ответ 400 и возвращает false
+func bindNotePath(c *gin.Context) (int, bool) {
+	var path NotePath
+	if err := c.ShouldBindUri(&path); err != nil {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_note_id"))
+		return 0, false
+	}
+	return path.Id, true
+}
+
+// GetTrash
+// @Summary Получить содержимое корзины
+// @Description Удалённые задачи и заметки пользователя от недавно удалённых к давним.
+// @Description По истечении срока хранения (trash.retention) записи удаляются окончательно
+// @Tags Корзина
+// @Security BearerAuth
+// @Produce	json
+// @Success 200 {object} storage.Trash "Getting the trash is successful"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/trash [get]
+// Обработка Get-запроса типа /api/trash
+func GetTrash(timeout time.Duration, trash storage.TrashStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		items, err := trash.Trash(ctx)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.trash"))
+			return
+		}
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// RestoreTask
+// @Summary Восстановить задачу из корзины
+// @Description Связи с задачами, которые за это время удалены, снимаются
+// @Tags Корзина
+// @Security BearerAuth
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Success 200 {object} model.Task "The task has been restored"
+// @Header 200 {string} ETag "New version of the task"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task isn't in the trash"
+// @Failure 409 {object} problem.Problem "Another task already has the same name"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/trash/tasks/{id}/restore [post]
+// Обработка Post-запроса типа /api/trash/tasks/{id}/restore, напр.:
+// /api/trash/tasks/1/restore
+func RestoreTask(timeout time.Duration, trash storage.TrashStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		id, ok := bindTaskPath(c)
+		if !ok {
+			return
+		}
+
+		task, err := trash.RestoreTask(withActor(ctx, c), id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_restore", id))
+			return
+		}
+		setETag(c, task.Version)
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+// RestoreNote
+// @Summary Восстановить заметку из корзины
+// @Description Если задача заметки за это время удалена, заметка открепляется
+// @Tags Корзина
+// @Security BearerAuth
+// @Produce	json
+// @Param id path int true "Note ID"
+// @Success 200 {object} model.Note "The note has been restored"
+// @Header 200 {string} ETag "New version of the note"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a note isn't in the trash"
+// @Failure 409 {object} problem.Problem "Another note already has the same name"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/trash/notes/{id}/restore [post]
+// Обработка Post-запроса типа /api/trash/notes/{id}/restore, напр.:
+// /api/trash/notes/1/restore
+func RestoreNote(timeout time.Duration, trash storage.TrashStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		id, ok := bindNotePath(c)
+		if !ok {
+			return
+		}
+
+		note, err := trash.RestoreNote(withActor(ctx, c), id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note_restore", id))
+			return
+		}
+		setETag(c, note.Version)
+		c.JSON(http.StatusOK, note)
+	}
+}
+
+// PurgeTask
+// @Summary Окончательно удалить задачу из корзины
+// @Description Вместе с задачей удаляются история её статусов и выданные к ней доступы
+// @Tags Корзина
+// @Security BearerAuth
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Success 200 {object} model.Task "The task has been permanently deleted"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task isn't in the trash"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/trash/tasks/{id} [delete]
+// Обработка Delete-запроса типа /api/trash/tasks/{id}, напр.:
+// /api/trash/tasks/1
+func PurgeTask(timeout time.Duration, trash storage.TrashStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		id, ok := bindTaskPath(c)
+		if !ok {
+			return
+		}
+
+		task, err := trash.PurgeTask(withActor(ctx, c), id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_purge", id))
+			return
+		}
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+// PurgeNote
+// @Summary Окончательно удалить заметку из корзины
+// @Tags Корзина
+// @Security BearerAuth
+// @Produce	json
+// @Param id path int true "Note ID"
+// @Success 200 {object} model.Note "The note has been permanently deleted"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a note isn't in the trash"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/trash/notes/{id} [delete]
+// Обработка Delete-запроса типа /api/trash/notes/{id}, напр.:
+// /api/trash/notes/1
+func PurgeNote(timeout time.Duration, trash storage.TrashStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		id, ok := bindNotePath(c)
+		if !ok {
+			return
+		}
+
+		note, err := trash.PurgeNote(withActor(ctx, c), id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.note_purge", id))
+			return
+		}
+		c.JSON(http.StatusOK, note)
+	}
+}
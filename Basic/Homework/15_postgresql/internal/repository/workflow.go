@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// TaskPath идентификатор задачи в пути запроса
+type TaskPath struct {
+	Id int `uri:"id" binding:"required,gt=0"`
+}
+
+// TransitionRequest запрос на перевод задачи в новый статус
+type TransitionRequest struct {
+	Status string `json:"status" binding:"required" example:"in_progress"`
+}
+
+// TaskHistory история статусов задачи
+type TaskHistory struct {
+	TaskId int                  `json:"taskId"`
+	Items  []model.StatusChange `json:"items"`
+}
+
+// TransitionTask
+// @Summary Перевести задачу в новый статус
+// @Tags Статус задачи
+// @Accept	json
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Param transition body TransitionRequest true "Target status"
+// @Success 200 {object} model.Task "The task status has been changed"
+// @Failure 400 {object} problem.Problem "Invalid request or unknown status"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 409 {object} problem.Problem "The transition is not allowed or the task is closed"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/transition [post]
+// Обработка Post-запроса типа /api/tasks/{id}/transition, напр.:
+// /api/tasks/1/transition с телом {"status": "in_progress"};
+// вместо кода статуса допускается его подпись на русском или английском языке
+func TransitionTask(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var path TaskPath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+			return
+		}
+		var req TransitionRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, err.Error())
+			return
+		}
+
+		to, err := model.ParseStatus(req.Status)
+		if err != nil {
+			problem.Error(c, err)
+			return
+		}
+
+		task, err := tasks.UpdateTask(withActor(ctx, c), path.Id, func(task *model.Task) error {
+			return task.Transition(to)
+		})
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_transition", path.Id))
+			return
+		}
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+// GetTaskHistory
+// @Summary Получить историю статусов задачи
+// @Tags Статус задачи
+// @Produce	json
+// @Param id path int true "Task ID"
+// @Success 200 {object} TaskHistory "Getting the status history is successful"
+// @Failure 400 {object} problem.Problem "Invalid request"
+// @Failure 404 {object} problem.Problem "Not found: such a task doesn't exist"
+// @Failure 500 {object} problem.Problem "Internal server error"
+// @Failure 504 {object} problem.Problem "The storage did not respond in time"
+// @Router /api/tasks/{id}/history [get]
+// Обработка Get-запроса типа /api/tasks/{id}/history, напр.:
+// /api/tasks/1/history
+func GetTaskHistory(timeout time.Duration, tasks storage.TaskStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := opContext(c, timeout)
+		defer cancel()
+
+		var path TaskPath
+		if err := c.ShouldBindUri(&path); err != nil {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, problem.Message(c, "err.invalid_task_id"))
+			return
+		}
+
+		history, err := tasks.TaskHistory(ctx, path.Id)
+		if err != nil && abortOnContext(c, ctx) {
+			return
+		}
+		if err != nil {
+			problem.Error(c, i18n.Wrap(err, "ctx.task_history", path.Id))
+			return
+		}
+		c.JSON(http.StatusOK, TaskHistory{TaskId: path.Id, Items: history})
+	}
+}
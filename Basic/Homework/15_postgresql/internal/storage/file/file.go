@@ -0,0 +1,86 @@
+// Package file реализует storage.Store поверх json-файла state.json со всем содержимым хранилища.
+// Данные обслуживаются из памяти; после каждого изменения файл целиком заменяется атомарно,
+// поэтому сбой при записи не оставляет хранилище в смешанном состоянии
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage/memory"
+)
+
+// stateFile имя файла хранилища в каталоге dir
+const stateFile = "state.json"
+
+// Open считывает хранилище из каталога dir, создавая каталог при необходимости.
+// Если state.json ещё нет, хранилище пусто до первого изменения
+func Open(dir string) (*memory.Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("ошибка создания каталога хранилища: %w", err)
+	}
+
+	filename := filepath.Join(dir, stateFile)
+	var state memory.State
+	if _, err := readJSON(filename, &state); err != nil {
+		return nil, err
+	}
+
+	return memory.Restore(state, func(state memory.State) error {
+		return writeJSON(filename, state)
+	}), nil
+}
+
+// readJSON десериализует файл в dest и сообщает, был ли файл найден;
+// отсутствующий или пустой файл не считается ошибкой
+func readJSON(filename string, dest any) (bool, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(data) == 0) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("ошибка чтения файла '%s': %w", filename, err)
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return false, fmt.Errorf("ошибка десериализации файла '%s': %w", filename, err)
+	}
+	return true, nil
+}
+
+// writeJSON атомарно перезаписывает файл: данные пишутся во временный файл и сбрасываются на диск,
+// после чего временный файл переименовывается. При сбое на диске остаётся прежняя версия файла
+func writeJSON(filename string, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации данных для файла '%s': %w", filename, err)
+	}
+	tmp := filename + ".tmp"
+	if err := writeSynced(tmp, data); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("ошибка записи файла '%s': %w", tmp, err)
+	}
+	if err := os.Rename(tmp, filename); err != nil {
+		return fmt.Errorf("ошибка замены файла '%s': %w", filename, err)
+	}
+	return nil
+}
+
+// writeSynced записывает data в файл filename и дожидается сброса данных на диск
+func writeSynced(filename string, data []byte) error {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
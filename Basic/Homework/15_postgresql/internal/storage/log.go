@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+)
+
+// Типы сущностей, изменения которых попадают в журнал; у списков фиксируется только выдача доступа
+const (
+	EntityTask = "task"
+	EntityNote = "note"
+	EntityList = "list"
+)
+
+// Действия над сущностями, фиксируемые в журнале
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionShare   = "share"
+	ActionUnshare = "unshare"
+	ActionRestore = "restore"
+	ActionPurge   = "purge"
+)
+
+// LogRecord запись журнала изменений
+type LogRecord struct {
+	Id         int             `json:"id"`
+	EntityType string          `json:"entityType"`
+	EntityId   int             `json:"entityId"`
+	Action     string          `json:"action"`
+	Actor      string          `json:"actor"`
+	OwnerId    int             `json:"ownerId"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// LogFilter параметры отбора и пагинации записей журнала
+type LogFilter struct {
+	Entity   string
+	EntityId int
+	Action   string
+	From     time.Time
+	To       time.Time
+	Limit    int
+	Offset   int
+}
+
+// LogPage страница записей журнала изменений
+type LogPage struct {
+	Items  []LogRecord `json:"items"`
+	Total  int         `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+// Snapshot сериализует состояние сущности для журнала; nil соответствует отсутствию состояния
+func Snapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации снимка сущности: %w", err)
+	}
+	return data, nil
+}
+
+// ownerOf возвращает владельца задачи, заметки или доступа из состояния до или после изменения
+func ownerOf(states ...any) int {
+	for _, state := range states {
+		switch v := state.(type) {
+		case model.Task:
+			return v.OwnerId
+		case model.Note:
+			return v.OwnerId
+		case Share:
+			return v.OwnerId
+		}
+	}
+	return 0
+}
+
+// NewLogRecord формирует запись журнала о действии action над сущностью;
+// запись принадлежит владельцу сущности
+func NewLogRecord(entityType string, entityId int, action, actor string, before, after any) (LogRecord, error) {
+	record := LogRecord{
+		EntityType: entityType,
+		EntityId:   entityId,
+		Action:     action,
+		Actor:      actor,
+		OwnerId:    ownerOf(before, after),
+		CreatedAt:  time.Now().UTC(),
+	}
+	var err error
+	if record.Before, err = Snapshot(before); err != nil {
+		return record, err
+	}
+	if record.After, err = Snapshot(after); err != nil {
+		return record, err
+	}
+	return record, nil
+}
+
+// Match проверяет соответствие записи фильтру
+func (f LogFilter) Match(record LogRecord) bool {
+	return (f.Entity == "" || record.EntityType == f.Entity) &&
+		(f.EntityId == 0 || record.EntityId == f.EntityId) &&
+		(f.Action == "" || record.Action == f.Action) &&
+		InRange(record.CreatedAt, f.From, f.To)
+}
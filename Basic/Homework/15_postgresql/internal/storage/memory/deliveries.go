@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// deliveryKey ключ отметки о доставке; момент хранится числом, чтобы не зависеть от часового пояса
+type deliveryKey struct {
+	entityType string
+	entityId   int
+	fireAt     int64
+}
+
+func keyOf(entityType string, entityId int, fireAt time.Time) deliveryKey {
+	return deliveryKey{entityType: entityType, entityId: entityId, fireAt: fireAt.UnixNano()}
+}
+
+// Delivered реализует storage.DeliveryStore
+func (s *Store) Delivered(_ context.Context, entityType string, entityId int, fireAt time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.deliveries[keyOf(entityType, entityId, fireAt)]
+	return ok, nil
+}
+
+// MarkDelivered реализует storage.DeliveryStore
+func (s *Store) MarkDelivered(ctx context.Context, delivery storage.Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	key := keyOf(delivery.EntityType, delivery.EntityId, delivery.FireAt)
+	if _, ok := s.deliveries[key]; ok {
+		return nil
+	}
+	var before State
+	if s.persist != nil {
+		before = s.state()
+	}
+	s.deliveries[key] = delivery
+	return s.commit(before)
+}
@@ -0,0 +1,521 @@
+// Package memory реализует storage.Store в оперативной памяти процесса
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// State содержимое хранилища: задачи, заметки, журнал изменений, история статусов задач,
+// история откладывания напоминаний, отметки о доставленных напоминаниях, учётные записи, сессии
+// и доступы пользователей
+type State struct {
+	Tasks      []model.Task                 `json:"tasks"`
+	Notes      []model.Note                 `json:"notes"`
+	Log        []storage.LogRecord          `json:"log"`
+	History    map[int][]model.StatusChange `json:"history"`
+	Snoozes    Snoozes                      `json:"snoozes"`
+	Deliveries []storage.Delivery           `json:"deliveries"`
+	Users      []storage.Account            `json:"users"`
+	Sessions   []storage.Session            `json:"sessions"`
+	Shares     []storage.Share              `json:"shares"`
+}
+
+// Snoozes история откладывания напоминаний задач и заметок по их Id
+type Snoozes struct {
+	Tasks map[int][]model.Snooze `json:"tasks"`
+	Notes map[int][]model.Snooze `json:"notes"`
+}
+
+// PersistFunc сохраняет состояние хранилища после каждого изменения.
+// При ошибке изменение отменяется
+type PersistFunc func(state State) error
+
+// Store хранилище задач и заметок в памяти
+type Store struct {
+	mu         sync.RWMutex
+	tasks      map[int]model.Task
+	notes      map[int]model.Note
+	log        []storage.LogRecord
+	history    map[int][]model.StatusChange
+	snoozes    Snoozes
+	deliveries map[deliveryKey]storage.Delivery
+	users      map[int]storage.Account
+	sessions   map[string]storage.Session
+	shares     map[int]storage.Share
+	lastIds    struct{ task, note, log, user, share int }
+	persist    PersistFunc
+}
+
+var _ storage.Store = (*Store)(nil)
+
+// New создаёт пустое хранилище
+func New() *Store {
+	return Restore(State{}, nil)
+}
+
+// Restore создаёт хранилище с состоянием state; persist вызывается после каждого изменения (может быть nil)
+func Restore(state State, persist PersistFunc) *Store {
+	s := &Store{persist: persist}
+	s.load(state)
+	return s
+}
+
+// load заменяет содержимое хранилища состоянием state
+func (s *Store) load(state State) {
+	s.tasks = make(map[int]model.Task, len(state.Tasks))
+	s.notes = make(map[int]model.Note, len(state.Notes))
+	s.log = slices.Clone(state.Log)
+	s.history = cloneHistory(state.History)
+	s.snoozes = Snoozes{Tasks: cloneHistory(state.Snoozes.Tasks), Notes: cloneHistory(state.Snoozes.Notes)}
+	s.deliveries = make(map[deliveryKey]storage.Delivery, len(state.Deliveries))
+	for _, delivery := range state.Deliveries {
+		s.deliveries[keyOf(delivery.EntityType, delivery.EntityId, delivery.FireAt)] = delivery
+	}
+	s.users = make(map[int]storage.Account, len(state.Users))
+	s.sessions = make(map[string]storage.Session, len(state.Sessions))
+	for _, session := range state.Sessions {
+		s.sessions[session.TokenHash] = session
+	}
+	s.shares = make(map[int]storage.Share, len(state.Shares))
+	s.lastIds.task, s.lastIds.note, s.lastIds.log, s.lastIds.user, s.lastIds.share = 0, 0, 0, 0, 0
+	for _, task := range state.Tasks {
+		s.tasks[task.Id] = task
+		s.lastIds.task = max(s.lastIds.task, task.Id)
+	}
+	for _, note := range state.Notes {
+		s.notes[note.Id] = note
+		s.lastIds.note = max(s.lastIds.note, note.Id)
+	}
+	for _, record := range state.Log {
+		s.lastIds.log = max(s.lastIds.log, record.Id)
+	}
+	for _, account := range state.Users {
+		s.users[account.Id] = account
+		s.lastIds.user = max(s.lastIds.user, account.Id)
+	}
+	for _, share := range state.Shares {
+		s.shares[share.Id] = share
+		s.lastIds.share = max(s.lastIds.share, share.Id)
+	}
+}
+
+// state возвращает копию содержимого хранилища, упорядоченную по Id
+func (s *Store) state() State {
+	state := State{
+		Tasks:      slices.Collect(maps.Values(s.tasks)),
+		Notes:      slices.Collect(maps.Values(s.notes)),
+		Log:        slices.Clone(s.log),
+		History:    cloneHistory(s.history),
+		Snoozes:    Snoozes{Tasks: cloneHistory(s.snoozes.Tasks), Notes: cloneHistory(s.snoozes.Notes)},
+		Deliveries: slices.Collect(maps.Values(s.deliveries)),
+		Users:      slices.Collect(maps.Values(s.users)),
+		Sessions:   slices.Collect(maps.Values(s.sessions)),
+		Shares:     slices.Collect(maps.Values(s.shares)),
+	}
+	slices.SortFunc(state.Tasks, func(a, b model.Task) int { return a.Id - b.Id })
+	slices.SortFunc(state.Notes, func(a, b model.Note) int { return a.Id - b.Id })
+	slices.SortFunc(state.Deliveries, func(a, b storage.Delivery) int { return a.DeliveredAt.Compare(b.DeliveredAt) })
+	slices.SortFunc(state.Users, func(a, b storage.Account) int { return a.Id - b.Id })
+	slices.SortFunc(state.Sessions, func(a, b storage.Session) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	slices.SortFunc(state.Shares, func(a, b storage.Share) int { return a.Id - b.Id })
+	return state
+}
+
+// cloneHistory копирует историю статусов или откладываний, чтобы изменения копии не затрагивали оригинал
+func cloneHistory[T any](history map[int][]T) map[int][]T {
+	cloned := make(map[int][]T, len(history))
+	for id, changes := range history {
+		cloned[id] = slices.Clone(changes)
+	}
+	return cloned
+}
+
+// recordStatus добавляет в историю задачи смену статуса from -> to; вызывается под блокировкой
+func (s *Store) recordStatus(ctx context.Context, id int, from, to model.Status) {
+	for _, step := range model.StatusSteps(from, to) {
+		step.Actor = storage.ActorFrom(ctx)
+		step.ChangedAt = time.Now().UTC()
+		s.history[id] = append(s.history[id], step)
+	}
+}
+
+// recordSnooze добавляет в историю snoozes запись об откладывании напоминания,
+// если оно было отложено; вызывается под блокировкой
+func recordSnooze(ctx context.Context, snoozes map[int][]model.Snooze, id int, snooze model.Snooze, ok bool) {
+	if !ok {
+		return
+	}
+	snooze.Actor = storage.ActorFrom(ctx)
+	snooze.SnoozedAt = time.Now().UTC()
+	snoozes[id] = append(snoozes[id], snooze)
+}
+
+// mutate выполняет изменение под блокировкой, записывает его в журнал и сохраняет состояние.
+// Если сохранение не удалось, хранилище возвращается к состоянию до изменения
+func (s *Store) mutate(ctx context.Context, fn func() (storage.LogRecord, error)) error {
+	return s.mutateAll(ctx, func() ([]storage.LogRecord, error) {
+		record, err := fn()
+		return []storage.LogRecord{record}, err
+	})
+}
+
+// mutateAll выполняет изменение нескольких записей; соглашения те же, что у mutate
+func (s *Store) mutateAll(ctx context.Context, fn func() ([]storage.LogRecord, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// запрос мог быть отменён, пока изменение ждало блокировку
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var before State
+	if s.persist != nil {
+		before = s.state()
+	}
+	records, err := fn()
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		s.lastIds.log++
+		record.Id = s.lastIds.log
+		record.Actor = storage.ActorFrom(ctx)
+		s.log = append(s.log, record)
+	}
+	return s.commit(before)
+}
+
+// commit сохраняет состояние после изменения; если сохранение не удалось,
+// хранилище возвращается к состоянию before. Вызывается под блокировкой
+func (s *Store) commit(before State) error {
+	if s.persist == nil {
+		return nil
+	}
+	if err := s.persist(s.state()); err != nil {
+		s.load(before)
+		return fmt.Errorf("ошибка сохранения хранилища: %w", err)
+	}
+	return nil
+}
+
+// nameTaken проверяет, занято ли имя name другой записью владельца ownerId, кроме записи с Id exceptId;
+// имена уникальны в пределах записей одного владельца вне корзины
+func nameTaken[T any](items map[int]T, ownerId int, name string, exceptId int, nameOf func(T) (int, string, bool)) bool {
+	for id, item := range items {
+		if owner, itemName, trashed := nameOf(item); id != exceptId && !trashed && owner == ownerId && itemName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func taskName(task model.Task) (int, string, bool) {
+	return task.OwnerId, task.Name, task.DeletedAt != nil
+}
+
+func noteName(note model.Note) (int, string, bool) {
+	return note.OwnerId, note.Name, note.DeletedAt != nil
+}
+
+// visible проверяет, видна ли пользователю из ctx запись id типа entityType владельца ownerId
+// с метками tags: собственная или с выданным ему доступом; вызывается под блокировкой.
+// Записи в корзине не видны никому (см. taskVisible, noteVisible)
+func (s *Store) visible(ctx context.Context, entityType string, id, ownerId int, tags []string) bool {
+	if storage.Owns(ctx, ownerId) {
+		return true
+	}
+	user := storage.OwnerFrom(ctx)
+	for _, share := range s.shares {
+		if share.UserId == user && share.Covers(entityType, id, ownerId, tags) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Store) taskVisible(ctx context.Context, task model.Task) bool {
+	return task.DeletedAt == nil && s.visible(ctx, storage.EntityTask, task.Id, task.OwnerId, task.Tags)
+}
+
+func (s *Store) noteVisible(ctx context.Context, note model.Note) bool {
+	return note.DeletedAt == nil && s.visible(ctx, storage.EntityNote, note.Id, note.OwnerId, note.Tags)
+}
+
+// task возвращает задачу id, если она видна пользователю из ctx; вызывается под блокировкой
+func (s *Store) task(ctx context.Context, id int) (model.Task, error) {
+	task, ok := s.tasks[id]
+	if !ok || !s.taskVisible(ctx, task) {
+		return model.Task{}, storage.ErrNotFound
+	}
+	return task, nil
+}
+
+// note возвращает заметку id, если она видна пользователю из ctx; вызывается под блокировкой
+func (s *Store) note(ctx context.Context, id int) (model.Note, error) {
+	note, ok := s.notes[id]
+	if !ok || !s.noteVisible(ctx, note) {
+		return model.Note{}, storage.ErrNotFound
+	}
+	return note, nil
+}
+
+// ListTasks реализует storage.TaskStore
+func (s *Store) ListTasks(ctx context.Context, filter storage.TaskFilter) (storage.Page[model.Task], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var tasks []model.Task
+	for _, task := range s.tasks {
+		if s.taskVisible(ctx, task) && filter.Match(task) {
+			tasks = append(tasks, task)
+		}
+	}
+	return storage.Paginate(tasks, filter.Sort, filter.Cursor, filter.Limit, storage.TaskCursor)
+}
+
+// GetTask реализует storage.TaskStore
+func (s *Store) GetTask(ctx context.Context, id int) (model.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.task(ctx, id)
+}
+
+// CreateTask реализует storage.TaskStore
+func (s *Store) CreateTask(ctx context.Context, task model.Task) (model.Task, error) {
+	err := s.mutate(ctx, func() (storage.LogRecord, error) {
+		task.OwnerId = storage.OwnerFrom(ctx)
+		if nameTaken(s.tasks, task.OwnerId, task.Name, 0, taskName) {
+			return storage.LogRecord{}, storage.ErrDuplicateName
+		}
+		s.lastIds.task++
+		task.Id = s.lastIds.task
+		task.InitTimeStamp = time.Now().UTC()
+		task.UpdatedAt = nil
+		task.Version = 1
+		s.tasks[task.Id] = task
+		s.recordStatus(ctx, task.Id, "", task.Status)
+		return storage.NewLogRecord(storage.EntityTask, task.Id, storage.ActionCreate, "", nil, task)
+	})
+	return task, err
+}
+
+// UpdateTask реализует storage.TaskStore
+func (s *Store) UpdateTask(ctx context.Context, id int, change func(task *model.Task) error) (model.Task, error) {
+	var task model.Task
+	err := s.mutate(ctx, func() (storage.LogRecord, error) {
+		before, err := s.task(ctx, id)
+		if err != nil {
+			return storage.LogRecord{}, err
+		}
+		task = before
+		if err := change(&task); err != nil {
+			return storage.LogRecord{}, err
+		}
+		task.Id, task.OwnerId = id, before.OwnerId
+		if nameTaken(s.tasks, task.OwnerId, task.Name, id, taskName) {
+			return storage.LogRecord{}, storage.ErrDuplicateName
+		}
+		if err := storage.CheckTask(ctx, taskGraph{s}, before, task); err != nil {
+			return storage.LogRecord{}, err
+		}
+		now := time.Now().UTC()
+		task.Id, task.UpdatedAt, task.Version = id, &now, before.Version+1
+		s.tasks[id] = task
+		s.recordStatus(ctx, id, before.Status, task.Status)
+		snooze, ok := model.TaskSnooze(before, task)
+		recordSnooze(ctx, s.snoozes.Tasks, id, snooze, ok)
+		return storage.NewLogRecord(storage.EntityTask, id, storage.ActionUpdate, "", before, task)
+	})
+	return task, err
+}
+
+// TaskHistory реализует storage.TaskStore
+func (s *Store) TaskHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, err := s.task(ctx, id); err != nil {
+		return nil, err
+	}
+	return append(make([]model.StatusChange, 0, len(s.history[id])), s.history[id]...), nil
+}
+
+// TaskSnoozes реализует storage.TaskStore
+func (s *Store) TaskSnoozes(ctx context.Context, id int) ([]model.Snooze, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, err := s.task(ctx, id); err != nil {
+		return nil, err
+	}
+	return append(make([]model.Snooze, 0, len(s.snoozes.Tasks[id])), s.snoozes.Tasks[id]...), nil
+}
+
+// ListNotes реализует storage.NoteStore
+func (s *Store) ListNotes(ctx context.Context, filter storage.NoteFilter) (storage.Page[model.Note], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var notes []model.Note
+	for _, note := range s.notes {
+		if s.noteVisible(ctx, note) && filter.Match(note) {
+			notes = append(notes, note)
+		}
+	}
+	return storage.Paginate(notes, filter.Sort, filter.Cursor, filter.Limit, storage.NoteCursor)
+}
+
+// GetNote реализует storage.NoteStore
+func (s *Store) GetNote(ctx context.Context, id int) (model.Note, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.note(ctx, id)
+}
+
+// CreateNote реализует storage.NoteStore
+func (s *Store) CreateNote(ctx context.Context, note model.Note) (model.Note, error) {
+	err := s.mutate(ctx, func() (storage.LogRecord, error) {
+		note.OwnerId = storage.OwnerFrom(ctx)
+		if nameTaken(s.notes, note.OwnerId, note.Name, 0, noteName) {
+			return storage.LogRecord{}, storage.ErrDuplicateName
+		}
+		s.lastIds.note++
+		note.Id = s.lastIds.note
+		note.InitTimeStamp = time.Now().UTC()
+		note.UpdatedAt = nil
+		note.Version = 1
+		s.notes[note.Id] = note
+		return storage.NewLogRecord(storage.EntityNote, note.Id, storage.ActionCreate, "", nil, note)
+	})
+	return note, err
+}
+
+// UpdateNote реализует storage.NoteStore
+func (s *Store) UpdateNote(ctx context.Context, id int, change func(note *model.Note) error) (model.Note, error) {
+	var note model.Note
+	err := s.mutate(ctx, func() (storage.LogRecord, error) {
+		before, err := s.note(ctx, id)
+		if err != nil {
+			return storage.LogRecord{}, err
+		}
+		note = before
+		if err := change(&note); err != nil {
+			return storage.LogRecord{}, err
+		}
+		note.Id, note.OwnerId = id, before.OwnerId
+		if nameTaken(s.notes, note.OwnerId, note.Name, id, noteName) {
+			return storage.LogRecord{}, storage.ErrDuplicateName
+		}
+		if err := storage.CheckNote(ctx, taskGraph{s}, before, note); err != nil {
+			return storage.LogRecord{}, err
+		}
+		now := time.Now().UTC()
+		note.Id, note.UpdatedAt, note.Version = id, &now, before.Version+1
+		s.notes[id] = note
+		snooze, ok := model.NoteSnooze(before, note)
+		recordSnooze(ctx, s.snoozes.Notes, id, snooze, ok)
+		return storage.NewLogRecord(storage.EntityNote, id, storage.ActionUpdate, "", before, note)
+	})
+	return note, err
+}
+
+// DeleteNote реализует storage.NoteStore
+func (s *Store) DeleteNote(ctx context.Context, id int) (model.Note, error) {
+	var note model.Note
+	err := s.mutate(ctx, func() (storage.LogRecord, error) {
+		var err error
+		if note, err = s.note(ctx, id); err != nil {
+			return storage.LogRecord{}, err
+		}
+		if err := storage.CheckVersion(ctx, note.Version); err != nil {
+			return storage.LogRecord{}, err
+		}
+		before := note
+		now := time.Now().UTC()
+		note.DeletedAt = &now
+		note.Version++
+		s.notes[id] = note
+		return storage.NewLogRecord(storage.EntityNote, id, storage.ActionDelete, "", before, nil)
+	})
+	return note, err
+}
+
+// NoteSnoozes реализует storage.NoteStore
+func (s *Store) NoteSnoozes(ctx context.Context, id int) ([]model.Snooze, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, err := s.note(ctx, id); err != nil {
+		return nil, err
+	}
+	return append(make([]model.Snooze, 0, len(s.snoozes.Notes[id])), s.snoozes.Notes[id]...), nil
+}
+
+// ReadLog реализует storage.LogReader
+func (s *Store) ReadLog(ctx context.Context, filter storage.LogFilter) (storage.LogPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	page := storage.LogPage{Items: make([]storage.LogRecord, 0), Limit: filter.Limit, Offset: filter.Offset}
+	var records []storage.LogRecord
+	for _, record := range slices.Backward(s.log) {
+		if storage.Owns(ctx, record.OwnerId) && filter.Match(record) {
+			records = append(records, record)
+		}
+	}
+	page.Total = len(records)
+	if filter.Offset < len(records) {
+		page.Items = append(page.Items, records[filter.Offset:min(filter.Offset+filter.Limit, len(records))]...)
+	}
+	return page, nil
+}
+
+// Search реализует storage.Searcher
+func (s *Store) Search(ctx context.Context, filter storage.SearchFilter) (storage.SearchPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	terms := storage.SearchTerms(filter.Query)
+	var hits []storage.SearchHit
+	if filter.Type != storage.EntityNote {
+		for _, task := range s.tasks {
+			if !s.taskVisible(ctx, task) {
+				continue
+			}
+			if hit, ok := storage.MatchText(storage.EntityTask, task.Id, task.Name, task.Description, terms); ok {
+				hits = append(hits, hit)
+			}
+		}
+	}
+	if filter.Type != storage.EntityTask {
+		for _, note := range s.notes {
+			if !s.noteVisible(ctx, note) {
+				continue
+			}
+			if hit, ok := storage.MatchText(storage.EntityNote, note.Id, note.Name, note.Description, terms); ok {
+				hits = append(hits, hit)
+			}
+		}
+	}
+	return storage.SearchPage{
+		Items:  storage.SortHits(hits, filter.Limit, filter.Offset),
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+// Close реализует storage.Store
+func (s *Store) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,198 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+var errDiskFull = errors.New("disk full")
+
+// newTask задача name в том виде, в каком её сохраняет хранилище
+func newTask(name string) model.Task {
+	return model.Task{
+		Name:          name,
+		Status:        model.Created,
+		ReminderState: model.ReminderPending,
+		Labels:        model.Labels{Priority: model.PriorityNormal, Tags: []string{}},
+		BlockedBy:     []int{},
+	}
+}
+
+func TestCreateTaskNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		owner int
+		task  string
+		err   error
+	}{
+		{name: "new name", owner: 1, task: "other"},
+		{name: "name taken by the same owner", owner: 1, task: "task", err: storage.ErrDuplicateName},
+		{name: "name of another owner", owner: 2, task: "task"},
+		{name: "name of a task in the trash", owner: 1, task: "trashed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := New()
+			ctx := storage.WithOwner(context.Background(), 1)
+			_, err := store.CreateTask(ctx, newTask("task"))
+			assert.NoError(t, err)
+			trashed, err := store.CreateTask(ctx, newTask("trashed"))
+			assert.NoError(t, err)
+			_, err = store.DeleteTask(ctx, trashed.Id, storage.DeleteOptions{})
+			assert.NoError(t, err)
+
+			_, err = store.CreateTask(storage.WithOwner(context.Background(), tt.owner), newTask(tt.task))
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestGetTaskOwner(t *testing.T) {
+	store := New()
+	task, err := store.CreateTask(storage.WithOwner(context.Background(), 1), newTask("task"))
+	assert.NoError(t, err)
+
+	_, err = store.GetTask(storage.WithOwner(context.Background(), 2), task.Id)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	// внутренние службы видят записи всех пользователей
+	_, err = store.GetTask(context.Background(), task.Id)
+	assert.NoError(t, err)
+}
+
+func TestUpdateTaskVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []int
+		version  int
+		err      error
+	}{
+		{name: "without expected version", version: 3},
+		{name: "expected current version", expected: []int{2}, version: 3},
+		{name: "stale version", expected: []int{1}, version: 2, err: storage.ErrPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := New()
+			ctx := storage.WithOwner(context.Background(), 1)
+			task, err := store.CreateTask(ctx, newTask("task"))
+			assert.NoError(t, err)
+			_, err = store.UpdateTask(ctx, task.Id, func(task *model.Task) error { return task.Transition(model.Seen) })
+			assert.NoError(t, err)
+
+			ctx = storage.WithExpectedVersion(ctx, tt.expected...)
+			_, err = store.UpdateTask(ctx, task.Id, func(task *model.Task) error { return task.Transition(model.InProcess) })
+			assert.ErrorIs(t, err, tt.err)
+
+			task, err = store.GetTask(ctx, task.Id)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.version, task.Version)
+		})
+	}
+}
+
+func TestMutateRollback(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		change func(store *Store, id int) error
+	}{
+		{name: "create", change: func(store *Store, _ int) error {
+			_, err := store.CreateTask(ctx, newTask("other"))
+			return err
+		}},
+		{name: "update", change: func(store *Store, id int) error {
+			_, err := store.UpdateTask(ctx, id, func(task *model.Task) error {
+				task.Name = "renamed"
+				return nil
+			})
+			return err
+		}},
+		{name: "delete", change: func(store *Store, id int) error {
+			_, err := store.DeleteTask(ctx, id, storage.DeleteOptions{Notes: storage.NotesDetach})
+			return err
+		}},
+		{name: "delete with notes", change: func(store *Store, id int) error {
+			_, err := store.DeleteTask(ctx, id, storage.DeleteOptions{Notes: storage.NotesDelete})
+			return err
+		}},
+		{name: "first user registration", change: func(store *Store, _ int) error {
+			_, err := store.CreateUser(ctx, storage.Account{User: model.User{Login: "alice"}})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fail := false
+			saves := 0
+			store := Restore(State{}, func(State) error {
+				if fail {
+					return errDiskFull
+				}
+				saves++
+				return nil
+			})
+			task, err := store.CreateTask(ctx, newTask("task"))
+			assert.NoError(t, err)
+			_, err = store.CreateNote(ctx, model.Note{
+				Name:          "note",
+				TaskId:        &task.Id,
+				ReminderState: model.ReminderPending,
+				Labels:        model.Labels{Priority: model.PriorityNormal, Tags: []string{}},
+			})
+			assert.NoError(t, err)
+			before := store.state()
+
+			fail = true
+			err = tt.change(store, task.Id)
+
+			assert.ErrorIs(t, err, errDiskFull)
+			assert.Equal(t, before, store.state())
+
+			// после отката Id выдаются так, как будто неудачного изменения не было
+			fail = false
+			created, err := store.CreateTask(ctx, newTask("next"))
+			assert.NoError(t, err)
+			assert.Equal(t, task.Id+1, created.Id)
+			assert.Equal(t, 3, saves)
+			assert.Len(t, store.state().Log, 3)
+		})
+	}
+}
+
+func TestCreateUserAdoptsOwnerless(t *testing.T) {
+	legacy := newTask("legacy")
+	legacy.Id = 1
+	store := Restore(State{
+		Tasks: []model.Task{legacy},
+		Log:   []storage.LogRecord{{Id: 1, EntityType: storage.EntityTask, EntityId: 1, Action: storage.ActionCreate}},
+	}, nil)
+
+	alice, err := store.CreateUser(context.Background(), storage.Account{User: model.User{Login: "alice"}})
+	assert.NoError(t, err)
+	bob, err := store.CreateUser(context.Background(), storage.Account{User: model.User{Login: "bob"}})
+	assert.NoError(t, err)
+
+	// записи, созданные до появления пользователей, достаются первому из них
+	ctx := storage.WithOwner(context.Background(), alice.Id)
+	_, err = store.GetTask(ctx, 1)
+	assert.NoError(t, err)
+	log, err := store.ReadLog(ctx, storage.LogFilter{Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, log.Items, 1)
+
+	_, err = store.GetTask(storage.WithOwner(context.Background(), bob.Id), 1)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
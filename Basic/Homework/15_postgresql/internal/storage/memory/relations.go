@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// taskGraph реализует storage.TaskGraph над задачами хранилища, видимыми пользователю из ctx;
+// вызывается под блокировкой
+type taskGraph struct {
+	s *Store
+}
+
+// Task реализует storage.TaskGraph
+func (g taskGraph) Task(ctx context.Context, id int) (model.Task, error) {
+	return g.s.task(ctx, id)
+}
+
+// Subtasks реализует storage.TaskGraph
+func (g taskGraph) Subtasks(ctx context.Context, id int) ([]model.Task, error) {
+	return g.filter(ctx, func(task model.Task) bool {
+		return task.ParentId != nil && *task.ParentId == id
+	}), nil
+}
+
+// Dependents реализует storage.TaskGraph
+func (g taskGraph) Dependents(ctx context.Context, id int) ([]model.Task, error) {
+	return g.filter(ctx, func(task model.Task) bool {
+		return slices.Contains(task.BlockedBy, id)
+	}), nil
+}
+
+// Notes реализует storage.TaskGraph
+func (g taskGraph) Notes(ctx context.Context, id int) ([]model.Note, error) {
+	var notes []model.Note
+	for _, note := range g.s.notes {
+		if note.TaskId != nil && *note.TaskId == id && g.s.noteVisible(ctx, note) {
+			notes = append(notes, note)
+		}
+	}
+	slices.SortFunc(notes, func(a, b model.Note) int { return a.Id - b.Id })
+	return notes, nil
+}
+
+// filter возвращает видимые задачи, удовлетворяющие match, в порядке Id
+func (g taskGraph) filter(ctx context.Context, match func(task model.Task) bool) []model.Task {
+	var tasks []model.Task
+	for _, task := range g.s.tasks {
+		if g.s.taskVisible(ctx, task) && match(task) {
+			tasks = append(tasks, task)
+		}
+	}
+	slices.SortFunc(tasks, func(a, b model.Task) int { return a.Id - b.Id })
+	return tasks
+}
+
+// DeleteTask реализует storage.TaskStore
+func (s *Store) DeleteTask(ctx context.Context, id int, opts storage.DeleteOptions) (model.Task, error) {
+	var task model.Task
+	err := s.mutateAll(ctx, func() ([]storage.LogRecord, error) {
+		plan, err := storage.PlanDelete(ctx, taskGraph{s}, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		var records []storage.LogRecord
+		now := time.Now().UTC()
+		for _, change := range plan.DetachedNotes {
+			after := change.After
+			after.UpdatedAt = &now
+			after.Version++
+			s.notes[after.Id] = after
+			record, err := storage.NewLogRecord(storage.EntityNote, after.Id, storage.ActionUpdate, "", change.Before, after)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+		for _, note := range plan.DeletedNotes {
+			trashed := note
+			trashed.DeletedAt = &now
+			trashed.Version++
+			s.notes[note.Id] = trashed
+			record, err := storage.NewLogRecord(storage.EntityNote, note.Id, storage.ActionDelete, "", note, nil)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+		for _, change := range plan.Updated {
+			after := change.After
+			after.UpdatedAt = &now
+			after.Version++
+			s.tasks[after.Id] = after
+			record, err := storage.NewLogRecord(storage.EntityTask, after.Id, storage.ActionUpdate, "", change.Before, after)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+		for _, deleted := range plan.Deleted {
+			task = deleted
+			task.DeletedAt = &now
+			task.Version++
+			s.tasks[deleted.Id] = task
+			record, err := storage.NewLogRecord(storage.EntityTask, deleted.Id, storage.ActionDelete, "", deleted, nil)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+		return records, nil
+	})
+	return task, err
+}
+
+// TaskTree реализует storage.TaskStore
+func (s *Store) TaskTree(ctx context.Context, id int) (model.TaskNode, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return storage.BuildTree(ctx, taskGraph{s}, id)
+}
+
+// TaskNotes реализует storage.NoteStore
+func (s *Store) TaskNotes(ctx context.Context, taskId int) ([]model.Note, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	notes, err := taskGraph{s}.Notes(ctx, taskId)
+	if notes == nil {
+		notes = make([]model.Note, 0)
+	}
+	return notes, err
+}
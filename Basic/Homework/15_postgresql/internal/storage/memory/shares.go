@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// findShare возвращает доступ пользователя userId к target владельца ownerId; вызывается под блокировкой
+func (s *Store) findShare(ownerId int, target storage.ShareTarget, userId int) (storage.Share, bool) {
+	for _, share := range s.shares {
+		if share.OwnerId == ownerId && share.ShareTarget == target && share.UserId == userId {
+			return share, true
+		}
+	}
+	return storage.Share{}, false
+}
+
+// Share реализует storage.ShareStore
+func (s *Store) Share(ctx context.Context, share storage.Share) (storage.Share, error) {
+	err := s.mutate(ctx, func() (storage.LogRecord, error) {
+		share.OwnerId = storage.OwnerFrom(ctx)
+		var before any
+		if existing, ok := s.findShare(share.OwnerId, share.ShareTarget, share.UserId); ok {
+			before = existing
+			share.Id, share.CreatedAt = existing.Id, existing.CreatedAt
+		} else {
+			s.lastIds.share++
+			share.Id, share.CreatedAt = s.lastIds.share, time.Now().UTC()
+		}
+		s.shares[share.Id] = share
+		return storage.NewLogRecord(share.EntityType, share.EntityId, storage.ActionShare, "", before, share)
+	})
+	return share, err
+}
+
+// Unshare реализует storage.ShareStore
+func (s *Store) Unshare(ctx context.Context, target storage.ShareTarget, userId int) (storage.Share, error) {
+	var share storage.Share
+	err := s.mutate(ctx, func() (storage.LogRecord, error) {
+		var ok bool
+		if share, ok = s.findShare(storage.OwnerFrom(ctx), target, userId); !ok {
+			return storage.LogRecord{}, storage.ErrNotFound
+		}
+		delete(s.shares, share.Id)
+		return storage.NewLogRecord(share.EntityType, share.EntityId, storage.ActionUnshare, "", share, nil)
+	})
+	return share, err
+}
+
+// Shares реализует storage.ShareStore
+func (s *Store) Shares(ctx context.Context, target storage.ShareTarget) ([]storage.Share, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	owner := storage.OwnerFrom(ctx)
+	shares := make([]storage.Share, 0)
+	for _, share := range s.shares {
+		if share.OwnerId == owner && share.ShareTarget == target {
+			shares = append(shares, share)
+		}
+	}
+	slices.SortFunc(shares, func(a, b storage.Share) int { return a.Id - b.Id })
+	return shares, nil
+}
+
+// SharedWith реализует storage.ShareStore
+func (s *Store) SharedWith(ctx context.Context, ownerId int) ([]storage.Share, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user := storage.OwnerFrom(ctx)
+	var shares []storage.Share
+	for _, share := range s.shares {
+		if share.OwnerId == ownerId && share.UserId == user {
+			shares = append(shares, share)
+		}
+	}
+	return shares, nil
+}
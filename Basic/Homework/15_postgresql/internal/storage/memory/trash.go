@@ -0,0 +1,196 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// trashedTask возвращает задачу id из корзины владельца из ctx; вызывается под блокировкой
+func (s *Store) trashedTask(ctx context.Context, id int) (model.Task, error) {
+	task, ok := s.tasks[id]
+	if !ok || !storage.Trashed(ctx, task.OwnerId, task.DeletedAt) {
+		return model.Task{}, storage.ErrNotFound
+	}
+	return task, nil
+}
+
+// trashedNote возвращает заметку id из корзины владельца из ctx; вызывается под блокировкой
+func (s *Store) trashedNote(ctx context.Context, id int) (model.Note, error) {
+	note, ok := s.notes[id]
+	if !ok || !storage.Trashed(ctx, note.OwnerId, note.DeletedAt) {
+		return model.Note{}, storage.ErrNotFound
+	}
+	return note, nil
+}
+
+// Trash реализует storage.TrashStore
+func (s *Store) Trash(ctx context.Context) (storage.Trash, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	trash := storage.Trash{Tasks: make([]model.Task, 0), Notes: make([]model.Note, 0)}
+	for _, task := range s.tasks {
+		if storage.Trashed(ctx, task.OwnerId, task.DeletedAt) {
+			trash.Tasks = append(trash.Tasks, task)
+		}
+	}
+	for _, note := range s.notes {
+		if storage.Trashed(ctx, note.OwnerId, note.DeletedAt) {
+			trash.Notes = append(trash.Notes, note)
+		}
+	}
+	slices.SortFunc(trash.Tasks, func(a, b model.Task) int {
+		return cmp.Or(b.DeletedAt.Compare(*a.DeletedAt), b.Id-a.Id)
+	})
+	slices.SortFunc(trash.Notes, func(a, b model.Note) int {
+		return cmp.Or(b.DeletedAt.Compare(*a.DeletedAt), b.Id-a.Id)
+	})
+	return trash, nil
+}
+
+// RestoreTask реализует storage.TrashStore
+func (s *Store) RestoreTask(ctx context.Context, id int) (model.Task, error) {
+	var task model.Task
+	err := s.mutate(ctx, func() (storage.LogRecord, error) {
+		before, err := s.trashedTask(ctx, id)
+		if err != nil {
+			return storage.LogRecord{}, err
+		}
+		if nameTaken(s.tasks, before.OwnerId, before.Name, id, taskName) {
+			return storage.LogRecord{}, storage.ErrDuplicateName
+		}
+		if task, err = storage.RestoredTask(ctx, taskGraph{s}, before); err != nil {
+			return storage.LogRecord{}, err
+		}
+		now := time.Now().UTC()
+		task.UpdatedAt = &now
+		task.Version++
+		s.tasks[id] = task
+		return storage.NewLogRecord(storage.EntityTask, id, storage.ActionRestore, "", before, task)
+	})
+	return task, err
+}
+
+// RestoreNote реализует storage.TrashStore
+func (s *Store) RestoreNote(ctx context.Context, id int) (model.Note, error) {
+	var note model.Note
+	err := s.mutate(ctx, func() (storage.LogRecord, error) {
+		before, err := s.trashedNote(ctx, id)
+		if err != nil {
+			return storage.LogRecord{}, err
+		}
+		if nameTaken(s.notes, before.OwnerId, before.Name, id, noteName) {
+			return storage.LogRecord{}, storage.ErrDuplicateName
+		}
+		if note, err = storage.RestoredNote(ctx, taskGraph{s}, before); err != nil {
+			return storage.LogRecord{}, err
+		}
+		now := time.Now().UTC()
+		note.UpdatedAt = &now
+		note.Version++
+		s.notes[id] = note
+		return storage.NewLogRecord(storage.EntityNote, id, storage.ActionRestore, "", before, note)
+	})
+	return note, err
+}
+
+// PurgeTask реализует storage.TrashStore
+func (s *Store) PurgeTask(ctx context.Context, id int) (model.Task, error) {
+	var task model.Task
+	err := s.mutate(ctx, func() (storage.LogRecord, error) {
+		var err error
+		if task, err = s.trashedTask(ctx, id); err != nil {
+			return storage.LogRecord{}, err
+		}
+		s.purgeTask(task)
+		return storage.NewLogRecord(storage.EntityTask, id, storage.ActionPurge, "", task, nil)
+	})
+	return task, err
+}
+
+// PurgeNote реализует storage.TrashStore
+func (s *Store) PurgeNote(ctx context.Context, id int) (model.Note, error) {
+	var note model.Note
+	err := s.mutate(ctx, func() (storage.LogRecord, error) {
+		var err error
+		if note, err = s.trashedNote(ctx, id); err != nil {
+			return storage.LogRecord{}, err
+		}
+		s.purgeNote(note)
+		return storage.NewLogRecord(storage.EntityNote, id, storage.ActionPurge, "", note, nil)
+	})
+	return note, err
+}
+
+// PurgeTrash реализует storage.TrashStore
+func (s *Store) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	var records []storage.LogRecord
+	err := s.mutateAll(ctx, func() ([]storage.LogRecord, error) {
+		for _, task := range s.tasks {
+			if !storage.Trashed(ctx, task.OwnerId, task.DeletedAt) || !task.DeletedAt.Before(before) {
+				continue
+			}
+			s.purgeTask(task)
+			record, err := storage.NewLogRecord(storage.EntityTask, task.Id, storage.ActionPurge, "", task, nil)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+		for _, note := range s.notes {
+			if !storage.Trashed(ctx, note.OwnerId, note.DeletedAt) || !note.DeletedAt.Before(before) {
+				continue
+			}
+			s.purgeNote(note)
+			record, err := storage.NewLogRecord(storage.EntityNote, note.Id, storage.ActionPurge, "", note, nil)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+		return records, nil
+	})
+	return len(records), err
+}
+
+// purgeTask окончательно удаляет задачу с историей и доступами и снимает ссылки на неё
+// остающихся подзадач и заметок; вызывается под блокировкой
+func (s *Store) purgeTask(task model.Task) {
+	delete(s.tasks, task.Id)
+	delete(s.history, task.Id)
+	delete(s.snoozes.Tasks, task.Id)
+	for id, other := range s.tasks {
+		if other.ParentId != nil && *other.ParentId == task.Id {
+			other.ParentId = nil
+			s.tasks[id] = other
+		}
+	}
+	for id, note := range s.notes {
+		if note.TaskId != nil && *note.TaskId == task.Id {
+			note.TaskId = nil
+			s.notes[id] = note
+		}
+	}
+	s.purgeShares(storage.EntityTask, task.Id, task.OwnerId)
+}
+
+// purgeNote окончательно удаляет заметку с историей и доступами; вызывается под блокировкой
+func (s *Store) purgeNote(note model.Note) {
+	delete(s.notes, note.Id)
+	delete(s.snoozes.Notes, note.Id)
+	s.purgeShares(storage.EntityNote, note.Id, note.OwnerId)
+}
+
+// purgeShares удаляет доступы к записи id типа entityType владельца ownerId; вызывается под блокировкой
+func (s *Store) purgeShares(entityType string, id, ownerId int) {
+	for shareId, share := range s.shares {
+		if share.OwnerId == ownerId && share.EntityType == entityType && share.EntityId == id {
+			delete(s.shares, shareId)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// CreateUser реализует storage.UserStore
+func (s *Store) CreateUser(ctx context.Context, account storage.Account) (storage.Account, error) {
+	err := s.mutateAll(ctx, func() ([]storage.LogRecord, error) {
+		for _, existing := range s.users {
+			if existing.Login == account.Login {
+				return nil, storage.ErrDuplicateName
+			}
+		}
+		s.lastIds.user++
+		account.Id = s.lastIds.user
+		account.CreatedAt = time.Now().UTC()
+		if len(s.users) == 0 {
+			s.adoptOwnerless(account.Id)
+		}
+		s.users[account.Id] = account
+		return nil, nil
+	})
+	return account, err
+}
+
+// adoptOwnerless передаёт владельцу ownerId задачи, заметки и записи журнала без владельца
+func (s *Store) adoptOwnerless(ownerId int) {
+	for id, task := range s.tasks {
+		if task.OwnerId == 0 {
+			task.OwnerId = ownerId
+			s.tasks[id] = task
+		}
+	}
+	for id, note := range s.notes {
+		if note.OwnerId == 0 {
+			note.OwnerId = ownerId
+			s.notes[id] = note
+		}
+	}
+	for i := range s.log {
+		if s.log[i].OwnerId == 0 {
+			s.log[i].OwnerId = ownerId
+		}
+	}
+}
+
+// UserByLogin реализует storage.UserStore
+func (s *Store) UserByLogin(_ context.Context, login string) (storage.Account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, account := range s.users {
+		if account.Login == login {
+			return account, nil
+		}
+	}
+	return storage.Account{}, storage.ErrNotFound
+}
+
+// GetUser реализует storage.UserStore
+func (s *Store) GetUser(_ context.Context, id int) (model.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	account, ok := s.users[id]
+	if !ok {
+		return model.User{}, storage.ErrNotFound
+	}
+	return account.User, nil
+}
+
+// CreateSession реализует storage.UserStore; сессии с истёкшим сроком удаляются
+func (s *Store) CreateSession(ctx context.Context, session storage.Session) error {
+	return s.mutateAll(ctx, func() ([]storage.LogRecord, error) {
+		for tokenHash, existing := range s.sessions {
+			if !session.CreatedAt.Before(existing.ExpiresAt) {
+				delete(s.sessions, tokenHash)
+			}
+		}
+		s.sessions[session.TokenHash] = session
+		return nil, nil
+	})
+}
+
+// GetSession реализует storage.UserStore
+func (s *Store) GetSession(_ context.Context, tokenHash string) (storage.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[tokenHash]
+	if !ok {
+		return storage.Session{}, storage.ErrNotFound
+	}
+	return session, nil
+}
+
+// DeleteSession реализует storage.UserStore
+func (s *Store) DeleteSession(ctx context.Context, tokenHash string) error {
+	return s.mutateAll(ctx, func() ([]storage.LogRecord, error) {
+		delete(s.sessions, tokenHash)
+		return nil, nil
+	})
+}
@@ -0,0 +1,59 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// deliveryDoc документ отметки о доставке напоминания
+type deliveryDoc struct {
+	Id          string    `bson:"_id"`
+	EntityType  string    `bson:"entityType"`
+	EntityId    int       `bson:"entityId"`
+	FireAt      time.Time `bson:"fireAt"`
+	DeliveredAt time.Time `bson:"deliveredAt"`
+}
+
+// deliveryId _id отметки о доставке; MongoDB хранит время с точностью до миллисекунд
+func deliveryId(entityType string, entityId int, fireAt time.Time) string {
+	return fmt.Sprintf("%s:%d:%d", entityType, entityId, fireAt.UnixMilli())
+}
+
+// Delivered реализует storage.DeliveryStore
+func (s *Store) Delivered(ctx context.Context, entityType string, entityId int, fireAt time.Time) (bool, error) {
+	n, err := s.db.Collection(deliveriesCollection).CountDocuments(
+		ctx,
+		bson.M{"_id": deliveryId(entityType, entityId, fireAt)},
+		options.Count().SetLimit(1),
+	)
+	if err != nil {
+		return false, fmt.Errorf("ошибка чтения отметки о доставке: %w", err)
+	}
+	return n > 0, nil
+}
+
+// MarkDelivered реализует storage.DeliveryStore
+func (s *Store) MarkDelivered(ctx context.Context, delivery storage.Delivery) error {
+	id := deliveryId(delivery.EntityType, delivery.EntityId, delivery.FireAt)
+	_, err := s.db.Collection(deliveriesCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$setOnInsert": deliveryDoc{
+			Id:          id,
+			EntityType:  delivery.EntityType,
+			EntityId:    delivery.EntityId,
+			FireAt:      delivery.FireAt.UTC().Truncate(time.Millisecond),
+			DeliveredAt: delivery.DeliveredAt.UTC().Truncate(time.Millisecond),
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка записи отметки о доставке: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// statusDoc документ истории статусов задачи
+type statusDoc struct {
+	Id        int          `bson:"_id"`
+	TaskId    int          `bson:"taskId"`
+	From      model.Status `bson:"from"`
+	To        model.Status `bson:"to"`
+	Actor     string       `bson:"actor"`
+	ChangedAt time.Time    `bson:"changedAt"`
+}
+
+// writeStatus добавляет в историю задачи смену статуса from -> to.
+// Как и журнал, история записывается после изменения задачи
+func (s *Store) writeStatus(ctx context.Context, taskId int, from, to model.Status) error {
+	for _, step := range model.StatusSteps(from, to) {
+		id, err := s.nextId(ctx, historyCollection)
+		if err != nil {
+			return err
+		}
+		_, err = s.db.Collection(historyCollection).InsertOne(ctx, statusDoc{
+			Id:        id,
+			TaskId:    taskId,
+			From:      step.From,
+			To:        step.To,
+			Actor:     storage.ActorFrom(ctx),
+			ChangedAt: time.Now().UTC().Truncate(time.Millisecond),
+		})
+		if err != nil {
+			return fmt.Errorf("ошибка записи в историю статусов: %w", err)
+		}
+	}
+	return nil
+}
+
+// TaskHistory реализует storage.TaskStore
+func (s *Store) TaskHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	if _, err := getOne[taskDoc](ctx, s, tasksCollection, id); err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.db.Collection(historyCollection).Find(
+		ctx,
+		bson.M{"taskId": id},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var docs []statusDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	history := make([]model.StatusChange, 0, len(docs))
+	for _, doc := range docs {
+		history = append(history, model.StatusChange{
+			From:      doc.From,
+			To:        doc.To,
+			Actor:     doc.Actor,
+			ChangedAt: doc.ChangedAt,
+		})
+	}
+	return history, nil
+}
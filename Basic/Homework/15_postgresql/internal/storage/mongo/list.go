@@ -0,0 +1,168 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Соответствие полей сортировки полям документов
+var (
+	taskSortFields = map[string]string{"id": "_id", "name": "name", "dueDate": "dueDate"}
+	noteSortFields = map[string]string{"id": "_id", "name": "name", "alarmTimeStamp": "alarmTimeStamp"}
+)
+
+// timeRange возвращает условие на поле-дату по границам [from, to)
+func timeRange(from, to time.Time) bson.M {
+	cond := bson.M{}
+	if !from.IsZero() {
+		cond["$gte"] = from
+	}
+	if !to.IsZero() {
+		cond["$lt"] = to
+	}
+	return cond
+}
+
+// nameContains возвращает условие поиска подстроки в имени без учёта регистра
+func nameContains(name string) bson.M {
+	return bson.M{"$regex": regexp.QuoteMeta(name), "$options": "i"}
+}
+
+// labelsMatch добавляет к filter условия на приоритет (любой из priorities) и метки (все из tags)
+func labelsMatch(filter bson.M, priorities []model.Priority, tags []string) {
+	if len(priorities) > 0 {
+		filter["priority"] = bson.M{"$in": priorities}
+	}
+	if len(tags) > 0 {
+		filter["tags"] = bson.M{"$all": tags}
+	}
+}
+
+// findPage добавляет к filter keyset-условие по курсору и считывает страницу документов
+func findPage[D any, T any](
+	ctx context.Context,
+	collection *mongo.Collection,
+	filter bson.M,
+	sortFields map[string]string,
+	f pageParams,
+	convert func(D) T,
+	position func(sort string, item T) storage.Cursor,
+) (storage.Page[T], error) {
+	page := storage.Page[T]{Items: make([]T, 0)}
+	cur, err := storage.DecodeCursor(f.cursor, f.sort)
+	if err != nil {
+		return page, err
+	}
+
+	name, desc := storage.SortField(f.sort)
+	field := sortFields[name]
+	cmp, dir := "$gt", 1
+	if desc {
+		cmp, dir = "$lt", -1
+	}
+
+	if cur != nil {
+		var value any = cur.Name
+		if field != "name" {
+			value = cur.Time
+		}
+		keyset := bson.M{"_id": bson.M{cmp: cur.Id}}
+		if field != "_id" {
+			keyset = bson.M{"$or": bson.A{
+				bson.M{field: bson.M{cmp: value}},
+				bson.M{field: value, "_id": bson.M{cmp: cur.Id}},
+			}}
+		}
+		filter = bson.M{"$and": bson.A{filter, keyset}}
+	}
+
+	order := bson.D{{Key: "_id", Value: dir}}
+	if field != "_id" {
+		order = bson.D{{Key: field, Value: dir}, {Key: "_id", Value: dir}}
+	}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(order).SetLimit(int64(f.limit)+1))
+	if err != nil {
+		return page, fmt.Errorf("ошибка считывания страницы из БД: %w", err)
+	}
+	var docs []D
+	if err := cursor.All(ctx, &docs); err != nil {
+		return page, fmt.Errorf("ошибка считывания страницы из БД: %w", err)
+	}
+
+	for _, doc := range docs {
+		page.Items = append(page.Items, convert(doc))
+	}
+	if len(page.Items) > f.limit {
+		page.Items = page.Items[:f.limit]
+		page.NextCursor = position(f.sort, page.Items[f.limit-1]).Encode()
+	}
+	return page, nil
+}
+
+// pageParams параметры сортировки и пагинации, общие для задач и заметок
+type pageParams struct {
+	sort   string
+	limit  int
+	cursor string
+}
+
+// ListTasks реализует storage.TaskStore
+func (s *Store) ListTasks(ctx context.Context, f storage.TaskFilter) (storage.Page[model.Task], error) {
+	filter := bson.M{}
+	if f.Status != "" {
+		filter["status"] = f.Status
+	}
+	if due := timeRange(f.DueFrom, f.DueTo); len(due) > 0 {
+		filter["dueDate"] = due
+	}
+	if f.Name != "" {
+		filter["name"] = nameContains(f.Name)
+	}
+	labelsMatch(filter, f.Priority, f.Tags)
+	filter, err := s.visible(ctx, tasksCollection, filter)
+	if err != nil {
+		return storage.Page[model.Task]{Items: make([]model.Task, 0)}, err
+	}
+	return findPage(
+		ctx,
+		s.db.Collection(tasksCollection),
+		filter,
+		taskSortFields,
+		pageParams{sort: f.Sort, limit: f.Limit, cursor: f.Cursor},
+		taskDoc.model,
+		storage.TaskCursor,
+	)
+}
+
+// ListNotes реализует storage.NoteStore
+func (s *Store) ListNotes(ctx context.Context, f storage.NoteFilter) (storage.Page[model.Note], error) {
+	filter := bson.M{}
+	if alarm := timeRange(f.AlarmFrom, f.AlarmTo); len(alarm) > 0 {
+		filter["alarmTimeStamp"] = alarm
+	}
+	if f.Name != "" {
+		filter["name"] = nameContains(f.Name)
+	}
+	labelsMatch(filter, f.Priority, f.Tags)
+	filter, err := s.visible(ctx, notesCollection, filter)
+	if err != nil {
+		return storage.Page[model.Note]{Items: make([]model.Note, 0)}, err
+	}
+	return findPage(
+		ctx,
+		s.db.Collection(notesCollection),
+		filter,
+		noteSortFields,
+		pageParams{sort: f.Sort, limit: f.Limit, cursor: f.Cursor},
+		noteDoc.model,
+		storage.NoteCursor,
+	)
+}
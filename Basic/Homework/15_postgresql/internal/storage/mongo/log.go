@@ -0,0 +1,110 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// logDoc документ журнала изменений; снимки хранятся в виде json-строк
+type logDoc struct {
+	Id         int       `bson:"_id"`
+	EntityType string    `bson:"entityType"`
+	EntityId   int       `bson:"entityId"`
+	Action     string    `bson:"action"`
+	Actor      string    `bson:"actor"`
+	OwnerId    int       `bson:"ownerId,omitempty"`
+	Before     string    `bson:"before,omitempty"`
+	After      string    `bson:"after,omitempty"`
+	CreatedAt  time.Time `bson:"createdAt"`
+}
+
+// writeLog добавляет запись в журнал изменений.
+// Вызывается в транзакции изменения (см. withTx), поэтому запись фиксируется вместе с ним
+func (s *Store) writeLog(ctx context.Context, entityType string, entityId int, action string, before, after any) error {
+	record, err := storage.NewLogRecord(entityType, entityId, action, storage.ActorFrom(ctx), before, after)
+	if err != nil {
+		return err
+	}
+	id, err := s.nextId(ctx, logCollection)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Collection(logCollection).InsertOne(ctx, logDoc{
+		Id:         id,
+		EntityType: record.EntityType,
+		EntityId:   record.EntityId,
+		Action:     record.Action,
+		Actor:      record.Actor,
+		OwnerId:    record.OwnerId,
+		Before:     string(record.Before),
+		After:      string(record.After),
+		CreatedAt:  record.CreatedAt.Truncate(time.Millisecond),
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка записи в журнал изменений: %w", err)
+	}
+	return nil
+}
+
+// ReadLog реализует storage.LogReader
+func (s *Store) ReadLog(ctx context.Context, f storage.LogFilter) (storage.LogPage, error) {
+	page := storage.LogPage{Items: make([]storage.LogRecord, 0), Limit: f.Limit, Offset: f.Offset}
+
+	filter := owned(ctx, bson.M{})
+	if f.Entity != "" {
+		filter["entityType"] = f.Entity
+	}
+	if f.EntityId != 0 {
+		filter["entityId"] = f.EntityId
+	}
+	if f.Action != "" {
+		filter["action"] = f.Action
+	}
+	if created := timeRange(f.From, f.To); len(created) > 0 {
+		filter["createdAt"] = created
+	}
+
+	collection := s.db.Collection(logCollection)
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return page, err
+	}
+	page.Total = int(total)
+
+	cursor, err := collection.Find(ctx, filter, options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(f.Offset)).
+		SetLimit(int64(f.Limit)),
+	)
+	if err != nil {
+		return page, err
+	}
+	var docs []logDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return page, err
+	}
+	for _, doc := range docs {
+		record := storage.LogRecord{
+			Id:         doc.Id,
+			EntityType: doc.EntityType,
+			EntityId:   doc.EntityId,
+			Action:     doc.Action,
+			Actor:      doc.Actor,
+			OwnerId:    doc.OwnerId,
+			CreatedAt:  doc.CreatedAt,
+		}
+		if doc.Before != "" {
+			record.Before = []byte(doc.Before)
+		}
+		if doc.After != "" {
+			record.After = []byte(doc.After)
+		}
+		page.Items = append(page.Items, record)
+	}
+	return page, nil
+}
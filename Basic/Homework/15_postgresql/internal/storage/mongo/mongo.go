@@ -0,0 +1,621 @@
+// Package mongo реализует storage.Store поверх MongoDB.
+// Документы хранятся с целочисленными _id, которые выдаются счётчиками коллекции counters,
+// поэтому идентификаторы совпадают по формату с остальными хранилищами
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Имена коллекций
+const (
+	tasksCollection      = "tasks"
+	notesCollection      = "notes"
+	logCollection        = "remindables_log"
+	historyCollection    = "task_status_history"
+	snoozesCollection    = "reminder_snoozes"
+	deliveriesCollection = "reminder_deliveries"
+	usersCollection      = "users"
+	sessionsCollection   = "sessions"
+	sharesCollection     = "shares"
+	countersCollection   = "counters"
+	migrationsCollection = "migrations"
+)
+
+// Store хранилище задач и заметок в MongoDB
+type Store struct {
+	client *mongo.Client
+	db     *mongo.Database
+}
+
+var _ storage.Store = (*Store)(nil)
+
+// Open подключается к MongoDB по uri, выбирает базу database, применяет миграции данных прежних версий
+// и создаёт необходимые индексы. Изменения нескольких документов выполняются в транзакциях,
+// поэтому MongoDB должна быть запущена как набор реплик
+func Open(ctx context.Context, uri, database string) (*Store, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to ping MongoDB: %w", err)
+	}
+
+	s := &Store{client: client, db: client.Database(database)}
+	if err := s.checkReplicaSet(ctx); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, err
+	}
+	if err := s.migrate(ctx); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, err
+	}
+	if err := s.ensureIndexes(ctx); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, err
+	}
+	return s, nil
+}
+
+// checkReplicaSet проверяет, что сервер поддерживает транзакции: входит в набор реплик или является mongos
+func (s *Store) checkReplicaSet(ctx context.Context) error {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := s.db.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return fmt.Errorf("failed to query MongoDB topology: %w", err)
+	}
+	if hello.SetName == "" && hello.Msg != "isdbgrid" {
+		return errors.New("MongoDB must run as a replica set to support transactions")
+	}
+	return nil
+}
+
+// withTx выполняет fn в транзакции: изменения всех документов фиксируются вместе или отменяются.
+// Операции внутри fn должны использовать переданный ей ctx. При временных ошибках (например,
+// конфликте с параллельной транзакцией) драйвер повторяет fn целиком
+func withTx[T any](ctx context.Context, s *Store, fn func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	session, err := s.client.StartSession()
+	if err != nil {
+		return result, fmt.Errorf("ошибка начала сессии MongoDB: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx mongo.SessionContext) (any, error) {
+		var err error
+		result, err = fn(ctx)
+		return nil, err
+	})
+	return result, err
+}
+
+// migration изменение данных прежних версий, применяемое к базе однократно
+type migration struct {
+	name  string
+	apply func(s *Store, ctx context.Context) error
+}
+
+// migrations миграции в порядке применения; новые добавляются в конец под новыми именами
+var migrations = []migration{
+	{name: "drop_name_indexes", apply: (*Store).dropIndexes},
+	{name: "status_codes", apply: (*Store).migrateStatuses},
+	{name: "versions", apply: (*Store).migrateVersions},
+	{name: "note_init_time", apply: (*Store).migrateNoteTimes},
+}
+
+// migrate применяет миграции, ещё не отмеченные в коллекции migrations, и отмечает их.
+// Миграции допускают повторный запуск, поэтому одновременный запуск нескольких экземпляров безопасен
+func (s *Store) migrate(ctx context.Context) error {
+	collection := s.db.Collection(migrationsCollection)
+	for _, m := range migrations {
+		err := collection.FindOne(ctx, bson.M{"_id": m.name}).Err()
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("failed to read migration %s: %w", m.name, err)
+		}
+		if err := m.apply(s, ctx); err != nil {
+			return err
+		}
+		_, err = collection.UpdateOne(
+			ctx,
+			bson.M{"_id": m.name},
+			bson.M{"$setOnInsert": bson.M{"appliedAt": time.Now().UTC()}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+// Close реализует storage.Store
+func (s *Store) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+// dropIndexes удаляет индексы прежних версий: имена задач и заметок теперь уникальны
+// в пределах владельца и только вне корзины. Отсутствующие индексы и коллекции пропускаются
+func (s *Store) dropIndexes(ctx context.Context) error {
+	for _, collection := range []string{tasksCollection, notesCollection} {
+		for _, index := range []string{"name_1", "ownerId_1_name_1"} {
+			_, err := s.db.Collection(collection).Indexes().DropOne(ctx, index)
+			var cmdErr mongo.CommandError
+			if err != nil && !(errors.As(err, &cmdErr) && (cmdErr.Code == codeNamespaceNotFound || cmdErr.Code == codeIndexNotFound)) {
+				return fmt.Errorf("failed to drop index %s of %s: %w", index, collection, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Коды ошибок команд MongoDB
+const (
+	codeNamespaceNotFound = 26
+	codeIndexNotFound     = 27
+)
+
+// ensureIndexes создаёт индексы коллекций задач, заметок, журнала, пользователей и доступов.
+// Уникальность имён включает deletedAt: у записей вне корзины он отсутствует и индексируется как null,
+// поэтому записи в корзине не занимают имён
+func (s *Store) ensureIndexes(ctx context.Context) error {
+	text := func(lang string) *options.IndexOptions {
+		return options.Index().
+			SetName("text_search").
+			SetDefaultLanguage(lang).
+			SetWeights(bson.D{{Key: "name", Value: 3}, {Key: "description", Value: 1}})
+	}
+	indexes := map[string][]mongo.IndexModel{
+		tasksCollection: {
+			{Keys: bson.D{{Key: "ownerId", Value: 1}, {Key: "name", Value: 1}, {Key: "deletedAt", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "status", Value: 1}}},
+			{Keys: bson.D{{Key: "priority", Value: 1}}},
+			{Keys: bson.D{{Key: "tags", Value: 1}}},
+			{Keys: bson.D{{Key: "parentId", Value: 1}}},
+			{Keys: bson.D{{Key: "blockedBy", Value: 1}}},
+			{Keys: bson.D{{Key: "dueDate", Value: 1}, {Key: "_id", Value: 1}}},
+			{Keys: bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}}, Options: text("russian")},
+		},
+		notesCollection: {
+			{Keys: bson.D{{Key: "ownerId", Value: 1}, {Key: "name", Value: 1}, {Key: "deletedAt", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "alarmTimeStamp", Value: 1}, {Key: "_id", Value: 1}}},
+			{Keys: bson.D{{Key: "taskId", Value: 1}}},
+			{Keys: bson.D{{Key: "priority", Value: 1}}},
+			{Keys: bson.D{{Key: "tags", Value: 1}}},
+			{Keys: bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}}, Options: text("russian")},
+		},
+		logCollection: {
+			{Keys: bson.D{{Key: "entityType", Value: 1}, {Key: "entityId", Value: 1}}},
+			{Keys: bson.D{{Key: "ownerId", Value: 1}}},
+			{Keys: bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}},
+		},
+		historyCollection: {
+			{Keys: bson.D{{Key: "taskId", Value: 1}, {Key: "_id", Value: 1}}},
+		},
+		snoozesCollection: {
+			{Keys: bson.D{{Key: "entityType", Value: 1}, {Key: "entityId", Value: 1}, {Key: "_id", Value: 1}}},
+		},
+		usersCollection: {
+			{Keys: bson.D{{Key: "login", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		// сессии с истёкшим сроком удаляет сама MongoDB
+		sessionsCollection: {
+			{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		},
+		sharesCollection: {
+			{
+				Keys: bson.D{
+					{Key: "ownerId", Value: 1}, {Key: "entityType", Value: 1}, {Key: "entityId", Value: 1},
+					{Key: "list", Value: 1}, {Key: "userId", Value: 1},
+				},
+				Options: options.Index().SetUnique(true),
+			},
+			{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "ownerId", Value: 1}}},
+		},
+	}
+	for collection, models := range indexes {
+		if _, err := s.db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return fmt.Errorf("failed to create indexes of %s: %w", collection, err)
+		}
+	}
+	return nil
+}
+
+// migrateStatuses заменяет статусы задач, сохранённые русскими подписями, кодами статусов.
+// Повторный запуск ничего не меняет
+func (s *Store) migrateStatuses(ctx context.Context) error {
+	for _, status := range []model.Status{
+		model.Created, model.Updated, model.Seen, model.InProcess, model.Suspended,
+		model.Submitted, model.Completed, model.Cancelled, model.Returned, model.Backlog,
+	} {
+		label := status.Localize(i18n.RU)
+		for collection, fields := range map[string][]string{
+			tasksCollection:   {"status"},
+			historyCollection: {"from", "to"},
+		} {
+			for _, field := range fields {
+				_, err := s.db.Collection(collection).UpdateMany(
+					ctx,
+					bson.M{field: label},
+					bson.M{"$set": bson.M{field: status}},
+				)
+				if err != nil {
+					return fmt.Errorf("ошибка перевода статусов %s на коды: %w", collection, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// migrateVersions назначает версию 1 задачам и заметкам, сохранённым до появления версий,
+// чтобы условные изменения по версии находили и их. Повторный запуск ничего не меняет
+func (s *Store) migrateVersions(ctx context.Context) error {
+	for _, collection := range []string{tasksCollection, notesCollection} {
+		_, err := s.db.Collection(collection).UpdateMany(
+			ctx,
+			bson.M{"version": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"version": 1}},
+		)
+		if err != nil {
+			return fmt.Errorf("ошибка назначения версий %s: %w", collection, err)
+		}
+	}
+	return nil
+}
+
+// migrateNoteTimes переименовывает поле даты создания заметок createdAt в initTimeStamp,
+// как у задач. Повторный запуск ничего не меняет
+func (s *Store) migrateNoteTimes(ctx context.Context) error {
+	_, err := s.db.Collection(notesCollection).UpdateMany(
+		ctx,
+		bson.M{"createdAt": bson.M{"$exists": true}},
+		bson.M{"$rename": bson.M{"createdAt": "initTimeStamp"}},
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка переименования даты создания заметок: %w", err)
+	}
+	return nil
+}
+
+// nextId выдаёт следующий Id для коллекции collection
+func (s *Store) nextId(ctx context.Context, collection string) (int, error) {
+	var counter struct {
+		Seq int `bson:"seq"`
+	}
+	err := s.db.Collection(countersCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": collection},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка выдачи Id для %s: %w", collection, err)
+	}
+	return counter.Seq, nil
+}
+
+// mapError приводит ошибки MongoDB к ошибкам пакета storage
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return storage.ErrNotFound
+	case mongo.IsDuplicateKeyError(err):
+		return storage.ErrDuplicateName
+	}
+	return err
+}
+
+// taskDoc документ задачи
+type taskDoc struct {
+	Id            int                 `bson:"_id"`
+	Name          string              `bson:"name"`
+	Description   string              `bson:"description"`
+	InitTimeStamp time.Time           `bson:"initTimeStamp"`
+	DueDate       time.Time           `bson:"dueDate"`
+	Status        model.Status        `bson:"status"`
+	Recurrence    string              `bson:"recurrence,omitempty"`
+	Timezone      string              `bson:"timezone,omitempty"`
+	ReminderState model.ReminderState `bson:"reminderState,omitempty"`
+	SnoozedFrom   *time.Time          `bson:"snoozedFrom,omitempty"`
+	model.Labels  `bson:",inline"`
+	ParentId      *int       `bson:"parentId,omitempty"`
+	BlockedBy     []int      `bson:"blockedBy,omitempty"`
+	OwnerId       int        `bson:"ownerId,omitempty"`
+	UpdatedAt     *time.Time `bson:"updatedAt,omitempty"`
+	DeletedAt     *time.Time `bson:"deletedAt,omitempty"`
+	Version       int        `bson:"version"`
+}
+
+// model возвращает задачу; у документов, созданных до появления состояний напоминаний,
+// приоритетов и зависимостей, напоминание ожидает срабатывания, приоритет обычный,
+// а блокировок нет
+func (d taskDoc) model() model.Task {
+	task := model.Task(d)
+	task.ReminderState = model.MigrateReminderState(task.ReminderState)
+	task.Labels = model.MigrateLabels(task.Labels)
+	task.BlockedBy = model.MigrateBlockedBy(task.BlockedBy)
+	return task
+}
+
+// noteDoc документ заметки
+type noteDoc struct {
+	Id             int                 `bson:"_id"`
+	Name           string              `bson:"name"`
+	Description    string              `bson:"description"`
+	AlarmTimeStamp time.Time           `bson:"alarmTimeStamp"`
+	InitTimeStamp  time.Time           `bson:"initTimeStamp"`
+	Recurrence     string              `bson:"recurrence,omitempty"`
+	Timezone       string              `bson:"timezone,omitempty"`
+	ReminderState  model.ReminderState `bson:"reminderState,omitempty"`
+	SnoozedFrom    *time.Time          `bson:"snoozedFrom,omitempty"`
+	model.Labels   `bson:",inline"`
+	TaskId         *int       `bson:"taskId,omitempty"`
+	OwnerId        int        `bson:"ownerId,omitempty"`
+	UpdatedAt      *time.Time `bson:"updatedAt,omitempty"`
+	DeletedAt      *time.Time `bson:"deletedAt,omitempty"`
+	Version        int        `bson:"version"`
+}
+
+// model возвращает заметку; соглашения те же, что у taskDoc.model
+func (d noteDoc) model() model.Note {
+	note := model.Note(d)
+	note.ReminderState = model.MigrateReminderState(note.ReminderState)
+	note.Labels = model.MigrateLabels(note.Labels)
+	return note
+}
+
+// owned дополняет filter условием принадлежности документов владельцу из ctx;
+// в контексте без владельца (см. storage.OwnerFrom) принадлежащими считаются все документы
+func owned(ctx context.Context, filter bson.M) bson.M {
+	if owner := storage.OwnerFrom(ctx); owner != 0 {
+		filter["ownerId"] = owner
+	}
+	return filter
+}
+
+// visible дополняет filter документов коллекции collection (задач или заметок) условием видимости
+// пользователю из ctx: собственных документов, документов, к которым ему выдан доступ, и документов
+// из списков, к которым ему выдан доступ; в контексте без владельца видны все документы.
+// Документы в корзине не видны никому
+func (s *Store) visible(ctx context.Context, collection string, filter bson.M) (bson.M, error) {
+	filter["deletedAt"] = nil
+	user := storage.OwnerFrom(ctx)
+	if user == 0 {
+		return filter, nil
+	}
+	shares, err := s.findShares(ctx, bson.M{"userId": user})
+	if err != nil {
+		return nil, err
+	}
+	entityType := storage.EntityTask
+	if collection == notesCollection {
+		entityType = storage.EntityNote
+	}
+	anyOf := bson.A{bson.M{"ownerId": user}}
+	var ids []int
+	for _, share := range shares {
+		switch share.EntityType {
+		case entityType:
+			ids = append(ids, share.EntityId)
+		case storage.EntityList:
+			anyOf = append(anyOf, bson.M{"ownerId": share.OwnerId, "tags": share.List})
+		}
+	}
+	if len(ids) > 0 {
+		anyOf = append(anyOf, bson.M{"_id": bson.M{"$in": ids}})
+	}
+	if len(anyOf) == 1 {
+		filter["ownerId"] = user
+		return filter, nil
+	}
+	return bson.M{"$and": bson.A{filter, bson.M{"$or": anyOf}}}, nil
+}
+
+// getOne считывает документ коллекции collection по Id, если он виден пользователю из ctx
+func getOne[D any](ctx context.Context, s *Store, collection string, id int) (D, error) {
+	var doc D
+	filter, err := s.visible(ctx, collection, bson.M{"_id": id})
+	if err != nil {
+		return doc, err
+	}
+	err = s.db.Collection(collection).FindOne(ctx, filter).Decode(&doc)
+	return doc, mapError(err)
+}
+
+// GetTask реализует storage.TaskStore
+func (s *Store) GetTask(ctx context.Context, id int) (model.Task, error) {
+	doc, err := getOne[taskDoc](ctx, s, tasksCollection, id)
+	return doc.model(), err
+}
+
+// CreateTask реализует storage.TaskStore
+func (s *Store) CreateTask(ctx context.Context, task model.Task) (model.Task, error) {
+	return withTx(ctx, s, func(ctx context.Context) (model.Task, error) {
+		id, err := s.nextId(ctx, tasksCollection)
+		if err != nil {
+			return task, err
+		}
+		task.Id = id
+		task.OwnerId = storage.OwnerFrom(ctx)
+		task.InitTimeStamp = time.Now().UTC().Truncate(time.Millisecond)
+		task.UpdatedAt = nil
+		task.Version = 1
+		if _, err := s.db.Collection(tasksCollection).InsertOne(ctx, taskDoc(task)); err != nil {
+			return task, mapError(err)
+		}
+		if err := s.writeStatus(ctx, task.Id, "", task.Status); err != nil {
+			return task, err
+		}
+		return task, s.writeLog(ctx, storage.EntityTask, task.Id, storage.ActionCreate, nil, task)
+	})
+}
+
+// UpdateTask реализует storage.TaskStore
+func (s *Store) UpdateTask(ctx context.Context, id int, change func(task *model.Task) error) (model.Task, error) {
+	return withTx(ctx, s, func(ctx context.Context) (model.Task, error) {
+		doc, err := getOne[taskDoc](ctx, s, tasksCollection, id)
+		if err != nil {
+			return model.Task{}, err
+		}
+		before := doc.model()
+		task := before
+		if err := change(&task); err != nil {
+			return task, err
+		}
+		task.Id, task.OwnerId = id, before.OwnerId
+		if err := storage.CheckTask(ctx, taskGraph{s}, before, task); err != nil {
+			return task, err
+		}
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		task.UpdatedAt = &now
+		task.Version = before.Version + 1
+
+		// Документ заменяется, только если его версия не изменилась с момента считывания
+		result, err := s.db.Collection(tasksCollection).ReplaceOne(
+			ctx,
+			bson.M{"_id": id, "version": before.Version},
+			taskDoc(task),
+		)
+		if err != nil {
+			return task, mapError(err)
+		}
+		if result.MatchedCount == 0 {
+			return task, i18n.Wrap(storage.ErrConflict, "ctx.task", id)
+		}
+		if err := s.writeStatus(ctx, id, before.Status, task.Status); err != nil {
+			return task, err
+		}
+		if snooze, ok := model.TaskSnooze(before, task); ok {
+			if err := s.writeSnooze(ctx, storage.EntityTask, id, snooze); err != nil {
+				return task, err
+			}
+		}
+		return task, s.writeLog(ctx, storage.EntityTask, id, storage.ActionUpdate, before, task)
+	})
+}
+
+// GetNote реализует storage.NoteStore
+func (s *Store) GetNote(ctx context.Context, id int) (model.Note, error) {
+	doc, err := getOne[noteDoc](ctx, s, notesCollection, id)
+	return doc.model(), err
+}
+
+// CreateNote реализует storage.NoteStore
+func (s *Store) CreateNote(ctx context.Context, note model.Note) (model.Note, error) {
+	return withTx(ctx, s, func(ctx context.Context) (model.Note, error) {
+		id, err := s.nextId(ctx, notesCollection)
+		if err != nil {
+			return note, err
+		}
+		note.Id = id
+		note.OwnerId = storage.OwnerFrom(ctx)
+		note.InitTimeStamp = time.Now().UTC().Truncate(time.Millisecond)
+		note.UpdatedAt = nil
+		note.Version = 1
+		if _, err := s.db.Collection(notesCollection).InsertOne(ctx, noteDoc(note)); err != nil {
+			return note, mapError(err)
+		}
+		return note, s.writeLog(ctx, storage.EntityNote, note.Id, storage.ActionCreate, nil, note)
+	})
+}
+
+// UpdateNote реализует storage.NoteStore
+func (s *Store) UpdateNote(ctx context.Context, id int, change func(note *model.Note) error) (model.Note, error) {
+	return withTx(ctx, s, func(ctx context.Context) (model.Note, error) {
+		doc, err := getOne[noteDoc](ctx, s, notesCollection, id)
+		if err != nil {
+			return model.Note{}, err
+		}
+		before := doc.model()
+		note := before
+		if err := change(&note); err != nil {
+			return note, err
+		}
+		note.Id, note.OwnerId = id, before.OwnerId
+		if err := storage.CheckNote(ctx, taskGraph{s}, before, note); err != nil {
+			return note, err
+		}
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		note.UpdatedAt = &now
+		note.Version = before.Version + 1
+
+		// Документ заменяется, только если его версия не изменилась с момента считывания
+		result, err := s.db.Collection(notesCollection).ReplaceOne(
+			ctx,
+			bson.M{"_id": id, "version": before.Version},
+			noteDoc(note),
+		)
+		if err != nil {
+			return note, mapError(err)
+		}
+		if result.MatchedCount == 0 {
+			return note, i18n.Wrap(storage.ErrConflict, "ctx.note", id)
+		}
+		if snooze, ok := model.NoteSnooze(before, note); ok {
+			if err := s.writeSnooze(ctx, storage.EntityNote, id, snooze); err != nil {
+				return note, err
+			}
+		}
+		return note, s.writeLog(ctx, storage.EntityNote, id, storage.ActionUpdate, before, note)
+	})
+}
+
+// DeleteNote реализует storage.NoteStore.
+// Заметка перемещается в корзину, только если её версия не изменилась с момента считывания
+func (s *Store) DeleteNote(ctx context.Context, id int) (model.Note, error) {
+	return withTx(ctx, s, func(ctx context.Context) (model.Note, error) {
+		doc, err := getOne[noteDoc](ctx, s, notesCollection, id)
+		if err != nil {
+			return model.Note{}, err
+		}
+		before := doc.model()
+		if err := storage.CheckVersion(ctx, before.Version); err != nil {
+			return before, err
+		}
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		if err := s.trash(ctx, notesCollection, before.Id, before.Version, now); err != nil {
+			return before, i18n.Wrap(err, "ctx.note", id)
+		}
+		if err := s.writeLog(ctx, storage.EntityNote, id, storage.ActionDelete, before, nil); err != nil {
+			return before, err
+		}
+		note := before
+		note.DeletedAt = &now
+		note.Version++
+		return note, nil
+	})
+}
+
+// trash перемещает документ id коллекции collection в корзину в момент now и увеличивает его версию,
+// только если версия документа по-прежнему равна version; иначе возвращает storage.ErrConflict
+func (s *Store) trash(ctx context.Context, collection string, id, version int, now time.Time) error {
+	result, err := s.db.Collection(collection).UpdateOne(
+		ctx,
+		bson.M{"_id": id, "version": version, "deletedAt": nil},
+		bson.M{"$set": bson.M{"deletedAt": now}, "$inc": bson.M{"version": 1}},
+	)
+	if err != nil {
+		return mapError(err)
+	}
+	if result.MatchedCount == 0 {
+		return storage.ErrConflict
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// taskGraph реализует storage.TaskGraph запросами к документам, видимым пользователю из ctx
+type taskGraph struct {
+	s *Store
+}
+
+// Task реализует storage.TaskGraph
+func (g taskGraph) Task(ctx context.Context, id int) (model.Task, error) {
+	doc, err := getOne[taskDoc](ctx, g.s, tasksCollection, id)
+	return doc.model(), err
+}
+
+// Subtasks реализует storage.TaskGraph
+func (g taskGraph) Subtasks(ctx context.Context, id int) ([]model.Task, error) {
+	return g.find(ctx, bson.M{"parentId": id})
+}
+
+// Dependents реализует storage.TaskGraph
+func (g taskGraph) Dependents(ctx context.Context, id int) ([]model.Task, error) {
+	return g.find(ctx, bson.M{"blockedBy": id})
+}
+
+// Notes реализует storage.TaskGraph
+func (g taskGraph) Notes(ctx context.Context, id int) ([]model.Note, error) {
+	filter, err := g.s.visible(ctx, notesCollection, bson.M{"taskId": id})
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := g.s.db.Collection(notesCollection).Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []noteDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	notes := make([]model.Note, 0, len(docs))
+	for _, doc := range docs {
+		notes = append(notes, doc.model())
+	}
+	return notes, nil
+}
+
+// find возвращает видимые задачи, удовлетворяющие filter, в порядке Id
+func (g taskGraph) find(ctx context.Context, filter bson.M) ([]model.Task, error) {
+	filter, err := g.s.visible(ctx, tasksCollection, filter)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := g.s.db.Collection(tasksCollection).Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []taskDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	tasks := make([]model.Task, 0, len(docs))
+	for _, doc := range docs {
+		tasks = append(tasks, doc.model())
+	}
+	return tasks, nil
+}
+
+// DeleteTask реализует storage.TaskStore.
+// Каждый документ изменяется или перемещается в корзину, только если его версия не изменилась
+// с момента составления плана; весь план применяется в одной транзакции
+func (s *Store) DeleteTask(ctx context.Context, id int, opts storage.DeleteOptions) (model.Task, error) {
+	return withTx(ctx, s, func(ctx context.Context) (model.Task, error) {
+		plan, err := storage.PlanDelete(ctx, taskGraph{s}, id, opts)
+		if err != nil {
+			return model.Task{}, err
+		}
+		if err := s.deleteTaskNotes(ctx, plan); err != nil {
+			return model.Task{}, err
+		}
+		tasks := s.db.Collection(tasksCollection)
+		for _, change := range plan.Updated {
+			after := change.After
+			now := time.Now().UTC().Truncate(time.Millisecond)
+			after.UpdatedAt = &now
+			after.Version++
+			result, err := tasks.ReplaceOne(ctx, bson.M{"_id": after.Id, "version": change.Before.Version}, taskDoc(after))
+			if err != nil {
+				return model.Task{}, mapError(err)
+			}
+			if result.MatchedCount == 0 {
+				return model.Task{}, i18n.Wrap(storage.ErrConflict, "ctx.task", after.Id)
+			}
+			if err := s.writeLog(ctx, storage.EntityTask, after.Id, storage.ActionUpdate, change.Before, after); err != nil {
+				return model.Task{}, err
+			}
+		}
+		var deleted model.Task
+		for _, task := range plan.Deleted {
+			now := time.Now().UTC().Truncate(time.Millisecond)
+			if err := s.trash(ctx, tasksCollection, task.Id, task.Version, now); err != nil {
+				return model.Task{}, i18n.Wrap(err, "ctx.task", task.Id)
+			}
+			if err := s.writeLog(ctx, storage.EntityTask, task.Id, storage.ActionDelete, task, nil); err != nil {
+				return task, err
+			}
+			deleted = task
+			deleted.DeletedAt = &now
+			deleted.Version++
+		}
+		return deleted, nil
+	})
+}
+
+// deleteTaskNotes открепляет заметки удаляемых задач или перемещает их в корзину согласно плану plan
+func (s *Store) deleteTaskNotes(ctx context.Context, plan storage.DeletePlan) error {
+	notes := s.db.Collection(notesCollection)
+	for _, change := range plan.DetachedNotes {
+		after := change.After
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		after.UpdatedAt = &now
+		after.Version++
+		result, err := notes.ReplaceOne(ctx, bson.M{"_id": after.Id, "version": change.Before.Version}, noteDoc(after))
+		if err != nil {
+			return mapError(err)
+		}
+		if result.MatchedCount == 0 {
+			return i18n.Wrap(storage.ErrConflict, "ctx.note", after.Id)
+		}
+		if err := s.writeLog(ctx, storage.EntityNote, after.Id, storage.ActionUpdate, change.Before, after); err != nil {
+			return err
+		}
+	}
+	for _, note := range plan.DeletedNotes {
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		if err := s.trash(ctx, notesCollection, note.Id, note.Version, now); err != nil {
+			return i18n.Wrap(err, "ctx.note", note.Id)
+		}
+		if err := s.writeLog(ctx, storage.EntityNote, note.Id, storage.ActionDelete, note, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TaskTree реализует storage.TaskStore
+func (s *Store) TaskTree(ctx context.Context, id int) (model.TaskNode, error) {
+	return storage.BuildTree(ctx, taskGraph{s}, id)
+}
+
+// TaskNotes реализует storage.NoteStore
+func (s *Store) TaskNotes(ctx context.Context, taskId int) ([]model.Note, error) {
+	return taskGraph{s}.Notes(ctx, taskId)
+}
@@ -0,0 +1,84 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// searchDoc проекция документа, найденного по текстовому индексу
+type searchDoc struct {
+	Id          int     `bson:"_id"`
+	Name        string  `bson:"name"`
+	Description string  `bson:"description"`
+	Score       float64 `bson:"score"`
+}
+
+// searchCollection находит limit наиболее релевантных документов коллекции, видимых пользователю из ctx,
+// по текстовому индексу
+func (s *Store) searchCollection(ctx context.Context, collection, entityType, query string, limit int) ([]storage.SearchHit, error) {
+	filter, err := s.visible(ctx, collection, bson.M{"$text": bson.M{"$search": query, "$language": "russian"}})
+	if err != nil {
+		return nil, err
+	}
+	score := bson.M{"$meta": "textScore"}
+	cursor, err := s.db.Collection(collection).Find(
+		ctx,
+		filter,
+		options.Find().
+			SetProjection(bson.M{"name": 1, "description": 1, "score": score}).
+			SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+			SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка полнотекстового поиска в коллекции %s: %w", collection, err)
+	}
+	var docs []searchDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("ошибка полнотекстового поиска в коллекции %s: %w", collection, err)
+	}
+
+	// MongoDB не выделяет совпадения, поэтому фрагменты строятся по искомым словам запроса
+	terms := storage.SearchTerms(query)
+	hits := make([]storage.SearchHit, 0, len(docs))
+	for _, doc := range docs {
+		nameSnippet, _ := storage.Highlight(doc.Name, terms, 0)
+		descrSnippet, _ := storage.Highlight(doc.Description, terms, storage.SnippetWords)
+		hits = append(hits, storage.SearchHit{
+			Type:               entityType,
+			Id:                 doc.Id,
+			Name:               doc.Name,
+			Rank:               doc.Score,
+			NameSnippet:        nameSnippet,
+			DescriptionSnippet: descrSnippet,
+		})
+	}
+	return hits, nil
+}
+
+// Search реализует storage.Searcher по текстовым индексам с русской морфологией
+func (s *Store) Search(ctx context.Context, filter storage.SearchFilter) (storage.SearchPage, error) {
+	page := storage.SearchPage{Limit: filter.Limit, Offset: filter.Offset}
+
+	// из каждой коллекции достаточно первых offset+limit результатов
+	var hits []storage.SearchHit
+	if filter.Type != storage.EntityNote {
+		tasks, err := s.searchCollection(ctx, tasksCollection, storage.EntityTask, filter.Query, filter.Offset+filter.Limit)
+		if err != nil {
+			return page, err
+		}
+		hits = append(hits, tasks...)
+	}
+	if filter.Type != storage.EntityTask {
+		notes, err := s.searchCollection(ctx, notesCollection, storage.EntityNote, filter.Query, filter.Offset+filter.Limit)
+		if err != nil {
+			return page, err
+		}
+		hits = append(hits, notes...)
+	}
+	page.Items = storage.SortHits(hits, filter.Limit, filter.Offset)
+	return page, nil
+}
@@ -0,0 +1,127 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// shareDoc документ доступа пользователя к задаче, заметке или списку
+type shareDoc struct {
+	Id         int        `bson:"_id"`
+	EntityType string     `bson:"entityType"`
+	EntityId   int        `bson:"entityId"`
+	List       string     `bson:"list"`
+	OwnerId    int        `bson:"ownerId"`
+	UserId     int        `bson:"userId"`
+	Login      string     `bson:"login"`
+	Role       model.Role `bson:"role"`
+	CreatedAt  time.Time  `bson:"createdAt"`
+}
+
+func (d shareDoc) model() storage.Share {
+	return storage.Share{
+		Id:          d.Id,
+		ShareTarget: storage.ShareTarget{EntityType: d.EntityType, EntityId: d.EntityId, List: d.List},
+		OwnerId:     d.OwnerId,
+		UserId:      d.UserId,
+		Login:       d.Login,
+		Role:        d.Role,
+		CreatedAt:   d.CreatedAt,
+	}
+}
+
+// shareFilter возвращает условие отбора доступов к target владельца ownerId
+func shareFilter(ownerId int, target storage.ShareTarget) bson.M {
+	return bson.M{"ownerId": ownerId, "entityType": target.EntityType, "entityId": target.EntityId, "list": target.List}
+}
+
+// findShares возвращает доступы, удовлетворяющие filter, в порядке Id
+func (s *Store) findShares(ctx context.Context, filter bson.M) ([]storage.Share, error) {
+	cursor, err := s.db.Collection(sharesCollection).Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения доступов: %w", err)
+	}
+	var docs []shareDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("ошибка чтения доступов: %w", err)
+	}
+	shares := make([]storage.Share, 0, len(docs))
+	for _, doc := range docs {
+		shares = append(shares, doc.model())
+	}
+	return shares, nil
+}
+
+// Share реализует storage.ShareStore
+func (s *Store) Share(ctx context.Context, share storage.Share) (storage.Share, error) {
+	return withTx(ctx, s, func(ctx context.Context) (storage.Share, error) {
+		share.OwnerId = storage.OwnerFrom(ctx)
+		collection := s.db.Collection(sharesCollection)
+		filter := shareFilter(share.OwnerId, share.ShareTarget)
+		filter["userId"] = share.UserId
+
+		var existing shareDoc
+		err := collection.FindOneAndUpdate(ctx, filter, bson.M{"$set": bson.M{"role": share.Role}}).Decode(&existing)
+		if err == nil {
+			before := existing.model()
+			share.Id, share.CreatedAt = before.Id, before.CreatedAt
+			return share, s.writeLog(ctx, share.EntityType, share.EntityId, storage.ActionShare, before, share)
+		}
+		if err := mapError(err); !errors.Is(err, storage.ErrNotFound) {
+			return share, err
+		}
+
+		id, err := s.nextId(ctx, sharesCollection)
+		if err != nil {
+			return share, err
+		}
+		share.Id = id
+		share.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+		_, err = collection.InsertOne(ctx, shareDoc{
+			Id:         share.Id,
+			EntityType: share.EntityType,
+			EntityId:   share.EntityId,
+			List:       share.List,
+			OwnerId:    share.OwnerId,
+			UserId:     share.UserId,
+			Login:      share.Login,
+			Role:       share.Role,
+			CreatedAt:  share.CreatedAt,
+		})
+		if err != nil {
+			return share, mapError(err)
+		}
+		return share, s.writeLog(ctx, share.EntityType, share.EntityId, storage.ActionShare, nil, share)
+	})
+}
+
+// Unshare реализует storage.ShareStore
+func (s *Store) Unshare(ctx context.Context, target storage.ShareTarget, userId int) (storage.Share, error) {
+	return withTx(ctx, s, func(ctx context.Context) (storage.Share, error) {
+		filter := shareFilter(storage.OwnerFrom(ctx), target)
+		filter["userId"] = userId
+		var doc shareDoc
+		if err := s.db.Collection(sharesCollection).FindOneAndDelete(ctx, filter).Decode(&doc); err != nil {
+			return storage.Share{}, mapError(err)
+		}
+		share := doc.model()
+		return share, s.writeLog(ctx, share.EntityType, share.EntityId, storage.ActionUnshare, share, nil)
+	})
+}
+
+// Shares реализует storage.ShareStore
+func (s *Store) Shares(ctx context.Context, target storage.ShareTarget) ([]storage.Share, error) {
+	return s.findShares(ctx, shareFilter(storage.OwnerFrom(ctx), target))
+}
+
+// SharedWith реализует storage.ShareStore
+func (s *Store) SharedWith(ctx context.Context, ownerId int) ([]storage.Share, error) {
+	return s.findShares(ctx, bson.M{"userId": storage.OwnerFrom(ctx), "ownerId": ownerId})
+}
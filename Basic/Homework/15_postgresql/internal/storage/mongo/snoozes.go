@@ -0,0 +1,97 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// snoozeDoc документ истории откладывания напоминаний задач и заметок
+type snoozeDoc struct {
+	Id         int       `bson:"_id"`
+	EntityType string    `bson:"entityType"`
+	EntityId   int       `bson:"entityId"`
+	From       time.Time `bson:"from"`
+	Until      time.Time `bson:"until"`
+	Actor      string    `bson:"actor"`
+	SnoozedAt  time.Time `bson:"snoozedAt"`
+}
+
+// writeSnooze добавляет запись в историю откладывания напоминаний сущности.
+// Как и история статусов, записывается после изменения сущности
+func (s *Store) writeSnooze(ctx context.Context, entityType string, entityId int, snooze model.Snooze) error {
+	id, err := s.nextId(ctx, snoozesCollection)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Collection(snoozesCollection).InsertOne(ctx, snoozeDoc{
+		Id:         id,
+		EntityType: entityType,
+		EntityId:   entityId,
+		From:       snooze.From,
+		Until:      snooze.Until,
+		Actor:      storage.ActorFrom(ctx),
+		SnoozedAt:  time.Now().UTC().Truncate(time.Millisecond),
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка записи в историю откладывания напоминаний: %w", err)
+	}
+	return nil
+}
+
+// deleteSnoozes удаляет историю откладывания напоминаний удалённой сущности
+func (s *Store) deleteSnoozes(ctx context.Context, entityType string, entityId int) error {
+	_, err := s.db.Collection(snoozesCollection).DeleteMany(ctx, bson.M{"entityType": entityType, "entityId": entityId})
+	if err != nil {
+		return fmt.Errorf("ошибка удаления истории откладывания напоминаний: %w", err)
+	}
+	return nil
+}
+
+// readSnoozes возвращает историю откладывания напоминаний сущности от ранних записей к поздним
+func (s *Store) readSnoozes(ctx context.Context, entityType string, entityId int) ([]model.Snooze, error) {
+	cursor, err := s.db.Collection(snoozesCollection).Find(
+		ctx,
+		bson.M{"entityType": entityType, "entityId": entityId},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var docs []snoozeDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	snoozes := make([]model.Snooze, 0, len(docs))
+	for _, doc := range docs {
+		snoozes = append(snoozes, model.Snooze{
+			From:      doc.From,
+			Until:     doc.Until,
+			Actor:     doc.Actor,
+			SnoozedAt: doc.SnoozedAt,
+		})
+	}
+	return snoozes, nil
+}
+
+// TaskSnoozes реализует storage.TaskStore
+func (s *Store) TaskSnoozes(ctx context.Context, id int) ([]model.Snooze, error) {
+	if _, err := getOne[taskDoc](ctx, s, tasksCollection, id); err != nil {
+		return nil, err
+	}
+	return s.readSnoozes(ctx, storage.EntityTask, id)
+}
+
+// NoteSnoozes реализует storage.NoteStore
+func (s *Store) NoteSnoozes(ctx context.Context, id int) ([]model.Snooze, error) {
+	if _, err := getOne[noteDoc](ctx, s, notesCollection, id); err != nil {
+		return nil, err
+	}
+	return s.readSnoozes(ctx, storage.EntityNote, id)
+}
@@ -0,0 +1,227 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// trashed дополняет filter условием нахождения документов в корзине владельца из ctx
+func trashed(ctx context.Context, filter bson.M) bson.M {
+	filter["deletedAt"] = bson.M{"$ne": nil}
+	return owned(ctx, filter)
+}
+
+// findTrashed возвращает документы коллекции collection, удовлетворяющие filter,
+// от недавно удалённых к давним
+func findTrashed[D any, T any](ctx context.Context, s *Store, collection string, filter bson.M, convert func(D) T) ([]T, error) {
+	cursor, err := s.db.Collection(collection).Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "deletedAt", Value: -1}, {Key: "_id", Value: -1}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения корзины: %w", err)
+	}
+	var docs []D
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("ошибка чтения корзины: %w", err)
+	}
+	items := make([]T, 0, len(docs))
+	for _, doc := range docs {
+		items = append(items, convert(doc))
+	}
+	return items, nil
+}
+
+// Trash реализует storage.TrashStore
+func (s *Store) Trash(ctx context.Context) (storage.Trash, error) {
+	var (
+		trash storage.Trash
+		err   error
+	)
+	trash.Tasks, err = findTrashed(ctx, s, tasksCollection, trashed(ctx, bson.M{}), taskDoc.model)
+	if err != nil {
+		return trash, err
+	}
+	trash.Notes, err = findTrashed(ctx, s, notesCollection, trashed(ctx, bson.M{}), noteDoc.model)
+	return trash, err
+}
+
+// RestoreTask реализует storage.TrashStore.
+// Документ восстанавливается, только если его версия не изменилась с момента считывания;
+// совпадение имени с задачей вне корзины отклоняет уникальный индекс
+func (s *Store) RestoreTask(ctx context.Context, id int) (model.Task, error) {
+	return withTx(ctx, s, func(ctx context.Context) (model.Task, error) {
+		var doc taskDoc
+		err := s.db.Collection(tasksCollection).FindOne(ctx, trashed(ctx, bson.M{"_id": id})).Decode(&doc)
+		if err != nil {
+			return model.Task{}, mapError(err)
+		}
+		before := doc.model()
+		task, err := storage.RestoredTask(ctx, taskGraph{s}, before)
+		if err != nil {
+			return task, err
+		}
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		task.UpdatedAt = &now
+		task.Version++
+
+		result, err := s.db.Collection(tasksCollection).ReplaceOne(
+			ctx,
+			bson.M{"_id": id, "version": before.Version},
+			taskDoc(task),
+		)
+		if err != nil {
+			return task, mapError(err)
+		}
+		if result.MatchedCount == 0 {
+			return task, i18n.Wrap(storage.ErrConflict, "ctx.task", id)
+		}
+		return task, s.writeLog(ctx, storage.EntityTask, id, storage.ActionRestore, before, task)
+	})
+}
+
+// RestoreNote реализует storage.TrashStore; соглашения те же, что у RestoreTask
+func (s *Store) RestoreNote(ctx context.Context, id int) (model.Note, error) {
+	return withTx(ctx, s, func(ctx context.Context) (model.Note, error) {
+		var doc noteDoc
+		err := s.db.Collection(notesCollection).FindOne(ctx, trashed(ctx, bson.M{"_id": id})).Decode(&doc)
+		if err != nil {
+			return model.Note{}, mapError(err)
+		}
+		before := doc.model()
+		note, err := storage.RestoredNote(ctx, taskGraph{s}, before)
+		if err != nil {
+			return note, err
+		}
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		note.UpdatedAt = &now
+		note.Version++
+
+		result, err := s.db.Collection(notesCollection).ReplaceOne(
+			ctx,
+			bson.M{"_id": id, "version": before.Version},
+			noteDoc(note),
+		)
+		if err != nil {
+			return note, mapError(err)
+		}
+		if result.MatchedCount == 0 {
+			return note, i18n.Wrap(storage.ErrConflict, "ctx.note", id)
+		}
+		return note, s.writeLog(ctx, storage.EntityNote, id, storage.ActionRestore, before, note)
+	})
+}
+
+// PurgeTask реализует storage.TrashStore
+func (s *Store) PurgeTask(ctx context.Context, id int) (model.Task, error) {
+	return withTx(ctx, s, func(ctx context.Context) (model.Task, error) {
+		var doc taskDoc
+		err := s.db.Collection(tasksCollection).FindOneAndDelete(ctx, trashed(ctx, bson.M{"_id": id})).Decode(&doc)
+		if err != nil {
+			return model.Task{}, mapError(err)
+		}
+		task := doc.model()
+		if err := s.purgeTask(ctx, task); err != nil {
+			return task, err
+		}
+		return task, s.writeLog(ctx, storage.EntityTask, id, storage.ActionPurge, task, nil)
+	})
+}
+
+// PurgeNote реализует storage.TrashStore
+func (s *Store) PurgeNote(ctx context.Context, id int) (model.Note, error) {
+	return withTx(ctx, s, func(ctx context.Context) (model.Note, error) {
+		var doc noteDoc
+		err := s.db.Collection(notesCollection).FindOneAndDelete(ctx, trashed(ctx, bson.M{"_id": id})).Decode(&doc)
+		if err != nil {
+			return model.Note{}, mapError(err)
+		}
+		note := doc.model()
+		if err := s.purgeNote(ctx, note); err != nil {
+			return note, err
+		}
+		return note, s.writeLog(ctx, storage.EntityNote, id, storage.ActionPurge, note, nil)
+	})
+}
+
+// PurgeTrash реализует storage.TrashStore.
+// Документы удаляются по одному, чтобы каждое удаление попало в журнал
+func (s *Store) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	filter := func() bson.M {
+		return owned(ctx, bson.M{"deletedAt": bson.M{"$ne": nil, "$lt": before}})
+	}
+	tasks, err := findTrashed(ctx, s, tasksCollection, filter(), taskDoc.model)
+	if err != nil {
+		return 0, err
+	}
+	notes, err := findTrashed(ctx, s, notesCollection, filter(), noteDoc.model)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, task := range tasks {
+		if _, err := s.PurgeTask(ctx, task.Id); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	for _, note := range notes {
+		if _, err := s.PurgeNote(ctx, note.Id); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purgeTask удаляет историю и доступы окончательно удалённой задачи task
+// и снимает ссылки на неё остающихся подзадач и заметок
+func (s *Store) purgeTask(ctx context.Context, task model.Task) error {
+	if _, err := s.db.Collection(historyCollection).DeleteMany(ctx, bson.M{"taskId": task.Id}); err != nil {
+		return fmt.Errorf("ошибка удаления истории статусов: %w", err)
+	}
+	if err := s.deleteSnoozes(ctx, storage.EntityTask, task.Id); err != nil {
+		return err
+	}
+	if _, err := s.db.Collection(tasksCollection).UpdateMany(
+		ctx,
+		bson.M{"parentId": task.Id},
+		bson.M{"$unset": bson.M{"parentId": ""}},
+	); err != nil {
+		return fmt.Errorf("ошибка открепления подзадач: %w", err)
+	}
+	if _, err := s.db.Collection(notesCollection).UpdateMany(
+		ctx,
+		bson.M{"taskId": task.Id},
+		bson.M{"$unset": bson.M{"taskId": ""}},
+	); err != nil {
+		return fmt.Errorf("ошибка открепления заметок: %w", err)
+	}
+	return s.purgeShares(ctx, storage.EntityTask, task.Id, task.OwnerId)
+}
+
+// purgeNote удаляет историю и доступы окончательно удалённой заметки note
+func (s *Store) purgeNote(ctx context.Context, note model.Note) error {
+	if err := s.deleteSnoozes(ctx, storage.EntityNote, note.Id); err != nil {
+		return err
+	}
+	return s.purgeShares(ctx, storage.EntityNote, note.Id, note.OwnerId)
+}
+
+// purgeShares удаляет доступы к окончательно удалённой записи id типа entityType владельца ownerId
+func (s *Store) purgeShares(ctx context.Context, entityType string, id, ownerId int) error {
+	filter := shareFilter(ownerId, storage.ShareTarget{EntityType: entityType, EntityId: id})
+	if _, err := s.db.Collection(sharesCollection).DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("ошибка удаления доступов: %w", err)
+	}
+	return nil
+}
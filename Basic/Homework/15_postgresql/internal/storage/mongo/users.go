@@ -0,0 +1,117 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// userDoc документ учётной записи
+type userDoc struct {
+	Id           int       `bson:"_id"`
+	Login        string    `bson:"login"`
+	CreatedAt    time.Time `bson:"createdAt"`
+	PasswordHash string    `bson:"passwordHash"`
+}
+
+func (d userDoc) account() storage.Account {
+	return storage.Account{
+		User:         model.User{Id: d.Id, Login: d.Login, CreatedAt: d.CreatedAt},
+		PasswordHash: d.PasswordHash,
+	}
+}
+
+// sessionDoc документ сессии; _id - хеш токена
+type sessionDoc struct {
+	TokenHash string    `bson:"_id"`
+	UserId    int       `bson:"userId"`
+	CreatedAt time.Time `bson:"createdAt"`
+	ExpiresAt time.Time `bson:"expiresAt"`
+}
+
+// CreateUser реализует storage.UserStore; первым считается пользователь, раньше которого
+// учётных записей нет
+func (s *Store) CreateUser(ctx context.Context, account storage.Account) (storage.Account, error) {
+	return withTx(ctx, s, func(ctx context.Context) (storage.Account, error) {
+		id, err := s.nextId(ctx, usersCollection)
+		if err != nil {
+			return account, err
+		}
+		account.Id = id
+		account.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+		_, err = s.db.Collection(usersCollection).InsertOne(ctx, userDoc{
+			Id:           account.Id,
+			Login:        account.Login,
+			CreatedAt:    account.CreatedAt,
+			PasswordHash: account.PasswordHash,
+		})
+		if err != nil {
+			return account, mapError(err)
+		}
+		earlier, err := s.db.Collection(usersCollection).CountDocuments(ctx, bson.M{"_id": bson.M{"$lt": account.Id}})
+		if err != nil {
+			return account, fmt.Errorf("ошибка проверки учётных записей: %w", err)
+		}
+		if earlier == 0 {
+			return account, s.adoptOwnerless(ctx, account.Id)
+		}
+		return account, nil
+	})
+}
+
+// adoptOwnerless передаёт владельцу ownerId задачи, заметки и записи журнала без владельца
+func (s *Store) adoptOwnerless(ctx context.Context, ownerId int) error {
+	for _, collection := range []string{tasksCollection, notesCollection, logCollection} {
+		_, err := s.db.Collection(collection).UpdateMany(
+			ctx,
+			bson.M{"ownerId": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"ownerId": ownerId}},
+		)
+		if err != nil {
+			return fmt.Errorf("ошибка передачи записей %s владельцу: %w", collection, err)
+		}
+	}
+	return nil
+}
+
+// UserByLogin реализует storage.UserStore
+func (s *Store) UserByLogin(ctx context.Context, login string) (storage.Account, error) {
+	var doc userDoc
+	err := s.db.Collection(usersCollection).FindOne(ctx, bson.M{"login": login}).Decode(&doc)
+	return doc.account(), mapError(err)
+}
+
+// GetUser реализует storage.UserStore
+func (s *Store) GetUser(ctx context.Context, id int) (model.User, error) {
+	var doc userDoc
+	err := s.db.Collection(usersCollection).FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	return doc.account().User, mapError(err)
+}
+
+// CreateSession реализует storage.UserStore; сессии с истёкшим сроком удаляет TTL-индекс
+func (s *Store) CreateSession(ctx context.Context, session storage.Session) error {
+	_, err := s.db.Collection(sessionsCollection).InsertOne(ctx, sessionDoc(session))
+	if err != nil {
+		return fmt.Errorf("ошибка записи сессии: %w", mapError(err))
+	}
+	return nil
+}
+
+// GetSession реализует storage.UserStore
+func (s *Store) GetSession(ctx context.Context, tokenHash string) (storage.Session, error) {
+	var doc sessionDoc
+	err := s.db.Collection(sessionsCollection).FindOne(ctx, bson.M{"_id": tokenHash}).Decode(&doc)
+	return storage.Session(doc), mapError(err)
+}
+
+// DeleteSession реализует storage.UserStore
+func (s *Store) DeleteSession(ctx context.Context, tokenHash string) error {
+	if _, err := s.db.Collection(sessionsCollection).DeleteOne(ctx, bson.M{"_id": tokenHash}); err != nil {
+		return fmt.Errorf("ошибка удаления сессии: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// Delivered реализует storage.DeliveryStore
+func (s *Store) Delivered(ctx context.Context, entityType string, entityId int, fireAt time.Time) (bool, error) {
+	var delivered bool
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT EXISTS(
+			SELECT 1 FROM reminder_deliveries
+			WHERE entity_type = $1 AND entity_id = $2 AND fire_at = $3
+		)`,
+		entityType, entityId, fireAt,
+	).Scan(&delivered)
+	if err != nil {
+		return false, fmt.Errorf("ошибка чтения отметки о доставке: %w", err)
+	}
+	return delivered, nil
+}
+
+// MarkDelivered реализует storage.DeliveryStore
+func (s *Store) MarkDelivered(ctx context.Context, delivery storage.Delivery) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO reminder_deliveries(entity_type, entity_id, fire_at, delivered_at)
+		VALUES($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING`,
+		delivery.EntityType, delivery.EntityId, delivery.FireAt, delivery.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка записи отметки о доставке: %w", err)
+	}
+	return nil
+}
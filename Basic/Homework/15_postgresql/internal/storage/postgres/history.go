@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// writeStatus добавляет в историю задачи смену статуса from -> to в рамках переданной транзакции
+func writeStatus(ctx context.Context, tx dbtx, taskId int, from, to model.Status) error {
+	for _, step := range model.StatusSteps(from, to) {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO task_status_history(task_id, from_status, to_status, actor)
+			VALUES($1, $2, $3, $4)`,
+			taskId, step.From, step.To, storage.ActorFrom(ctx),
+		)
+		if err != nil {
+			return fmt.Errorf("ошибка записи в историю статусов: %w", err)
+		}
+	}
+	return nil
+}
+
+// TaskHistory реализует storage.TaskStore
+func (s *Store) TaskHistory(ctx context.Context, id int) ([]model.StatusChange, error) {
+	var exists bool
+	err := s.db.QueryRowContext(
+		ctx,
+		"SELECT EXISTS(SELECT 1 FROM tasks WHERE id=$1 AND "+visible("tasks", 2)+")",
+		id, storage.OwnerFrom(ctx),
+	).Scan(&exists)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT from_status, to_status, actor, changed_at
+		FROM task_status_history
+		WHERE task_id = $1
+		ORDER BY id`,
+		id,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	history := make([]model.StatusChange, 0)
+	for rows.Next() {
+		var change model.StatusChange
+		if err := rows.Scan(&change.From, &change.To, &change.Actor, &change.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
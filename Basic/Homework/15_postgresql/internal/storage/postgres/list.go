@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// Соответствие полей сортировки колонкам таблиц
+var (
+	taskSortColumns = map[string]string{"id": "id", "name": "name", "dueDate": "due_date"}
+	noteSortColumns = map[string]string{"id": "id", "name": "name", "alarmTimeStamp": "alarm_at"}
+)
+
+// likePattern экранирует спецсимволы LIKE и возвращает шаблон поиска подстроки
+func likePattern(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return "%" + s + "%"
+}
+
+// listQuery накапливает условия WHERE и аргументы запроса списка
+type listQuery struct {
+	conditions []string
+	args       []any
+}
+
+func (q *listQuery) add(condition string, arg any) {
+	q.args = append(q.args, arg)
+	q.conditions = append(q.conditions, fmt.Sprintf(condition, len(q.args)))
+}
+
+// owner добавляет условие принадлежности строк владельцу из ctx
+func (q *listQuery) owner(ctx context.Context) {
+	q.args = append(q.args, storage.OwnerFrom(ctx))
+	q.conditions = append(q.conditions, owned(len(q.args)))
+}
+
+// visible добавляет условие видимости строк таблицы table пользователю из ctx
+func (q *listQuery) visible(ctx context.Context, table string) {
+	q.args = append(q.args, storage.OwnerFrom(ctx))
+	q.conditions = append(q.conditions, visible(table, len(q.args)))
+}
+
+// labels добавляет условия на приоритет (любой из priorities) и метки (все из tags);
+// условие на метки использует GIN-индекс по tags
+func (q *listQuery) labels(priorities []model.Priority, tags []string) {
+	if len(priorities) > 0 {
+		codes := make([]string, len(priorities))
+		for i, priority := range priorities {
+			codes[i] = string(priority)
+		}
+		q.add("priority = ANY($%d)", codes)
+	}
+	if len(tags) > 0 {
+		q.add("tags @> $%d::text[]", tags)
+	}
+}
+
+// build формирует запрос с keyset-условием по курсору, сортировкой и лимитом
+func (q *listQuery) build(
+	columns,
+	table string,
+	sortColumns map[string]string,
+	sort string,
+	cur *storage.Cursor,
+	limit int,
+) (string, []any) {
+	field, desc := storage.SortField(sort)
+	column := sortColumns[field]
+	cmp, order := ">", "ASC"
+	if desc {
+		cmp, order = "<", "DESC"
+	}
+
+	if cur != nil {
+		switch column {
+		case "id":
+			q.add("id "+cmp+" $%d", cur.Id)
+		case "name":
+			q.args = append(q.args, cur.Name, cur.Id)
+		default:
+			q.args = append(q.args, cur.Time, cur.Id)
+		}
+		if column != "id" {
+			q.conditions = append(q.conditions, fmt.Sprintf(
+				"(%s, id) %s ($%d, $%d)", column, cmp, len(q.args)-1, len(q.args),
+			))
+		}
+	}
+
+	query := "SELECT " + columns + " FROM " + table
+	if len(q.conditions) > 0 {
+		query += " WHERE " + strings.Join(q.conditions, " AND ")
+	}
+	orderBy := "id " + order
+	if column != "id" {
+		orderBy = column + " " + order + ", " + orderBy
+	}
+	q.args = append(q.args, limit+1)
+	query += fmt.Sprintf(" ORDER BY %s LIMIT $%d", orderBy, len(q.args))
+	return query, q.args
+}
+
+// listPage выполняет запрос списка и формирует страницу с курсором на следующую
+func listPage[T any](
+	ctx context.Context,
+	db dbtx,
+	query string,
+	args []any,
+	sort string,
+	limit int,
+	scan func(rowScanner) (T, error),
+	position func(sort string, item T) storage.Cursor,
+) (storage.Page[T], error) {
+	page := storage.Page[T]{Items: make([]T, 0)}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return page, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return page, err
+		}
+		page.Items = append(page.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return page, err
+	}
+
+	if len(page.Items) > limit {
+		page.Items = page.Items[:limit]
+		page.NextCursor = position(sort, page.Items[limit-1]).Encode()
+	}
+	return page, nil
+}
+
+// ListTasks реализует storage.TaskStore
+func (s *Store) ListTasks(ctx context.Context, filter storage.TaskFilter) (storage.Page[model.Task], error) {
+	cur, err := storage.DecodeCursor(filter.Cursor, filter.Sort)
+	if err != nil {
+		return storage.Page[model.Task]{Items: make([]model.Task, 0)}, err
+	}
+
+	var q listQuery
+	q.visible(ctx, "tasks")
+	if filter.Status != "" {
+		q.add("status = $%d", filter.Status)
+	}
+	if !filter.DueFrom.IsZero() {
+		q.add("due_date >= $%d", filter.DueFrom)
+	}
+	if !filter.DueTo.IsZero() {
+		q.add("due_date < $%d", filter.DueTo)
+	}
+	if filter.Name != "" {
+		q.add("name ILIKE $%d", likePattern(filter.Name))
+	}
+	q.labels(filter.Priority, filter.Tags)
+	query, args := q.build(taskColumns, "tasks", taskSortColumns, filter.Sort, cur, filter.Limit)
+	return listPage(ctx, s.db, query, args, filter.Sort, filter.Limit, scanTask, storage.TaskCursor)
+}
+
+// ListNotes реализует storage.NoteStore
+func (s *Store) ListNotes(ctx context.Context, filter storage.NoteFilter) (storage.Page[model.Note], error) {
+	cur, err := storage.DecodeCursor(filter.Cursor, filter.Sort)
+	if err != nil {
+		return storage.Page[model.Note]{Items: make([]model.Note, 0)}, err
+	}
+
+	var q listQuery
+	q.visible(ctx, "notes")
+	if !filter.AlarmFrom.IsZero() {
+		q.add("alarm_at >= $%d", filter.AlarmFrom)
+	}
+	if !filter.AlarmTo.IsZero() {
+		q.add("alarm_at < $%d", filter.AlarmTo)
+	}
+	if filter.Name != "" {
+		q.add("name ILIKE $%d", likePattern(filter.Name))
+	}
+	q.labels(filter.Priority, filter.Tags)
+	query, args := q.build(noteColumns, "notes", noteSortColumns, filter.Sort, cur, filter.Limit)
+	return listPage(ctx, s.db, query, args, filter.Sort, filter.Limit, scanNote, storage.NoteCursor)
+}
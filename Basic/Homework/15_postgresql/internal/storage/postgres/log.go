@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// writeLog добавляет запись в журнал изменений remindables_log в рамках переданной транзакции
+func writeLog(ctx context.Context, tx dbtx, entityType string, entityId int, action string, before, after any) error {
+	record, err := storage.NewLogRecord(entityType, entityId, action, storage.ActorFrom(ctx), before, after)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO remindables_log(entity_type, entity_id, action, actor, before, after, owner_id)
+		VALUES($1, $2, $3, $4, $5, $6, NULLIF($7, 0))`,
+		record.EntityType, record.EntityId, record.Action, record.Actor, []byte(record.Before), []byte(record.After),
+		record.OwnerId,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка записи в журнал изменений: %w", err)
+	}
+	return nil
+}
+
+// ReadLog реализует storage.LogReader
+func (s *Store) ReadLog(ctx context.Context, filter storage.LogFilter) (storage.LogPage, error) {
+	page := storage.LogPage{Items: make([]storage.LogRecord, 0), Limit: filter.Limit, Offset: filter.Offset}
+
+	var q listQuery
+	q.owner(ctx)
+	if filter.Entity != "" {
+		q.add("entity_type = $%d", filter.Entity)
+	}
+	if filter.EntityId != 0 {
+		q.add("entity_id = $%d", filter.EntityId)
+	}
+	if filter.Action != "" {
+		q.add("action = $%d", filter.Action)
+	}
+	if !filter.From.IsZero() {
+		q.add("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		q.add("created_at < $%d", filter.To)
+	}
+	where := " WHERE " + strings.Join(q.conditions, " AND ")
+
+	err := s.db.QueryRowContext(ctx, "SELECT count(*) FROM remindables_log"+where, q.args...).Scan(&page.Total)
+	if err != nil {
+		return page, err
+	}
+
+	args := append(q.args, filter.Limit, filter.Offset)
+	rows, err := s.db.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			`SELECT id, entity_type, entity_id, action, actor, coalesce(owner_id, 0), before, after, created_at
+			FROM remindables_log%s
+			ORDER BY created_at DESC, id DESC
+			LIMIT $%d OFFSET $%d`,
+			where, len(args)-1, len(args),
+		),
+		args...,
+	)
+	if err != nil {
+		return page, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var record storage.LogRecord
+		var before, after []byte
+		if err := rows.Scan(
+			&record.Id,
+			&record.EntityType,
+			&record.EntityId,
+			&record.Action,
+			&record.Actor,
+			&record.OwnerId,
+			&before,
+			&after,
+			&record.CreatedAt,
+		); err != nil {
+			return page, err
+		}
+		record.Before = before
+		record.After = after
+		page.Items = append(page.Items, record)
+	}
+	return page, rows.Err()
+}
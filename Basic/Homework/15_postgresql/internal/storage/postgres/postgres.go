@@ -0,0 +1,381 @@
+// Package postgres реализует storage.Store поверх PostgreSQL
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+// Store хранилище задач и заметок в PostgreSQL
+type Store struct {
+	db *sql.DB
+}
+
+var _ storage.Store = (*Store)(nil)
+
+// Open подключается к PostgreSQL по dsn и накатывает миграции из каталога migrations
+func Open(ctx context.Context, dsn, migrations string) (*Store, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connect db: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("ping db: %w", err)
+	}
+
+	if err := goose.SetDialect("postgres"); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("cannot set dialect: %w", err)
+	}
+	if err := goose.UpContext(ctx, db, migrations); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("cannot do up migration: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close реализует storage.Store
+func (s *Store) Close(context.Context) error {
+	return s.db.Close()
+}
+
+// dbtx общий интерфейс *sql.DB и *sql.Tx
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// withTx выполняет fn в транзакции: фиксирует ее при успехе и откатывает при ошибке
+func (s *Store) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return nil
+}
+
+// mapError приводит ошибки БД к ошибкам пакета storage
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return storage.ErrNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation:
+		return storage.ErrDuplicateName
+	case errors.As(err, &pgErr) && (pgErr.Code == pgerrcode.SerializationFailure || pgErr.Code == pgerrcode.DeadlockDetected):
+		return storage.ErrConflict
+	}
+	return err
+}
+
+// versionError приводит ошибку изменения строки, условного по версии (WHERE version = $n),
+// к ошибкам пакета storage: отсутствие изменённой строки означает, что её версия изменилась
+// с момента считывания
+func versionError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrConflict
+	}
+	return mapError(err)
+}
+
+// Наборы колонок, считываемых из таблиц задач и заметок
+const (
+	taskColumns = "id, name, description, created_at, due_date, status, recurrence, timezone, reminder_state, snoozed_from, " +
+		"priority, array_to_json(tags), parent_id, array_to_json(blocked_by), coalesce(owner_id, 0), updated_at, deleted_at, version"
+	noteColumns = "id, name, description, alarm_at, created_at, recurrence, timezone, reminder_state, snoozed_from, " +
+		"priority, array_to_json(tags), task_id, coalesce(owner_id, 0), updated_at, deleted_at, version"
+)
+
+// owned возвращает условие принадлежности строки владельцу, Id которого передаётся аргументом $n;
+// владелец 0 (контекст внутренних служб, см. storage.OwnerFrom) видит все строки
+func owned(n int) string {
+	return fmt.Sprintf("($%d = 0 OR owner_id = $%d)", n, n)
+}
+
+// visible возвращает условие видимости строки таблицы table (tasks или notes) пользователю,
+// Id которого передаётся аргументом $n: собственной строки, строки, к которой ему выдан доступ,
+// и строки из списка, к которому ему выдан доступ; пользователь 0 видит все строки.
+// Строки в корзине не видны никому
+func visible(table string, n int) string {
+	entityType := storage.EntityTask
+	if table == "notes" {
+		entityType = storage.EntityNote
+	}
+	return fmt.Sprintf(
+		`(%[2]s.deleted_at IS NULL AND ($%[1]d = 0 OR %[2]s.owner_id = $%[1]d OR EXISTS (
+			SELECT 1 FROM shares
+			WHERE shares.user_id = $%[1]d AND shares.owner_id = %[2]s.owner_id
+				AND (shares.entity_type = '%[3]s' AND shares.entity_id = %[2]s.id
+					OR shares.entity_type = '%[4]s' AND shares.list = ANY(%[2]s.tags)))))`,
+		n, table, entityType, storage.EntityList,
+	)
+}
+
+// ownerArg возвращает владельца новой строки из ctx; без владельца записывается NULL
+func ownerArg(ctx context.Context) *int {
+	if owner := storage.OwnerFrom(ctx); owner != 0 {
+		return &owner
+	}
+	return nil
+}
+
+// jsonList считывает массив, выбранный как array_to_json(column): метки или Id задач
+type jsonList[T any] []T
+
+// Scan реализует sql.Scanner
+func (l *jsonList[T]) Scan(src any) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		*l = make(jsonList[T], 0)
+		return nil
+	case string:
+		data = []byte(src)
+	case []byte:
+		data = src
+	default:
+		return fmt.Errorf("неожиданный тип массива %T", src)
+	}
+	items := make(jsonList[T], 0)
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("ошибка разбора массива: %w", err)
+	}
+	*l = items
+	return nil
+}
+
+// tagsArg возвращает метки для записи в колонку text[]; nil записывается пустым массивом
+func tagsArg(tags []string) []string {
+	if tags == nil {
+		return make([]string, 0)
+	}
+	return tags
+}
+
+// rowScanner общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask считывает задачу из строки, полученной по колонкам taskColumns
+func scanTask(row rowScanner) (model.Task, error) {
+	var task model.Task
+	err := row.Scan(
+		&task.Id,
+		&task.Name,
+		&task.Description,
+		&task.InitTimeStamp,
+		&task.DueDate,
+		&task.Status,
+		&task.Recurrence,
+		&task.Timezone,
+		&task.ReminderState,
+		&task.SnoozedFrom,
+		&task.Priority,
+		(*jsonList[string])(&task.Tags),
+		&task.ParentId,
+		(*jsonList[int])(&task.BlockedBy),
+		&task.OwnerId,
+		&task.UpdatedAt,
+		&task.DeletedAt,
+		&task.Version,
+	)
+	return task, err
+}
+
+// scanNote считывает заметку из строки, полученной по колонкам noteColumns
+func scanNote(row rowScanner) (model.Note, error) {
+	var note model.Note
+	err := row.Scan(
+		&note.Id,
+		&note.Name,
+		&note.Description,
+		&note.AlarmTimeStamp,
+		&note.InitTimeStamp,
+		&note.Recurrence,
+		&note.Timezone,
+		&note.ReminderState,
+		&note.SnoozedFrom,
+		&note.Priority,
+		(*jsonList[string])(&note.Tags),
+		&note.TaskId,
+		&note.OwnerId,
+		&note.UpdatedAt,
+		&note.DeletedAt,
+		&note.Version,
+	)
+	return note, err
+}
+
+// GetTask реализует storage.TaskStore
+func (s *Store) GetTask(ctx context.Context, id int) (model.Task, error) {
+	return taskGraph{tx: s.db}.Task(ctx, id)
+}
+
+// CreateTask реализует storage.TaskStore.
+// Id и временные метки назначаются БД атомарно и записываются обратно в задачу
+func (s *Store) CreateTask(ctx context.Context, task model.Task) (model.Task, error) {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(
+			ctx,
+			`INSERT INTO tasks(name, description, due_date, status, recurrence, timezone, reminder_state, priority, tags, owner_id)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING id, created_at, coalesce(owner_id, 0), updated_at, version`,
+			task.Name, task.Description, task.DueDate, task.Status, task.Recurrence, task.Timezone,
+			model.MigrateReminderState(task.ReminderState), model.MigratePriority(task.Priority), tagsArg(task.Tags),
+			ownerArg(ctx),
+		).Scan(&task.Id, &task.InitTimeStamp, &task.OwnerId, &task.UpdatedAt, &task.Version)
+		if err != nil {
+			return mapError(err)
+		}
+		if err := writeStatus(ctx, tx, task.Id, "", task.Status); err != nil {
+			return err
+		}
+		return writeLog(ctx, tx, storage.EntityTask, task.Id, storage.ActionCreate, nil, task)
+	})
+	return task, err
+}
+
+// UpdateTask реализует storage.TaskStore
+func (s *Store) UpdateTask(ctx context.Context, id int, change func(task *model.Task) error) (model.Task, error) {
+	var task model.Task
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := taskGraph{tx: tx, lock: "FOR UPDATE"}.Task(ctx, id)
+		if err != nil {
+			return err
+		}
+		task = before
+		if err := change(&task); err != nil {
+			return err
+		}
+		task.Id, task.OwnerId, task.Version = id, before.OwnerId, before.Version
+		if err := storage.CheckTask(ctx, taskGraph{tx: tx, lock: "FOR SHARE"}, before, task); err != nil {
+			return err
+		}
+
+		if err := updateTask(ctx, tx, &task); err != nil {
+			return err
+		}
+		if err := writeStatus(ctx, tx, task.Id, before.Status, task.Status); err != nil {
+			return err
+		}
+		if snooze, ok := model.TaskSnooze(before, task); ok {
+			if err := writeSnooze(ctx, tx, "task_snoozes", "task_id", task.Id, snooze); err != nil {
+				return err
+			}
+		}
+		return writeLog(ctx, tx, storage.EntityTask, task.Id, storage.ActionUpdate, before, task)
+	})
+	return task, err
+}
+
+// GetNote реализует storage.NoteStore
+func (s *Store) GetNote(ctx context.Context, id int) (model.Note, error) {
+	note, err := scanNote(s.db.QueryRowContext(
+		ctx,
+		"SELECT "+noteColumns+" FROM notes WHERE id=$1 AND "+visible("notes", 2),
+		id, storage.OwnerFrom(ctx),
+	))
+	return note, mapError(err)
+}
+
+// CreateNote реализует storage.NoteStore.
+// Id и временные метки назначаются БД атомарно и записываются обратно в заметку
+func (s *Store) CreateNote(ctx context.Context, note model.Note) (model.Note, error) {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(
+			ctx,
+			`INSERT INTO notes(name, description, alarm_at, recurrence, timezone, reminder_state, priority, tags, owner_id)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id, created_at, coalesce(owner_id, 0), updated_at, version`,
+			note.Name, note.Description, note.AlarmTimeStamp, note.Recurrence, note.Timezone,
+			model.MigrateReminderState(note.ReminderState), model.MigratePriority(note.Priority), tagsArg(note.Tags),
+			ownerArg(ctx),
+		).Scan(&note.Id, &note.InitTimeStamp, &note.OwnerId, &note.UpdatedAt, &note.Version)
+		if err != nil {
+			return mapError(err)
+		}
+		return writeLog(ctx, tx, storage.EntityNote, note.Id, storage.ActionCreate, nil, note)
+	})
+	return note, err
+}
+
+// UpdateNote реализует storage.NoteStore
+func (s *Store) UpdateNote(ctx context.Context, id int, change func(note *model.Note) error) (model.Note, error) {
+	var note model.Note
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := scanNote(tx.QueryRowContext(
+			ctx,
+			"SELECT "+noteColumns+" FROM notes WHERE id=$1 AND "+visible("notes", 2)+" FOR UPDATE",
+			id, storage.OwnerFrom(ctx),
+		))
+		if err != nil {
+			return mapError(err)
+		}
+		note = before
+		if err := change(&note); err != nil {
+			return err
+		}
+		note.Id, note.OwnerId, note.Version = id, before.OwnerId, before.Version
+		if err := storage.CheckNote(ctx, taskGraph{tx: tx, lock: "FOR SHARE"}, before, note); err != nil {
+			return err
+		}
+
+		if err := updateNote(ctx, tx, &note); err != nil {
+			return err
+		}
+		if snooze, ok := model.NoteSnooze(before, note); ok {
+			if err := writeSnooze(ctx, tx, "note_snoozes", "note_id", note.Id, snooze); err != nil {
+				return err
+			}
+		}
+		return writeLog(ctx, tx, storage.EntityNote, note.Id, storage.ActionUpdate, before, note)
+	})
+	return note, err
+}
+
+// DeleteNote реализует storage.NoteStore: заметка перемещается в корзину
+func (s *Store) DeleteNote(ctx context.Context, id int) (model.Note, error) {
+	var note model.Note
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := scanNote(tx.QueryRowContext(
+			ctx,
+			"SELECT "+noteColumns+" FROM notes WHERE id=$1 AND "+visible("notes", 2)+" FOR UPDATE",
+			id, storage.OwnerFrom(ctx),
+		))
+		if err != nil {
+			return mapError(err)
+		}
+		if err := storage.CheckVersion(ctx, before.Version); err != nil {
+			return err
+		}
+		note = before
+		if err := moveToTrash(ctx, tx, "notes", id, &note.DeletedAt, &note.Version); err != nil {
+			return err
+		}
+		return writeLog(ctx, tx, storage.EntityNote, note.Id, storage.ActionDelete, before, nil)
+	})
+	return note, err
+}
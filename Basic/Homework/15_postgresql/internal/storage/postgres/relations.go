@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// taskGraph реализует storage.TaskGraph запросами к строкам, видимым пользователю из ctx;
+// lock - предложение блокировки читаемых строк ("FOR SHARE", "FOR UPDATE")
+// или пустая строка для чтения без блокировки
+type taskGraph struct {
+	tx   dbtx
+	lock string
+}
+
+// Task реализует storage.TaskGraph
+func (g taskGraph) Task(ctx context.Context, id int) (model.Task, error) {
+	task, err := scanTask(g.tx.QueryRowContext(
+		ctx,
+		"SELECT "+taskColumns+" FROM tasks WHERE id=$1 AND "+visible("tasks", 2)+" "+g.lock,
+		id, storage.OwnerFrom(ctx),
+	))
+	return task, mapError(err)
+}
+
+// Subtasks реализует storage.TaskGraph
+func (g taskGraph) Subtasks(ctx context.Context, id int) ([]model.Task, error) {
+	return g.query(ctx, "parent_id = $1", id)
+}
+
+// Dependents реализует storage.TaskGraph
+func (g taskGraph) Dependents(ctx context.Context, id int) ([]model.Task, error) {
+	return g.query(ctx, "blocked_by @> ARRAY[$1::int]", id)
+}
+
+// Notes реализует storage.TaskGraph
+func (g taskGraph) Notes(ctx context.Context, id int) ([]model.Note, error) {
+	rows, err := g.tx.QueryContext(
+		ctx,
+		"SELECT "+noteColumns+" FROM notes WHERE task_id = $1 AND "+visible("notes", 2)+" ORDER BY id "+g.lock,
+		id, storage.OwnerFrom(ctx),
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	notes := make([]model.Note, 0)
+	for rows.Next() {
+		note, err := scanNote(rows)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, note)
+	}
+	return notes, rows.Err()
+}
+
+// query возвращает видимые задачи, удовлетворяющие условию where с аргументами args, в порядке Id
+func (g taskGraph) query(ctx context.Context, where string, args ...any) ([]model.Task, error) {
+	args = append(args, storage.OwnerFrom(ctx))
+	rows, err := g.tx.QueryContext(
+		ctx,
+		"SELECT "+taskColumns+" FROM tasks WHERE "+where+" AND "+visible("tasks", len(args))+" ORDER BY id "+g.lock,
+		args...,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var tasks []model.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// updateTask записывает изменённую задачу в рамках переданной транзакции, если версия строки
+// по-прежнему равна task.Version, и обновляет UpdatedAt и версию задачи; иначе возвращает storage.ErrConflict
+func updateTask(ctx context.Context, tx dbtx, task *model.Task) error {
+	err := tx.QueryRowContext(ctx, `
+		UPDATE tasks
+		SET name = $1, description = $2, due_date = $3, status = $4,
+			recurrence = $5, timezone = $6, reminder_state = $7, snoozed_from = $8,
+			priority = $9, tags = $10, parent_id = $11, blocked_by = $12, updated_at = now(), version = version + 1
+		WHERE id = $13 AND version = $14
+		RETURNING updated_at, version`,
+		task.Name, task.Description, task.DueDate, task.Status, task.Recurrence, task.Timezone,
+		task.ReminderState, task.SnoozedFrom, model.MigratePriority(task.Priority), tagsArg(task.Tags),
+		task.ParentId, model.MigrateBlockedBy(task.BlockedBy), task.Id, task.Version,
+	).Scan(&task.UpdatedAt, &task.Version)
+	return versionError(err)
+}
+
+// updateNote записывает изменённую заметку; соглашения те же, что у updateTask
+func updateNote(ctx context.Context, tx dbtx, note *model.Note) error {
+	err := tx.QueryRowContext(ctx, `
+		UPDATE notes
+		SET name = $1, description = $2, alarm_at = $3,
+			recurrence = $4, timezone = $5, reminder_state = $6, snoozed_from = $7,
+			priority = $8, tags = $9, task_id = $10, updated_at = now(), version = version + 1
+		WHERE id = $11 AND version = $12
+		RETURNING updated_at, version`,
+		note.Name, note.Description, note.AlarmTimeStamp, note.Recurrence, note.Timezone,
+		note.ReminderState, note.SnoozedFrom, model.MigratePriority(note.Priority), tagsArg(note.Tags),
+		note.TaskId, note.Id, note.Version,
+	).Scan(&note.UpdatedAt, &note.Version)
+	return versionError(err)
+}
+
+// moveToTrash перемещает строку id таблицы table (tasks или notes) в корзину в рамках переданной
+// транзакции, если версия строки по-прежнему равна *version, и записывает время перемещения
+// в deletedAt, а новую версию - в version; иначе возвращает storage.ErrConflict
+func moveToTrash(ctx context.Context, tx dbtx, table string, id int, deletedAt **time.Time, version *int) error {
+	err := tx.QueryRowContext(
+		ctx,
+		"UPDATE "+table+" SET deleted_at = now(), version = version + 1 WHERE id = $1 AND version = $2 RETURNING deleted_at, version",
+		id, *version,
+	).Scan(deletedAt, version)
+	return versionError(err)
+}
+
+// DeleteTask реализует storage.TaskStore: задачи и заметки по плану перемещаются в корзину,
+// сохраняя ссылки task_id и parent_id до окончательного удаления
+func (s *Store) DeleteTask(ctx context.Context, id int, opts storage.DeleteOptions) (model.Task, error) {
+	var task model.Task
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		plan, err := storage.PlanDelete(ctx, taskGraph{tx: tx, lock: "FOR UPDATE"}, id, opts)
+		if err != nil {
+			return err
+		}
+		for _, change := range plan.DetachedNotes {
+			after := change.After
+			if err := updateNote(ctx, tx, &after); err != nil {
+				return err
+			}
+			if err := writeLog(ctx, tx, storage.EntityNote, after.Id, storage.ActionUpdate, change.Before, after); err != nil {
+				return err
+			}
+		}
+		for _, note := range plan.DeletedNotes {
+			after := note
+			if err := moveToTrash(ctx, tx, "notes", note.Id, &after.DeletedAt, &after.Version); err != nil {
+				return err
+			}
+			if err := writeLog(ctx, tx, storage.EntityNote, note.Id, storage.ActionDelete, note, nil); err != nil {
+				return err
+			}
+		}
+		for _, change := range plan.Updated {
+			after := change.After
+			if err := updateTask(ctx, tx, &after); err != nil {
+				return err
+			}
+			if err := writeLog(ctx, tx, storage.EntityTask, after.Id, storage.ActionUpdate, change.Before, after); err != nil {
+				return err
+			}
+		}
+		for _, deleted := range plan.Deleted {
+			task = deleted
+			if err := moveToTrash(ctx, tx, "tasks", deleted.Id, &task.DeletedAt, &task.Version); err != nil {
+				return err
+			}
+			if err := writeLog(ctx, tx, storage.EntityTask, deleted.Id, storage.ActionDelete, deleted, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return task, err
+}
+
+// TaskTree реализует storage.TaskStore
+func (s *Store) TaskTree(ctx context.Context, id int) (model.TaskNode, error) {
+	var node model.TaskNode
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		node, err = storage.BuildTree(ctx, taskGraph{tx: tx}, id)
+		return err
+	})
+	return node, err
+}
+
+// TaskNotes реализует storage.NoteStore
+func (s *Store) TaskNotes(ctx context.Context, taskId int) ([]model.Note, error) {
+	return taskGraph{tx: s.db}.Notes(ctx, taskId)
+}
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// searchSelect формирует выборку совпадений из таблицы table для запроса $1 среди строк, видимых пользователю $4
+func searchSelect(entityType, table string) string {
+	return fmt.Sprintf(
+		`SELECT '%s' AS type, id, name, ts_rank(search, query) AS rank,
+			ts_headline('russian', name, query, 'HighlightAll=true'),
+			ts_headline('russian', description, query, 'MaxWords=%d, MinWords=8, MaxFragments=2')
+		FROM %s, websearch_to_tsquery('russian', $1) AS query
+		WHERE search @@ query AND %s`,
+		entityType, storage.SnippetWords, table, visible(table, 4),
+	)
+}
+
+// Search реализует storage.Searcher по колонкам search с русской морфологией
+func (s *Store) Search(ctx context.Context, filter storage.SearchFilter) (storage.SearchPage, error) {
+	page := storage.SearchPage{Items: make([]storage.SearchHit, 0), Limit: filter.Limit, Offset: filter.Offset}
+
+	var selects []string
+	if filter.Type != storage.EntityNote {
+		selects = append(selects, searchSelect(storage.EntityTask, "tasks"))
+	}
+	if filter.Type != storage.EntityTask {
+		selects = append(selects, searchSelect(storage.EntityNote, "notes"))
+	}
+	query := selects[0]
+	if len(selects) > 1 {
+		query += " UNION ALL " + selects[1]
+	}
+	query += " ORDER BY rank DESC, type, id LIMIT $2 OFFSET $3"
+
+	rows, err := s.db.QueryContext(ctx, query, filter.Query, filter.Limit, filter.Offset, storage.OwnerFrom(ctx))
+	if err != nil {
+		return page, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	for rows.Next() {
+		var hit storage.SearchHit
+		if err := rows.Scan(
+			&hit.Type,
+			&hit.Id,
+			&hit.Name,
+			&hit.Rank,
+			&hit.NameSnippet,
+			&hit.DescriptionSnippet,
+		); err != nil {
+			return page, err
+		}
+		page.Items = append(page.Items, hit)
+	}
+	return page, rows.Err()
+}
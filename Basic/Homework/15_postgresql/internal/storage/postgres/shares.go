@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// shareColumns набор колонок, считываемых из таблицы доступов
+const shareColumns = "id, entity_type, entity_id, list, owner_id, user_id, login, role, created_at"
+
+func scanShare(row rowScanner) (storage.Share, error) {
+	var share storage.Share
+	err := row.Scan(
+		&share.Id, &share.EntityType, &share.EntityId, &share.List,
+		&share.OwnerId, &share.UserId, &share.Login, &share.Role, &share.CreatedAt,
+	)
+	return share, err
+}
+
+// queryShares возвращает доступы, удовлетворяющие условию where с аргументами args, в порядке Id
+func (s *Store) queryShares(ctx context.Context, where string, args ...any) ([]storage.Share, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+shareColumns+" FROM shares WHERE "+where+" ORDER BY id", args...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	shares := make([]storage.Share, 0)
+	for rows.Next() {
+		share, err := scanShare(rows)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, rows.Err()
+}
+
+// Share реализует storage.ShareStore
+func (s *Store) Share(ctx context.Context, share storage.Share) (storage.Share, error) {
+	share.OwnerId = storage.OwnerFrom(ctx)
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var before any
+		existing, err := scanShare(tx.QueryRowContext(
+			ctx,
+			`SELECT `+shareColumns+` FROM shares
+			WHERE owner_id = $1 AND entity_type = $2 AND entity_id = $3 AND list = $4 AND user_id = $5 FOR UPDATE`,
+			share.OwnerId, share.EntityType, share.EntityId, share.List, share.UserId,
+		))
+		switch {
+		case err == nil:
+			before = existing
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		share, err = scanShare(tx.QueryRowContext(
+			ctx,
+			`INSERT INTO shares(entity_type, entity_id, list, owner_id, user_id, login, role)
+			VALUES($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (owner_id, entity_type, entity_id, list, user_id) DO UPDATE SET role = EXCLUDED.role
+			RETURNING `+shareColumns,
+			share.EntityType, share.EntityId, share.List, share.OwnerId, share.UserId, share.Login, share.Role,
+		))
+		if err != nil {
+			return mapError(err)
+		}
+		return writeLog(ctx, tx, share.EntityType, share.EntityId, storage.ActionShare, before, share)
+	})
+	return share, err
+}
+
+// Unshare реализует storage.ShareStore
+func (s *Store) Unshare(ctx context.Context, target storage.ShareTarget, userId int) (storage.Share, error) {
+	var share storage.Share
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		share, err = scanShare(tx.QueryRowContext(
+			ctx,
+			`DELETE FROM shares
+			WHERE owner_id = $1 AND entity_type = $2 AND entity_id = $3 AND list = $4 AND user_id = $5
+			RETURNING `+shareColumns,
+			storage.OwnerFrom(ctx), target.EntityType, target.EntityId, target.List, userId,
+		))
+		if err != nil {
+			return mapError(err)
+		}
+		return writeLog(ctx, tx, share.EntityType, share.EntityId, storage.ActionUnshare, share, nil)
+	})
+	return share, err
+}
+
+// Shares реализует storage.ShareStore
+func (s *Store) Shares(ctx context.Context, target storage.ShareTarget) ([]storage.Share, error) {
+	return s.queryShares(
+		ctx,
+		"owner_id = $1 AND entity_type = $2 AND entity_id = $3 AND list = $4",
+		storage.OwnerFrom(ctx), target.EntityType, target.EntityId, target.List,
+	)
+}
+
+// SharedWith реализует storage.ShareStore
+func (s *Store) SharedWith(ctx context.Context, ownerId int) ([]storage.Share, error) {
+	return s.queryShares(ctx, "user_id = $1 AND owner_id = $2", storage.OwnerFrom(ctx), ownerId)
+}
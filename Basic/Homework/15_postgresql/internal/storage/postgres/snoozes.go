@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// writeSnooze добавляет запись в историю откладывания table (task_snoozes или note_snoozes),
+// где column - колонка Id задачи или заметки, в рамках переданной транзакции
+func writeSnooze(ctx context.Context, tx dbtx, table, column string, id int, snooze model.Snooze) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO `+table+`(`+column+`, from_at, until_at, actor) VALUES($1, $2, $3, $4)`,
+		id, snooze.From, snooze.Until, storage.ActorFrom(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка записи в историю откладывания напоминаний: %w", err)
+	}
+	return nil
+}
+
+// readSnoozes возвращает историю откладывания table записи id таблицы parent, видимой пользователю из ctx
+func (s *Store) readSnoozes(ctx context.Context, parent, table, column string, id int) ([]model.Snooze, error) {
+	var exists bool
+	err := s.db.QueryRowContext(
+		ctx,
+		"SELECT EXISTS(SELECT 1 FROM "+parent+" WHERE id=$1 AND "+visible(parent, 2)+")",
+		id, storage.OwnerFrom(ctx),
+	).Scan(&exists)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT from_at, until_at, actor, snoozed_at
+		FROM `+table+`
+		WHERE `+column+` = $1
+		ORDER BY id`,
+		id,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	snoozes := make([]model.Snooze, 0)
+	for rows.Next() {
+		var snooze model.Snooze
+		if err := rows.Scan(&snooze.From, &snooze.Until, &snooze.Actor, &snooze.SnoozedAt); err != nil {
+			return nil, err
+		}
+		snoozes = append(snoozes, snooze)
+	}
+	return snoozes, rows.Err()
+}
+
+// TaskSnoozes реализует storage.TaskStore
+func (s *Store) TaskSnoozes(ctx context.Context, id int) ([]model.Snooze, error) {
+	return s.readSnoozes(ctx, "tasks", "task_snoozes", "task_id", id)
+}
+
+// NoteSnoozes реализует storage.NoteStore
+func (s *Store) NoteSnoozes(ctx context.Context, id int) ([]model.Snooze, error) {
+	return s.readSnoozes(ctx, "notes", "note_snoozes", "note_id", id)
+}
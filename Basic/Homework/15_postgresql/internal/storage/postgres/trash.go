@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// trashed возвращает условие нахождения строки в корзине владельца, Id которого передаётся аргументом $n
+func trashed(n int) string {
+	return "deleted_at IS NOT NULL AND " + owned(n)
+}
+
+// queryTrashed возвращает строки таблицы table из корзины, удовлетворяющие условию where с аргументами args,
+// от недавно удалённых к давним; строки считываются по колонкам columns функцией scan,
+// lock - предложение блокировки (см. taskGraph)
+func queryTrashed[T any](
+	ctx context.Context,
+	tx dbtx,
+	table, columns, lock string,
+	scan func(rowScanner) (T, error),
+	where string,
+	args ...any,
+) ([]T, error) {
+	rows, err := tx.QueryContext(
+		ctx,
+		"SELECT "+columns+" FROM "+table+" WHERE "+where+" ORDER BY deleted_at DESC, id DESC "+lock,
+		args...,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	items := make([]T, 0)
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Trash реализует storage.TrashStore
+func (s *Store) Trash(ctx context.Context) (storage.Trash, error) {
+	var (
+		trash storage.Trash
+		err   error
+	)
+	owner := storage.OwnerFrom(ctx)
+	if trash.Tasks, err = queryTrashed(ctx, s.db, "tasks", taskColumns, "", scanTask, trashed(1), owner); err != nil {
+		return trash, err
+	}
+	trash.Notes, err = queryTrashed(ctx, s.db, "notes", noteColumns, "", scanNote, trashed(1), owner)
+	return trash, err
+}
+
+// trashedTask считывает задачу id из корзины владельца из ctx с блокировкой строки
+func trashedTask(ctx context.Context, tx dbtx, id int) (model.Task, error) {
+	task, err := scanTask(tx.QueryRowContext(
+		ctx,
+		"SELECT "+taskColumns+" FROM tasks WHERE id=$1 AND "+trashed(2)+" FOR UPDATE",
+		id, storage.OwnerFrom(ctx),
+	))
+	return task, mapError(err)
+}
+
+// trashedNote считывает заметку id из корзины владельца из ctx с блокировкой строки
+func trashedNote(ctx context.Context, tx dbtx, id int) (model.Note, error) {
+	note, err := scanNote(tx.QueryRowContext(
+		ctx,
+		"SELECT "+noteColumns+" FROM notes WHERE id=$1 AND "+trashed(2)+" FOR UPDATE",
+		id, storage.OwnerFrom(ctx),
+	))
+	return note, mapError(err)
+}
+
+// RestoreTask реализует storage.TrashStore.
+// Совпадение имени с задачей вне корзины отклоняет уникальный индекс index_task_owner_name
+func (s *Store) RestoreTask(ctx context.Context, id int) (model.Task, error) {
+	var task model.Task
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := trashedTask(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if task, err = storage.RestoredTask(ctx, taskGraph{tx: tx, lock: "FOR SHARE"}, before); err != nil {
+			return err
+		}
+		err = tx.QueryRowContext(
+			ctx,
+			`UPDATE tasks SET parent_id = $1, blocked_by = $2, deleted_at = NULL, updated_at = now(), version = version + 1
+			WHERE id = $3 AND version = $4
+			RETURNING updated_at, version`,
+			task.ParentId, model.MigrateBlockedBy(task.BlockedBy), id, before.Version,
+		).Scan(&task.UpdatedAt, &task.Version)
+		if err != nil {
+			return versionError(err)
+		}
+		return writeLog(ctx, tx, storage.EntityTask, id, storage.ActionRestore, before, task)
+	})
+	return task, err
+}
+
+// RestoreNote реализует storage.TrashStore; соглашения те же, что у RestoreTask
+func (s *Store) RestoreNote(ctx context.Context, id int) (model.Note, error) {
+	var note model.Note
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := trashedNote(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if note, err = storage.RestoredNote(ctx, taskGraph{tx: tx, lock: "FOR SHARE"}, before); err != nil {
+			return err
+		}
+		err = tx.QueryRowContext(
+			ctx,
+			`UPDATE notes SET task_id = $1, deleted_at = NULL, updated_at = now(), version = version + 1
+			WHERE id = $2 AND version = $3
+			RETURNING updated_at, version`,
+			note.TaskId, id, before.Version,
+		).Scan(&note.UpdatedAt, &note.Version)
+		if err != nil {
+			return versionError(err)
+		}
+		return writeLog(ctx, tx, storage.EntityNote, id, storage.ActionRestore, before, note)
+	})
+	return note, err
+}
+
+// PurgeTask реализует storage.TrashStore
+func (s *Store) PurgeTask(ctx context.Context, id int) (model.Task, error) {
+	var task model.Task
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		if task, err = trashedTask(ctx, tx, id); err != nil {
+			return err
+		}
+		return purgeTask(ctx, tx, task)
+	})
+	return task, err
+}
+
+// PurgeNote реализует storage.TrashStore
+func (s *Store) PurgeNote(ctx context.Context, id int) (model.Note, error) {
+	var note model.Note
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		if note, err = trashedNote(ctx, tx, id); err != nil {
+			return err
+		}
+		return purgeNote(ctx, tx, note)
+	})
+	return note, err
+}
+
+// PurgeTrash реализует storage.TrashStore
+func (s *Store) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	purged := 0
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		owner := storage.OwnerFrom(ctx)
+		tasks, err := queryTrashed(ctx, tx, "tasks", taskColumns, "FOR UPDATE", scanTask, "deleted_at < $2 AND "+trashed(1), owner, before)
+		if err != nil {
+			return err
+		}
+		notes, err := queryTrashed(ctx, tx, "notes", noteColumns, "FOR UPDATE", scanNote, "deleted_at < $2 AND "+trashed(1), owner, before)
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			if err := purgeTask(ctx, tx, task); err != nil {
+				return err
+			}
+		}
+		for _, note := range notes {
+			if err := purgeNote(ctx, tx, note); err != nil {
+				return err
+			}
+		}
+		purged = len(tasks) + len(notes)
+		return nil
+	})
+	return purged, err
+}
+
+// purgeTask окончательно удаляет задачу task с доступами к ней в рамках переданной транзакции.
+// Ссылки остающихся подзадач и заметок снимаются до удаления, поэтому внешние ключи parent_id
+// и task_id не нарушаются; история статусов и откладываний удаляется каскадно
+func purgeTask(ctx context.Context, tx dbtx, task model.Task) error {
+	for _, query := range []string{
+		"UPDATE tasks SET parent_id = NULL WHERE parent_id = $1",
+		"UPDATE notes SET task_id = NULL WHERE task_id = $1",
+		"DELETE FROM tasks WHERE id = $1",
+	} {
+		if _, err := tx.ExecContext(ctx, query, task.Id); err != nil {
+			return mapError(err)
+		}
+	}
+	if err := purgeShares(ctx, tx, storage.EntityTask, task.Id, task.OwnerId); err != nil {
+		return err
+	}
+	return writeLog(ctx, tx, storage.EntityTask, task.Id, storage.ActionPurge, task, nil)
+}
+
+// purgeNote окончательно удаляет заметку note с доступами к ней в рамках переданной транзакции
+func purgeNote(ctx context.Context, tx dbtx, note model.Note) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM notes WHERE id = $1", note.Id); err != nil {
+		return mapError(err)
+	}
+	if err := purgeShares(ctx, tx, storage.EntityNote, note.Id, note.OwnerId); err != nil {
+		return err
+	}
+	return writeLog(ctx, tx, storage.EntityNote, note.Id, storage.ActionPurge, note, nil)
+}
+
+// purgeShares удаляет доступы к окончательно удалённой записи id типа entityType владельца ownerId
+func purgeShares(ctx context.Context, tx dbtx, entityType string, id, ownerId int) error {
+	_, err := tx.ExecContext(
+		ctx,
+		"DELETE FROM shares WHERE owner_id = $1 AND entity_type = $2 AND entity_id = $3",
+		ownerId, entityType, id,
+	)
+	return mapError(err)
+}
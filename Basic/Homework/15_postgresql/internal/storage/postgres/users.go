@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// CreateUser реализует storage.UserStore; блокировка таблицы users не даёт двум одновременным
+// регистрациям обеим счесть себя первыми
+func (s *Store) CreateUser(ctx context.Context, account storage.Account) (storage.Account, error) {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "LOCK TABLE users IN EXCLUSIVE MODE"); err != nil {
+			return fmt.Errorf("ошибка блокировки таблицы users: %w", err)
+		}
+		var first bool
+		if err := tx.QueryRowContext(ctx, "SELECT NOT EXISTS (SELECT 1 FROM users)").Scan(&first); err != nil {
+			return fmt.Errorf("ошибка проверки учётных записей: %w", err)
+		}
+		err := tx.QueryRowContext(
+			ctx,
+			`INSERT INTO users(login, password_hash) VALUES($1, $2) RETURNING id, created_at`,
+			account.Login, account.PasswordHash,
+		).Scan(&account.Id, &account.CreatedAt)
+		if err != nil || !first {
+			return mapError(err)
+		}
+		return adoptOwnerless(ctx, tx, account.Id)
+	})
+	return account, err
+}
+
+// adoptOwnerless передаёт владельцу ownerId задачи, заметки и записи журнала без владельца
+func adoptOwnerless(ctx context.Context, tx *sql.Tx, ownerId int) error {
+	for _, table := range []string{"tasks", "notes", "remindables_log"} {
+		query := "UPDATE " + table + " SET owner_id = $1 WHERE owner_id IS NULL"
+		if _, err := tx.ExecContext(ctx, query, ownerId); err != nil {
+			return fmt.Errorf("ошибка передачи записей %s владельцу: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// UserByLogin реализует storage.UserStore
+func (s *Store) UserByLogin(ctx context.Context, login string) (storage.Account, error) {
+	var account storage.Account
+	err := s.db.QueryRowContext(
+		ctx,
+		"SELECT id, login, created_at, password_hash FROM users WHERE login = $1",
+		login,
+	).Scan(&account.Id, &account.Login, &account.CreatedAt, &account.PasswordHash)
+	return account, mapError(err)
+}
+
+// GetUser реализует storage.UserStore
+func (s *Store) GetUser(ctx context.Context, id int) (model.User, error) {
+	var user model.User
+	err := s.db.QueryRowContext(
+		ctx,
+		"SELECT id, login, created_at FROM users WHERE id = $1",
+		id,
+	).Scan(&user.Id, &user.Login, &user.CreatedAt)
+	return user, mapError(err)
+}
+
+// CreateSession реализует storage.UserStore; сессии с истёкшим сроком удаляются в той же транзакции
+func (s *Store) CreateSession(ctx context.Context, session storage.Session) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM sessions WHERE expires_at <= $1", session.CreatedAt); err != nil {
+			return fmt.Errorf("ошибка удаления истёкших сессий: %w", err)
+		}
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO sessions(token_hash, user_id, created_at, expires_at) VALUES($1, $2, $3, $4)`,
+			session.TokenHash, session.UserId, session.CreatedAt, session.ExpiresAt,
+		)
+		return mapError(err)
+	})
+}
+
+// GetSession реализует storage.UserStore
+func (s *Store) GetSession(ctx context.Context, tokenHash string) (storage.Session, error) {
+	var session storage.Session
+	err := s.db.QueryRowContext(
+		ctx,
+		"SELECT token_hash, user_id, created_at, expires_at FROM sessions WHERE token_hash = $1",
+		tokenHash,
+	).Scan(&session.TokenHash, &session.UserId, &session.CreatedAt, &session.ExpiresAt)
+	return session, mapError(err)
+}
+
+// DeleteSession реализует storage.UserStore
+func (s *Store) DeleteSession(ctx context.Context, tokenHash string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM sessions WHERE token_hash = $1", tokenHash)
+	return mapError(err)
+}
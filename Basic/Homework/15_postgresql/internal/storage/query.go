@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+)
+
+// Cursor положение последнего элемента страницы в выбранном порядке сортировки
+type Cursor struct {
+	Sort string    `json:"s"`
+	Name string    `json:"n,omitempty"`
+	Time time.Time `json:"t"`
+	Id   int       `json:"id"`
+}
+
+// Encode кодирует курсор в непрозрачную строку next_cursor
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor разбирает курсор raw, выданный для порядка сортировки sort.
+// Для пустой строки возвращает nil
+func DecodeCursor(raw, sort string) (*Cursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cur Cursor
+	if err := json.Unmarshal(data, &cur); err != nil || cur.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return &cur, nil
+}
+
+// SortField возвращает поле сортировки без префикса направления и признак сортировки по убыванию
+func SortField(sort string) (field string, desc bool) {
+	return strings.TrimPrefix(sort, "-"), strings.HasPrefix(sort, "-")
+}
+
+// TaskCursor возвращает курсор, указывающий на задачу task
+func TaskCursor(sort string, task model.Task) Cursor {
+	return Cursor{Sort: sort, Name: task.Name, Time: task.DueDate, Id: task.Id}
+}
+
+// NoteCursor возвращает курсор, указывающий на заметку note
+func NoteCursor(sort string, note model.Note) Cursor {
+	return Cursor{Sort: sort, Name: note.Name, Time: note.AlarmTimeStamp, Id: note.Id}
+}
+
+// InRange проверяет попадание t в интервал [from, to); нулевые границы не ограничивают интервал
+func InRange(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+}
+
+// containsFold проверяет вхождение подстроки без учёта регистра
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// labelsMatch проверяет, что приоритет входит в priorities, а метки содержат все tags;
+// пустые условия не ограничивают отбор
+func labelsMatch(labels model.Labels, priorities []model.Priority, tags []string) bool {
+	if len(priorities) > 0 && !slices.Contains(priorities, model.MigratePriority(labels.Priority)) {
+		return false
+	}
+	for _, tag := range tags {
+		if !slices.Contains(labels.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match проверяет соответствие задачи условиям отбора фильтра
+func (f TaskFilter) Match(task model.Task) bool {
+	return (f.Status == "" || task.Status == f.Status) &&
+		InRange(task.DueDate, f.DueFrom, f.DueTo) &&
+		(f.Name == "" || containsFold(task.Name, f.Name)) &&
+		labelsMatch(task.Labels, f.Priority, f.Tags)
+}
+
+// Match проверяет соответствие заметки условиям отбора фильтра
+func (f NoteFilter) Match(note model.Note) bool {
+	return InRange(note.AlarmTimeStamp, f.AlarmFrom, f.AlarmTo) &&
+		(f.Name == "" || containsFold(note.Name, f.Name)) &&
+		labelsMatch(note.Labels, f.Priority, f.Tags)
+}
+
+// compareCursors сравнивает позиции двух элементов в порядке сортировки sort с упорядочиванием по Id
+func compareCursors(sort string, a, b Cursor) int {
+	field, desc := SortField(sort)
+	var c int
+	switch field {
+	case "name":
+		c = cmp.Compare(a.Name, b.Name)
+	case "dueDate", "alarmTimeStamp":
+		c = a.Time.Compare(b.Time)
+	}
+	if c == 0 {
+		c = cmp.Compare(a.Id, b.Id)
+	}
+	if desc {
+		return -c
+	}
+	return c
+}
+
+// Paginate упорядочивает отобранные элементы и возвращает страницу после курсора raw.
+// Используется хранилищами, которые держат данные в памяти
+func Paginate[T any](items []T, sort, raw string, limit int, position func(sort string, item T) Cursor) (Page[T], error) {
+	page := Page[T]{Items: make([]T, 0)}
+	cur, err := DecodeCursor(raw, sort)
+	if err != nil {
+		return page, err
+	}
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		return compareCursors(sort, position(sort, a), position(sort, b))
+	})
+	if cur != nil {
+		start := slices.IndexFunc(items, func(item T) bool {
+			return compareCursors(sort, position(sort, item), *cur) > 0
+		})
+		if start < 0 {
+			start = len(items)
+		}
+		items = items[start:]
+	}
+
+	page.Items = append(page.Items, items[:min(limit, len(items))]...)
+	if len(items) > limit {
+		page.NextCursor = position(sort, page.Items[limit-1]).Encode()
+	}
+	return page, nil
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// Фиксированный набор задач с совпадающими сроками и именами, чтобы порядок определялся Id
+func tasksFixture() []model.Task {
+	day := func(d int) time.Time { return time.Date(2026, 11, d, 0, 0, 0, 0, time.UTC) }
+	return []model.Task{
+		{Id: 1, Name: "b", DueDate: day(3)},
+		{Id: 2, Name: "a", DueDate: day(1)},
+		{Id: 3, Name: "b", DueDate: day(2)},
+		{Id: 4, Name: "c", DueDate: day(2)},
+		{Id: 5, Name: "a", DueDate: day(5)},
+	}
+}
+
+func taskIds(tasks []model.Task) []int {
+	ids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.Id)
+	}
+	return ids
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name  string
+		sort  string
+		limit int
+		want  []int
+	}{
+		{name: "by id", sort: "id", limit: 2, want: []int{1, 2, 3, 4, 5}},
+		{name: "by id descending", sort: "-id", limit: 3, want: []int{5, 4, 3, 2, 1}},
+		{name: "by name with ties broken by id", sort: "name", limit: 2, want: []int{2, 5, 1, 3, 4}},
+		{name: "by due date descending", sort: "-dueDate", limit: 1, want: []int{5, 1, 4, 3, 2}},
+		{name: "single page", sort: "dueDate", limit: 10, want: []int{2, 3, 4, 1, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var ids []int
+			cursor, pages := "", 0
+			for {
+				page, err := Paginate(tasksFixture(), tt.sort, cursor, tt.limit, TaskCursor)
+				assert.NoError(t, err)
+				assert.LessOrEqual(t, len(page.Items), tt.limit)
+				ids = append(ids, taskIds(page.Items)...)
+				pages++
+				if page.NextCursor == "" || pages > len(tt.want) {
+					break
+				}
+				cursor = page.NextCursor
+			}
+
+			assert.Equal(t, tt.want, ids)
+			assert.Equal(t, (len(tt.want)+tt.limit-1)/tt.limit, pages)
+		})
+	}
+}
+
+func TestPaginateSkipsDeletedCursorItem(t *testing.T) {
+	page, err := Paginate(tasksFixture(), "dueDate", "", 2, TaskCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, taskIds(page.Items))
+
+	// задача, на которую указывает курсор, удалена до запроса следующей страницы
+	rest := tasksFixture()
+	rest = append(rest[:2], rest[3:]...)
+	page, err = Paginate(rest, "dueDate", page.NextCursor, 2, TaskCursor)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 1}, taskIds(page.Items))
+}
+
+func TestDecodeCursor(t *testing.T) {
+	valid := Cursor{Sort: "name", Name: "b", Id: 3}
+	tests := []struct {
+		name string
+		raw  string
+		sort string
+		want *Cursor
+		err  error
+	}{
+		{name: "empty cursor", raw: "", sort: "name"},
+		{name: "valid cursor", raw: valid.Encode(), sort: "name", want: &valid},
+		{name: "cursor of another sort order", raw: valid.Encode(), sort: "-name", err: ErrInvalidCursor},
+		{name: "not base64", raw: "cursor!", sort: "name", err: ErrInvalidCursor},
+		{name: "not json", raw: "bm90IGpzb24", sort: "name", err: ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cursor, err := DecodeCursor(tt.raw, tt.sort)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, cursor)
+		})
+	}
+}
+
+func TestTaskFilterMatch(t *testing.T) {
+	task := model.Task{
+		Name:    "Квартальный Отчёт",
+		DueDate: time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+		Status:  model.InProcess,
+		Labels:  model.Labels{Priority: model.PriorityHigh, Tags: []string{"work", "q4"}},
+	}
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   bool
+	}{
+		{name: "empty filter", filter: TaskFilter{}, want: true},
+		{name: "status", filter: TaskFilter{Status: model.InProcess}, want: true},
+		{name: "other status", filter: TaskFilter{Status: model.Created}, want: false},
+		{name: "name ignoring case", filter: TaskFilter{Name: "отчёт"}, want: true},
+		{name: "due date range end is exclusive", filter: TaskFilter{DueTo: task.DueDate}, want: false},
+		{name: "due date range start is inclusive", filter: TaskFilter{DueFrom: task.DueDate}, want: true},
+		{name: "any of priorities", filter: TaskFilter{Priority: []model.Priority{model.PriorityLow, model.PriorityHigh}}, want: true},
+		{name: "all tags", filter: TaskFilter{Tags: []string{"work", "q4"}}, want: true},
+		{name: "missing tag", filter: TaskFilter{Tags: []string{"work", "home"}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.filter.Match(task))
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+)
+
+var (
+	// ErrHasSubtasks задачу с подзадачами нельзя удалить с политикой DeleteRestrict
+	ErrHasSubtasks = i18n.New("err.has_subtasks")
+	// ErrRelatedNotFound родительская или блокирующая задача не существует
+	ErrRelatedNotFound = i18n.New("err.related_not_found")
+)
+
+// DeletePolicy политика удаления задачи, у которой есть подзадачи
+type DeletePolicy string
+
+const (
+	// DeleteRestrict отказать в удалении, если у задачи есть подзадачи
+	DeleteRestrict DeletePolicy = "restrict"
+	// DeleteCascade удалить задачу вместе с подзадачами всех уровней
+	DeleteCascade DeletePolicy = "cascade"
+	// DeleteOrphan отвязать подзадачи от удаляемой задачи
+	DeleteOrphan DeletePolicy = "orphan"
+)
+
+// Known проверяет, входит ли policy в перечень политик; пустая политика означает DeleteRestrict
+func (policy DeletePolicy) Known() bool {
+	return policy == "" || policy == DeleteRestrict || policy == DeleteCascade || policy == DeleteOrphan
+}
+
+// NotesPolicy политика удаления заметок, прикреплённых к удаляемой задаче
+type NotesPolicy string
+
+const (
+	// NotesDetach открепить заметки от удаляемой задачи
+	NotesDetach NotesPolicy = "detach"
+	// NotesDelete удалить заметки вместе с задачей
+	NotesDelete NotesPolicy = "delete"
+)
+
+// Known проверяет, входит ли policy в перечень политик; пустая политика означает NotesDetach
+func (policy NotesPolicy) Known() bool {
+	return policy == "" || policy == NotesDetach || policy == NotesDelete
+}
+
+// DeleteOptions политики удаления задачи: для её подзадач и прикреплённых заметок
+type DeleteOptions struct {
+	Subtasks DeletePolicy
+	Notes    NotesPolicy
+}
+
+// TaskGraph чтение задач и их связей в рамках изменения хранилища
+// (транзакции или блокировки), по которым проверяются и изменяются связи задач
+type TaskGraph interface {
+	// Task возвращает задачу по Id или ErrNotFound
+	Task(ctx context.Context, id int) (model.Task, error)
+	// Subtasks возвращает подзадачи задачи id в порядке Id
+	Subtasks(ctx context.Context, id int) ([]model.Task, error)
+	// Dependents возвращает задачи, заблокированные задачей id, в порядке Id
+	Dependents(ctx context.Context, id int) ([]model.Task, error)
+	// Notes возвращает заметки, прикреплённые к задаче id, в порядке Id
+	Notes(ctx context.Context, id int) ([]model.Note, error)
+}
+
+// TaskChange изменение задачи before -> after
+type TaskChange struct {
+	Before model.Task
+	After  model.Task
+}
+
+// NoteChange изменение заметки before -> after
+type NoteChange struct {
+	Before model.Note
+	After  model.Note
+}
+
+// DeletePlan изменения хранилища при удалении задачи. Заметки изменяются и удаляются
+// раньше задач, к которым они прикреплены
+type DeletePlan struct {
+	// Deleted удаляемые задачи: сначала подзадачи нижних уровней, последней - сама задача
+	Deleted []model.Task
+	// Updated оставшиеся задачи, отвязываемые от удаляемых
+	Updated []TaskChange
+	// DeletedNotes заметки удаляемых задач, удаляемые по политике NotesDelete
+	DeletedNotes []model.Note
+	// DetachedNotes заметки удаляемых задач, открепляемые по политике NotesDetach
+	DetachedNotes []NoteChange
+}
+
+// related возвращает связанную задачу id; отсутствие задачи возвращается как ErrRelatedNotFound
+func related(ctx context.Context, g TaskGraph, id int, key string) (model.Task, error) {
+	task, err := g.Task(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return task, i18n.Wrap(ErrRelatedNotFound, key, id)
+	}
+	return task, err
+}
+
+// CheckTask проверяет изменение задачи before -> after в графе g: версия задачи совпадает
+// с ожидаемой (см. CheckVersion), новые родитель и блокирующие задачи существуют и не образуют
+// циклов, открытая задача не становится подзадачей закрытой, а завершаемая задача не имеет
+// открытых подзадач
+func CheckTask(ctx context.Context, g TaskGraph, before, after model.Task) error {
+	if err := CheckVersion(ctx, before.Version); err != nil {
+		return err
+	}
+	if after.ParentId != nil && (before.ParentId == nil || *before.ParentId != *after.ParentId) {
+		if err := checkParent(ctx, g, after); err != nil {
+			return err
+		}
+	}
+	for _, blocker := range after.BlockedBy {
+		if slices.Contains(before.BlockedBy, blocker) {
+			continue
+		}
+		if err := checkBlocker(ctx, g, after.Id, blocker); err != nil {
+			return err
+		}
+	}
+	if model.Completes(before.Status, after.Status) {
+		return checkSubtasksClosed(ctx, g, after.Id)
+	}
+	return nil
+}
+
+// checkParent проверяет нового родителя задачи task, поднимаясь по цепочке родителей
+func checkParent(ctx context.Context, g TaskGraph, task model.Task) error {
+	parent, err := related(ctx, g, *task.ParentId, "ctx.parent_task")
+	if err != nil {
+		return err
+	}
+	path := []int{task.Id, parent.Id}
+	for ancestor := parent; ancestor.ParentId != nil; path = append(path, ancestor.Id) {
+		if *ancestor.ParentId == task.Id {
+			return model.DependencyCycle(append(path, task.Id)...)
+		}
+		if ancestor, err = g.Task(ctx, *ancestor.ParentId); err != nil {
+			return err
+		}
+	}
+	if parent.Closed() && !task.Closed() {
+		return i18n.Wrap(model.ErrTaskClosed, "ctx.parent_task", parent.Id)
+	}
+	return nil
+}
+
+// checkBlocker проверяет новую блокировку задачи id задачей blocker, обходя задачи,
+// которыми blocker заблокирован
+func checkBlocker(ctx context.Context, g TaskGraph, id, blocker int) error {
+	first, err := related(ctx, g, blocker, "ctx.blocker_task")
+	if err != nil {
+		return err
+	}
+	// путь до каждой посещённой задачи - для текста ошибки
+	paths := map[int][]int{blocker: {id, blocker}}
+	queue := []model.Task{first}
+	for len(queue) > 0 {
+		task := queue[0]
+		queue = queue[1:]
+		for _, next := range task.BlockedBy {
+			if _, seen := paths[next]; seen {
+				continue
+			}
+			path := append(slices.Clone(paths[task.Id]), next)
+			if next == id {
+				return model.DependencyCycle(path...)
+			}
+			paths[next] = path
+			nextTask, err := g.Task(ctx, next)
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			queue = append(queue, nextTask)
+		}
+	}
+	return nil
+}
+
+// checkSubtasksClosed возвращает ErrOpenSubtasks, если у задачи id есть незавершённые
+// и неотменённые подзадачи
+func checkSubtasksClosed(ctx context.Context, g TaskGraph, id int) error {
+	subtasks, err := g.Subtasks(ctx, id)
+	if err != nil {
+		return err
+	}
+	open := make([]int, 0)
+	for _, subtask := range subtasks {
+		if !subtask.Closed() {
+			open = append(open, subtask.Id)
+		}
+	}
+	if len(open) > 0 {
+		return i18n.Wrap(model.ErrOpenSubtasks, "ctx.open_subtasks", open)
+	}
+	return nil
+}
+
+// PlanDelete составляет изменения хранилища при удалении задачи id по политикам opts.
+// Версия задачи id проверяется через CheckVersion; удалённые задачи снимаются с блокировок
+// остающихся задач
+func PlanDelete(ctx context.Context, g TaskGraph, id int, opts DeleteOptions) (DeletePlan, error) {
+	var plan DeletePlan
+	task, err := g.Task(ctx, id)
+	if err != nil {
+		return plan, err
+	}
+	if err := CheckVersion(ctx, task.Version); err != nil {
+		return plan, err
+	}
+	subtasks, err := g.Subtasks(ctx, id)
+	if err != nil {
+		return plan, err
+	}
+
+	switch {
+	case len(subtasks) == 0:
+	case opts.Subtasks == DeleteCascade:
+		if plan.Deleted, err = descendants(ctx, g, subtasks); err != nil {
+			return plan, err
+		}
+	case opts.Subtasks == DeleteOrphan:
+		for _, subtask := range subtasks {
+			after := subtask
+			after.ParentId = nil
+			plan.Updated = append(plan.Updated, TaskChange{Before: subtask, After: after})
+		}
+	default:
+		ids := make([]int, len(subtasks))
+		for i, subtask := range subtasks {
+			ids[i] = subtask.Id
+		}
+		return plan, i18n.Wrap(ErrHasSubtasks, "ctx.subtasks", ids)
+	}
+	plan.Deleted = append(plan.Deleted, task)
+	if err := plan.unblock(ctx, g); err != nil {
+		return plan, err
+	}
+	return plan, plan.notes(ctx, g, opts.Notes)
+}
+
+// descendants возвращает подзадачи subtasks вместе с их подзадачами всех уровней,
+// каждую - после её собственных подзадач
+func descendants(ctx context.Context, g TaskGraph, subtasks []model.Task) ([]model.Task, error) {
+	var all []model.Task
+	for _, subtask := range subtasks {
+		children, err := g.Subtasks(ctx, subtask.Id)
+		if err != nil {
+			return nil, err
+		}
+		below, err := descendants(ctx, g, children)
+		if err != nil {
+			return nil, err
+		}
+		all = append(append(all, below...), subtask)
+	}
+	return all, nil
+}
+
+// unblock добавляет в план снятие блокировок удаляемыми задачами с остающихся задач
+func (plan *DeletePlan) unblock(ctx context.Context, g TaskGraph) error {
+	deleted := func(id int) bool {
+		return slices.ContainsFunc(plan.Deleted, func(task model.Task) bool { return task.Id == id })
+	}
+	plan.Updated = slices.DeleteFunc(plan.Updated, func(change TaskChange) bool {
+		return deleted(change.After.Id)
+	})
+	for _, task := range plan.Deleted {
+		dependents, err := g.Dependents(ctx, task.Id)
+		if err != nil {
+			return err
+		}
+		for _, dependent := range dependents {
+			if deleted(dependent.Id) {
+				continue
+			}
+			i := slices.IndexFunc(plan.Updated, func(change TaskChange) bool { return change.After.Id == dependent.Id })
+			if i < 0 {
+				plan.Updated = append(plan.Updated, TaskChange{Before: dependent, After: dependent})
+				i = len(plan.Updated) - 1
+			}
+			plan.Updated[i].After.Unblock(task.Id)
+		}
+	}
+	return nil
+}
+
+// notes добавляет в план удаление или открепление заметок удаляемых задач по политике policy
+func (plan *DeletePlan) notes(ctx context.Context, g TaskGraph, policy NotesPolicy) error {
+	for _, task := range plan.Deleted {
+		notes, err := g.Notes(ctx, task.Id)
+		if err != nil {
+			return err
+		}
+		for _, note := range notes {
+			if policy == NotesDelete {
+				plan.DeletedNotes = append(plan.DeletedNotes, note)
+				continue
+			}
+			after := note
+			after.AttachTo(nil)
+			plan.DetachedNotes = append(plan.DetachedNotes, NoteChange{Before: note, After: after})
+		}
+	}
+	return nil
+}
+
+// CheckNote проверяет изменение заметки before -> after в графе g: версия заметки совпадает
+// с ожидаемой (см. CheckVersion), а задача, к которой прикрепляется заметка, существует
+func CheckNote(ctx context.Context, g TaskGraph, before, after model.Note) error {
+	if err := CheckVersion(ctx, before.Version); err != nil {
+		return err
+	}
+	if after.TaskId == nil || (before.TaskId != nil && *before.TaskId == *after.TaskId) {
+		return nil
+	}
+	_, err := related(ctx, g, *after.TaskId, "ctx.linked_task")
+	return err
+}
+
+// BuildTree возвращает дерево зависимостей задачи id: подзадачи всех уровней и у каждой
+// задачи - цепочки задач, которыми она заблокирована
+func BuildTree(ctx context.Context, g TaskGraph, id int) (model.TaskNode, error) {
+	task, err := g.Task(ctx, id)
+	if err != nil {
+		return model.TaskNode{}, err
+	}
+	return buildNode(ctx, g, task, true)
+}
+
+// buildNode строит узел дерева зависимостей задачи task, с подзадачами, если withSubtasks;
+// связи ацикличны, поэтому обход конечен
+func buildNode(ctx context.Context, g TaskGraph, task model.Task, withSubtasks bool) (model.TaskNode, error) {
+	node := model.TaskNode{Task: task, Subtasks: make([]model.TaskNode, 0), BlockedBy: make([]model.TaskNode, 0)}
+	if withSubtasks {
+		subtasks, err := g.Subtasks(ctx, task.Id)
+		if err != nil {
+			return node, err
+		}
+		for _, subtask := range subtasks {
+			child, err := buildNode(ctx, g, subtask, true)
+			if err != nil {
+				return node, err
+			}
+			node.Subtasks = append(node.Subtasks, child)
+		}
+	}
+	for _, id := range task.BlockedBy {
+		blocker, err := g.Task(ctx, id)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return node, err
+		}
+		child, err := buildNode(ctx, g, blocker, false)
+		if err != nil {
+			return node, err
+		}
+		node.BlockedBy = append(node.BlockedBy, child)
+	}
+	return node, nil
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// SearchFilter параметры полнотекстового поиска
+type SearchFilter struct {
+	Query  string
+	Type   string // task, note; пустое значение - по всем сущностям
+	Limit  int
+	Offset int
+}
+
+// SearchHit найденная задача или заметка с рангом и фрагментами текста,
+// в которых совпадения выделены тегами <b></b>
+type SearchHit struct {
+	Type               string  `json:"type"`
+	Id                 int     `json:"id"`
+	Name               string  `json:"name"`
+	Rank               float64 `json:"rank"`
+	NameSnippet        string  `json:"nameSnippet"`
+	DescriptionSnippet string  `json:"descriptionSnippet"`
+}
+
+// SearchPage страница результатов поиска, упорядоченных по убыванию ранга
+type SearchPage struct {
+	Items  []SearchHit `json:"items"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+// SnippetWords число слов описания, попадающих во фрагмент вокруг первого совпадения
+const SnippetWords = 25
+
+// Веса совпадений в названии и описании при ранжировании без поддержки БД
+const (
+	nameWeight        = 1.0
+	descriptionWeight = 0.4
+)
+
+// SortHits упорядочивает результаты по убыванию ранга и возвращает страницу [offset, offset+limit)
+func SortHits(hits []SearchHit, limit, offset int) []SearchHit {
+	slices.SortStableFunc(hits, func(a, b SearchHit) int {
+		switch {
+		case a.Rank > b.Rank:
+			return -1
+		case a.Rank < b.Rank:
+			return 1
+		case a.Type != b.Type:
+			return strings.Compare(a.Type, b.Type)
+		}
+		return a.Id - b.Id
+	})
+	if offset >= len(hits) {
+		return []SearchHit{}
+	}
+	return hits[offset:min(offset+limit, len(hits))]
+}
+
+// SearchTerms выделяет из запроса искомые слова, пропуская исключения вида -слово
+func SearchTerms(query string) []string {
+	var terms []string
+	for _, field := range strings.Fields(normalizeWord(query)) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		terms = append(terms, strings.FieldsFunc(field, isNotWordRune)...)
+	}
+	return terms
+}
+
+// MatchText ищет термины в названии и описании и возвращает результат с рангом и фрагментами;
+// ok=false, если совпадений нет
+func MatchText(entityType string, id int, name, description string, terms []string) (hit SearchHit, ok bool) {
+	nameSnippet, nameMatches := Highlight(name, terms, 0)
+	descrSnippet, descrMatches := Highlight(description, terms, SnippetWords)
+	if nameMatches+descrMatches == 0 {
+		return hit, false
+	}
+	return SearchHit{
+		Type:               entityType,
+		Id:                 id,
+		Name:               name,
+		Rank:               nameWeight*float64(nameMatches) + descriptionWeight*float64(descrMatches),
+		NameSnippet:        nameSnippet,
+		DescriptionSnippet: descrSnippet,
+	}, true
+}
+
+// normalizeWord приводит слово к нижнему регистру и заменяет "ё" на "е"
+func normalizeWord(word string) string {
+	return strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// matchesTerm приближённо сравнивает словоформы: слово совпадает с искомым,
+// если начинается с его основы (искомое слово без двух последних букв, но не короче трёх)
+func matchesTerm(word string, terms []string) bool {
+	word = normalizeWord(word)
+	for _, term := range terms {
+		stem := []rune(term)
+		if len(stem) > 4 {
+			stem = stem[:max(3, len(stem)-2)]
+		}
+		if strings.HasPrefix(word, string(stem)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Highlight выделяет совпадения в тексте тегами <b></b> и возвращает число совпадений.
+// При maxWords > 0 возвращает фрагмент не длиннее maxWords слов, начинающийся незадолго до первого совпадения
+func Highlight(text string, terms []string, maxWords int) (string, int) {
+	words := strings.Fields(text)
+	first, matches := -1, 0
+	for i, word := range words {
+		core := strings.TrimFunc(word, isNotWordRune)
+		if core == "" || !matchesTerm(core, terms) {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		matches++
+		words[i] = strings.Replace(word, core, "<b>"+core+"</b>", 1)
+	}
+
+	if maxWords <= 0 || len(words) <= maxWords {
+		return strings.Join(words, " "), matches
+	}
+	start := max(0, first-maxWords/4)
+	end := min(len(words), start+maxWords)
+	snippet := strings.Join(words[start:end], " ")
+	if start > 0 {
+		snippet = "... " + snippet
+	}
+	if end < len(words) {
+		snippet += " ..."
+	}
+	return snippet, matches
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+)
+
+// ShareTarget объект доступа: задача или заметка EntityId либо список List -
+// задачи и заметки владельца, отмеченные меткой List
+type ShareTarget struct {
+	EntityType string `json:"entityType"`
+	EntityId   int    `json:"entityId,omitempty"`
+	List       string `json:"list,omitempty"`
+}
+
+// Share доступ пользователя UserId с логином Login к объекту владельца OwnerId в роли Role
+type Share struct {
+	Id int `json:"id"`
+	ShareTarget
+	OwnerId   int        `json:"ownerId"`
+	UserId    int        `json:"userId"`
+	Login     string     `json:"login"`
+	Role      model.Role `json:"role"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// Covers проверяет, распространяется ли доступ на запись id типа entityType
+// владельца ownerId с метками tags
+func (s Share) Covers(entityType string, id, ownerId int, tags []string) bool {
+	if s.OwnerId != ownerId {
+		return false
+	}
+	if s.EntityType == EntityList {
+		return slices.Contains(tags, s.List)
+	}
+	return s.EntityType == entityType && s.EntityId == id
+}
+
+// GrantedRole возвращает старшую роль, выданную доступами shares на запись id типа entityType
+// владельца ownerId с метками tags; пустая роль означает отсутствие доступа
+func GrantedRole(shares []Share, entityType string, id, ownerId int, tags []string) model.Role {
+	var role model.Role
+	for _, share := range shares {
+		if share.Covers(entityType, id, ownerId, tags) {
+			role = model.MaxRole(role, share.Role)
+		}
+	}
+	return role
+}
+
+// ShareStore доступы пользователей к чужим задачам, заметкам и спискам.
+// Записи, к которым пользователю из ctx выдан доступ, видны ему в TaskStore и NoteStore
+// наравне с собственными; права роли проверяют обработчики API
+type ShareStore interface {
+	// Share выдаёт доступ share.UserId к объекту владельца из ctx; повторная выдача тому же
+	// пользователю заменяет роль. Фиксируется в журнале действием ActionShare
+	Share(ctx context.Context, share Share) (Share, error)
+	// Unshare отзывает доступ пользователя userId к target владельца из ctx и возвращает отозванный доступ.
+	// Фиксируется в журнале действием ActionUnshare
+	Unshare(ctx context.Context, target ShareTarget, userId int) (Share, error)
+	// Shares возвращает доступы к target, выданные владельцем из ctx, в порядке Id
+	Shares(ctx context.Context, target ShareTarget) ([]Share, error)
+	// SharedWith возвращает доступы, выданные пользователю из ctx владельцем ownerId
+	SharedWith(ctx context.Context, ownerId int) ([]Share, error)
+}
@@ -0,0 +1,207 @@
+// Package storage описывает хранилища задач и заметок, не зависящие от конкретной БД.
+// Реализации находятся во вложенных пакетах memory, file, mongo и postgres
+package storage
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+)
+
+var (
+	// ErrNotFound запись с запрошенным Id не существует
+	ErrNotFound = i18n.New("err.not_found")
+	// ErrDuplicateName запись с таким именем уже существует
+	ErrDuplicateName = i18n.New("err.duplicate_name")
+	// ErrConflict запись изменена параллельным запросом
+	ErrConflict = i18n.New("err.conflict")
+	// ErrInvalidCursor курсор не разобран или не соответствует порядку сортировки
+	ErrInvalidCursor = i18n.New("err.invalid_cursor")
+	// ErrPreconditionFailed версия записи не совпадает с ожидаемой клиентом (см. WithExpectedVersion)
+	ErrPreconditionFailed = i18n.New("err.precondition_failed")
+)
+
+// TaskFilter параметры отбора, сортировки и пагинации задач
+type TaskFilter struct {
+	Status   model.Status
+	DueFrom  time.Time
+	DueTo    time.Time
+	Name     string
+	Priority []model.Priority // любой из перечисленных приоритетов
+	Tags     []string         // все перечисленные метки
+	Sort     string           // id, name, dueDate; префикс "-" - по убыванию
+	Limit    int
+	Cursor   string
+}
+
+// NoteFilter параметры отбора, сортировки и пагинации заметок
+type NoteFilter struct {
+	AlarmFrom time.Time
+	AlarmTo   time.Time
+	Name      string
+	Priority  []model.Priority // любой из перечисленных приоритетов
+	Tags      []string         // все перечисленные метки
+	Sort      string           // id, name, alarmTimeStamp; префикс "-" - по убыванию
+	Limit     int
+	Cursor    string
+}
+
+// Page страница списка с курсором на следующую страницу
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// TaskStore хранилище задач.
+// Изменяющие методы фиксируют изменение в журнале; автор изменения передаётся в ctx через WithActor.
+// Все методы видят только записи владельца из ctx (см. WithOwner) и записи, к которым ему выдан
+// доступ (см. ShareStore); новые записи получают этого владельца.
+// Создание записи назначает ей версию 1, каждое изменение увеличивает версию на 1;
+// изменение и удаление записи проверяют её версию через CheckVersion
+type TaskStore interface {
+	// ListTasks возвращает страницу задач, отобранных и упорядоченных согласно filter
+	ListTasks(ctx context.Context, filter TaskFilter) (Page[model.Task], error)
+	// GetTask возвращает задачу по Id
+	GetTask(ctx context.Context, id int) (model.Task, error)
+	// CreateTask сохраняет новую задачу и возвращает её с назначенными Id и временными метками
+	CreateTask(ctx context.Context, task model.Task) (model.Task, error)
+	// UpdateTask применяет к задаче с Id изменения change и возвращает сохранённую задачу;
+	// смена статуса фиксируется в истории статусов, откладывание напоминания - в истории откладываний.
+	// Связи с другими задачами проверяются через CheckTask
+	UpdateTask(ctx context.Context, id int, change func(task *model.Task) error) (model.Task, error)
+	// DeleteTask перемещает в корзину задачу по Id с подзадачами и прикреплёнными заметками
+	// согласно политикам opts (см. PlanDelete) и возвращает её последнее состояние
+	DeleteTask(ctx context.Context, id int, opts DeleteOptions) (model.Task, error)
+	// TaskHistory возвращает историю статусов задачи от ранних изменений к поздним,
+	// начиная со статуса, присвоенного при создании
+	TaskHistory(ctx context.Context, id int) ([]model.StatusChange, error)
+	// TaskSnoozes возвращает историю откладывания напоминаний о сроке задачи от ранних к поздним
+	TaskSnoozes(ctx context.Context, id int) ([]model.Snooze, error)
+	// TaskTree возвращает дерево зависимостей задачи (см. BuildTree)
+	TaskTree(ctx context.Context, id int) (model.TaskNode, error)
+}
+
+// NoteStore хранилище заметок; соглашения те же, что у TaskStore
+type NoteStore interface {
+	// ListNotes возвращает страницу заметок, отобранных и упорядоченных согласно filter
+	ListNotes(ctx context.Context, filter NoteFilter) (Page[model.Note], error)
+	// GetNote возвращает заметку по Id
+	GetNote(ctx context.Context, id int) (model.Note, error)
+	// CreateNote сохраняет новую заметку и возвращает её с назначенными Id и временными метками
+	CreateNote(ctx context.Context, note model.Note) (model.Note, error)
+	// UpdateNote применяет к заметке с Id изменения change и возвращает сохранённую заметку;
+	// откладывание напоминания фиксируется в истории откладываний.
+	// Задача, к которой прикрепляется заметка, проверяется через CheckNote
+	UpdateNote(ctx context.Context, id int, change func(note *model.Note) error) (model.Note, error)
+	// DeleteNote перемещает заметку по Id в корзину и возвращает её последнее состояние
+	DeleteNote(ctx context.Context, id int) (model.Note, error)
+	// NoteSnoozes возвращает историю откладывания напоминаний заметки от ранних к поздним
+	NoteSnoozes(ctx context.Context, id int) ([]model.Snooze, error)
+	// TaskNotes возвращает заметки, прикреплённые к задаче taskId, в порядке Id
+	TaskNotes(ctx context.Context, taskId int) ([]model.Note, error)
+}
+
+// LogReader журнал изменений задач и заметок
+type LogReader interface {
+	// ReadLog возвращает страницу записей журнала, отобранных согласно filter, от новых к старым
+	ReadLog(ctx context.Context, filter LogFilter) (LogPage, error)
+}
+
+// Searcher полнотекстовый поиск по задачам и заметкам
+type Searcher interface {
+	// Search возвращает страницу результатов, упорядоченных по убыванию ранга
+	Search(ctx context.Context, filter SearchFilter) (SearchPage, error)
+}
+
+// Delivery отметка о доставке напоминания о задаче или заметке, назначенного на момент FireAt
+type Delivery struct {
+	EntityType  string    `json:"entityType"`
+	EntityId    int       `json:"entityId"`
+	FireAt      time.Time `json:"fireAt"`
+	DeliveredAt time.Time `json:"deliveredAt"`
+}
+
+// DeliveryStore отметки о доставленных напоминаниях; по ним диспетчер напоминаний
+// не доставляет напоминание повторно после перезапуска
+type DeliveryStore interface {
+	// Delivered сообщает, отмечено ли напоминание о сущности на момент fireAt доставленным
+	Delivered(ctx context.Context, entityType string, entityId int, fireAt time.Time) (bool, error)
+	// MarkDelivered отмечает напоминание доставленным; повторная отметка не считается ошибкой
+	MarkDelivered(ctx context.Context, delivery Delivery) error
+}
+
+// Store хранилище задач и заметок, выбираемое при запуске приложения
+type Store interface {
+	TaskStore
+	NoteStore
+	LogReader
+	Searcher
+	DeliveryStore
+	UserStore
+	ShareStore
+	TrashStore
+	// Close освобождает ресурсы хранилища
+	Close(ctx context.Context) error
+}
+
+type actorKey struct{}
+
+// WithActor возвращает контекст с автором изменений для журнала
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom возвращает автора изменений из контекста
+func ActorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return "unknown"
+}
+
+type ownerKey struct{}
+
+// WithOwner возвращает контекст пользователя userId: хранилище видит в нём только записи
+// этого пользователя и назначает его владельцем новых записей
+func WithOwner(ctx context.Context, userId int) context.Context {
+	return context.WithValue(ctx, ownerKey{}, userId)
+}
+
+// OwnerFrom возвращает Id владельца из контекста; 0 означает контекст внутренних служб
+// (напр. диспетчера напоминаний), в котором видны записи всех пользователей
+func OwnerFrom(ctx context.Context) int {
+	owner, _ := ctx.Value(ownerKey{}).(int)
+	return owner
+}
+
+// Owns проверяет, принадлежит ли в контексте ctx запись владельца ownerId пользователю из ctx;
+// в контексте внутренних служб принадлежащими считаются все записи
+func Owns(ctx context.Context, ownerId int) bool {
+	owner := OwnerFrom(ctx)
+	return owner == 0 || owner == ownerId
+}
+
+type versionKey struct{}
+
+// WithExpectedVersion возвращает контекст изменения записи, которое выполняется, только если
+// текущая версия записи - одна из versions (см. CheckVersion). Без ожидаемых версий
+// запись изменяется независимо от версии
+func WithExpectedVersion(ctx context.Context, versions ...int) context.Context {
+	if len(versions) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, versionKey{}, versions)
+}
+
+// CheckVersion проверяет текущую версию version изменяемой записи по ожидаемым версиям из ctx;
+// несовпадение возвращается как ErrPreconditionFailed
+func CheckVersion(ctx context.Context, version int) error {
+	versions, ok := ctx.Value(versionKey{}).([]int)
+	if !ok || slices.Contains(versions, version) {
+		return nil
+	}
+	return ErrPreconditionFailed
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []int
+		version  int
+		err      error
+	}{
+		{name: "no expected version", expected: nil, version: 3},
+		{name: "matching version", expected: []int{3}, version: 3},
+		{name: "one of expected versions", expected: []int{1, 2, 3}, version: 2},
+		{name: "stale version", expected: []int{2}, version: 3, err: ErrPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := WithExpectedVersion(context.Background(), tt.expected...)
+
+			assert.ErrorIs(t, CheckVersion(ctx, tt.version), tt.err)
+		})
+	}
+}
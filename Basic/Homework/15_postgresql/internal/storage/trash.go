@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+)
+
+// Trash содержимое корзины: задачи и заметки от недавно удалённых к давним
+type Trash struct {
+	Tasks []model.Task `json:"tasks"`
+	Notes []model.Note `json:"notes"`
+}
+
+// TrashStore корзина удалённых задач и заметок.
+// DeleteTask и DeleteNote не удаляют записи, а отмечают их DeletedAt: такие записи не видны остальным
+// методам хранилища, но сохраняют историю статусов и откладываний и могут быть восстановлены.
+// Корзина видна только владельцу записей из ctx; изменения фиксируются в журнале
+type TrashStore interface {
+	// Trash возвращает содержимое корзины
+	Trash(ctx context.Context) (Trash, error)
+	// RestoreTask восстанавливает задачу из корзины (см. RestoredTask) и возвращает её
+	RestoreTask(ctx context.Context, id int) (model.Task, error)
+	// RestoreNote восстанавливает заметку из корзины (см. RestoredNote) и возвращает её
+	RestoreNote(ctx context.Context, id int) (model.Note, error)
+	// PurgeTask окончательно удаляет задачу из корзины вместе с историей и выданными к ней доступами;
+	// ссылки на неё остающихся в корзине подзадач и заметок снимаются
+	PurgeTask(ctx context.Context, id int) (model.Task, error)
+	// PurgeNote окончательно удаляет заметку из корзины; соглашения те же, что у PurgeTask
+	PurgeNote(ctx context.Context, id int) (model.Note, error)
+	// PurgeTrash окончательно удаляет записи, перемещённые в корзину раньше before,
+	// и возвращает их число
+	PurgeTrash(ctx context.Context, before time.Time) (int, error)
+}
+
+// RestoredTask возвращает задачу task, восстанавливаемую из корзины: связи с задачами,
+// которые за это время удалены, снимаются, а отметка DeletedAt сбрасывается
+func RestoredTask(ctx context.Context, g TaskGraph, task model.Task) (model.Task, error) {
+	if task.ParentId != nil {
+		ok, err := taskExists(ctx, g, *task.ParentId)
+		if err != nil {
+			return task, err
+		}
+		if !ok {
+			task.ParentId = nil
+		}
+	}
+	blockedBy := make([]int, 0, len(task.BlockedBy))
+	for _, id := range task.BlockedBy {
+		ok, err := taskExists(ctx, g, id)
+		if err != nil {
+			return task, err
+		}
+		if ok {
+			blockedBy = append(blockedBy, id)
+		}
+	}
+	task.BlockedBy = slices.Clip(blockedBy)
+	task.DeletedAt = nil
+	return task, nil
+}
+
+// RestoredNote возвращает заметку note, восстанавливаемую из корзины; соглашения те же, что у RestoredTask
+func RestoredNote(ctx context.Context, g TaskGraph, note model.Note) (model.Note, error) {
+	if note.TaskId != nil {
+		ok, err := taskExists(ctx, g, *note.TaskId)
+		if err != nil {
+			return note, err
+		}
+		if !ok {
+			note.TaskId = nil
+		}
+	}
+	note.DeletedAt = nil
+	return note, nil
+}
+
+// taskExists проверяет, видна ли задача id вне корзины
+func taskExists(ctx context.Context, g TaskGraph, id int) (bool, error) {
+	_, err := g.Task(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Trashed проверяет, находится ли в корзине владельца из ctx запись владельца ownerId с отметкой deletedAt
+func Trashed(ctx context.Context, ownerId int, deletedAt *time.Time) bool {
+	return deletedAt != nil && Owns(ctx, ownerId)
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+)
+
+// Account учётная запись пользователя с хешем пароля; хеш не передаётся клиентам API
+type Account struct {
+	model.User
+	PasswordHash string `json:"passwordHash"`
+}
+
+// Session сессия пользователя, открытая по токену доступа; хранится только хеш токена
+type Session struct {
+	TokenHash string    `json:"tokenHash"`
+	UserId    int       `json:"userId"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// UserStore хранилище учётных записей и сессий пользователей.
+// Учётные записи и сессии не принадлежат владельцам и не фиксируются в журнале
+type UserStore interface {
+	// CreateUser сохраняет новую учётную запись и возвращает её с назначенными Id и датой
+	// регистрации; занятый логин возвращается как ErrDuplicateName.
+	// Первый зарегистрированный пользователь становится владельцем задач, заметок и записей журнала,
+	// созданных до появления пользователей
+	CreateUser(ctx context.Context, account Account) (Account, error)
+	// UserByLogin возвращает учётную запись по логину
+	UserByLogin(ctx context.Context, login string) (Account, error)
+	// GetUser возвращает пользователя по Id
+	GetUser(ctx context.Context, id int) (model.User, error)
+	// CreateSession сохраняет новую сессию; сессии с истёкшим сроком при этом могут быть удалены
+	CreateSession(ctx context.Context, session Session) error
+	// GetSession возвращает сессию по хешу токена
+	GetSession(ctx context.Context, tokenHash string) (Session, error)
+	// DeleteSession удаляет сессию по хешу токена; отсутствие сессии ошибкой не считается
+	DeleteSession(ctx context.Context, tokenHash string) error
+}
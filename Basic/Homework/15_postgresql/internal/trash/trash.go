@@ -0,0 +1,63 @@
+// Package trash окончательно удаляет задачи и заметки, пролежавшие в корзине дольше срока хранения
+package trash
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+)
+
+// Actor автор окончательных удалений по истечении срока хранения
+const Actor = "trash"
+
+// Purger периодическая очистка корзины
+type Purger struct {
+	store storage.TrashStore
+	// retention срок хранения записей в корзине
+	retention time.Duration
+	// interval период между очистками
+	interval time.Duration
+	// timeout предельное время одной очистки
+	timeout time.Duration
+}
+
+// New создаёт очистку корзины хранилища store: раз в interval записи, перемещённые в корзину
+// раньше чем retention назад, удаляются окончательно; одна очистка длится не дольше timeout
+func New(store storage.TrashStore, retention, interval, timeout time.Duration) *Purger {
+	return &Purger{store: store, retention: retention, interval: interval, timeout: timeout}
+}
+
+// Run очищает корзину сразу и затем раз в interval до отмены ctx.
+// Очистка выполняется в контексте без владельца и затрагивает корзины всех пользователей
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.Purge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge однократно удаляет записи с истёкшим сроком хранения; ошибка записывается в журнал,
+// а оставшиеся записи удаляются при следующей очистке
+func (p *Purger) Purge(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(storage.WithActor(ctx, Actor), p.timeout)
+	defer cancel()
+
+	before := time.Now().UTC().Add(-p.retention)
+	purged, err := p.store.PurgeTrash(ctx, before)
+	switch {
+	case err != nil && ctx.Err() == nil:
+		slog.Error("purge trash", "before", before, "purged", purged, "error", err)
+	case err != nil:
+		slog.Warn("purge trash interrupted", "before", before, "purged", purged, "error", err)
+	case purged > 0:
+		slog.Info("trash purged", "before", before, "purged", purged)
+	}
+}
@@ -2,91 +2,352 @@ package main
 
 import (
 	"context"
-	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
 	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+	_ "time/tzdata" // база часовых поясов для образов без системной tzdata
 
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/12_gRPC/proto_api/pkg/grpc/v1/remindables_api"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/access"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/auth"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/config"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/grpcapi"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/i18n"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/model"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/problem"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/reminder"
 	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/repository"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/trash"
 	"github.com/gin-gonic/gin"
-	_ "github.com/jackc/pgx/v5/stdlib"
-	"github.com/pressly/goose/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
 	ctx := context.Background()
 
-	// Подключаемся к PostgreSQL
-	dsn := "host=localhost port=5432 user=otus_user password=otus_password dbname=remindables sslmode=disable"
-	db, err := sql.Open("pgx", dsn)
-	if err != nil {
-		slog.Error("connect db", "error", err)
+	// Загрузка конфигурации: значения по умолчанию < файл < окружение < флаги
+	cfg, err := config.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
 		return
 	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	slog.SetDefault(cfg.Log.Logger(os.Stderr))
+	slog.Info("config loaded",
+		"storage", cfg.Storage.Backend,
+		"http", cfg.HTTP.Addr,
+		"grpc", cfg.GRPC.Addr,
+	)
+
+	// run возвращает управление после отложенной очистки, поэтому выход с ошибкой её не пропускает
+	if err := run(ctx, cfg); err != nil {
+		slog.Error("server stopped with error", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run запускает серверы с хранилищем и фоновыми задачами по конфигурации cfg и ожидает
+// сигнала завершения. Возвращает ошибку, если не удалось запустить сервис или один из серверов упал
+func run(ctx context.Context, cfg config.Config) error {
+	store, err := openStore(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("open %s storage: %w", cfg.Storage.Backend, err)
+	}
 
 	defer func() {
-		err := db.Close()
+		err := store.Close(ctx)
 		if err != nil {
-			slog.Error("close db", "error", err)
+			slog.Error("close storage", "error", err)
 		}
 	}()
 
-	if err := db.PingContext(ctx); err != nil {
-		slog.Error("ping db", "error", err)
-		return
-	}
+	// Язык ответов и часовой пояс для клиентов, не передавших Accept-Language и X-Timezone;
+	// часовой пояс уже проверен в cfg.Validate
+	lang := i18n.Lang(cfg.Locale.Default)
+	loc, _ := time.LoadLocation(cfg.Locale.Timezone)
 
-	// Накатываем миграции
-	if err := goose.SetDialect("postgres"); err != nil {
-		slog.Error("cannot set dialect", "error", err)
-		return
+	// Диспетчер напоминаний: очередь восстанавливается из хранилища, а изменения задач
+	// и заметок через store перепланируют их напоминания
+	remindCtx, stopReminders := context.WithCancel(context.Background())
+	var reminders sync.WaitGroup
+	defer func() {
+		stopReminders()
+		reminders.Wait()
+	}()
+	if cfg.Reminders.Enabled {
+		notifiers, err := reminder.Notifiers(cfg.Reminders, os.Stdout)
+		if err != nil {
+			return fmt.Errorf("reminder notifiers: %w", err)
+		}
+		dispatcher := reminder.New(store, notifiers, reminder.Options{
+			Timeout:  cfg.Reminders.Timeout,
+			RetryMin: cfg.Reminders.RetryMin,
+			RetryMax: cfg.Reminders.RetryMax,
+			CatchUp:  cfg.Reminders.CatchUp,
+			Lang:     lang,
+			Location: loc,
+		})
+		store = reminder.Watch(store, dispatcher)
+		reminders.Go(func() {
+			if err := dispatcher.Load(remindCtx); err != nil {
+				slog.Error("load reminders", "error", err)
+			}
+			dispatcher.Run(remindCtx)
+		})
+		slog.Info("reminders enabled", "notifiers", cfg.Reminders.NotifierList())
 	}
 
-	const migrate = "./migrations"
+	// Очистка корзины: удалённые задачи и заметки хранятся trash.retention и затем удаляются окончательно
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	var purger sync.WaitGroup
+	purger.Go(func() {
+		trash.New(store, cfg.Trash.Retention, cfg.Trash.PurgeInterval, cfg.Timeouts.Write).Run(purgeCtx)
+	})
+	defer func() {
+		stopPurge()
+		purger.Wait()
+	}()
 
-	if err := goose.UpContext(ctx, db, migrate); err != nil {
-		slog.Error("cannot do up migration", "error", err)
-		return
+	// Учётные записи и токены доступа; задачи и заметки видны только их владельцу и пользователям,
+	// которым он выдал доступ, а журнал - только владельцу
+	accounts := auth.New(store, cfg.Auth.TokenTTL)
+	acl := access.New(store)
+
+	// Запуск gRPC-сервера
+	lis, err := net.Listen("tcp", cfg.GRPC.Addr)
+	if err != nil {
+		return fmt.Errorf("gRPC listen on %s: %w", cfg.GRPC.Addr, err)
 	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcapi.LoggingUnaryInterceptor,
+			grpcapi.RecoveryUnaryInterceptor,
+			grpcapi.LocaleUnaryInterceptor(lang, loc),
+			grpcapi.AuthUnaryInterceptor(accounts, cfg.Timeouts.Read),
+		),
+		grpc.ChainStreamInterceptor(
+			grpcapi.LoggingStreamInterceptor,
+			grpcapi.RecoveryStreamInterceptor,
+			grpcapi.LocaleStreamInterceptor(lang, loc),
+			grpcapi.AuthStreamInterceptor(accounts, cfg.Timeouts.Read),
+		),
+	)
+	remindables_api.RegisterRemindablesServiceServer(s, grpcapi.NewServer(store, store, store, accounts, acl, cfg.Timeouts))
+	reflection.Register(s)
+	serveErr := make(chan error, 2)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			serveErr <- fmt.Errorf("gRPC: %w", err)
+		}
+	}()
 
-	// Создаём роутер
-	r := gin.Default()
+	// Создаём роутер; паника в обработчике превращается в ответ 500 application/problem+json
+	r := gin.New()
+	r.Use(gin.Logger(), problem.Recovery(), repository.Locale(lang, loc))
+	r.NoRoute(func(c *gin.Context) {
+		problem.Write(c, http.StatusNotFound, problem.CodeNotFound, problem.Message(c, "err.route_not_found"))
+	})
 	api := r.Group("/api")
-	apiTasks := api.Group("/tasks")
-	apiNotes := api.Group("/notes")
+	apiAuth := api.Group("/auth")
+
+	// Остальные группы требуют заголовок Authorization: Bearer <токен>
+	private := api.Group("", repository.Authenticate(cfg.Timeouts.Read, accounts))
+	apiMe := private.Group("/auth")
+	apiTasks := private.Group("/tasks")
+	apiNotes := private.Group("/notes")
+	apiLists := private.Group("/lists")
+	apiTrash := private.Group("/trash")
 
 	// Endpoints
 
+	// /api/auth/register с телом {"login": "alice", "password": "..."}
+	apiAuth.POST("register", repository.Register(cfg.Timeouts.Write, accounts))
+
+	// /api/auth/login с телом {"login": "alice", "password": "..."}
+	apiAuth.POST("login", repository.Login(cfg.Timeouts.Write, accounts))
+
+	// /api/auth/logout
+	apiMe.POST("logout", repository.Logout(cfg.Timeouts.Write, accounts))
+
+	// /api/auth/me
+	apiMe.GET("me", repository.GetMe())
+
 	// /api/tasks/items
-	apiTasks.GET("items", repository.GetTasks(ctx, db))
+	apiTasks.GET("items", repository.GetTasks(cfg.Timeouts.Read, store))
 
 	// /api/tasks/item/id/?id=<id_integer_number>
-	apiTasks.GET("item/id", repository.GetTasksById(ctx, db))
+	apiTasks.GET("item/id", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleViewer), repository.GetTasksById(cfg.Timeouts.Read, store, store))
 
 	// /api/notes/items
-	apiNotes.GET("items", repository.GetNotes(ctx, db))
+	apiNotes.GET("items", repository.GetNotes(cfg.Timeouts.Read, store))
 
 	// /api/notes/item/id/?id=<id_integer_number>
-	apiNotes.GET("item/id", repository.GetNotesById(ctx, db))
+	apiNotes.GET("item/id", repository.Require(cfg.Timeouts.Read, acl, storage.EntityNote, model.RoleViewer), repository.GetNotesById(cfg.Timeouts.Read, store))
 
 	// /api/tasks/item
-	apiTasks.POST("item", repository.PostNewTask(ctx, db))
+	apiTasks.POST("item", repository.PostNewTask(cfg.Timeouts.Write, store))
 
 	// /api/notes/item
-	apiNotes.POST("item", repository.PostNewNote(ctx, db))
+	apiNotes.POST("item", repository.PostNewNote(cfg.Timeouts.Write, store))
 
 	// /api/tasks/item/id/?id=<id_integer_number>
-	apiTasks.PUT("item/id", repository.PutTaskById(ctx, db))
+	apiTasks.PUT("item/id", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleEditor), repository.PutTaskById(cfg.Timeouts.Write, store))
 
 	// /api/notes/item/id/?id=<id_integer_number>
-	apiNotes.PUT("item/id", repository.PutNoteById(ctx, db))
+	apiNotes.PUT("item/id", repository.Require(cfg.Timeouts.Read, acl, storage.EntityNote, model.RoleEditor), repository.PutNoteById(cfg.Timeouts.Write, store))
 
-	// /api/tasks/item/id/?id=<id_integer_number>
-	apiTasks.DELETE("item/id", repository.DeleteTaskById(ctx, db))
+	// /api/tasks/item/id/?id=<id_integer_number>, тело - application/merge-patch+json или application/json-patch+json
+	apiTasks.PATCH("item/id", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleEditor), repository.PatchTaskById(cfg.Timeouts.Write, store))
+
+	// /api/notes/item/id/?id=<id_integer_number>, тело - application/merge-patch+json или application/json-patch+json
+	apiNotes.PATCH("item/id", repository.Require(cfg.Timeouts.Read, acl, storage.EntityNote, model.RoleEditor), repository.PatchNoteById(cfg.Timeouts.Write, store))
+
+	// /api/tasks/item/id/?id=<id_integer_number>&cascade=<restrict|cascade|orphan>&notes=<detach|delete>
+	apiTasks.DELETE("item/id", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleOwner), repository.DeleteTaskById(cfg.Timeouts.Write, store))
 
 	// /api/notes/item/id/?id=<id_integer_number>
-	apiNotes.DELETE("item/id", repository.DeleteNoteById(ctx, db))
+	apiNotes.DELETE("item/id", repository.Require(cfg.Timeouts.Read, acl, storage.EntityNote, model.RoleOwner), repository.DeleteNoteById(cfg.Timeouts.Write, store))
+
+	// /api/tasks/<id>/transition
+	apiTasks.POST(":id/transition", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleEditor), repository.TransitionTask(cfg.Timeouts.Write, store))
+
+	// /api/tasks/<id>/history
+	apiTasks.GET(":id/history", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleViewer), repository.GetTaskHistory(cfg.Timeouts.Read, store))
 
-	// Запуск сервера на :8080
-	if err := r.Run(":8080"); err != nil {
-		panic(err)
+	// /api/tasks/<id>/occurrences?from=<date>&to=<date>&limit=<n>
+	apiTasks.GET(":id/occurrences", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleViewer), repository.GetTaskOccurrences(cfg.Timeouts.Read, store))
+
+	// /api/notes/<id>/occurrences?from=<date>&to=<date>&limit=<n>
+	apiNotes.GET(":id/occurrences", repository.Require(cfg.Timeouts.Read, acl, storage.EntityNote, model.RoleViewer), repository.GetNoteOccurrences(cfg.Timeouts.Read, store))
+
+	// /api/tasks/<id>/snooze с телом {"for": "15m"} или {"until": "завтра 9:00"}
+	apiTasks.POST(":id/snooze", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleEditor), repository.SnoozeTask(cfg.Timeouts.Write, store))
+
+	// /api/tasks/<id>/acknowledge
+	apiTasks.POST(":id/acknowledge", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleEditor), repository.AcknowledgeTask(cfg.Timeouts.Write, store))
+
+	// /api/tasks/<id>/dismiss
+	apiTasks.POST(":id/dismiss", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleEditor), repository.DismissTask(cfg.Timeouts.Write, store))
+
+	// /api/tasks/<id>/snoozes
+	apiTasks.GET(":id/snoozes", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleViewer), repository.GetTaskSnoozes(cfg.Timeouts.Read, store))
+
+	// /api/notes/<id>/snooze с телом {"for": "15m"} или {"until": "завтра 9:00"}
+	apiNotes.POST(":id/snooze", repository.Require(cfg.Timeouts.Read, acl, storage.EntityNote, model.RoleEditor), repository.SnoozeNote(cfg.Timeouts.Write, store))
+
+	// /api/notes/<id>/acknowledge
+	apiNotes.POST(":id/acknowledge", repository.Require(cfg.Timeouts.Read, acl, storage.EntityNote, model.RoleEditor), repository.AcknowledgeNote(cfg.Timeouts.Write, store))
+
+	// /api/notes/<id>/dismiss
+	apiNotes.POST(":id/dismiss", repository.Require(cfg.Timeouts.Read, acl, storage.EntityNote, model.RoleEditor), repository.DismissNote(cfg.Timeouts.Write, store))
+
+	// /api/notes/<id>/snoozes
+	apiNotes.GET(":id/snoozes", repository.Require(cfg.Timeouts.Read, acl, storage.EntityNote, model.RoleViewer), repository.GetNoteSnoozes(cfg.Timeouts.Read, store))
+
+	// /api/tasks/<id>/parent с телом {"parentId": <id>} или {"parentId": null}
+	apiTasks.PUT(":id/parent", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleEditor), repository.SetTaskParent(cfg.Timeouts.Write, store))
+
+	// /api/tasks/<id>/blockers с телом {"id": <id>}
+	apiTasks.POST(":id/blockers", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleEditor), repository.AddTaskBlocker(cfg.Timeouts.Write, store))
+
+	// /api/tasks/<id>/blockers/<blockerId>
+	apiTasks.DELETE(":id/blockers/:blockerId", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleEditor), repository.RemoveTaskBlocker(cfg.Timeouts.Write, store))
+
+	// /api/tasks/<id>/tree
+	apiTasks.GET(":id/tree", repository.Require(cfg.Timeouts.Read, acl, storage.EntityTask, model.RoleViewer), repository.GetTaskTree(cfg.Timeouts.Read, store))
+
+	// /api/notes/<id>/task с телом {"taskId": <id>} или {"taskId": null}
+	apiNotes.PUT(":id/task", repository.Require(cfg.Timeouts.Read, acl, storage.EntityNote, model.RoleEditor), repository.SetNoteTask(cfg.Timeouts.Write, store))
+
+	// /api/tasks/<id>/shares с телом {"login": "bob", "role": "viewer"}
+	apiTasks.POST(":id/shares", repository.PostShare(cfg.Timeouts.Write, acl, storage.EntityTask))
+	apiTasks.GET(":id/shares", repository.GetShares(cfg.Timeouts.Read, acl, storage.EntityTask))
+
+	// /api/tasks/<id>/shares/<login>
+	apiTasks.DELETE(":id/shares/:login", repository.DeleteShare(cfg.Timeouts.Write, acl, storage.EntityTask))
+
+	// /api/notes/<id>/shares с телом {"login": "bob", "role": "viewer"}
+	apiNotes.POST(":id/shares", repository.PostShare(cfg.Timeouts.Write, acl, storage.EntityNote))
+	apiNotes.GET(":id/shares", repository.GetShares(cfg.Timeouts.Read, acl, storage.EntityNote))
+
+	// /api/notes/<id>/shares/<login>
+	apiNotes.DELETE(":id/shares/:login", repository.DeleteShare(cfg.Timeouts.Write, acl, storage.EntityNote))
+
+	// /api/lists/<list>/shares с телом {"login": "bob", "role": "editor"}; список - задачи и заметки с меткой <list>
+	apiLists.POST(":list/shares", repository.PostShare(cfg.Timeouts.Write, acl, storage.EntityList))
+	apiLists.GET(":list/shares", repository.GetShares(cfg.Timeouts.Read, acl, storage.EntityList))
+
+	// /api/lists/<list>/shares/<login>
+	apiLists.DELETE(":list/shares/:login", repository.DeleteShare(cfg.Timeouts.Write, acl, storage.EntityList))
+
+	// /api/trash
+	apiTrash.GET("", repository.GetTrash(cfg.Timeouts.Read, store))
+
+	// /api/trash/tasks/<id>/restore
+	apiTrash.POST("tasks/:id/restore", repository.RestoreTask(cfg.Timeouts.Write, store))
+
+	// /api/trash/notes/<id>/restore
+	apiTrash.POST("notes/:id/restore", repository.RestoreNote(cfg.Timeouts.Write, store))
+
+	// /api/trash/tasks/<id>
+	apiTrash.DELETE("tasks/:id", repository.PurgeTask(cfg.Timeouts.Write, store))
+
+	// /api/trash/notes/<id>
+	apiTrash.DELETE("notes/:id", repository.PurgeNote(cfg.Timeouts.Write, store))
+
+	// /api/log?entity=<task|note|list>&entity_id=<id>&action=<create|update|delete|share|unshare|restore|purge>&from=<RFC3339>&to=<RFC3339>&limit=<n>&offset=<n>
+	private.GET("log", repository.GetLog(cfg.Timeouts.Search, store))
+
+	// /api/search?q=<query>&type=<task|note>&limit=<n>&offset=<n>
+	private.GET("search", repository.GetSearch(cfg.Timeouts.Search, store))
+
+	// Запуск HTTP-сервера
+	srv := &http.Server{Addr: cfg.HTTP.Addr, Handler: r}
+	go func() {
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("HTTP: %w", err)
+		}
+	}()
+
+	// Ожидание SIGINT/SIGTERM или ошибки одного из серверов
+	sigCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	var failed error
+	select {
+	case failed = <-serveErr:
+		slog.Error("server failed", "error", failed)
+	case <-sigCtx.Done():
+		slog.Info("shutting down", "timeout", cfg.Shutdown.Timeout)
 	}
+	stop()
+
+	// Серверы перестают принимать новые запросы и дожидаются текущих не дольше shutdown.timeout;
+	// затем останавливается диспетчер напоминаний, хранилище закрывается последним отложенным вызовом store.Close
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Shutdown.Timeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("HTTP shutdown timed out, closing connections", "error", err)
+			_ = srv.Close()
+		}
+	})
+	wg.Go(func() {
+		grpcapi.GracefulStop(shutdownCtx, s)
+	})
+	wg.Wait()
+	slog.Info("servers stopped")
+	return failed
 }
@@ -0,0 +1,123 @@
+-- +goose Up
+CREATE table IF NOT EXISTS tasks_relational (
+    id              serial primary key,
+    name            text not null,
+    description     text not null default '',
+    due_date        timestamptz not null,
+    status          text not null,
+    created_at      timestamptz not null default now(),
+    updated_at      timestamptz
+);
+
+CREATE table IF NOT EXISTS notes_relational (
+    id              serial primary key,
+    name            text not null,
+    description     text not null default '',
+    alarm_at        timestamptz not null,
+    created_at      timestamptz not null default now(),
+    updated_at      timestamptz
+);
+
+-- Переносим данные из jsonb-колонок, сохраняя идентификаторы, известные клиентам
+INSERT INTO tasks_relational (id, name, description, due_date, status, created_at, updated_at)
+SELECT (task->>'id')::int,
+       task->>'name',
+       coalesce(task->>'description', ''),
+       coalesce((task->>'dueDate')::timestamptz, '0001-01-01 00:00:00+00'),
+       coalesce(task->>'status', 'Создана'),
+       coalesce((task->>'initTimeStamp')::timestamptz, created_at),
+       updated_at
+FROM tasks;
+
+INSERT INTO notes_relational (id, name, description, alarm_at, created_at, updated_at)
+SELECT (note->>'id')::int,
+       note->>'name',
+       coalesce(note->>'description', ''),
+       coalesce((note->>'alarmTimeStamp')::timestamptz, '0001-01-01 00:00:00+00'),
+       created_at,
+       updated_at
+FROM notes;
+
+SELECT setval(pg_get_serial_sequence('tasks_relational', 'id'), coalesce(max(id), 0) + 1, false) FROM tasks_relational;
+SELECT setval(pg_get_serial_sequence('notes_relational', 'id'), coalesce(max(id), 0) + 1, false) FROM notes_relational;
+
+DROP table tasks;
+DROP table notes;
+
+ALTER table tasks_relational RENAME TO tasks;
+ALTER sequence tasks_relational_id_seq RENAME TO tasks_id_seq;
+ALTER index tasks_relational_pkey RENAME TO tasks_pkey;
+
+ALTER table notes_relational RENAME TO notes;
+ALTER sequence notes_relational_id_seq RENAME TO notes_id_seq;
+ALTER index notes_relational_pkey RENAME TO notes_pkey;
+
+CREATE UNIQUE INDEX index_task_name ON tasks (name);
+CREATE INDEX index_task_status ON tasks (status);
+CREATE INDEX index_task_due_date ON tasks (due_date);
+
+CREATE UNIQUE INDEX index_note_name ON notes (name);
+CREATE INDEX index_note_alarm_at ON notes (alarm_at);
+
+
+-- +goose Down
+CREATE table IF NOT EXISTS tasks_jsonb (
+    id              serial primary key,
+    task            jsonb UNIQUE not null,
+    created_at      timestamptz not null default now(),
+    updated_at      timestamptz
+);
+
+CREATE table IF NOT EXISTS notes_jsonb (
+    id              serial primary key,
+    note            jsonb UNIQUE not null,
+    created_at      timestamptz not null default now(),
+    updated_at      timestamptz
+);
+
+INSERT INTO tasks_jsonb (id, task, created_at, updated_at)
+SELECT id,
+       jsonb_build_object(
+           'id', id,
+           'name', name,
+           'description', description,
+           'initTimeStamp', created_at,
+           'dueDate', due_date,
+           'status', status
+       ),
+       created_at,
+       updated_at
+FROM tasks;
+
+INSERT INTO notes_jsonb (id, note, created_at, updated_at)
+SELECT id,
+       jsonb_build_object(
+           'id', id,
+           'name', name,
+           'description', description,
+           'alarmTimeStamp', alarm_at
+       ),
+       created_at,
+       updated_at
+FROM notes;
+
+SELECT setval(pg_get_serial_sequence('tasks_jsonb', 'id'), coalesce(max(id), 0) + 1, false) FROM tasks_jsonb;
+SELECT setval(pg_get_serial_sequence('notes_jsonb', 'id'), coalesce(max(id), 0) + 1, false) FROM notes_jsonb;
+
+DROP table tasks;
+DROP table notes;
+
+ALTER table tasks_jsonb RENAME TO tasks;
+ALTER sequence tasks_jsonb_id_seq RENAME TO tasks_id_seq;
+ALTER index tasks_jsonb_pkey RENAME TO tasks_pkey;
+ALTER index tasks_jsonb_task_key RENAME TO tasks_task_key;
+
+ALTER table notes_jsonb RENAME TO notes;
+ALTER sequence notes_jsonb_id_seq RENAME TO notes_id_seq;
+ALTER index notes_jsonb_pkey RENAME TO notes_pkey;
+ALTER index notes_jsonb_note_key RENAME TO notes_note_key;
+
+CREATE INDEX index_task ON tasks USING GIN (task);
+CREATE UNIQUE INDEX index_task_name ON tasks (((task->>'name')::text));
+CREATE INDEX index_note ON notes USING GIN (note);
+CREATE UNIQUE INDEX index_note_name ON notes (((note->>'name')::text));
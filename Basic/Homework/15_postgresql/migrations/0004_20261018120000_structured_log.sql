@@ -0,0 +1,55 @@
+-- +goose Up
+ALTER table remindables_log
+    ADD COLUMN entity_type  text not null default '',
+    ADD COLUMN entity_id    int not null default 0,
+    ADD COLUMN action       text not null default '',
+    ADD COLUMN actor        text not null default '',
+    ADD COLUMN before       jsonb,
+    ADD COLUMN after        jsonb;
+
+-- Разбираем старые текстовые описания вида "Создана новая задача <имя>"
+UPDATE remindables_log
+SET entity_type = CASE
+        WHEN description LIKE '%задач%' THEN 'task'
+        ELSE 'note'
+    END,
+    action = CASE
+        WHEN description LIKE 'Созда%' THEN 'create'
+        WHEN description LIKE 'Измен%' THEN 'update'
+        ELSE 'delete'
+    END,
+    actor = 'unknown',
+    after = jsonb_build_object('description', description);
+
+ALTER table remindables_log
+    ALTER COLUMN entity_type DROP DEFAULT,
+    ALTER COLUMN entity_id DROP DEFAULT,
+    ALTER COLUMN action DROP DEFAULT,
+    ALTER COLUMN actor DROP DEFAULT,
+    DROP COLUMN description;
+
+CREATE INDEX index_log_entity ON remindables_log (entity_type, entity_id);
+CREATE INDEX index_log_action ON remindables_log (action);
+CREATE INDEX index_log_created_at ON remindables_log (created_at);
+
+-- +goose Down
+DROP INDEX index_log_entity;
+DROP INDEX index_log_action;
+DROP INDEX index_log_created_at;
+
+ALTER table remindables_log ADD COLUMN description text not null default '';
+
+UPDATE remindables_log
+SET description = coalesce(
+    after->>'description',
+    concat_ws(' ', action, entity_type, entity_id, coalesce(after->>'name', before->>'name'))
+);
+
+ALTER table remindables_log
+    ALTER COLUMN description DROP DEFAULT,
+    DROP COLUMN entity_type,
+    DROP COLUMN entity_id,
+    DROP COLUMN action,
+    DROP COLUMN actor,
+    DROP COLUMN before,
+    DROP COLUMN after;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Поисковые векторы с русской морфологией: совпадения в названии весомее совпадений в описании
+ALTER table tasks
+    ADD COLUMN search tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', name), 'A') ||
+        setweight(to_tsvector('russian', description), 'B')
+    ) STORED;
+
+ALTER table notes
+    ADD COLUMN search tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', name), 'A') ||
+        setweight(to_tsvector('russian', description), 'B')
+    ) STORED;
+
+CREATE INDEX index_task_search ON tasks USING GIN (search);
+CREATE INDEX index_note_search ON notes USING GIN (search);
+
+-- +goose Down
+DROP INDEX index_task_search;
+DROP INDEX index_note_search;
+
+ALTER table tasks DROP COLUMN search;
+ALTER table notes DROP COLUMN search;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE table IF NOT EXISTS task_status_history (
+    id              serial primary key,
+    task_id         int not null references tasks (id) ON DELETE CASCADE,
+    from_status     text not null,
+    to_status       text not null,
+    actor           text not null,
+    changed_at      timestamptz not null default now()
+);
+
+CREATE INDEX index_task_status_history_task ON task_status_history (task_id, id);
+
+-- Для существующих задач история начинается с их текущего статуса
+INSERT INTO task_status_history (task_id, from_status, to_status, actor, changed_at)
+SELECT id, '', status, 'unknown', created_at
+FROM tasks;
+
+-- +goose Down
+DROP table task_status_history;
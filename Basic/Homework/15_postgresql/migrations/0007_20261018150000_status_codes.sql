@@ -0,0 +1,59 @@
+-- +goose Up
+-- Статусы задач хранятся кодами вместо русских подписей
+CREATE TEMP table status_codes (
+    label   text primary key,
+    code    text not null unique
+) ON COMMIT DROP;
+
+INSERT INTO status_codes (label, code) VALUES
+    ('Создана', 'created'),
+    ('Изменена', 'updated'),
+    ('Просмотрена', 'seen'),
+    ('В работе', 'in_progress'),
+    ('Приостановлена', 'suspended'),
+    ('Решена, ждет контроля', 'submitted'),
+    ('Завершена', 'completed'),
+    ('Отменена', 'cancelled'),
+    ('Возвращена на доработку', 'returned'),
+    ('Бэклог', 'backlog');
+
+UPDATE tasks t SET status = m.code FROM status_codes m WHERE t.status = m.label;
+UPDATE task_status_history h SET from_status = m.code FROM status_codes m WHERE h.from_status = m.label;
+UPDATE task_status_history h SET to_status = m.code FROM status_codes m WHERE h.to_status = m.label;
+
+-- Снимки задач в журнале изменений
+UPDATE remindables_log l SET before = jsonb_set(l.before, '{status}', to_jsonb(m.code))
+FROM status_codes m WHERE l.entity_type = 'task' AND l.before->>'status' = m.label;
+UPDATE remindables_log l SET after = jsonb_set(l.after, '{status}', to_jsonb(m.code))
+FROM status_codes m WHERE l.entity_type = 'task' AND l.after->>'status' = m.label;
+
+ALTER table tasks ALTER COLUMN status SET DEFAULT 'created';
+
+-- +goose Down
+CREATE TEMP table status_codes (
+    label   text primary key,
+    code    text not null unique
+) ON COMMIT DROP;
+
+INSERT INTO status_codes (label, code) VALUES
+    ('Создана', 'created'),
+    ('Изменена', 'updated'),
+    ('Просмотрена', 'seen'),
+    ('В работе', 'in_progress'),
+    ('Приостановлена', 'suspended'),
+    ('Решена, ждет контроля', 'submitted'),
+    ('Завершена', 'completed'),
+    ('Отменена', 'cancelled'),
+    ('Возвращена на доработку', 'returned'),
+    ('Бэклог', 'backlog');
+
+ALTER table tasks ALTER COLUMN status DROP DEFAULT;
+
+UPDATE tasks t SET status = m.label FROM status_codes m WHERE t.status = m.code;
+UPDATE task_status_history h SET from_status = m.label FROM status_codes m WHERE h.from_status = m.code;
+UPDATE task_status_history h SET to_status = m.label FROM status_codes m WHERE h.to_status = m.code;
+
+UPDATE remindables_log l SET before = jsonb_set(l.before, '{status}', to_jsonb(m.label))
+FROM status_codes m WHERE l.entity_type = 'task' AND l.before->>'status' = m.code;
+UPDATE remindables_log l SET after = jsonb_set(l.after, '{status}', to_jsonb(m.label))
+FROM status_codes m WHERE l.entity_type = 'task' AND l.after->>'status' = m.code;
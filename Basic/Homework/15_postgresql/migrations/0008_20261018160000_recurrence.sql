@@ -0,0 +1,18 @@
+-- +goose Up
+-- Правила повторения RRULE и часовой пояс, в котором вычисляются повторения
+ALTER table tasks
+    ADD COLUMN recurrence text not null default '',
+    ADD COLUMN timezone text not null default '';
+
+ALTER table notes
+    ADD COLUMN recurrence text not null default '',
+    ADD COLUMN timezone text not null default '';
+
+-- +goose Down
+ALTER table notes
+    DROP COLUMN timezone,
+    DROP COLUMN recurrence;
+
+ALTER table tasks
+    DROP COLUMN timezone,
+    DROP COLUMN recurrence;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Отметки о доставленных напоминаниях: после перезапуска напоминание не доставляется повторно
+CREATE table IF NOT EXISTS reminder_deliveries (
+    entity_type     text not null,
+    entity_id       int not null,
+    fire_at         timestamptz not null,
+    delivered_at    timestamptz not null default now(),
+    primary key (entity_type, entity_id, fire_at)
+);
+
+-- +goose Down
+DROP table reminder_deliveries;
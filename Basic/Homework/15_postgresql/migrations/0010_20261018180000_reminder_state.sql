@@ -0,0 +1,44 @@
+-- +goose Up
+-- Состояние напоминания и время срабатывания до его откладывания
+ALTER table tasks
+    ADD COLUMN reminder_state text not null default 'pending',
+    ADD COLUMN snoozed_from timestamptz;
+
+ALTER table notes
+    ADD COLUMN reminder_state text not null default 'pending',
+    ADD COLUMN snoozed_from timestamptz;
+
+-- История откладывания напоминаний
+CREATE table IF NOT EXISTS task_snoozes (
+    id              serial primary key,
+    task_id         int not null references tasks (id) ON DELETE CASCADE,
+    from_at         timestamptz not null,
+    until_at        timestamptz not null,
+    actor           text not null,
+    snoozed_at      timestamptz not null default now()
+);
+
+CREATE INDEX index_task_snoozes_task ON task_snoozes (task_id, id);
+
+CREATE table IF NOT EXISTS note_snoozes (
+    id              serial primary key,
+    note_id         int not null references notes (id) ON DELETE CASCADE,
+    from_at         timestamptz not null,
+    until_at        timestamptz not null,
+    actor           text not null,
+    snoozed_at      timestamptz not null default now()
+);
+
+CREATE INDEX index_note_snoozes_note ON note_snoozes (note_id, id);
+
+-- +goose Down
+DROP table note_snoozes;
+DROP table task_snoozes;
+
+ALTER table notes
+    DROP COLUMN snoozed_from,
+    DROP COLUMN reminder_state;
+
+ALTER table tasks
+    DROP COLUMN snoozed_from,
+    DROP COLUMN reminder_state;
@@ -0,0 +1,32 @@
+-- +goose Up
+-- Приоритет и метки задач и заметок
+ALTER table tasks
+    ADD COLUMN priority text not null default 'normal'
+        CHECK (priority IN ('low', 'normal', 'high', 'urgent')),
+    ADD COLUMN tags text[] not null default '{}';
+
+ALTER table notes
+    ADD COLUMN priority text not null default 'normal'
+        CHECK (priority IN ('low', 'normal', 'high', 'urgent')),
+    ADD COLUMN tags text[] not null default '{}';
+
+CREATE INDEX index_task_priority ON tasks (priority);
+CREATE INDEX index_note_priority ON notes (priority);
+
+-- Отбор по меткам: tags @> ARRAY[...]
+CREATE INDEX index_task_tags ON tasks USING GIN (tags);
+CREATE INDEX index_note_tags ON notes USING GIN (tags);
+
+-- +goose Down
+DROP INDEX index_note_tags;
+DROP INDEX index_task_tags;
+DROP INDEX index_note_priority;
+DROP INDEX index_task_priority;
+
+ALTER table notes
+    DROP COLUMN tags,
+    DROP COLUMN priority;
+
+ALTER table tasks
+    DROP COLUMN tags,
+    DROP COLUMN priority;
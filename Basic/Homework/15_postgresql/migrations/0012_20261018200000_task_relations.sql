@@ -0,0 +1,20 @@
+-- +goose Up
+-- Подзадачи и блокирующие зависимости задач.
+-- Подзадачи удаляются приложением по выбранной политике, поэтому внешний ключ запрещает
+-- удаление родителя, пока у него остаются подзадачи
+ALTER table tasks
+    ADD COLUMN parent_id int references tasks (id) ON DELETE RESTRICT,
+    ADD COLUMN blocked_by int[] not null default '{}';
+
+CREATE INDEX index_task_parent ON tasks (parent_id);
+
+-- Поиск задач, заблокированных задачей: blocked_by @> ARRAY[id]
+CREATE INDEX index_task_blocked_by ON tasks USING GIN (blocked_by);
+
+-- +goose Down
+DROP INDEX index_task_blocked_by;
+DROP INDEX index_task_parent;
+
+ALTER table tasks
+    DROP COLUMN blocked_by,
+    DROP COLUMN parent_id;
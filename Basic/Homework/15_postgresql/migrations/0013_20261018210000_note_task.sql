@@ -0,0 +1,13 @@
+-- +goose Up
+-- Привязка заметок к задачам (много заметок к одной задаче).
+-- При удалении задачи приложение открепляет или удаляет её заметки по выбранной политике
+ALTER table notes
+    ADD COLUMN task_id int references tasks (id) ON DELETE RESTRICT;
+
+CREATE INDEX index_note_task ON notes (task_id);
+
+-- +goose Down
+DROP INDEX index_note_task;
+
+ALTER table notes
+    DROP COLUMN task_id;
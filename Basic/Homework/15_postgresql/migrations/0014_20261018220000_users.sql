@@ -0,0 +1,60 @@
+-- +goose Up
+-- Учётные записи пользователей и сессии, открытые по токенам доступа (хранится только хеш токена)
+CREATE table IF NOT EXISTS users (
+    id              serial primary key,
+    login           text not null unique,
+    password_hash   text not null,
+    created_at      timestamptz not null default now()
+);
+
+CREATE table IF NOT EXISTS sessions (
+    token_hash      text primary key,
+    user_id         int not null references users (id) ON DELETE CASCADE,
+    created_at      timestamptz not null default now(),
+    expires_at      timestamptz not null
+);
+
+CREATE INDEX index_session_expires_at ON sessions (expires_at);
+
+-- Задачи, заметки и записи журнала принадлежат пользователям.
+-- Записи, созданные до появления пользователей, остаются без владельца, пока не зарегистрируется
+-- первый пользователь: при регистрации они передаются ему
+ALTER table tasks
+    ADD COLUMN owner_id int references users (id);
+
+ALTER table notes
+    ADD COLUMN owner_id int references users (id);
+
+ALTER table remindables_log
+    ADD COLUMN owner_id int;
+
+-- Имена уникальны в пределах записей одного владельца; для записей без владельца - отдельный
+-- частичный индекс, так как в обычном уникальном индексе NULL не совпадают
+DROP INDEX index_task_name;
+DROP INDEX index_note_name;
+CREATE UNIQUE INDEX index_task_owner_name ON tasks (owner_id, name) WHERE owner_id IS NOT NULL;
+CREATE UNIQUE INDEX index_note_owner_name ON notes (owner_id, name) WHERE owner_id IS NOT NULL;
+CREATE UNIQUE INDEX index_task_ownerless_name ON tasks (name) WHERE owner_id IS NULL;
+CREATE UNIQUE INDEX index_note_ownerless_name ON notes (name) WHERE owner_id IS NULL;
+CREATE INDEX index_log_owner ON remindables_log (owner_id);
+
+-- +goose Down
+DROP INDEX index_log_owner;
+DROP INDEX index_note_ownerless_name;
+DROP INDEX index_task_ownerless_name;
+DROP INDEX index_note_owner_name;
+DROP INDEX index_task_owner_name;
+CREATE UNIQUE INDEX index_task_name ON tasks (name);
+CREATE UNIQUE INDEX index_note_name ON notes (name);
+
+ALTER table remindables_log
+    DROP COLUMN owner_id;
+
+ALTER table notes
+    DROP COLUMN owner_id;
+
+ALTER table tasks
+    DROP COLUMN owner_id;
+
+DROP table sessions;
+DROP table users;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Доступ пользователей к чужим задачам, заметкам и спискам (задачам и заметкам владельца с меткой list).
+-- entity_id заполняется у задач и заметок, list - у списков; логин получателя не меняется и хранится для ответов API
+CREATE table IF NOT EXISTS shares (
+    id              serial primary key,
+    entity_type     text not null,
+    entity_id       int not null default 0,
+    list            text not null default '',
+    owner_id        int not null references users (id) ON DELETE CASCADE,
+    user_id         int not null references users (id) ON DELETE CASCADE,
+    login           text not null,
+    role            text not null,
+    created_at      timestamptz not null default now()
+);
+
+CREATE UNIQUE INDEX index_share_target_user ON shares (owner_id, entity_type, entity_id, list, user_id);
+CREATE INDEX index_share_user_owner ON shares (user_id, owner_id);
+
+-- +goose Down
+DROP table shares;
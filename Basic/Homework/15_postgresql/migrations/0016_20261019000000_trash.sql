@@ -0,0 +1,46 @@
+-- +goose Up
+-- Корзина: удалённые задачи и заметки отмечаются deleted_at и окончательно удаляются
+-- по истечении срока хранения или по запросу владельца.
+-- Записи в корзине не занимают имён
+ALTER table tasks
+    ADD COLUMN deleted_at timestamptz;
+
+ALTER table notes
+    ADD COLUMN deleted_at timestamptz;
+
+DROP INDEX index_task_ownerless_name;
+DROP INDEX index_note_ownerless_name;
+DROP INDEX index_task_owner_name;
+DROP INDEX index_note_owner_name;
+CREATE UNIQUE INDEX index_task_owner_name ON tasks (owner_id, name) WHERE owner_id IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX index_note_owner_name ON notes (owner_id, name) WHERE owner_id IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX index_task_ownerless_name ON tasks (name) WHERE owner_id IS NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX index_note_ownerless_name ON notes (name) WHERE owner_id IS NULL AND deleted_at IS NULL;
+CREATE INDEX index_task_deleted_at ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX index_note_deleted_at ON notes (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+-- Записи из корзины удаляются окончательно: без них имена снова уникальны без условия
+DELETE FROM shares WHERE entity_type = 'task' AND entity_id IN (SELECT id FROM tasks WHERE deleted_at IS NOT NULL);
+DELETE FROM shares WHERE entity_type = 'note' AND entity_id IN (SELECT id FROM notes WHERE deleted_at IS NOT NULL);
+DELETE FROM notes WHERE deleted_at IS NOT NULL;
+UPDATE notes SET task_id = NULL WHERE task_id IN (SELECT id FROM tasks WHERE deleted_at IS NOT NULL);
+UPDATE tasks SET parent_id = NULL WHERE parent_id IN (SELECT id FROM tasks WHERE deleted_at IS NOT NULL);
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+
+DROP INDEX index_note_deleted_at;
+DROP INDEX index_task_deleted_at;
+DROP INDEX index_note_ownerless_name;
+DROP INDEX index_task_ownerless_name;
+DROP INDEX index_note_owner_name;
+DROP INDEX index_task_owner_name;
+CREATE UNIQUE INDEX index_task_owner_name ON tasks (owner_id, name) WHERE owner_id IS NOT NULL;
+CREATE UNIQUE INDEX index_note_owner_name ON notes (owner_id, name) WHERE owner_id IS NOT NULL;
+CREATE UNIQUE INDEX index_task_ownerless_name ON tasks (name) WHERE owner_id IS NULL;
+CREATE UNIQUE INDEX index_note_ownerless_name ON notes (name) WHERE owner_id IS NULL;
+
+ALTER table notes
+    DROP COLUMN deleted_at;
+
+ALTER table tasks
+    DROP COLUMN deleted_at;
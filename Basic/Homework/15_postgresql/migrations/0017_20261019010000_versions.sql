@@ -0,0 +1,15 @@
+-- +goose Up
+-- Версии задач и заметок для оптимистичной блокировки: каждое изменение увеличивает версию,
+-- а изменяющие запросы выполняются условно по версии, считанной клиентом
+ALTER table tasks
+    ADD COLUMN version int NOT NULL DEFAULT 1;
+
+ALTER table notes
+    ADD COLUMN version int NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER table notes
+    DROP COLUMN version;
+
+ALTER table tasks
+    DROP COLUMN version;
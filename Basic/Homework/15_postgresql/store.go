@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/config"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage/file"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage/memory"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage/mongo"
+	"github.com/corridda/OTUS_Golang_Developer/Basic/Homework/15_postgresql/internal/storage/postgres"
+)
+
+// openStore открывает хранилище, выбранное в конфигурации
+func openStore(ctx context.Context, cfg config.Config) (storage.Store, error) {
+	switch cfg.Storage.Backend {
+	case config.BackendMemory:
+		return memory.New(), nil
+	case config.BackendFile:
+		return file.Open(cfg.Storage.DataDir)
+	case config.BackendMongo:
+		return mongo.Open(ctx, cfg.Mongo.URI, cfg.Mongo.Database)
+	case config.BackendPostgres:
+		return postgres.Open(ctx, cfg.Postgres.DSN(), cfg.Postgres.Migrations)
+	}
+	return nil, fmt.Errorf("неизвестное хранилище %q", cfg.Storage.Backend)
+}
@@ -0,0 +1,225 @@
+// Package dateinput разбирает даты и время, введённые пользователем: RFC 3339,
+// даты ISO, русские форматы ДД.ММ.ГГГГ [ЧЧ:ММ] и относительные выражения
+// вида «завтра 9:00», «через 3 дня», «+2h».
+// Пакет общий для CLI, REST- и gRPC-сервисов домашних заданий
+package dateinput
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrEmpty передана пустая строка
+var ErrEmpty = errors.New("пустое значение даты")
+
+// ErrUnrecognized строка не соответствует ни одному из поддерживаемых форматов
+var ErrUnrecognized = errors.New("формат даты не распознан")
+
+// Абсолютные форматы без часового пояса; значение интерпретируется в поясе пользователя
+var layouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2.1.2006 15:04:05",
+	"2.1.2006 15:04",
+	"2.1.2006",
+}
+
+// Смещение дней относительно сегодняшнего для слов «сегодня», «завтра» и т.п.
+var dayWords = map[string]int{
+	"сегодня":     0,
+	"today":       0,
+	"завтра":      1,
+	"tomorrow":    1,
+	"послезавтра": 2,
+	"вчера":       -1,
+	"yesterday":   -1,
+}
+
+// unit единица относительного смещения
+type unit int
+
+const (
+	minutes unit = iota
+	hours
+	days
+	weeks
+	months
+)
+
+// Префиксы слов, обозначающих единицы смещения; покрывают все падежные формы
+var unitPrefixes = []struct {
+	prefix string
+	unit   unit
+}{
+	{"мин", minutes},
+	{"min", minutes},
+	{"час", hours},
+	{"hour", hours},
+	{"ден", days},
+	{"дн", days},
+	{"сут", days},
+	{"day", days},
+	{"недел", weeks},
+	{"week", weeks},
+	{"месяц", months},
+	{"month", months},
+}
+
+var (
+	// «через 3 дня», «через час», «in 2 hours», «через 2 дня в 9:00»
+	relativeRe = regexp.MustCompile(`^(?:через|in)\s+(?:(\d+|an?|one)\s+)?(\pL+)(?:\s+(.+))?$`)
+	// «+2h», «-1d», «+1w»
+	shortDaysRe = regexp.MustCompile(`^([+-])(\d+)([dw])$`)
+	// «9:00», «в 9:00», «at 18:30», «в 9»
+	clockRe = regexp.MustCompile(`^(?:(?:в|at)\s+)?(\d{1,2})(?::(\d{2}))?$`)
+)
+
+// Parse разбирает value и возвращает момент времени в UTC.
+// Значения без явного часового пояса и относительные выражения отсчитываются
+// в поясе loc (UTC, если loc == nil) от момента now. Дата без времени
+// означает полночь этого дня
+func Parse(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	raw := strings.TrimSpace(value)
+	if raw == "" {
+		return time.Time{}, ErrEmpty
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+		return t.UTC(), nil
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, raw, loc); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	if t, ok := parseRelative(normalize(raw), now.In(loc)); ok {
+		return t.UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrUnrecognized, value)
+}
+
+// normalize приводит строку к нижнему регистру, заменяет «ё» на «е» и схлопывает пробелы
+func normalize(value string) string {
+	value = strings.ReplaceAll(strings.ToLower(value), "ё", "е")
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// parseRelative разбирает относительные выражения; now уже переведён в пояс пользователя
+func parseRelative(s string, now time.Time) (time.Time, bool) {
+	switch s {
+	case "сейчас", "now":
+		return now, true
+	}
+
+	if m := shortDaysRe.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+		if m[3] == "w" {
+			n *= 7
+		}
+		return now.AddDate(0, 0, n), true
+	}
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		if d, err := time.ParseDuration(s); err == nil {
+			return now.Add(d), true
+		}
+		return time.Time{}, false
+	}
+
+	if m := relativeRe.FindStringSubmatch(s); m != nil {
+		return parseOffset(m[1], m[2], m[3], now)
+	}
+
+	word, rest, _ := strings.Cut(s, " ")
+	if offset, ok := dayWords[word]; ok {
+		return atClock(now.AddDate(0, 0, offset), rest, true)
+	}
+	// одно время суток относится к сегодняшнему дню; голое число без «в» часом не считается
+	if word == "в" || word == "at" || (rest == "" && strings.Contains(s, ":")) {
+		return atClock(now, s, false)
+	}
+	return time.Time{}, false
+}
+
+// parseOffset сдвигает now на count единиц, заданных словом word; для дней и
+// более крупных единиц допускается уточнение времени суток clock
+func parseOffset(count, word, clock string, now time.Time) (time.Time, bool) {
+	n := 1
+	if count != "" && count != "a" && count != "an" && count != "one" {
+		var err error
+		if n, err = strconv.Atoi(count); err != nil {
+			return time.Time{}, false
+		}
+	}
+
+	u, ok := unitOf(word)
+	if !ok {
+		return time.Time{}, false
+	}
+	var t time.Time
+	switch u {
+	case minutes:
+		t = now.Add(time.Duration(n) * time.Minute)
+	case hours:
+		t = now.Add(time.Duration(n) * time.Hour)
+	case days:
+		t = now.AddDate(0, 0, n)
+	case weeks:
+		t = now.AddDate(0, 0, 7*n)
+	case months:
+		t = now.AddDate(0, n, 0)
+	}
+	if clock == "" {
+		return t, true
+	}
+	if u == minutes || u == hours {
+		return time.Time{}, false
+	}
+	return atClock(t, clock, false)
+}
+
+// unitOf определяет единицу смещения по слову в любой падежной форме
+func unitOf(word string) (unit, bool) {
+	for _, p := range unitPrefixes {
+		if strings.HasPrefix(word, p.prefix) {
+			return p.unit, true
+		}
+	}
+	return 0, false
+}
+
+// atClock устанавливает в day время суток из clock («9:00», «в 9:00», «at 9»).
+// Пустой clock допустим только при allowEmpty и означает полночь
+func atClock(day time.Time, clock string, allowEmpty bool) (time.Time, bool) {
+	if clock == "" {
+		if !allowEmpty {
+			return time.Time{}, false
+		}
+		return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location()), true
+	}
+	m := clockRe.FindStringSubmatch(clock)
+	if m == nil {
+		return time.Time{}, false
+	}
+	h, _ := strconv.Atoi(m[1])
+	mi := 0
+	if m[2] != "" {
+		mi, _ = strconv.Atoi(m[2])
+	}
+	if h > 23 || mi > 59 {
+		return time.Time{}, false
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), h, mi, 0, 0, day.Location()), true
+}
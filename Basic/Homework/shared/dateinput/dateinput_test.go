@@ -0,0 +1,57 @@
+package dateinput
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	// пользователь в Москве, сейчас 18.10.2026 12:30 по местному времени
+	loc := time.FixedZone("MSK", 3*60*60)
+	now := time.Date(2026, 10, 18, 12, 30, 0, 0, loc)
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, loc).UTC()
+	}
+	tests := []struct {
+		name  string
+		value string
+		loc   *time.Location
+		want  time.Time
+		err   error
+	}{
+		{name: "rfc3339 keeps its own zone", value: "2026-11-02T09:00:00+05:00", loc: loc, want: time.Date(2026, 11, 2, 4, 0, 0, 0, time.UTC)},
+		{name: "iso date is midnight in user zone", value: "2026-11-02", loc: loc, want: at(11, 2, 0, 0)},
+		{name: "iso date and time", value: "2026-11-02 09:15", loc: loc, want: at(11, 2, 9, 15)},
+		{name: "russian date", value: "2.11.2026", loc: loc, want: at(11, 2, 0, 0)},
+		{name: "russian date and time", value: "02.11.2026 18:30", loc: loc, want: at(11, 2, 18, 30)},
+		{name: "nil location means utc", value: "2026-11-02", want: time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "now", value: "сейчас", loc: loc, want: now.UTC()},
+		{name: "tomorrow at clock", value: "Завтра 9:00", loc: loc, want: at(10, 19, 9, 0)},
+		{name: "tomorrow without clock is midnight", value: "завтра", loc: loc, want: at(10, 19, 0, 0)},
+		{name: "today at hour", value: "в 18", loc: loc, want: at(10, 18, 18, 0)},
+		{name: "in days", value: "через 3 дня", loc: loc, want: at(10, 21, 12, 30)},
+		{name: "in days at clock", value: "через 2 дня в 9:00", loc: loc, want: at(10, 20, 9, 0)},
+		{name: "in an hour", value: "через час", loc: loc, want: at(10, 18, 13, 30)},
+		{name: "english offset", value: "in 2 weeks", loc: loc, want: at(11, 1, 12, 30)},
+		{name: "short hours", value: "+2h", loc: loc, want: at(10, 18, 14, 30)},
+		{name: "short days", value: "+1d", loc: loc, want: at(10, 19, 12, 30)},
+		{name: "short weeks back", value: "-1w", loc: loc, want: at(10, 11, 12, 30)},
+		{name: "empty", value: "  ", loc: loc, err: ErrEmpty},
+		{name: "unrecognized", value: "когда-нибудь", loc: loc, err: ErrUnrecognized},
+		{name: "hour out of range", value: "завтра 25:00", loc: loc, err: ErrUnrecognized},
+		{name: "clock after hours offset", value: "через 2 часа в 9:00", loc: loc, err: ErrUnrecognized},
+		{name: "bare number", value: "9", loc: loc, err: ErrUnrecognized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse(tt.value, now, tt.loc)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
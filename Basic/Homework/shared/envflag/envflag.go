@@ -0,0 +1,34 @@
+// Package envflag регистрирует флаги командной строки, значения по умолчанию которых
+// переопределяются переменными окружения REMINDABLES_*. Имена те же, что у конфигурации
+// 15_postgresql: флагу -http-addr соответствует переменная REMINDABLES_HTTP_ADDR.
+// Приоритет, от низшего к высшему: значение по умолчанию, окружение, флаг
+package envflag
+
+import (
+	"flag"
+	"os"
+	"strings"
+)
+
+// EnvPrefix префикс переменных окружения
+const EnvPrefix = "REMINDABLES_"
+
+// EnvName возвращает имя переменной окружения флага name
+func EnvName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// String регистрирует строковый флаг name в flag.CommandLine; значение по умолчанию
+// value заменяется значением переменной окружения EnvName(name), если она задана
+func String(name, value, usage string) *string {
+	return stringFlag(flag.CommandLine, os.LookupEnv, name, value, usage)
+}
+
+// stringFlag регистрирует флаг в fs, читая окружение через lookupEnv
+func stringFlag(fs *flag.FlagSet, lookupEnv func(string) (string, bool), name, value, usage string) *string {
+	env := EnvName(name)
+	if raw, ok := lookupEnv(env); ok {
+		value = raw
+	}
+	return fs.String(name, value, usage+" (env "+env+")")
+}
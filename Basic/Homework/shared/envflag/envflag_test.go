@@ -0,0 +1,37 @@
+package envflag
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringFlag(t *testing.T) {
+	env := map[string]string{"REMINDABLES_HTTP_ADDR": ":9090"}
+	lookupEnv := func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+	tests := []struct {
+		name string
+		flag string
+		args []string
+		want string
+	}{
+		{name: "default", flag: "grpc-addr", want: "localhost:5001"},
+		{name: "environment overrides default", flag: "http-addr", want: ":9090"},
+		{name: "flag overrides environment", flag: "http-addr", args: []string{"-http-addr", ":7070"}, want: ":7070"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			value := stringFlag(fs, lookupEnv, tt.flag, "localhost:5001", "address")
+
+			assert.NoError(t, fs.Parse(tt.args))
+			assert.Equal(t, tt.want, *value)
+		})
+	}
+}